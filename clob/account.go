@@ -3,21 +3,118 @@ package clob
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
+	"math/big"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
 
+// balanceCache 余额和授权信息的缓存
+// ttl 为 0 时不缓存，每次查询都直接请求
+type balanceCache struct {
+	mu                 sync.Mutex
+	ttl                time.Duration
+	usdcBalance        *big.Int
+	usdcFetchedAt      time.Time
+	allowance          *types.BalanceAllowance
+	allowanceFetchedAt time.Time
+}
+
+// invalidate 使缓存失效，下次查询会重新读取
+func (bc *balanceCache) invalidate() {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.usdcFetchedAt = time.Time{}
+	bc.allowance = nil
+	bc.allowanceFetchedAt = time.Time{}
+}
+
 // GetUSDCBalance gets USDC balance
+// 单位为 USDC，大额时 float64 可能损失精度，需要精确金额时使用 GetUSDCBalanceRaw
 func (c *accountClientImpl) GetUSDCBalance() (float64, error) {
-	return c.baseClient.web3Client.GetUSDCBalance(c.baseClient.proxyAddress)
+	balance, err := c.GetUSDCBalanceRaw()
+	if err != nil {
+		return 0, err
+	}
+	return types.USDCToFloat(balance), nil
+}
+
+// GetUSDCBalanceRaw 获取链上 USDC 余额的原始整数（余额乘以 10^6，USDC 有 types.USDCDecimals 位小数）
+// 与 GetUSDCBalance 共用余额缓存，返回值为副本，可用 types.FormatUSDC 精确格式化
+func (c *accountClientImpl) GetUSDCBalanceRaw() (*big.Int, error) {
+	cache := c.baseClient.balances
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.ttl > 0 && cache.usdcBalance != nil && time.Since(cache.usdcFetchedAt) < cache.ttl {
+		return new(big.Int).Set(cache.usdcBalance), nil
+	}
+
+	balance, err := c.baseClient.web3Client.GetUSDCBalanceRaw(c.baseClient.proxyAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.usdcBalance = new(big.Int).Set(balance)
+	cache.usdcFetchedAt = time.Now()
+	return balance, nil
 }
 
 // GetBalanceAllowance 获取余额授权信息
 func (c *accountClientImpl) GetBalanceAllowance() (*types.BalanceAllowance, error) {
+	cache := c.baseClient.balances
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.ttl > 0 && cache.allowance != nil && time.Since(cache.allowanceFetchedAt) < cache.ttl {
+		return cache.allowance, nil
+	}
+
+	allowance, err := c.fetchBalanceAllowance()
+	if err != nil {
+		return nil, err
+	}
+
+	cache.allowance = allowance
+	cache.allowanceFetchedAt = time.Now()
+	return allowance, nil
+}
+
+// GetUSDCBalanceOf 获取指定地址的 USDC 链上余额（不使用缓存）
+// 可用于一个客户端同时监控多个钱包
+func (c *accountClientImpl) GetUSDCBalanceOf(address types.EthAddress) (float64, error) {
+	if err := address.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	return c.baseClient.web3Client.GetUSDCBalance(address)
+}
+
+// GetBalanceAllowanceOf 获取指定地址的 USDC 余额和授权额度（不使用缓存）
+// Allowance 为对 CTFExchange 的授权，NegRiskAllowance 为对 NegRiskCTFExchange 和 NegRiskAdapter 授权中较小的值
+// CLOB API 只能查询当前凭证对应的账户，因此这里直接读取链上数据，单位为 USDC
+func (c *accountClientImpl) GetBalanceAllowanceOf(address types.EthAddress) (*types.BalanceAllowance, error) {
+	return c.baseClient.web3Client.GetUSDCBalanceAllowance(address)
+}
+
+// RefreshBalances 使余额缓存失效并重新读取 USDC 余额和授权信息
+func (c *accountClientImpl) RefreshBalances() error {
+	c.baseClient.balances.invalidate()
+
+	if _, err := c.GetUSDCBalance(); err != nil {
+		return fmt.Errorf("failed to refresh USDC balance: %w", err)
+	}
+	if _, err := c.GetBalanceAllowance(); err != nil {
+		return fmt.Errorf("failed to refresh balance allowance: %w", err)
+	}
+	return nil
+}
+
+// fetchBalanceAllowance 从 API 获取余额授权信息（不使用缓存）
+func (c *accountClientImpl) fetchBalanceAllowance() (*types.BalanceAllowance, error) {
 	// Validate API credentials
 	if c.baseClient.deriveCreds == nil {
 		return nil, fmt.Errorf("API credentials not set")
@@ -34,12 +131,12 @@ func (c *accountClientImpl) GetBalanceAllowance() (*types.BalanceAllowance, erro
 		Body:        nil,
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	return http.Get[types.BalanceAllowance](c.baseClient.baseURL, internal.GetBalanceAllowance, nil, http.WithHeaders(headers))
+	return http.Get[types.BalanceAllowance](c.baseClient.baseURL, internal.GetBalanceAllowance, nil, c.requestOptions(http.WithHeaders(headers))...)
 }
 
 // UpdateBalanceAllowance 更新余额授权
@@ -58,19 +155,12 @@ func (c *accountClientImpl) UpdateBalanceAllowance(amount float64) (*types.Balan
 		"amount": amount,
 	}
 
-	// Marshal body to JSON
-	bodyJSON, err := json.Marshal(requestBody)
+	// Marshal body to JSON in Python's json.dumps format (with spaces)
+	bodyJSON, err := internal.MarshalPythonJSON(requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal body: %w", err)
 	}
 
-	// Convert compact JSON to Python's json.dumps format (with spaces)
-	bodyJSONStr := string(bodyJSON)
-	bodyJSONStr = regexp.MustCompile(`":(\S)`).ReplaceAllString(bodyJSONStr, `": $1`)
-	bodyJSONStr = regexp.MustCompile(`,(")`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSONStr = regexp.MustCompile(`,(\{|\[)`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSON = []byte(bodyJSONStr)
-
 	// Create request args for signing
 	requestBodyForSigning := types.RequestBody(bodyJSON)
 	requestArgs := &types.RequestArgs{
@@ -80,13 +170,30 @@ func (c *accountClientImpl) UpdateBalanceAllowance(amount float64) (*types.Balan
 	}
 
 	// Create Level 2 headers
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
+	c.baseClient.assertSignedBody(requestArgs, headers, bodyJSON)
+
 	// Execute POST request
-	return http.Post[types.BalanceAllowance](c.baseClient.baseURL, internal.UpdateBalanceAllowance, requestBody, http.WithHeaders(headers))
+	// 发送与签名相同的字节，避免 http.Post 重新序列化导致签名不一致
+	responseBody, err := http.PostRaw(c.baseClient.baseURL, internal.UpdateBalanceAllowance, bodyJSON, c.requestOptions(http.WithHeaders(headers))...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.BalanceAllowance{}
+	if len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	// 授权已变化，使缓存失效
+	c.baseClient.balances.invalidate()
+	return result, nil
 }
 
 // GetNotifications 获取通知列表
@@ -105,6 +212,26 @@ func (c *accountClientImpl) GetNotifications(limit int, offset int) ([]types.Not
 		"offset": strconv.Itoa(offset),
 	}
 
+	return c.getNotifications(params)
+}
+
+// GetAllNotifications 获取全部通知
+// 通知接口返回不分页的数组，不带 limit/offset 时一次请求返回全部通知
+func (c *accountClientImpl) GetAllNotifications() ([]types.Notification, error) {
+	// Validate API credentials
+	if c.baseClient.deriveCreds == nil {
+		return nil, fmt.Errorf("API credentials not set")
+	}
+	if c.baseClient.deriveCreds.Key == "" || c.baseClient.deriveCreds.Secret == "" || c.baseClient.deriveCreds.Passphrase == "" {
+		return nil, fmt.Errorf("API credentials incomplete: key=%v, secret=%v, passphrase=%v",
+			c.baseClient.deriveCreds.Key != "", c.baseClient.deriveCreds.Secret != "", c.baseClient.deriveCreds.Passphrase != "")
+	}
+
+	return c.getNotifications(nil)
+}
+
+// getNotifications 发送带认证的通知查询请求
+func (c *accountClientImpl) getNotifications(params map[string]string) ([]types.Notification, error) {
 	// Set up authentication headers
 	requestArgs := &types.RequestArgs{
 		Method:      "GET",
@@ -112,12 +239,12 @@ func (c *accountClientImpl) GetNotifications(limit int, offset int) ([]types.Not
 		Body:        nil,
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	result, err := http.Get[[]types.Notification](c.baseClient.baseURL, internal.GetNotifications, params, http.WithHeaders(headers))
+	result, err := http.Get[[]types.Notification](c.baseClient.baseURL, internal.GetNotifications, params, c.requestOptions(http.WithHeaders(headers))...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notifications: %w", err)
 	}
@@ -149,19 +276,12 @@ func (c *accountClientImpl) DropNotifications(notificationIDs []string) error {
 		"notification_ids": notificationIDs,
 	}
 
-	// Marshal body to JSON
-	bodyJSON, err := json.Marshal(requestBody)
+	// Marshal body to JSON in Python's json.dumps format (with spaces)
+	bodyJSON, err := internal.MarshalPythonJSON(requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to marshal body: %w", err)
 	}
 
-	// Convert compact JSON to Python's json.dumps format (with spaces)
-	bodyJSONStr := string(bodyJSON)
-	bodyJSONStr = regexp.MustCompile(`":(\S)`).ReplaceAllString(bodyJSONStr, `": $1`)
-	bodyJSONStr = regexp.MustCompile(`,(")`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSONStr = regexp.MustCompile(`,(\{|\[)`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSON = []byte(bodyJSONStr)
-
 	// Create request args for signing
 	requestBodyForSigning := types.RequestBody(bodyJSON)
 	requestArgs := &types.RequestArgs{
@@ -171,12 +291,42 @@ func (c *accountClientImpl) DropNotifications(notificationIDs []string) error {
 	}
 
 	// Create Level 2 headers
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return fmt.Errorf("failed to create headers: %w", err)
 	}
 
+	c.baseClient.assertSignedBody(requestArgs, headers, bodyJSON)
+
 	// Execute DELETE request
-	_, err = http.DeleteRaw[map[string]interface{}](c.baseClient.baseURL, internal.DropNotifications, bodyJSON, http.WithHeaders(headers))
+	_, err = http.DeleteRaw[map[string]interface{}](c.baseClient.baseURL, internal.DropNotifications, bodyJSON, c.requestOptions(http.WithHeaders(headers))...)
 	return err
 }
+
+// MarkNotificationsRead 将通知标记为已读
+// Polymarket API 不区分“已读”和“删除”：删除的通知即视为已读，因此等同于 DropNotifications
+func (c *accountClientImpl) MarkNotificationsRead(notificationIDs []string) error {
+	return c.DropNotifications(notificationIDs)
+}
+
+// DropAllNotifications 获取所有通知并全部删除
+// 没有通知时直接返回 nil
+func (c *accountClientImpl) DropAllNotifications() error {
+	notifications, err := c.GetAllNotifications()
+	if err != nil {
+		return fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	notificationIDs := make([]string, 0, len(notifications))
+	for _, n := range notifications {
+		if n.ID != "" {
+			notificationIDs = append(notificationIDs, n.ID)
+		}
+	}
+
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	return c.DropNotifications(notificationIDs)
+}
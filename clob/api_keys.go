@@ -1,7 +1,10 @@
 package clob
 
 import (
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
@@ -26,12 +29,12 @@ func (c *apiKeyClientImpl) GetAPIKeys() ([]types.APIKey, error) {
 		Body:        nil,
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	result, err := http.Get[[]types.APIKey](c.baseClient.baseURL, internal.GetAPIKeys, nil, http.WithHeaders(headers))
+	result, err := http.Get[[]types.APIKey](c.baseClient.baseURL, internal.GetAPIKeys, nil, c.requestOptions(http.WithHeaders(headers))...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get API keys: %w", err)
 	}
@@ -61,12 +64,12 @@ func (c *apiKeyClientImpl) DeleteAPIKey(keyID string) error {
 		Body:        nil,
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	_, err = http.Delete[map[string]interface{}](c.baseClient.baseURL, fmt.Sprintf("%s/%s", internal.DeleteAPIKey, keyID), nil, http.WithHeaders(headers))
+	_, err = http.Delete[map[string]interface{}](c.baseClient.baseURL, fmt.Sprintf("%s/%s", internal.DeleteAPIKey, keyID), nil, c.requestOptions(http.WithHeaders(headers))...)
 	return err
 }
 
@@ -88,12 +91,12 @@ func (c *apiKeyClientImpl) CreateReadonlyAPIKey() (*types.APIKey, error) {
 		Body:        nil,
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	return http.Post[types.APIKey](c.baseClient.baseURL, internal.CreateReadonlyAPIKey, nil, http.WithHeaders(headers))
+	return http.Post[types.APIKey](c.baseClient.baseURL, internal.CreateReadonlyAPIKey, nil, c.requestOptions(http.WithHeaders(headers))...)
 }
 
 // GetReadonlyAPIKeys 获取只读 API 密钥列表
@@ -114,12 +117,12 @@ func (c *apiKeyClientImpl) GetReadonlyAPIKeys() ([]types.APIKey, error) {
 		Body:        nil,
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	result, err := http.Get[[]types.APIKey](c.baseClient.baseURL, internal.GetReadonlyAPIKeys, nil, http.WithHeaders(headers))
+	result, err := http.Get[[]types.APIKey](c.baseClient.baseURL, internal.GetReadonlyAPIKeys, nil, c.requestOptions(http.WithHeaders(headers))...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get readonly API keys: %w", err)
 	}
@@ -149,11 +152,130 @@ func (c *apiKeyClientImpl) DeleteReadonlyAPIKey(keyID string) error {
 		Body:        nil,
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	_, err = http.Delete[map[string]interface{}](c.baseClient.baseURL, fmt.Sprintf("%s/%s", internal.DeleteReadonlyAPIKey, keyID), nil, http.WithHeaders(headers))
+	_, err = http.Delete[map[string]interface{}](c.baseClient.baseURL, fmt.Sprintf("%s/%s", internal.DeleteReadonlyAPIKey, keyID), nil, c.requestOptions(http.WithHeaders(headers))...)
 	return err
 }
+
+// keyScopeCache 当前 API 凭证权限范围的缓存
+// 权限范围在凭证生命周期内不变，成功结果只需查询一次；网络等临时错误缓存 internal.KeyScopeErrorTTL，
+// 避免 API 不可用时每次下单都重复请求密钥列表
+type keyScopeCache struct {
+	mu       sync.Mutex
+	resolved bool
+	scope    string
+	err      error
+	failErr  error            // 最近一次查询的临时错误
+	failedAt time.Time        // failErr 的发生时间
+	now      func() time.Time // 当前时间（测试中替换），nil 时使用 time.Now
+}
+
+// CurrentKeyScope 返回当前使用的 API 凭证的权限范围（types.APIKeyScopeRead 或 types.APIKeyScopeTrade）
+// 在 API 密钥列表和只读密钥列表中查找当前凭证，结果会被缓存
+func (c *apiKeyClientImpl) CurrentKeyScope() (string, error) {
+	return c.baseClient.currentKeyScope()
+}
+
+// currentKeyScope 查询并缓存当前凭证的权限范围
+// 查询密钥列表时不持有锁，慢请求不会阻塞其他读取缓存的调用
+func (c *baseClient) currentKeyScope() (string, error) {
+	cache := c.keyScope
+	if cache == nil {
+		return c.fetchKeyScope()
+	}
+
+	cache.mu.Lock()
+	if cache.resolved {
+		scope, err := cache.scope, cache.err
+		cache.mu.Unlock()
+		return scope, err
+	}
+	if cache.failErr != nil && cache.clock().Sub(cache.failedAt) < internal.KeyScopeErrorTTL {
+		err := cache.failErr
+		cache.mu.Unlock()
+		return "", err
+	}
+	cache.mu.Unlock()
+
+	scope, err := c.fetchKeyScope()
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if err != nil && !errors.Is(err, errAPIKeyNotListed) {
+		// 临时错误只缓存一小段时间，过期后重新查询
+		cache.failErr, cache.failedAt = err, cache.clock()
+		return "", err
+	}
+	cache.resolved = true
+	cache.scope, cache.err = scope, err
+	cache.failErr = nil
+	return scope, err
+}
+
+// clock 返回当前时间
+func (cache *keyScopeCache) clock() time.Time {
+	if cache.now != nil {
+		return cache.now()
+	}
+	return time.Now()
+}
+
+// errAPIKeyNotListed 当前凭证不在任何 API 密钥列表中
+var errAPIKeyNotListed = errors.New("current API key not found in API keys list")
+
+// fetchKeyScope 请求 API 密钥列表并确定当前凭证的权限范围
+func (c *baseClient) fetchKeyScope() (string, error) {
+	apiKeyClient := &apiKeyClientImpl{baseClient: c}
+	keys, err := apiKeyClient.GetAPIKeys()
+	if err != nil {
+		return "", err
+	}
+	// 只读密钥列表查询失败不影响结果，当前凭证通常在完整列表中
+	readonlyKeys, err := apiKeyClient.GetReadonlyAPIKeys()
+	if err != nil {
+		internal.LogDebug("获取只读 API 密钥列表失败: %v", err)
+	}
+
+	scope, ok := scopeForKey(c.deriveCreds.Key, keys, readonlyKeys)
+	if !ok {
+		return "", errAPIKeyNotListed
+	}
+	return scope, nil
+}
+
+// ensureCanTrade 下单前检查当前凭证是否具备交易权限
+// 只读凭证返回 types.ErrAPIKeyCannotTrade；权限查询失败时不阻止下单，由服务端最终判断
+func (c *baseClient) ensureCanTrade() error {
+	if c.deriveCreds == nil {
+		return nil
+	}
+	scope, err := c.currentKeyScope()
+	if err != nil {
+		internal.LogDebug("无法确认 API 密钥权限范围，继续提交: %v", err)
+		return nil
+	}
+	if scope == types.APIKeyScopeRead {
+		return types.ErrAPIKeyCannotTrade
+	}
+	return nil
+}
+
+// scopeForKey 在密钥列表中查找指定密钥的权限范围
+// 出现在只读密钥列表中的密钥视为只读
+func scopeForKey(key string, keys []types.APIKey, readonlyKeys []types.APIKey) (string, bool) {
+	for _, k := range readonlyKeys {
+		if k.Key == key {
+			return types.APIKeyScopeRead, true
+		}
+	}
+	for _, k := range keys {
+		if k.Key == key {
+			return k.EffectiveScope(), true
+		}
+	}
+	return "", false
+}
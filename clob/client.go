@@ -1,51 +1,94 @@
 package clob
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/polymarket/go-order-utils/pkg/builder"
+	ordermodel "github.com/polymarket/go-order-utils/pkg/model"
+	"github.com/polymas/go-polymarket-sdk/data"
+	"github.com/polymas/go-polymarket-sdk/gamma"
 	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/rfq"
 	"github.com/polymas/go-polymarket-sdk/types"
 	"github.com/polymas/go-polymarket-sdk/web3"
 )
 
 // OrderClient 订单相关操作的轻量接口
 type OrderClient interface {
-	GetOrders(orderID *types.Keccak256, conditionID *types.Keccak256, tokenID *string) ([]types.OpenOrder, error)
+	GetOrders(orderID *types.Keccak256, conditionID *types.Keccak256, tokenID *string, options ...GetOrdersOption) ([]types.OpenOrder, error)
+	GetOrder(orderID types.Keccak256) (*types.OpenOrder, error)
+	GetOrdersCount(conditionID *types.Keccak256) (int, error)
+	GetTrade(tradeID string) (*types.ClobTrade, error)
+	WaitForOrderStatus(orderID types.Keccak256, target string, timeout time.Duration) (*types.OpenOrder, error)
+	WaitForOrderStatusContext(ctx context.Context, orderID types.Keccak256, target string, timeout time.Duration) (*types.OpenOrder, error)
 	CreateAndPostOrders(orderArgsList []types.OrderArgs, orderTypes []types.OrderType) ([]types.OrderPostResponse, error)
-	CancelOrders(orderIDs []types.Keccak256) (*types.OrderCancelResponse, error)
+	ReplaceOrders(cancelIDs []types.Keccak256, newOrders []types.OrderArgs, orderTypes []types.OrderType) (*types.ReplaceResult, error)
+	CancelOrders(orderIDs []types.Keccak256, options ...CancelOrdersOption) (*types.OrderCancelResponse, error)
 	CancelAll() (*types.OrderCancelResponse, error)
 	PostOrder(orderArgs types.OrderArgs, orderType types.OrderType) (*types.OrderPostResponse, error)
+	PostSignedOrder(signed *ordermodel.SignedOrder, orderType types.OrderType, owner string) (*types.OrderPostResponse, error)
+	PostRawOrder(orderArgs types.OrderArgsRaw, orderType types.OrderType) (*types.OrderPostResponse, error)
+	VerifyOrderSignature(signed *ordermodel.SignedOrder) (bool, error)
 	CancelOrder(orderID types.Keccak256) (*types.OrderCancelResponse, error)
 	CancelMarketOrders(conditionID types.Keccak256) (*types.OrderCancelResponse, error)
+	CancelMarketsOrders(conditionIDs []types.Keccak256, options ...CancelOrdersOption) (*types.MarketsCancelResponse, error)
+	EstimateOrderFee(orderArgs types.OrderArgs) (float64, error)
+	RequiredCollateral(orders []types.OrderArgs) (float64, error)
+	RequiredTokenBalances(orders []types.OrderArgs) (map[string]float64, error)
+	ExpirationFromNow(d time.Duration) int64
 }
 
 // MarketDataClient 市场数据相关操作的轻量接口
 type MarketDataClient interface {
-	GetOrderBook(tokenID string) (*types.OrderBookSummary, error)
+	GetOrderBook(tokenID string, options ...GetOrderBookOption) (*types.OrderBookSummary, error)
+	GetOrderBookDepth(tokenID string, levels int) (*types.OrderBookSummary, error)
+	PollOrderBookChanges(ctx context.Context, tokenID string, interval time.Duration) (<-chan types.BookDiff, error)
+	GetOrderBookRaw(tokenID string) (*types.OrderBookSummary, []byte, error)
+	GetQuote(tokenID string) (*types.Quote, error)
+	GetQuotes(tokenIDs []string) ([]types.Quote, error)
 	GetMultipleOrderBooks(requests []types.BookParams) ([]types.OrderBookSummaryResponse, error)
-	GetMidpoint(tokenID string) (*types.Midpoint, error)
+	GetMidpoint(tokenID string, options ...GetMidpointOption) (*types.Midpoint, error)
 	GetMidpoints(tokenIDs []string) ([]types.Midpoint, error)
+	GetTickSize(tokenID string) (types.TickSize, error)
+	ResolveTickSize(tokenID string, userTickSize *types.TickSize) (types.TickSize, error)
 	GetPrice(tokenID string, side types.OrderSide) (*types.Price, error)
 	GetPrices(requests []types.BookParams) ([]types.Price, error)
 	GetSpread(tokenID string) (*types.Spread, error)
 	GetSpreads(tokenIDs []string) ([]types.Spread, error)
 	GetLastTradePrice(tokenID string) (*types.LastTradePrice, error)
 	GetLastTradesPrices(tokenIDs []string) ([]types.LastTradePrice, error)
+	GetPricesHistory(tokenID string, interval types.PriceHistoryInterval, fidelity int) (*types.PriceHistory, error)
+	GetPricesHistoryBatch(tokenIDs []string, interval types.PriceHistoryInterval, fidelity int) (map[string]types.PriceHistory, error)
 	GetFeeRate(tokenID string) (int, error)
+	GetFeeRates(tokenIDs []string) (map[string]int, error)
+	GetNegRisk(tokenID string) (bool, error)
+	GetNegRisks(tokenIDs []string) (map[string]bool, error)
+	GetTokenMeta(tokenID string) (*types.TokenMeta, error)
 	GetTime() (time.Time, error)
 }
 
 // AccountClient 账户相关操作的轻量接口
 type AccountClient interface {
 	GetUSDCBalance() (float64, error)
+	GetUSDCBalanceRaw() (*big.Int, error)
 	GetBalanceAllowance() (*types.BalanceAllowance, error)
+	GetUSDCBalanceOf(address types.EthAddress) (float64, error)
+	GetBalanceAllowanceOf(address types.EthAddress) (*types.BalanceAllowance, error)
 	UpdateBalanceAllowance(amount float64) (*types.BalanceAllowance, error)
 	GetNotifications(limit int, offset int) ([]types.Notification, error)
+	GetAllNotifications() ([]types.Notification, error)
 	DropNotifications(notificationIDs []string) error
+	DropAllNotifications() error
+	MarkNotificationsRead(notificationIDs []string) error
+	RefreshBalances() error
+	GetPortfolioSummary(user types.EthAddress) (*types.Portfolio, error)
+	GetWinningToken(conditionID types.Keccak256) (tokenID string, outcome string, err error)
 }
 
 // APIKeyClient API Keys 管理相关操作的轻量接口
@@ -55,12 +98,22 @@ type APIKeyClient interface {
 	CreateReadonlyAPIKey() (*types.APIKey, error)
 	GetReadonlyAPIKeys() ([]types.APIKey, error)
 	DeleteReadonlyAPIKey(keyID string) error
+	CurrentKeyScope() (string, error)
 }
 
 // RewardClient 奖励相关操作的轻量接口
 type RewardClient interface {
 	IsOrderScoring(orderID types.Keccak256) (bool, error)
 	AreOrdersScoring(orderIDs []types.Keccak256) (map[types.Keccak256]bool, error)
+	GetRewardMarkets() ([]types.RewardMarket, error)
+	GetRewardsConfig() (*types.RewardsConfig, error)
+	GetRewardsEpoch(date string) (*types.RewardsEpoch, error)
+}
+
+// RFQClient 询价（Request for Quote）相关操作的轻量接口
+type RFQClient interface {
+	RequestQuote(ctx context.Context, tokenID string, side types.OrderSide, size float64) (*types.RFQQuote, error)
+	AcceptQuote(quoteID string) (*types.OrderPostResponse, error)
 }
 
 // ReadonlyClient 只读客户端接口，不需要私钥和API凭证
@@ -68,6 +121,11 @@ type RewardClient interface {
 type ReadonlyClient interface {
 	MarketDataClient
 	RewardClient
+	RateLimitStatus() http.RateLimitStatus
+	GetRateLimits() types.RateLimits
+	MetadataCacheStats() types.MetadataCacheStats
+	ChainID() types.ChainID
+	Environment() types.Environment
 }
 
 // Client 定义CLOB客户端的完整接口，通过组合各个功能接口实现
@@ -77,28 +135,445 @@ type Client interface {
 	OrderClient
 	AccountClient
 	APIKeyClient
+	RFQClient
+	CreateOrDeriveAPICreds() (*types.ApiCreds, error) // 创建或派生 API 凭证
+	ServerTimeOffset() time.Duration                  // 服务器时间与本地时间的偏差
+	Close()                                           // 停止后台任务（如定期时间同步）
 }
 
+// 编译期检查：实现类型的所有公开方法都通过接口暴露，调用方可以针对接口编写 mock
+var (
+	_ Client         = (*polymarketClobClient)(nil)
+	_ ReadonlyClient = (*readonlyClobClient)(nil)
+)
+
 // baseClient 基础客户端结构，包含所有共享的字段和方法
 type baseClient struct {
-	address       types.EthAddress // Base address
-	proxyAddress  types.EthAddress // Proxy address (for proxy wallets), cached
-	baseURL       string           // API 基础 URL
-	signatureType types.SignatureType
-	deriveCreds   *types.ApiCreds
-	tickSizes     map[string]types.TickSize
-	negRisk       map[string]bool
-	feeRates      map[string]int
-	orderBuilder  *builder.ExchangeOrderBuilderImpl
-	web3Client    web3.Client // 保存 Web3Client 引用（可能为nil，用于只读客户端）
+	address           types.EthAddress // Base address
+	proxyAddress      types.EthAddress // Proxy address (for proxy wallets), cached
+	baseURL           string           // API 基础 URL
+	signatureType     types.SignatureType
+	deriveCreds       *types.ApiCreds
+	tickSizes         *lruCache[types.TickSize]
+	negRisk           *negRiskCache
+	feeRates          *feeRateCache
+	tokenMeta         *tokenMetaCache
+	rewardMarkets     *rewardMarketsCache
+	balances          *balanceCache
+	keyScope          *keyScopeCache
+	minSizePolicy     types.MinOrderSizePolicy
+	orderSource       string
+	concurrentBatches int
+	bookSource        OrderBookSource  // 本地维护的订单簿（如 WSS 订阅），nil 表示总是通过 REST 查询
+	rateLimits        types.RateLimits // 通过 WithRateLimits 配置的限流，零值字段使用默认值
+	balancePrecheck   bool
+	dataClient        data.Client  // 查询持仓使用的 Data API 客户端（GetPortfolioSummary）
+	gammaClient       gamma.Client // 查询市场代币使用的 Gamma API 客户端（GetWinningToken）
+	rfqClient         rfq.Client   // 询价使用的 RFQ 客户端，附加本客户端的 L2 认证
+	authDebug         bool         // 输出 L1/L2 认证签名内容（WithAuthDebug）
+	orderBuilder      *builder.ExchangeOrderBuilderImpl
+	web3Client        web3.Client       // 保存 Web3Client 引用（可能为nil，用于只读客户端）
+	httpOptions       []http.HTTPOption // 客户端级别的 HTTP 选项（如代理）
+	timeOffset        int64             // 服务器时间与本地时间的偏差（纳秒，使用 atomic 操作）
+	timeSynced        int32             // 是否已同步过服务器时间（使用 atomic 操作）
+	stopTimeSync      chan struct{}     // 关闭后停止后台时间同步
+	closeOnce         sync.Once
 }
 
 // readonlyBaseClient 只读客户端的基础结构，不包含认证相关字段
 type readonlyBaseClient struct {
-	baseURL   string
-	tickSizes map[string]types.TickSize
-	negRisk   map[string]bool
-	feeRates  map[string]int
+	baseURL       string
+	tickSizes     *lruCache[types.TickSize]
+	negRisk       *negRiskCache
+	feeRates      *feeRateCache
+	tokenMeta     *tokenMetaCache
+	rewardMarkets *rewardMarketsCache
+	bookSource    OrderBookSource
+	rateLimits    types.RateLimits
+	httpOptions   []http.HTTPOption
+}
+
+// ClientOption CLOB 客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions CLOB 客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+	balanceCacheTTL   time.Duration
+	timeSyncInterval  time.Duration
+	minSizePolicy     types.MinOrderSizePolicy
+	orderSource       string
+	concurrentBatches int
+	bookSource        OrderBookSource
+	metadataCacheSize int
+	rateLimits        types.RateLimits
+	balancePrecheck   bool
+	dataClient        data.Client
+	gammaClient       gamma.Client
+	authDebug         bool
+}
+
+// WithProxyURL 设置客户端使用的代理地址
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
+}
+
+// WithBalanceCacheTTL 启用余额缓存，ttl 内重复查询 USDC 余额和授权信息直接返回缓存
+// 订单提交成功或更新授权后缓存会自动失效，也可以通过 RefreshBalances 强制刷新
+// 默认不缓存（ttl 为 0）
+func WithBalanceCacheTTL(ttl time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.balanceCacheTTL = ttl
+	}
+}
+
+// WithTimeouts 设置客户端的超时配置，未设置的字段使用默认值
+// CLOB 客户端只使用其中的 HTTP 超时
+func WithTimeouts(timeouts types.Timeouts) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Timeouts = timeouts
+	}
+}
+
+// WithPeriodicTimeSync 启用后台定期同步服务器时间，每隔 interval 调用一次 GetTime 更新时间偏差
+// 长时间运行的进程本地时钟会逐渐漂移，导致 L2 认证签名的时间戳被拒绝；启用后签名使用校正后的时间
+// 仅对完整客户端生效，通过 Close 停止。默认不启用（interval 为 0）
+func WithPeriodicTimeSync(interval time.Duration) ClientOption {
+	return func(opts *clientOptions) {
+		opts.timeSyncInterval = interval
+	}
+}
+
+// WithTransport 设置 CLOB 请求使用的传输层，默认创建的 Data、Gamma 和 RFQ 子客户端也使用它
+// 录制、回放的用法见 http.WithTransport
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Transport = transport
+	}
+}
+
+// WithMinOrderSizePolicy 设置订单数量低于市场最小下单数量时的处理方式
+// types.MinOrderSizeClamp（默认）将数量提高到最小值后提交；types.MinOrderSizeReject 不提交该订单并返回错误
+// 最小下单数量取自 OrderArgs.MinSize，未指定时使用已缓存的代币元数据中的 MinOrderSize，都没有时为 internal.DefaultMarketMinOrderSize
+func WithMinOrderSizePolicy(policy types.MinOrderSizePolicy) ClientOption {
+	return func(opts *clientOptions) {
+		opts.minSizePolicy = policy
+	}
+}
+
+// WithOrderSource 为通过该客户端提交的订单设置来源标签（如策略或机器人名称）
+// CLOB 下单接口没有来源字段，标签不会发送给服务端：提交结果的 OrderPostResponse.Source 会设置为该标签，
+// 并写入下单日志，便于同一账户运行多个策略时在本地区分订单来源
+func WithOrderSource(tag string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.orderSource = tag
+	}
+}
+
+// WithConcurrentBatches 设置 CreateAndPostOrders 超过 15 个订单时最多同时提交的批次数
+// 默认（n 不大于 1）逐批顺序提交；并发提交时每批开始前随机等待一小段时间以避免触发限流，返回结果仍与输入顺序一致
+func WithConcurrentBatches(n int) ClientOption {
+	return func(opts *clientOptions) {
+		opts.concurrentBatches = n
+	}
+}
+
+// OrderBookSource 提供本地维护的订单簿，websocket.Client 实现了该接口
+// 返回 false 表示没有可用的本地数据（未订阅、未收到快照或连接已断开）
+type OrderBookSource interface {
+	OrderBook(tokenID string) (*types.OrderBookSummary, bool)
+}
+
+// WithMetadataCacheSize 设置代币元数据缓存（tick size、负风险、手续费率、TokenMeta）各自的最大条目数，
+// 超过时淘汰最久未使用的代币，使遍历大量市场的长期进程内存有上限。
+// 默认为 internal.MetadataCacheSize；size < 0 时不限制条目数量
+func WithMetadataCacheSize(size int) ClientOption {
+	return func(opts *clientOptions) {
+		opts.metadataCacheSize = size
+	}
+}
+
+// cacheSize 返回代币元数据缓存的容量，未设置时使用默认值，0 表示不限制
+func (opts *clientOptions) cacheSize() int {
+	switch {
+	case opts.metadataCacheSize == 0:
+		return internal.MetadataCacheSize
+	case opts.metadataCacheSize < 0:
+		return 0
+	default:
+		return opts.metadataCacheSize
+	}
+}
+
+// WithRateLimits 设置客户端的限流配置（如账户的实际限流等级），零值字段保留默认值
+// 客户端按该配置限速，GetRateLimits 返回同样的值
+func WithRateLimits(limits types.RateLimits) ClientOption {
+	return func(opts *clientOptions) {
+		opts.rateLimits = limits
+	}
+}
+
+// WithOrderBookSource 设置本地订单簿来源，通常传入已订阅 MARKET 频道的 websocket.Client
+// 设置后 GetOrderBook、GetQuote 和 GetQuotes 优先使用来源中的订单簿，没有本地数据的代币回退到 REST 查询；
+// 返回的 OrderBookSummary.Source 和 Quote.Source 标明数据来自 wss 还是 rest
+func WithOrderBookSource(src OrderBookSource) ClientOption {
+	return func(opts *clientOptions) {
+		opts.bookSource = src
+	}
+}
+
+// WithBalancePrecheck 提交订单前检查 USDC 余额是否足够支付所有 BUY 订单（见 RequiredCollateral）、
+// 对应交易所的 USDC 授权额度是否足够（负风险市场检查 NegRiskCTFExchange 和 NegRiskAdapter），
+// 以及代币余额是否足够卖出所有 SELL 订单、交易所是否已获得代币的 ERC1155 授权（见 RequiredTokenBalances）
+// 余额不足时不提交并返回 *types.InsufficientBalanceError（errors.Is(err, types.ErrInsufficientBalance) 为 true），
+// 其中包含所需数量和缺口；USDC 授权不足时返回 *types.InsufficientAllowanceError，代币未授权时返回 *types.TokenNotApprovedError。
+// CreateAndPostOrders、PostSignedOrder 和 PostRawOrder 都会检查，已签名订单按签名的 makerAmount 计算所需数量。
+// 每次下单多一次余额查询（USDC 授权、代币余额和授权各合并为一次 Multicall），可配合 WithBalanceCacheTTL 使用；默认不检查
+func WithBalancePrecheck() ClientOption {
+	return func(opts *clientOptions) {
+		opts.balancePrecheck = true
+	}
+}
+
+// WithDataClient 设置 GetPortfolioSummary 查询持仓使用的 Data API 客户端
+// 默认使用与 CLOB 客户端相同的代理、超时和传输层配置创建
+func WithDataClient(client data.Client) ClientOption {
+	return func(opts *clientOptions) {
+		opts.dataClient = client
+	}
+}
+
+// buildDataClient 返回配置的 Data API 客户端，未配置时按 CLOB 客户端的网络配置创建
+func (opts *clientOptions) buildDataClient() data.Client {
+	if opts.dataClient != nil {
+		return opts.dataClient
+	}
+	return data.NewClient(
+		data.WithProxyURL(opts.ProxyURL),
+		data.WithTimeouts(opts.Timeouts),
+		data.WithTransport(opts.Transport),
+	)
+}
+
+// WithGammaClient 设置 GetWinningToken 查询市场代币使用的 Gamma API 客户端
+// 默认使用与 CLOB 客户端相同的代理、超时和传输层配置创建
+func WithGammaClient(client gamma.Client) ClientOption {
+	return func(opts *clientOptions) {
+		opts.gammaClient = client
+	}
+}
+
+// buildGammaClient 返回配置的 Gamma API 客户端，未配置时按 CLOB 客户端的网络配置创建
+func (opts *clientOptions) buildGammaClient() gamma.Client {
+	if opts.gammaClient != nil {
+		return opts.gammaClient
+	}
+	return gamma.NewClient(
+		gamma.WithProxyURL(opts.ProxyURL),
+		gamma.WithTimeouts(opts.Timeouts),
+		gamma.WithTransport(opts.Transport),
+	)
+}
+
+// WithAuthDebug 通过日志（INFO 级别）输出每次 L1/L2 认证请求头实际签名的内容，用于排查 401 认证失败
+// L1 输出 EIP-712 域、ClobAuth 消息各字段、摘要和签名；L2 输出参与 HMAC 的完整消息字符串和签名。
+// 可与 Python SDK 的签名内容逐字节对比。API secret 和 passphrase 不会输出，但签名和地址会输出，只应在排查问题时开启
+func WithAuthDebug() ClientOption {
+	return func(opts *clientOptions) {
+		opts.authDebug = true
+	}
+}
+
+// buildHTTPOptions 根据客户端配置构建 HTTP 选项
+// 代理地址无效时返回的选项使每个请求都返回该错误；请求按 GetRateLimits 返回的限流配置限速
+func (o *clientOptions) buildHTTPOptions() []http.HTTPOption {
+	limiter := http.NewRateLimiter(defaultRateLimits(o.rateLimits))
+	return append(http.ConnectionHTTPOptions(o.ConnectionOptions), http.WithRateLimiter(limiter))
+}
+
+// parseClientOptions 解析并验证客户端配置选项
+// 验证失败时仍返回已解析的配置，便于无法返回错误的构造函数继续使用
+func parseClientOptions(options []ClientOption) (*clientOptions, error) {
+	opts := internal.ApplyOptions(&clientOptions{}, options)
+
+	if _, err := opts.Proxy(); err != nil {
+		return opts, err
+	}
+	switch opts.minSizePolicy {
+	case "", types.MinOrderSizeClamp, types.MinOrderSizeReject:
+	default:
+		return opts, fmt.Errorf("invalid min order size policy: %q", opts.minSizePolicy)
+	}
+	if opts.concurrentBatches < 0 {
+		return opts, fmt.Errorf("invalid concurrent batches: %d", opts.concurrentBatches)
+	}
+	if l := opts.rateLimits; l.Window < 0 || l.General < 0 || l.MarketData < 0 || l.PostOrder < 0 || l.CancelOrder < 0 {
+		return opts, fmt.Errorf("invalid rate limits: %+v", l)
+	}
+
+	return opts, nil
+}
+
+// requestOptions 合并客户端级别的 HTTP 选项和请求级别的选项
+func (c *baseClient) requestOptions(options ...http.HTTPOption) []http.HTTPOption {
+	return append(append([]http.HTTPOption{}, c.httpOptions...), options...)
+}
+
+// requestOptions 合并客户端级别的 HTTP 选项和请求级别的选项
+func (c *readonlyBaseClient) requestOptions(options ...http.HTTPOption) []http.HTTPOption {
+	return append(append([]http.HTTPOption{}, c.httpOptions...), options...)
+}
+
+// RateLimitStatus 返回 CLOB API 最近一次响应中的限流状态
+// 可用于在触发 429 之前主动降低请求频率
+func (c *baseClient) RateLimitStatus() http.RateLimitStatus {
+	return http.GetRateLimitStatus(c.baseURL)
+}
+
+// RateLimitStatus 返回 CLOB API 最近一次响应中的限流状态
+func (c *readonlyBaseClient) RateLimitStatus() http.RateLimitStatus {
+	return http.GetRateLimitStatus(c.baseURL)
+}
+
+// MetadataCacheStats 返回代币元数据缓存的命中、未命中、淘汰次数和条目数
+func (c *baseClient) MetadataCacheStats() types.MetadataCacheStats {
+	return metadataCacheStats(c.tickSizes, c.negRisk, c.feeRates, c.tokenMeta)
+}
+
+// MetadataCacheStats 返回代币元数据缓存的命中、未命中、淘汰次数和条目数
+func (c *readonlyBaseClient) MetadataCacheStats() types.MetadataCacheStats {
+	return metadataCacheStats(c.tickSizes, c.negRisk, c.feeRates, c.tokenMeta)
+}
+
+// metadataCacheStats 汇总各个代币元数据缓存的统计
+func metadataCacheStats(tickSizes *lruCache[types.TickSize], negRisk *negRiskCache, feeRates *feeRateCache, tokenMeta *tokenMetaCache) types.MetadataCacheStats {
+	return types.MetadataCacheStats{
+		TickSize:  tickSizes.stats(),
+		NegRisk:   negRisk.values.stats(),
+		FeeRate:   feeRates.values.stats(),
+		TokenMeta: tokenMeta.entries.stats(),
+	}
+}
+
+// ChainID 返回客户端签名订单使用的链ID（来自 web3 客户端，NewClient 保证与 CLOB API 地址对应的链一致）
+func (c *baseClient) ChainID() types.ChainID {
+	return c.web3Client.GetChainID()
+}
+
+// Environment 返回客户端所在的网络环境，下单前可用于确认是否为主网
+func (c *baseClient) Environment() types.Environment {
+	return c.ChainID().Environment()
+}
+
+// ChainID 返回只读客户端对应的链ID
+// 只读客户端固定访问主网 CLOB API，始终返回 Polygon
+func (c *readonlyBaseClient) ChainID() types.ChainID {
+	return internal.Polygon
+}
+
+// Environment 返回只读客户端所在的网络环境
+func (c *readonlyBaseClient) Environment() types.Environment {
+	return c.ChainID().Environment()
+}
+
+// setTimeOffset 记录服务器时间与本地时间的偏差
+func (c *baseClient) setTimeOffset(offset time.Duration) {
+	atomic.StoreInt64(&c.timeOffset, int64(offset))
+	atomic.StoreInt32(&c.timeSynced, 1)
+}
+
+// isTimeSynced 是否已同步过服务器时间
+func (c *baseClient) isTimeSynced() bool {
+	return atomic.LoadInt32(&c.timeSynced) == 1
+}
+
+// serverNow 返回按服务器时钟校正后的当前时间
+func (c *baseClient) serverNow() time.Time {
+	return time.Now().Add(time.Duration(atomic.LoadInt64(&c.timeOffset)))
+}
+
+// ServerTimeOffset 返回最近一次同步得到的服务器时间与本地时间的偏差（服务器时间 - 本地时间）
+// 尚未同步时返回 0
+func (c *baseClient) ServerTimeOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.timeOffset))
+}
+
+// level2Headers 创建 L2 认证请求头
+// 已同步服务器时间时使用校正后的时间戳签名，避免本地时钟漂移导致认证失败
+func (c *baseClient) level2Headers(requestArgs *types.RequestArgs) (map[string]string, error) {
+	c.applyServerTimestamp(requestArgs)
+	headers, err := internal.CreateLevel2Headers(c.web3Client.GetSigner(), c.deriveCreds, requestArgs, false)
+	if err == nil && c.authDebug {
+		internal.LogInfo("[auth debug] %s", internal.DescribeLevel2Auth(requestArgs, headers))
+	}
+	return headers, err
+}
+
+// level1Headers 创建 L1 认证请求头（创建或派生 API 凭证时使用）
+func (c *baseClient) level1Headers() (map[string]string, error) {
+	headers, err := internal.CreateLevel1Headers(c.web3Client.GetSigner(), nil)
+	if err == nil && c.authDebug {
+		internal.LogInfo("[auth debug] %s", internal.DescribeLevel1Auth(c.web3Client.GetSigner(), headers))
+	}
+	return headers, err
+}
+
+// assertSignedBody 签名断言模式下校验实际发送的 body 与签名一致，不一致时直接 panic
+// 用于在测试中尽早发现只修改了 body 格式或签名路径其中之一的回归
+func (c *baseClient) assertSignedBody(requestArgs *types.RequestArgs, headers map[string]string, body []byte) {
+	if !internal.SignatureAssertionsEnabled() {
+		return
+	}
+	if err := internal.VerifySignedBody(c.deriveCreds.Secret, headers, requestArgs.Method, requestArgs.RequestPath, body); err != nil {
+		panic(err)
+	}
+}
+
+// applyServerTimestamp 已同步服务器时间时为请求设置校正后的签名时间戳
+func (c *baseClient) applyServerTimestamp(requestArgs *types.RequestArgs) {
+	if requestArgs.Timestamp == 0 && c.isTimeSynced() {
+		requestArgs.Timestamp = c.serverNow().UTC().Unix()
+	}
+}
+
+// startTimeSync 启动后台时间同步，每隔 interval 调用 syncTime 更新时间偏差
+// 偏差超过 internal.TimeSyncDriftWarnThreshold 时记录警告
+func (c *baseClient) startTimeSync(interval time.Duration, syncTime func() (time.Time, error)) {
+	stop := c.stopTimeSync
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if _, err := syncTime(); err != nil {
+					internal.LogWarn("定期同步服务器时间失败: %v", err)
+					continue
+				}
+				offset := c.ServerTimeOffset()
+				if offset > internal.TimeSyncDriftWarnThreshold || offset < -internal.TimeSyncDriftWarnThreshold {
+					internal.LogWarn("本地时钟与服务器时间偏差较大: %v", offset)
+				} else {
+					internal.LogDebug("服务器时间已同步，偏差: %v", offset)
+				}
+			}
+		}
+	}()
+}
+
+// Close 停止客户端的后台任务（如定期时间同步），可重复调用
+func (c *baseClient) Close() {
+	c.closeOnce.Do(func() {
+		if c.stopTimeSync != nil {
+			close(c.stopTimeSync)
+		}
+	})
 }
 
 // orderClientImpl 订单功能模块实现
@@ -154,18 +629,30 @@ type polymarketClobClient struct {
 	*accountClientImpl
 	*apiKeyClientImpl
 	*rewardClientImpl
+	*rfqClientImpl
 }
 
 // NewReadonlyClient 创建只读CLOB客户端
 // 不需要私钥和API凭证，只能使用公开的市场数据和奖励查询接口
+// 代理地址无效时后续请求都返回该错误；其他无效配置记录错误日志
 // 返回 ReadonlyClient 接口
-func NewReadonlyClient() ReadonlyClient {
+func NewReadonlyClient(options ...ClientOption) ReadonlyClient {
+	opts, err := parseClientOptions(options)
+	if err != nil {
+		internal.LogError("invalid CLOB client options: %v", err)
+	}
+
 	// 创建只读基础客户端
 	readonlyBase := &readonlyBaseClient{
-		baseURL:   internal.ClobAPIDomain,
-		tickSizes: make(map[string]types.TickSize),
-		negRisk:   make(map[string]bool),
-		feeRates:  make(map[string]int),
+		baseURL:       internal.ClobAPIDomain,
+		tickSizes:     newLRUCache[types.TickSize](opts.cacheSize()),
+		negRisk:       newNegRiskCache(opts.cacheSize()),
+		feeRates:      newFeeRateCache(opts.cacheSize()),
+		tokenMeta:     newTokenMetaCache(opts.cacheSize()),
+		rewardMarkets: &rewardMarketsCache{},
+		bookSource:    opts.bookSource,
+		rateLimits:    opts.rateLimits,
+		httpOptions:   opts.buildHTTPOptions(),
 	}
 
 	// 创建功能模块
@@ -184,29 +671,57 @@ func NewReadonlyClient() ReadonlyClient {
 // 需要私钥和API凭证，可以使用所有功能接口
 // 在初始化时自动调用 createOrDeriveAPICreds 获取 API 凭证
 // 返回 Client 接口，不允许直接访问实现类型
-func NewClient(web3Client web3.Client) (Client, error) {
+func NewClient(web3Client web3.Client, options ...ClientOption) (Client, error) {
+	opts, err := parseClientOptions(options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client options: %w", err)
+	}
+
 	// 从 web3.Client 获取所需信息
 	signatureType := web3Client.GetSignatureType()
+	if !signatureType.IsValid() {
+		return nil, fmt.Errorf("invalid signature type: %s", signatureType)
+	}
 	address := web3Client.GetBaseAddress()
 
+	// CLOB API 地址由链决定，避免测试网配置（签名、Environment 为 testnet）把订单提交到主网
+	baseURL, ok := internal.ClobAPIDomainForChain(web3Client.GetChainID())
+	if !ok {
+		return nil, fmt.Errorf("no CLOB API for chain %d (%s)", web3Client.GetChainID(), web3Client.GetChainID().Environment())
+	}
+
 	// Create order builder
 	chainIDBig := big.NewInt(int64(web3Client.GetChainID()))
-	orderBuilder := builder.NewExchangeOrderBuilderImpl(chainIDBig, func() int64 {
-		return time.Now().UnixNano()
-	})
+	orderBuilder := builder.NewExchangeOrderBuilderImpl(chainIDBig, newSaltGenerator())
 
 	// 创建基础客户端
 	base := &baseClient{
-		address:       address,
-		proxyAddress:  "", // Will be set in initialization
-		baseURL:       internal.ClobAPIDomain,
-		signatureType: signatureType,
-		tickSizes:     make(map[string]types.TickSize),
-		negRisk:       make(map[string]bool),
-		feeRates:      make(map[string]int),
-		orderBuilder:  orderBuilder,
-		web3Client:    web3Client,
+		address:           address,
+		proxyAddress:      "", // Will be set in initialization
+		baseURL:           baseURL,
+		signatureType:     signatureType,
+		tickSizes:         newLRUCache[types.TickSize](opts.cacheSize()),
+		negRisk:           newNegRiskCache(opts.cacheSize()),
+		feeRates:          newFeeRateCache(opts.cacheSize()),
+		tokenMeta:         newTokenMetaCache(opts.cacheSize()),
+		rewardMarkets:     &rewardMarketsCache{},
+		balances:          &balanceCache{ttl: opts.balanceCacheTTL},
+		keyScope:          &keyScopeCache{},
+		minSizePolicy:     opts.minSizePolicy,
+		orderSource:       opts.orderSource,
+		concurrentBatches: opts.concurrentBatches,
+		bookSource:        opts.bookSource,
+		rateLimits:        opts.rateLimits,
+		balancePrecheck:   opts.balancePrecheck,
+		dataClient:        opts.buildDataClient(),
+		gammaClient:       opts.buildGammaClient(),
+		authDebug:         opts.authDebug,
+		orderBuilder:      orderBuilder,
+		web3Client:        web3Client,
+		httpOptions:       opts.buildHTTPOptions(),
+		stopTimeSync:      make(chan struct{}),
 	}
+	base.rfqClient = opts.buildRFQClient(base.level2Headers)
 
 	// 自动创建或派生 API 凭证
 	derivedCreds, err := base.CreateOrDeriveAPICreds()
@@ -228,6 +743,7 @@ func NewClient(web3Client web3.Client) (Client, error) {
 	accountClient := &accountClientImpl{baseClient: base}
 	apiKeyClient := &apiKeyClientImpl{baseClient: base}
 	rewardClient := &rewardClientImpl{baseClient: base}
+	rfqClient := &rfqClientImpl{baseClient: base}
 
 	// 组合所有功能模块
 	clobClient := &polymarketClobClient{
@@ -237,6 +753,15 @@ func NewClient(web3Client web3.Client) (Client, error) {
 		accountClientImpl:    accountClient,
 		apiKeyClientImpl:     apiKeyClient,
 		rewardClientImpl:     rewardClient,
+		rfqClientImpl:        rfqClient,
+	}
+
+	// 定期同步服务器时间：先同步一次，之后在后台按间隔更新
+	if opts.timeSyncInterval > 0 {
+		if _, err := marketDataClient.GetTime(); err != nil {
+			internal.LogWarn("初始同步服务器时间失败: %v", err)
+		}
+		base.startTimeSync(opts.timeSyncInterval, marketDataClient.GetTime)
 	}
 
 	return clobClient, nil
@@ -245,19 +770,19 @@ func NewClient(web3Client web3.Client) (Client, error) {
 // CreateOrDeriveAPICreds creates or derives API credentials
 func (c *baseClient) CreateOrDeriveAPICreds() (*types.ApiCreds, error) {
 	// Try to create first
-	headers, err := internal.CreateLevel1Headers(c.web3Client.GetSigner(), nil)
+	headers, err := c.level1Headers()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create level 1 headers: %w", err)
 	}
 
-	creds, err := http.Post[types.ApiCreds](c.baseURL, internal.CreateAPIKey, nil, http.WithHeaders(headers))
+	creds, err := http.Post[types.ApiCreds](c.baseURL, internal.CreateAPIKey, nil, c.requestOptions(http.WithHeaders(headers))...)
 	if err != nil {
 		// If creation fails, try to derive (need to recreate headers for GET request)
-		headers, err = internal.CreateLevel1Headers(c.web3Client.GetSigner(), nil)
+		headers, err = c.level1Headers()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create level 1 headers for derive: %w", err)
 		}
-		creds, err = http.Get[types.ApiCreds](c.baseURL, internal.DeriveAPIKey, nil, http.WithHeaders(headers))
+		creds, err = http.Get[types.ApiCreds](c.baseURL, internal.DeriveAPIKey, nil, c.requestOptions(http.WithHeaders(headers))...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create or derive API creds: %w", err)
 		}
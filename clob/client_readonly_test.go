@@ -1,14 +1,28 @@
 package clob
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/polymas/go-polymarket-sdk/gamma"
+	sdkhttp "github.com/polymas/go-polymarket-sdk/http"
+	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/test"
 	"github.com/polymas/go-polymarket-sdk/types"
 	"github.com/polymas/go-polymarket-sdk/web3"
+	"github.com/polymas/go-polymarket-sdk/websocket"
 )
 
 // newTestClobClient 创建测试用的CLOB客户端（只读测试不需要认证）
@@ -170,6 +184,33 @@ func TestGetOrderBook(t *testing.T) {
 		t.Logf("GetOrderBook returned order book for token: %s", testTokenID)
 	})
 
+	// 只返回最优的若干档
+	t.Run("Depth", func(t *testing.T) {
+		orderBook, err := client.GetOrderBookDepth(testTokenID, 3)
+		if err != nil {
+			t.Fatalf("GetOrderBookDepth failed: %v", err)
+		}
+		if len(orderBook.Bids) > 3 || len(orderBook.Asks) > 3 {
+			t.Errorf("Expected at most 3 levels, got %d bids and %d asks", len(orderBook.Bids), len(orderBook.Asks))
+		}
+	})
+
+	// 同时返回原始 JSON
+	t.Run("Raw", func(t *testing.T) {
+		orderBook, raw, err := client.GetOrderBookRaw(testTokenID)
+		if err != nil {
+			t.Fatalf("GetOrderBookRaw failed: %v", err)
+		}
+		if orderBook == nil || len(raw) == 0 {
+			t.Fatal("GetOrderBookRaw returned empty result")
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			t.Fatalf("Raw response is not valid JSON: %v", err)
+		}
+		t.Logf("GetOrderBookRaw returned %d bytes with %d top-level fields", len(raw), len(fields))
+	})
+
 	// 边界条件测试 - 无效token ID
 	t.Run("InvalidTokenID", func(t *testing.T) {
 		_, err := client.GetOrderBook("invalid-token-id")
@@ -190,6 +231,360 @@ func TestGetOrderBook(t *testing.T) {
 	})
 }
 
+// TestOrderBookDecodingTolerance 订单簿的 price/size 可能是字符串或数字，两种格式应解析出相同的结果
+func TestOrderBookDecodingTolerance(t *testing.T) {
+	const tokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	want := &types.OrderBookSummary{
+		TokenID: tokenID,
+		Bids:    []types.OrderLevel{{Price: 0.48, Size: 100}, {Price: 0.5, Size: 250.5}},
+		Asks:    []types.OrderLevel{{Price: 0.52, Size: 30}, {Price: 0.55, Size: 0}},
+	}
+
+	for _, file := range []string{"book_string.json", "book_numeric.json"} {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", file))
+			if err != nil {
+				t.Fatalf("failed to read fixture: %v", err)
+			}
+
+			var book types.OrderBookSummary
+			if err := json.Unmarshal(data, &book); err != nil {
+				t.Fatalf("failed to decode OrderBookSummary: %v", err)
+			}
+			if !reflect.DeepEqual(&book, want) {
+				t.Errorf("Expected %+v, got %+v", want, book)
+			}
+
+			var resp types.OrderBookSummaryResponse
+			if err := json.Unmarshal(data, &resp); err != nil {
+				t.Fatalf("failed to decode OrderBookSummaryResponse: %v", err)
+			}
+			if !reflect.DeepEqual(resp.Bids, want.Bids) || !reflect.DeepEqual(resp.Asks, want.Asks) {
+				t.Errorf("Expected levels %+v / %+v, got %+v / %+v", want.Bids, want.Asks, resp.Bids, resp.Asks)
+			}
+			if resp.MinOrderSize != "5" || resp.TickSize != "0.01" || resp.Timestamp != "1700000000000" {
+				t.Errorf("Expected min_order_size 5, tick_size 0.01, timestamp 1700000000000, got %q, %q, %q", resp.MinOrderSize, resp.TickSize, resp.Timestamp)
+			}
+
+			// 通过 GetOrderBook 完整解析回放的响应
+			replayer := sdkhttp.NewReplayerFromInteractions([]sdkhttp.Interaction{{
+				Method:     "GET",
+				URL:        internal.ClobAPIDomain + internal.GetOrderBook + "?token_id=" + tokenID,
+				StatusCode: 200,
+				Body:       string(data),
+			}})
+			client := NewReadonlyClient(WithTransport(replayer))
+			got, err := client.GetOrderBook(tokenID)
+			if err != nil {
+				t.Fatalf("GetOrderBook failed: %v", err)
+			}
+			// GetOrderBook 额外标明数据来源
+			wantREST := *want
+			wantREST.Source = types.BookSourceREST
+			if !reflect.DeepEqual(got, &wantREST) {
+				t.Errorf("Expected %+v, got %+v", &wantREST, got)
+			}
+		})
+	}
+}
+
+func TestGetPricesHistoryBatch(t *testing.T) {
+	const hour = 1699999200 // 整点的 Unix 秒时间戳
+	historyURL := func(tokenID string) string {
+		return internal.ClobAPIDomain + internal.GetPricesHistory + "?fidelity=60&interval=1d&market=" + tokenID
+	}
+	// 两个序列返回同一组小时桶，但时间戳有几秒偏差；B 的数据点乱序
+	replayer := sdkhttp.NewReplayerFromInteractions([]sdkhttp.Interaction{
+		{Method: "GET", URL: historyURL("A"), StatusCode: 200, Body: fmt.Sprintf(`{"history":[{"t":%d,"p":0.5},{"t":%d,"p":0.55}]}`, hour, hour+3600)},
+		{Method: "GET", URL: historyURL("B"), StatusCode: 200, Body: fmt.Sprintf(`{"history":[{"t":%d,"p":0.45},{"t":%d,"p":0.5}]}`, hour+3605, hour+12)},
+	})
+	client := NewReadonlyClient(WithTransport(replayer))
+
+	t.Run("Single", func(t *testing.T) {
+		history, err := client.GetPricesHistory("A", types.PriceHistoryInterval1d, 60)
+		if err != nil {
+			t.Fatalf("GetPricesHistory failed: %v", err)
+		}
+		if history.TokenID != "A" || len(history.History) != 2 {
+			t.Fatalf("Unexpected history: %+v", history)
+		}
+		if !history.History[0].Timestamp.Equal(time.Unix(hour, 0)) || history.History[0].Value != 0.5 {
+			t.Errorf("Unexpected first point: %+v", history.History[0])
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		histories, err := client.GetPricesHistoryBatch([]string{"A", "B", "A"}, types.PriceHistoryInterval1d, 60)
+		if err != nil {
+			t.Fatalf("GetPricesHistoryBatch failed: %v", err)
+		}
+		if len(histories) != 2 {
+			t.Fatalf("Expected 2 series, got %d", len(histories))
+		}
+		a, b := histories["A"].History, histories["B"].History
+		if len(a) != 2 || len(b) != 2 {
+			t.Fatalf("Expected 2 points per series, got %d and %d", len(a), len(b))
+		}
+		for i := range a {
+			if !a[i].Timestamp.Equal(b[i].Timestamp) {
+				t.Errorf("Point %d not aligned: %v vs %v", i, a[i].Timestamp, b[i].Timestamp)
+			}
+		}
+		if b[0].Value != 0.5 || b[1].Value != 0.45 {
+			t.Errorf("Expected B sorted by time, got %+v", b)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := client.GetPricesHistoryBatch([]string{"A", "missing"}, types.PriceHistoryInterval1d, 60)
+		if !errors.Is(err, sdkhttp.ErrNoRecordedResponse) || !strings.Contains(err.Error(), "token missing") {
+			t.Errorf("Expected error for unrecorded token, got %v", err)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		histories, err := client.GetPricesHistoryBatch(nil, types.PriceHistoryInterval1d, 60)
+		if err != nil || len(histories) != 0 {
+			t.Errorf("Expected empty result, got %v, %v", histories, err)
+		}
+	})
+}
+
+func TestGetTokenMeta(t *testing.T) {
+	market := `{"condition_id":"` + test.FixtureConditionID + `","tokens":[` +
+		`{"token_id":"` + test.FixtureYesTokenID + `","outcome":"Yes","price":0.51},` +
+		`{"token_id":"` + test.FixtureNoTokenID + `","outcome":"No","price":0.49}],` +
+		`"minimum_order_size":5,"minimum_tick_size":0.01,"neg_risk":true,"accepting_orders":true,"enable_order_book":true,"maker_base_fee":0,"taker_base_fee":1000}`
+	transport := test.NewFixtureTransport(t,
+		test.OrderBookFixture(),
+		test.Fixture{Path: internal.GetMarket + test.FixtureConditionID, Body: market},
+	)
+	client := NewReadonlyClient(WithTransport(transport))
+
+	meta, err := client.GetTokenMeta(test.FixtureYesTokenID)
+	if err != nil {
+		t.Fatalf("GetTokenMeta failed: %v", err)
+	}
+	want := types.TokenMeta{
+		TokenID:         test.FixtureYesTokenID,
+		ConditionID:     test.FixtureConditionID,
+		Outcome:         "Yes",
+		TickSize:        "0.01",
+		NegRisk:         true,
+		FeeRateBps:      1000,
+		MinOrderSize:    5,
+		AcceptingOrders: true,
+		EnableOrderBook: true,
+	}
+	if *meta != want {
+		t.Errorf("Expected %+v, got %+v", want, *meta)
+	}
+	if len(transport.Requests()) != 2 {
+		t.Errorf("Expected 2 requests, got %d", len(transport.Requests()))
+	}
+
+	// 同一市场的其他代币以及负风险、手续费率查询都命中缓存
+	other, err := client.GetTokenMeta(test.FixtureNoTokenID)
+	if err != nil || other.Outcome != "No" {
+		t.Fatalf("Expected cached No token meta, got %+v (err=%v)", other, err)
+	}
+	if negRisk, err := client.GetNegRisk(test.FixtureNoTokenID); err != nil || !negRisk {
+		t.Errorf("Expected cached neg risk, got %v (err=%v)", negRisk, err)
+	}
+	if feeRate, err := client.GetFeeRate(test.FixtureYesTokenID); err != nil || feeRate != 1000 {
+		t.Errorf("Expected cached fee rate 1000, got %v (err=%v)", feeRate, err)
+	}
+	if len(transport.Requests()) != 2 {
+		t.Errorf("Expected no further requests, got %d", len(transport.Requests()))
+	}
+}
+
+func TestGetQuote(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	t.Run("Single", func(t *testing.T) {
+		transport := test.NewFixtureTransport(t, test.OrderBookFixture())
+		client := NewReadonlyClient(WithTransport(transport))
+
+		quote, err := client.GetQuote(test.FixtureYesTokenID)
+		if err != nil {
+			t.Fatalf("GetQuote failed: %v", err)
+		}
+		if quote.TokenID != test.FixtureYesTokenID || quote.Bid != 0.5 || quote.Ask != 0.52 ||
+			!near(quote.Mid, 0.51) || !near(quote.Spread, 0.02) || quote.LastTrade != 0.51 {
+			t.Errorf("Unexpected quote: %+v", quote)
+		}
+		if len(transport.Requests()) != 1 {
+			t.Errorf("Expected 1 request, got %d", len(transport.Requests()))
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		books := `[` +
+			`{"asset_id":"` + test.FixtureNoTokenID + `","bids":[],"asks":[{"price":"0.5","size":"10"}],"last_trade_price":"0.49"},` +
+			`{"asset_id":"` + test.FixtureYesTokenID + `","bids":[{"price":"0.48","size":"10"}],"asks":[{"price":"0.52","size":"10"}],"last_trade_price":"0.51"}]`
+		transport := test.NewFixtureTransport(t, test.Fixture{Method: "POST", Path: internal.GetOrderBooks, Body: books})
+		client := NewReadonlyClient(WithTransport(transport))
+
+		quotes, err := client.GetQuotes([]string{test.FixtureYesTokenID, test.FixtureNoTokenID, "missing"})
+		if err != nil {
+			t.Fatalf("GetQuotes failed: %v", err)
+		}
+		if len(quotes) != 2 || quotes[0].TokenID != test.FixtureYesTokenID || quotes[1].TokenID != test.FixtureNoTokenID {
+			t.Fatalf("Expected quotes in input order, got %+v", quotes)
+		}
+		if !near(quotes[0].Mid, 0.5) || !near(quotes[0].Spread, 0.04) {
+			t.Errorf("Unexpected quote: %+v", quotes[0])
+		}
+		// 没有买单时不计算中间价和价差
+		if quotes[1].Bid != 0 || quotes[1].Ask != 0.5 || quotes[1].Mid != 0 || quotes[1].Spread != 0 || quotes[1].LastTrade != 0.49 {
+			t.Errorf("Unexpected one-sided quote: %+v", quotes[1])
+		}
+		if len(transport.Requests()) != 1 {
+			t.Errorf("Expected 1 request, got %d", len(transport.Requests()))
+		}
+
+		if empty, err := client.GetQuotes(nil); err != nil || len(empty) != 0 {
+			t.Errorf("Expected empty result, got %v (err=%v)", empty, err)
+		}
+	})
+}
+
+// staticBookSource 返回固定订单簿的 OrderBookSource
+type staticBookSource map[string]*types.OrderBookSummary
+
+func (s staticBookSource) OrderBook(tokenID string) (*types.OrderBookSummary, bool) {
+	book, ok := s[tokenID]
+	if !ok {
+		return nil, false
+	}
+	return book.TopLevels(0), true
+}
+
+func TestOrderBookSource(t *testing.T) {
+	// websocket.Client 可以直接作为订单簿来源
+	var _ OrderBookSource = websocket.Client(nil)
+
+	source := staticBookSource{
+		test.FixtureNoTokenID: {
+			Bids:           []types.OrderLevel{{Price: 0.47, Size: 10}, {Price: 0.48, Size: 5}},
+			Asks:           []types.OrderLevel{{Price: 0.5, Size: 10}},
+			LastTradePrice: 0.49,
+		},
+	}
+
+	t.Run("OrderBook", func(t *testing.T) {
+		transport := test.NewFixtureTransport(t, test.OrderBookFixture())
+		client := NewReadonlyClient(WithTransport(transport), WithOrderBookSource(source))
+
+		book, err := client.GetOrderBook(test.FixtureNoTokenID, WithDepth(1))
+		if err != nil {
+			t.Fatalf("GetOrderBook failed: %v", err)
+		}
+		if book.Source != types.BookSourceWSS || book.TokenID != test.FixtureNoTokenID || len(book.Bids) != 1 || book.Bids[0].Price != 0.48 {
+			t.Errorf("Unexpected local book: %+v", book)
+		}
+		if len(transport.Requests()) != 0 {
+			t.Errorf("Expected no REST request for subscribed token, got %d", len(transport.Requests()))
+		}
+
+		// 没有本地数据时回退到 REST
+		book, err = client.GetOrderBook(test.FixtureYesTokenID)
+		if err != nil {
+			t.Fatalf("GetOrderBook failed: %v", err)
+		}
+		if book.Source != types.BookSourceREST || len(transport.Requests()) != 1 {
+			t.Errorf("Expected REST fallback, got source %q with %d requests", book.Source, len(transport.Requests()))
+		}
+	})
+
+	t.Run("Quotes", func(t *testing.T) {
+		books := `[{"asset_id":"` + test.FixtureYesTokenID + `","bids":[{"price":"0.48","size":"10"}],"asks":[{"price":"0.52","size":"10"}]}]`
+		transport := test.NewFixtureTransport(t, test.Fixture{Method: "POST", Path: internal.GetOrderBooks, Body: books})
+		client := NewReadonlyClient(WithTransport(transport), WithOrderBookSource(source))
+
+		quotes, err := client.GetQuotes([]string{test.FixtureYesTokenID, test.FixtureNoTokenID})
+		if err != nil {
+			t.Fatalf("GetQuotes failed: %v", err)
+		}
+		if len(quotes) != 2 || quotes[0].Source != types.BookSourceREST || quotes[1].Source != types.BookSourceWSS {
+			t.Fatalf("Unexpected quotes: %+v", quotes)
+		}
+		if quotes[1].Bid != 0.48 || quotes[1].Ask != 0.5 || quotes[1].LastTrade != 0.49 {
+			t.Errorf("Unexpected local quote: %+v", quotes[1])
+		}
+
+		// 只请求没有本地数据的代币
+		requests := transport.Requests()
+		if len(requests) != 1 || strings.Contains(requests[0].RequestBody, test.FixtureNoTokenID) {
+			t.Errorf("Expected only the REST token to be requested, got %+v", requests)
+		}
+
+		// 全部命中本地数据时不发送请求
+		if _, err := client.GetQuotes([]string{test.FixtureNoTokenID}); err != nil || len(transport.Requests()) != 1 {
+			t.Errorf("Expected no extra request, got %d (err=%v)", len(transport.Requests()), err)
+		}
+	})
+}
+
+func TestApplyOrderBookOptions(t *testing.T) {
+	book := &types.OrderBookSummary{
+		TokenID: "token",
+		Bids:    []types.OrderLevel{{Price: 0.45, Size: 10}, {Price: 0.48, Size: 5}, {Price: 0.47, Size: 7}},
+		Asks:    []types.OrderLevel{{Price: 0.55, Size: 10}, {Price: 0.52, Size: 5}, {Price: 0.53, Size: 7}},
+	}
+
+	// 截断后最优价在前
+	t.Run("Depth", func(t *testing.T) {
+		top := applyOrderBookOptions(book, []GetOrderBookOption{WithDepth(2)})
+		if len(top.Bids) != 2 || top.Bids[0].Price != 0.48 || top.Bids[1].Price != 0.47 {
+			t.Errorf("Unexpected bids: %+v", top.Bids)
+		}
+		if len(top.Asks) != 2 || top.Asks[0].Price != 0.52 || top.Asks[1].Price != 0.53 {
+			t.Errorf("Unexpected asks: %+v", top.Asks)
+		}
+		// 原订单簿不应被修改
+		if len(book.Bids) != 3 || book.Bids[0].Price != 0.45 {
+			t.Error("Expected original book to be unchanged")
+		}
+	})
+
+	t.Run("NoDepth", func(t *testing.T) {
+		if full := applyOrderBookOptions(book, nil); full != book {
+			t.Error("Expected book to be returned as is without depth option")
+		}
+	})
+}
+
+func TestNormalizeBookParams(t *testing.T) {
+	// 方向大小写不敏感，统一转为大写
+	t.Run("CaseInsensitive", func(t *testing.T) {
+		params, err := normalizeBookParams([]types.BookParams{
+			{TokenID: "a", Side: "buy"},
+			{TokenID: "b", Side: "Sell"},
+			{TokenID: "c"},
+		})
+		if err != nil {
+			t.Fatalf("normalizeBookParams failed: %v", err)
+		}
+		if params[0].Side != "BUY" || params[1].Side != "SELL" || params[2].Side != "" {
+			t.Errorf("Unexpected normalized sides: %+v", params)
+		}
+	})
+
+	// 无效方向在发送请求前返回错误
+	t.Run("InvalidSide", func(t *testing.T) {
+		client := NewReadonlyClient()
+		_, err := client.GetMultipleOrderBooks([]types.BookParams{{TokenID: "a", Side: "BYU"}})
+		if err == nil || !strings.Contains(err.Error(), "BYU") {
+			t.Errorf("Expected invalid side error, got %v", err)
+		}
+		if _, err := client.GetPrice("a", types.OrderSide("Bid")); err == nil {
+			t.Error("Expected error for invalid side in GetPrice")
+		}
+	})
+}
+
 func TestGetMultipleOrderBooks(t *testing.T) {
 	client := newTestClobClient(t)
 	config := test.LoadTestConfig()
@@ -362,6 +757,188 @@ func TestGetMidpoint(t *testing.T) {
 	})
 }
 
+func TestPollOrderBookChanges(t *testing.T) {
+	t.Run("Diff", func(t *testing.T) {
+		prev := &types.OrderBookSummary{
+			Bids: []types.OrderLevel{{Price: 0.48, Size: 100}, {Price: 0.47, Size: 50}},
+			Asks: []types.OrderLevel{{Price: 0.52, Size: 80}},
+		}
+		next := &types.OrderBookSummary{
+			Bids: []types.OrderLevel{{Price: 0.48, Size: 120}, {Price: 0.49, Size: 10}},
+			Asks: []types.OrderLevel{{Price: 0.52, Size: 80}},
+		}
+		diff := types.DiffOrderBooks(prev, next)
+		// 0.49 新增、0.48 数量变化、0.47 被移除；卖盘无变化
+		expectedBids := []types.OrderLevel{{Price: 0.49, Size: 10}, {Price: 0.48, Size: 120}, {Price: 0.47, Size: 0}}
+		if len(diff.Bids) != len(expectedBids) {
+			t.Fatalf("Expected %d changed bids, got %+v", len(expectedBids), diff.Bids)
+		}
+		for i, level := range expectedBids {
+			if diff.Bids[i] != level {
+				t.Errorf("Bid %d: expected %+v, got %+v", i, level, diff.Bids[i])
+			}
+		}
+		if len(diff.Asks) != 0 {
+			t.Errorf("Expected no changed asks, got %+v", diff.Asks)
+		}
+		if sameDiff := types.DiffOrderBooks(next, next); !sameDiff.IsEmpty() {
+			t.Errorf("Expected empty diff for identical books, got %+v", sameDiff)
+		}
+	})
+
+	t.Run("Poll", func(t *testing.T) {
+		books := []*types.OrderBookSummary{
+			{Bids: []types.OrderLevel{{Price: 0.48, Size: 100}}},
+			{Bids: []types.OrderLevel{{Price: 0.48, Size: 100}}}, // 无变化，不发送
+			nil, // 查询失败，继续轮询
+			{Bids: []types.OrderLevel{{Price: 0.48, Size: 60}}},
+		}
+		var calls int32
+		fetch := func(tokenID string) (*types.OrderBookSummary, error) {
+			i := int(atomic.AddInt32(&calls, 1)) - 1
+			if i >= len(books) {
+				i = len(books) - 1
+			}
+			if books[i] == nil {
+				return nil, errors.New("HTTP 503")
+			}
+			return books[i], nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch, err := pollOrderBookChanges(ctx, "123", time.Millisecond, fetch)
+		if err != nil {
+			t.Fatalf("pollOrderBookChanges failed: %v", err)
+		}
+
+		first := <-ch
+		if first.TokenID != "123" || len(first.Bids) != 1 || first.Bids[0].Size != 100 {
+			t.Errorf("Expected full first snapshot, got %+v", first)
+		}
+		second := <-ch
+		if len(second.Bids) != 1 || second.Bids[0].Size != 60 {
+			t.Errorf("Expected size change to 60, got %+v", second)
+		}
+
+		cancel()
+		for range ch {
+		}
+	})
+
+	t.Run("InvalidArgs", func(t *testing.T) {
+		fetch := func(string) (*types.OrderBookSummary, error) { return nil, nil }
+		if _, err := pollOrderBookChanges(context.Background(), "", time.Second, fetch); err == nil {
+			t.Error("Expected error for empty token ID")
+		}
+		if _, err := pollOrderBookChanges(context.Background(), "123", 0, fetch); err == nil {
+			t.Error("Expected error for non-positive interval")
+		}
+	})
+}
+
+func TestDecodePricesResponse(t *testing.T) {
+	requests := []types.BookParams{
+		{TokenID: "1"},               // 未指定方向，优先 BUY
+		{TokenID: "2"},               // 未指定方向，只有 SELL
+		{TokenID: "3", Side: "SELL"}, // 指定方向
+		{TokenID: "4", Side: "BUY"},  // 指定方向但响应中不存在
+	}
+
+	t.Run("NestedObject", func(t *testing.T) {
+		raw := []byte(`{"1": {"BUY": "0.52", "SELL": "0.48"}, "2": {"sell": "0.30"}, "3": {"BUY": "0.61", "SELL": "0.59"}, "4": {"SELL": "0.10"}}`)
+		prices, err := decodePricesResponse(requests, raw)
+		if err != nil {
+			t.Fatalf("decodePricesResponse failed: %v", err)
+		}
+		expected := []types.Price{
+			{BookParams: types.BookParams{TokenID: "1", Side: "BUY"}, Price: 0.52, ResolvedSide: types.OrderSideBUY},
+			{BookParams: types.BookParams{TokenID: "2", Side: "SELL"}, Price: 0.30, ResolvedSide: types.OrderSideSELL},
+			{BookParams: types.BookParams{TokenID: "3", Side: "SELL"}, Price: 0.59, ResolvedSide: types.OrderSideSELL},
+		}
+		if len(prices) != len(expected) {
+			t.Fatalf("Expected %d prices, got %+v", len(expected), prices)
+		}
+		for i := range expected {
+			if prices[i] != expected[i] {
+				t.Errorf("Price %d: expected %+v, got %+v", i, expected[i], prices[i])
+			}
+		}
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		raw := []byte(`[{"token_id": "1", "side": "sell", "price": "0.48"}, {"token_id": "3", "price": 0.59}]`)
+		prices, err := decodePricesResponse(requests, raw)
+		if err != nil {
+			t.Fatalf("decodePricesResponse failed: %v", err)
+		}
+		if len(prices) != 2 {
+			t.Fatalf("Expected 2 prices, got %+v", prices)
+		}
+		if prices[0].Side != "SELL" || prices[0].ResolvedSide != types.OrderSideSELL {
+			t.Errorf("Expected side from response, got %+v", prices[0])
+		}
+		// 响应未给出方向时使用请求中的方向
+		if prices[1].Side != "SELL" || prices[1].ResolvedSide != types.OrderSideSELL {
+			t.Errorf("Expected side from request, got %+v", prices[1])
+		}
+	})
+}
+
+func TestMidpointWithFallback(t *testing.T) {
+	book := &types.OrderBookSummary{
+		TokenID: "token",
+		Bids:    []types.OrderLevel{{Price: 0.40, Size: 10}, {Price: 0.44, Size: 5}},
+		Asks:    []types.OrderLevel{{Price: 0.60, Size: 10}, {Price: 0.50, Size: 5}},
+	}
+	bookCalls := 0
+	getBook := func() (*types.OrderBookSummary, error) {
+		bookCalls++
+		return book, nil
+	}
+	emptyMidpoint := &types.Midpoint{TokenID: "token"}
+
+	// 未开启回退时不请求订单簿
+	t.Run("Disabled", func(t *testing.T) {
+		bookCalls = 0
+		midpoint, err := midpointWithFallback("token", emptyMidpoint, nil, nil, getBook)
+		if err != nil || midpoint != emptyMidpoint || bookCalls != 0 {
+			t.Errorf("Expected API result without fallback, got %+v, %v (book calls: %d)", midpoint, err, bookCalls)
+		}
+	})
+
+	t.Run("APIResultPreferred", func(t *testing.T) {
+		bookCalls = 0
+		apiMidpoint := &types.Midpoint{TokenID: "token", Value: 0.5}
+		midpoint, err := midpointWithFallback("token", apiMidpoint, nil, []GetMidpointOption{WithMidpointBookFallback()}, getBook)
+		if err != nil || midpoint != apiMidpoint || bookCalls != 0 {
+			t.Errorf("Expected API result, got %+v, %v (book calls: %d)", midpoint, err, bookCalls)
+		}
+	})
+
+	// 接口无结果或报错时使用最优买卖价计算
+	t.Run("Fallback", func(t *testing.T) {
+		for _, apiErr := range []error{nil, errors.New("no orderbook")} {
+			midpoint, err := midpointWithFallback("token", emptyMidpoint, apiErr, []GetMidpointOption{WithMidpointBookFallback()}, getBook)
+			if err != nil {
+				t.Fatalf("midpointWithFallback failed: %v", err)
+			}
+			if math.Abs(midpoint.Value-0.47) > 1e-9 {
+				t.Errorf("Expected midpoint 0.47, got %v", midpoint.Value)
+			}
+		}
+	})
+
+	t.Run("OneSidedBook", func(t *testing.T) {
+		oneSided := func() (*types.OrderBookSummary, error) {
+			return &types.OrderBookSummary{TokenID: "token", Bids: book.Bids}, nil
+		}
+		if _, err := midpointWithFallback("token", emptyMidpoint, nil, []GetMidpointOption{WithMidpointBookFallback()}, oneSided); err == nil {
+			t.Error("Expected error for one-sided order book")
+		}
+	})
+}
+
 func TestGetMidpoints(t *testing.T) {
 	client := newTestClobClient(t)
 	config := test.LoadTestConfig()
@@ -698,6 +1275,49 @@ func TestGetSpreads(t *testing.T) {
 	})
 }
 
+// TestLastTradePriceDecoding 使用抓取的接口响应验证最后成交价解析
+func TestLastTradePriceDecoding(t *testing.T) {
+	// GET /last-trade-price
+	t.Run("Single", func(t *testing.T) {
+		var price types.LastTradePrice
+		if err := json.Unmarshal([]byte(`{"price":"0.57","side":"BUY"}`), &price); err != nil {
+			t.Fatalf("Failed to decode: %v", err)
+		}
+		if price.Value != 0.57 || price.Side != types.OrderSideBUY || price.Size != 0 || price.Timestamp.Time != nil {
+			t.Errorf("Unexpected last trade price: %+v", price)
+		}
+	})
+
+	// POST /last-trades-prices
+	t.Run("Batch", func(t *testing.T) {
+		data := `[{"token_id":"71321045679252212594626385532706912750332728571942532289631379312455583992563","price":"0.45","side":"sell"},{"token_id":"52114319501245915516055106046884209969926127482827954674443846427813813222426","price":0.5,"side":"BUY","size":"120.5","timestamp":"1700000000"}]`
+		var prices []types.LastTradePrice
+		if err := json.Unmarshal([]byte(data), &prices); err != nil {
+			t.Fatalf("Failed to decode: %v", err)
+		}
+		if len(prices) != 2 {
+			t.Fatalf("Expected 2 prices, got %d", len(prices))
+		}
+		if prices[0].Value != 0.45 || prices[0].Side != types.OrderSideSELL || !strings.HasPrefix(prices[0].TokenID, "7132") {
+			t.Errorf("Unexpected first price: %+v", prices[0])
+		}
+		if prices[1].Value != 0.5 || prices[1].Size != 120.5 || prices[1].Timestamp.Time == nil || prices[1].Timestamp.Time.Unix() != 1700000000 {
+			t.Errorf("Unexpected second price: %+v", prices[1])
+		}
+	})
+
+	// 兼容旧格式的 value 字段
+	t.Run("LegacyValue", func(t *testing.T) {
+		var price types.LastTradePrice
+		if err := json.Unmarshal([]byte(`{"token_id":"1","value":0.33}`), &price); err != nil {
+			t.Fatalf("Failed to decode: %v", err)
+		}
+		if price.Value != 0.33 || price.TokenID != "1" {
+			t.Errorf("Unexpected last trade price: %+v", price)
+		}
+	})
+}
+
 func TestGetLastTradePrice(t *testing.T) {
 	client := newTestClobClient(t)
 	config := test.LoadTestConfig()
@@ -827,6 +1447,306 @@ func TestGetLastTradesPrices(t *testing.T) {
 	})
 }
 
+func TestGetNegRisks(t *testing.T) {
+	client := newTestClobClient(t)
+
+	testData := getTestMarketData(t, "")
+	if testData == nil || len(testData.TokenIDs) == 0 {
+		t.Skip("Skipping test: could not get market data")
+		return
+	}
+
+	// 基本功能测试：批量结果应与单个查询一致
+	t.Run("Basic", func(t *testing.T) {
+		negRisks, err := client.GetNegRisks(testData.TokenIDs)
+		if err != nil {
+			t.Fatalf("GetNegRisks failed: %v", err)
+		}
+		if len(negRisks) != len(testData.TokenIDs) {
+			t.Fatalf("Expected %d results, got %d", len(testData.TokenIDs), len(negRisks))
+		}
+		for _, tokenID := range testData.TokenIDs {
+			negRisk, err := client.GetNegRisk(tokenID)
+			if err != nil {
+				t.Fatalf("GetNegRisk failed: %v", err)
+			}
+			if negRisks[tokenID] != negRisk {
+				t.Errorf("Token %s: GetNegRisks=%v, GetNegRisk=%v", tokenID, negRisks[tokenID], negRisk)
+			}
+		}
+		t.Logf("GetNegRisks returned: %v", negRisks)
+	})
+
+	// 空列表
+	t.Run("Empty", func(t *testing.T) {
+		negRisks, err := client.GetNegRisks(nil)
+		if err != nil {
+			t.Fatalf("GetNegRisks with empty list failed: %v", err)
+		}
+		if len(negRisks) != 0 {
+			t.Errorf("Expected empty result, got %v", negRisks)
+		}
+	})
+}
+
+func TestOrderBookMicroprice(t *testing.T) {
+	book := &types.OrderBookSummary{
+		TokenID: "token",
+		Bids: []types.OrderLevel{
+			{Price: 0.48, Size: 100},
+			{Price: 0.50, Size: 300},
+		},
+		Asks: []types.OrderLevel{
+			{Price: 0.55, Size: 200},
+			{Price: 0.52, Size: 100},
+		},
+	}
+
+	t.Run("Microprice", func(t *testing.T) {
+		// (0.50 × 100 + 0.52 × 300) / (300 + 100) = 0.515
+		got, ok := book.Microprice()
+		if !ok {
+			t.Fatal("Expected microprice")
+		}
+		if math.Abs(got-0.515) > 1e-9 {
+			t.Errorf("Expected microprice 0.515, got %v", got)
+		}
+	})
+
+	t.Run("Imbalance", func(t *testing.T) {
+		// 第一档：(300 - 100) / 400 = 0.5
+		got, ok := book.Imbalance(1)
+		if !ok || math.Abs(got-0.5) > 1e-9 {
+			t.Errorf("Expected top-level imbalance 0.5, got %v (ok=%v)", got, ok)
+		}
+		// 全部档位：(400 - 300) / 700
+		got, ok = book.Imbalance(0)
+		if !ok || math.Abs(got-100.0/700.0) > 1e-9 {
+			t.Errorf("Expected full-book imbalance %v, got %v (ok=%v)", 100.0/700.0, got, ok)
+		}
+	})
+
+	t.Run("OneSided", func(t *testing.T) {
+		oneSided := &types.OrderBookSummary{Bids: book.Bids}
+		if _, ok := oneSided.Microprice(); ok {
+			t.Error("Expected no microprice for one-sided book")
+		}
+		got, ok := oneSided.Imbalance(5)
+		if !ok || got != 1 {
+			t.Errorf("Expected imbalance 1 for bid-only book, got %v (ok=%v)", got, ok)
+		}
+		if _, ok := (&types.OrderBookSummary{}).Imbalance(5); ok {
+			t.Error("Expected no imbalance for empty book")
+		}
+	})
+}
+
+// TestInterfacesCoverImplementation 实现类型的每个公开方法都应出现在接口中，保证调用方可以 mock 完整客户端
+func TestInterfacesCoverImplementation(t *testing.T) {
+	cases := []struct {
+		name  string
+		impl  reflect.Type
+		iface reflect.Type
+	}{
+		{"Client", reflect.TypeOf(&polymarketClobClient{}), reflect.TypeOf((*Client)(nil)).Elem()},
+		{"ReadonlyClient", reflect.TypeOf(&readonlyClobClient{}), reflect.TypeOf((*ReadonlyClient)(nil)).Elem()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < tc.impl.NumMethod(); i++ {
+				name := tc.impl.Method(i).Name
+				if _, ok := tc.iface.MethodByName(name); !ok {
+					t.Errorf("%s does not expose method %s", tc.name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeeRate(t *testing.T) {
+	cases := []struct {
+		name     string
+		response string
+		expected int
+		wantErr  bool
+	}{
+		{"FeeRateNumber", `{"fee_rate": 100}`, 100, false},
+		{"FeeRateString", `{"fee_rate": "25"}`, 25, false},
+		{"BaseFeeNumber", `{"base_fee": 0}`, 0, false},
+		{"BaseFeeString", `{"base_fee": "1000"}`, 1000, false},
+		{"PreferFeeRate", `{"fee_rate": 10, "base_fee": 20}`, 10, false},
+		{"Missing", `{"other": 1}`, 0, true},
+		{"InvalidString", `{"base_fee": "abc"}`, 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var raw map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.response), &raw); err != nil {
+				t.Fatalf("Invalid fixture: %v", err)
+			}
+			feeRate, err := parseFeeRate(raw)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %d", feeRate)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFeeRate failed: %v", err)
+			}
+			if feeRate != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, feeRate)
+			}
+		})
+	}
+}
+
+func TestGetManyFeeRates(t *testing.T) {
+	cache := newFeeRateCache(0)
+	cache.store("cached", 50)
+
+	var calls int32
+	fetch := func(tokenID string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if tokenID == "bad" {
+			return 0, errors.New("HTTP 404")
+		}
+		return len(tokenID), nil
+	}
+
+	rates, err := getManyFeeRates(cache, []string{"cached", "a", "bb", "a"}, fetch)
+	if err != nil {
+		t.Fatalf("getManyFeeRates failed: %v", err)
+	}
+	if len(rates) != 3 || rates["cached"] != 50 || rates["a"] != 1 || rates["bb"] != 2 {
+		t.Errorf("Unexpected fee rates: %v", rates)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 fetches (cached and duplicate tokens skipped), got %d", calls)
+	}
+	if feeRate, ok := cache.lookup("bb"); !ok || feeRate != 2 {
+		t.Errorf("Expected fetched fee rate to be cached, got %d (%v)", feeRate, ok)
+	}
+
+	if _, err := getManyFeeRates(cache, []string{"bad", "ccc"}, fetch); err == nil {
+		t.Error("Expected error when a token fails")
+	}
+	if _, ok := cache.lookup("ccc"); !ok {
+		t.Error("Expected successful fee rate to be cached despite another failure")
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	t.Run("Eviction", func(t *testing.T) {
+		cache := newLRUCache[int](2)
+		cache.add("a", 1)
+		cache.add("b", 2)
+		// 读取 a 后 b 成为最久未使用的条目
+		if v, ok := cache.get("a"); !ok || v != 1 {
+			t.Fatalf("Expected a=1, got %d (%v)", v, ok)
+		}
+		cache.add("c", 3)
+		if _, ok := cache.get("b"); ok {
+			t.Error("Expected b to be evicted")
+		}
+		if v, ok := cache.get("a"); !ok || v != 1 {
+			t.Errorf("Expected a to survive eviction, got %d (%v)", v, ok)
+		}
+		cache.add("a", 10)
+		if v, _ := cache.get("a"); v != 10 {
+			t.Errorf("Expected updated value 10, got %d", v)
+		}
+
+		stats := cache.stats()
+		expected := types.CacheStats{Hits: 3, Misses: 1, Evictions: 1, Size: 2, Capacity: 2}
+		if stats != expected {
+			t.Errorf("Expected stats %+v, got %+v", expected, stats)
+		}
+	})
+
+	t.Run("Unbounded", func(t *testing.T) {
+		cache := newLRUCache[int](-1)
+		for i := 0; i < 100; i++ {
+			cache.add(strconv.Itoa(i), i)
+		}
+		if stats := cache.stats(); stats.Size != 100 || stats.Evictions != 0 {
+			t.Errorf("Expected unbounded cache to keep all entries, got %+v", stats)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		cache := newLRUCache[int](16)
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 200; i++ {
+					key := strconv.Itoa((g*200 + i) % 50)
+					cache.add(key, i)
+					cache.get(key)
+				}
+			}(g)
+		}
+		wg.Wait()
+		if stats := cache.stats(); stats.Size > 16 || stats.Hits+stats.Misses != 1600 {
+			t.Errorf("Unexpected stats after concurrent access: %+v", stats)
+		}
+	})
+}
+
+func TestMetadataCacheSize(t *testing.T) {
+	client := NewReadonlyClient(WithMetadataCacheSize(2))
+	stats := client.MetadataCacheStats()
+	if stats.TickSize.Capacity != 2 || stats.NegRisk.Capacity != 2 || stats.FeeRate.Capacity != 2 || stats.TokenMeta.Capacity != 2 {
+		t.Errorf("Expected capacity 2 for all caches, got %+v", stats)
+	}
+
+	client = NewReadonlyClient()
+	if stats := client.MetadataCacheStats(); stats.TickSize.Capacity != internal.MetadataCacheSize {
+		t.Errorf("Expected default capacity %d, got %+v", internal.MetadataCacheSize, stats.TickSize)
+	}
+}
+
+func TestGetRateLimits(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		client := &readonlyBaseClient{rateLimits: types.RateLimits{PostOrder: 3000}}
+		expected := types.RateLimits{
+			Window:      internal.RateLimitWindow,
+			General:     internal.RateLimitGeneral,
+			MarketData:  internal.RateLimitMarketData,
+			PostOrder:   3000,
+			CancelOrder: internal.RateLimitCancelOrder,
+		}
+		if limits := client.GetRateLimits(); limits != expected {
+			t.Errorf("Expected %+v, got %+v", expected, limits)
+		}
+	})
+
+	// 客户端按配置的限流限速：窗口内第二个请求等待到第一个请求移出窗口
+	t.Run("Throttled", func(t *testing.T) {
+		transport := test.NewFixtureTransport(t, test.Fixture{Path: internal.Time, Body: "1700000000"})
+		window := 200 * time.Millisecond
+		client := NewReadonlyClient(WithTransport(transport), WithRateLimits(types.RateLimits{Window: window, General: 1}))
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetTime(); err != nil {
+				t.Fatalf("GetTime failed: %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < window {
+			t.Errorf("Expected the second request to wait for the %v window, took %v", window, elapsed)
+		}
+	})
+
+	t.Run("InvalidOption", func(t *testing.T) {
+		if _, err := parseClientOptions([]ClientOption{WithRateLimits(types.RateLimits{General: -1})}); err == nil {
+			t.Error("Expected error for negative rate limit")
+		}
+	})
+}
+
 func TestGetFeeRate(t *testing.T) {
 	client := newTestClobClient(t)
 	config := test.LoadTestConfig()
@@ -908,3 +1828,117 @@ func TestGetTime(t *testing.T) {
 		}
 	})
 }
+
+func TestGetRewardMarkets(t *testing.T) {
+	// 两页 sampling-markets：奖励配置从 rewards.rates 转换为 RewardsConfig
+	transport := test.NewFixtureTransport(t,
+		test.Fixture{Path: internal.GetSamplingMarkets + "?next_cursor=MA%3D%3D", Body: `{"data":[
+			{"condition_id":"0x01","question":"Q1","market_slug":"q1","tokens":[{"token_id":"1","outcome":"Yes","price":0.4}],
+			 "rewards":{"rates":[{"asset_address":"0x2791bca1f2de4661ed88a30c99a7a9449aa84174","rewards_daily_rate":10}],"min_size":50,"max_spread":3.5}}
+		],"next_cursor":"MTAw"}`},
+		test.Fixture{Path: internal.GetSamplingMarkets + "?next_cursor=MTAw", Body: `{"data":[
+			{"condition_id":"0x02","question":"Q2","market_slug":"q2","tokens":[],"rewards":{"rates":[],"min_size":0,"max_spread":0}}
+		],"next_cursor":"LTE="}`},
+	)
+	client := NewReadonlyClient(WithTransport(transport))
+
+	// 基本功能测试
+	t.Run("Basic", func(t *testing.T) {
+		markets, err := client.GetRewardMarkets()
+		if err != nil {
+			t.Fatalf("GetRewardMarkets failed: %v", err)
+		}
+		if len(markets) != 2 || markets[0].ConditionID != "0x01" || markets[1].ConditionID != "0x02" {
+			t.Fatalf("Expected markets 0x01 and 0x02 across two pages, got %+v", markets)
+		}
+		first := markets[0]
+		if first.Question != "Q1" || first.MarketSlug != "q1" || len(first.Tokens) != 1 || first.Tokens[0].TokenID != "1" {
+			t.Errorf("Unexpected market fields: %+v", first)
+		}
+		if len(first.RewardsConfig) != 1 || first.RewardsConfig[0].RewardsDailyRate != 10 ||
+			first.RewardsMinSize != 50 || first.RewardsMaxSpread != 3.5 {
+			t.Errorf("Unexpected reward fields: %+v", first)
+		}
+		if len(markets[1].RewardsConfig) != 0 {
+			t.Errorf("Expected no rewards config for 0x02, got %+v", markets[1].RewardsConfig)
+		}
+	})
+
+	// 测试缓存：短时间内再次调用不再请求 API
+	t.Run("Cached", func(t *testing.T) {
+		first, err := client.GetRewardMarkets()
+		if err != nil {
+			t.Fatalf("GetRewardMarkets failed: %v", err)
+		}
+		requests := len(transport.Requests())
+		second, err := client.GetRewardMarkets()
+		if err != nil {
+			t.Fatalf("GetRewardMarkets failed: %v", err)
+		}
+		if len(first) != len(second) {
+			t.Errorf("Expected cached result with %d markets, got %d", len(first), len(second))
+		}
+		if got := len(transport.Requests()); got != requests {
+			t.Errorf("Expected cached result without requests, got %d extra", got-requests)
+		}
+	})
+}
+
+func TestGetRewardsConfig(t *testing.T) {
+	// 两页奖励配置：日期分别为日期、Unix 时间戳字符串和空值，金额为数字或字符串
+	transport := test.NewFixtureTransport(t,
+		test.Fixture{Path: internal.GetCurrentRewardsMarkets + "?next_cursor=MA%3D%3D", Body: `{"data":[
+			{"condition_id":"0x01","rewards_config":[{"asset_address":"0x2791bca1f2de4661ed88a30c99a7a9449aa84174","start_date":"2024-06-01","end_date":"2024-06-30","rate_per_day":100,"total_rewards":3000}]},
+			{"condition_id":"0x02","rewards_config":[{"asset_address":"0x2791bca1f2de4661ed88a30c99a7a9449aa84174","start_date":"2024-06-15","end_date":"2500-12-31","rate_per_day":"50","total_rewards":"10000"}]}
+		],"next_cursor":"MTAw"}`},
+		test.Fixture{Path: internal.GetCurrentRewardsMarkets + "?next_cursor=MTAw", Body: `{"data":[
+			{"condition_id":"0x03","rewards_config":[{"asset_address":"0x2791bca1f2de4661ed88a30c99a7a9449aa84174","start_date":"1717200000","end_date":"","rate_per_day":25,"total_rewards":0}]},
+			{"condition_id":"0x04","rewards_config":[]}
+		],"next_cursor":"LTE="}`},
+	)
+	client := NewReadonlyClient(WithTransport(transport))
+
+	config, err := client.GetRewardsConfig()
+	if err != nil {
+		t.Fatalf("GetRewardsConfig failed: %v", err)
+	}
+	if config.TotalPool != 13000 || config.MarketsCount != 3 || config.AssetAddress == "" {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+	if config.StartDate.Time == nil || !config.StartDate.Time.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)) ||
+		config.EndDate.Time == nil || config.EndDate.Time.Year() != 2500 {
+		t.Errorf("Unexpected program boundaries: %v - %v", config.StartDate, config.EndDate)
+	}
+	if !config.CurrentEpoch.Contains(time.Now()) || config.DailyPool != config.CurrentEpoch.TotalRewards {
+		t.Errorf("Expected current epoch to contain now, got %+v", config.CurrentEpoch)
+	}
+
+	cases := []struct {
+		date    string
+		epoch   string
+		rewards float64
+		markets int
+	}{
+		{"2024-06-10", "2024-06-10", 125, 2},           // 0x01 + 0x03
+		{"2024-06-20T12:00:00Z", "2024-06-20", 175, 3}, // 三个市场都生效
+		{"1719792000", "2024-07-01", 75, 2},            // 0x01 已于 6 月 30 日结束
+	}
+	for _, tc := range cases {
+		epoch, err := client.GetRewardsEpoch(tc.date)
+		if err != nil {
+			t.Fatalf("GetRewardsEpoch(%q) failed: %v", tc.date, err)
+		}
+		if epoch.Epoch != tc.epoch || epoch.TotalRewards != tc.rewards || epoch.MarketsCount != tc.markets ||
+			epoch.EndDate.Sub(epoch.StartDate) != 24*time.Hour {
+			t.Errorf("GetRewardsEpoch(%q) = %+v, want epoch %s with %v rewards in %d markets", tc.date, epoch, tc.epoch, tc.rewards, tc.markets)
+		}
+	}
+
+	requests := len(transport.Requests())
+	if _, err := client.GetRewardsEpoch("06/10/2024"); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+	if len(transport.Requests()) != requests {
+		t.Error("Expected invalid date to be rejected without a request")
+	}
+}
@@ -1,9 +1,34 @@
 package clob
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/polymarket/go-order-utils/pkg/builder"
+	ordermodel "github.com/polymarket/go-order-utils/pkg/model"
+	ordersigner "github.com/polymarket/go-order-utils/pkg/signer"
+	"github.com/polymas/go-polymarket-sdk/data"
+	"github.com/polymas/go-polymarket-sdk/gamma"
+	sdkhttp "github.com/polymas/go-polymarket-sdk/http"
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/signing"
 	"github.com/polymas/go-polymarket-sdk/test"
 	"github.com/polymas/go-polymarket-sdk/types"
 	"github.com/polymas/go-polymarket-sdk/web3"
@@ -126,6 +151,93 @@ func TestGetOrders(t *testing.T) {
 			t.Logf("GetOrders pagination test: returned %d orders (pagination handled internally)", len(orders))
 		}
 	})
+
+	// 测试按状态过滤
+	t.Run("StatusFilter", func(t *testing.T) {
+		orders, err := client.GetOrders(nil, nil, nil, WithOrdersStatus(types.OrderStatusLive))
+		if err != nil {
+			t.Fatalf("GetOrders with status filter failed: %v", err)
+		}
+		for _, order := range orders {
+			if !strings.EqualFold(order.Status, types.OrderStatusLive) {
+				t.Errorf("Expected only %s orders, got order %s with status %s", types.OrderStatusLive, order.OrderID, order.Status)
+			}
+		}
+		t.Logf("GetOrders with status %s returned %d orders", types.OrderStatusLive, len(orders))
+	})
+}
+
+// TestOpenOrderDecoding 使用抓取的 /data/orders 响应验证订单字段解析
+func TestOpenOrderDecoding(t *testing.T) {
+	data := `{"data":[{"id":"0xb816482a5187a3d3db49cbaf6fe3ddf24f53e6c712b5a4bf5e01d0ec7b11dabc","status":"LIVE","owner":"f4f247b7-4ac7-ff29-a152-04fda0a8755a","maker_address":"0x1b3cc5b3a6e6f1a4c9a0fc3a0ab3ed6fcd35d2a3","market":"0xbd31dc8a20211944f6b70f31557f1001557b59905b7738480ca09bd4532f84af","asset_id":"52114319501245915516055106046884209969926127482827954674443846427813813222426","side":"BUY","original_size":"100","size_matched":"35.5","price":"0.57","associate_trades":["28c4d2eb-bbea-40e7-a9f0-b2fdb56b2c2e"],"outcome":"Yes","created_at":1672290687,"expiration":"0","order_type":"GTC"}],"next_cursor":"LTE=","limit":100,"count":1}`
+
+	var response types.PaginatedResponse[types.OpenOrder]
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("Failed to decode orders: %v", err)
+	}
+	if len(response.Data) != 1 {
+		t.Fatalf("Expected 1 order, got %d", len(response.Data))
+	}
+
+	order := response.Data[0]
+	if order.Status != types.OrderStatusLive || order.Side != types.OrderSideBUY || order.Outcome != "Yes" || order.OrderType != "GTC" {
+		t.Errorf("Unexpected order fields: %+v", order)
+	}
+	if float64(order.Price) != 0.57 || float64(order.OriginalSize) != 100 || float64(order.SizeMatched) != 35.5 {
+		t.Errorf("Unexpected order amounts: price=%v original=%v matched=%v", order.Price, order.OriginalSize, order.SizeMatched)
+	}
+	if len(order.AssociateTrades) != 1 || order.AssociateTrades[0] != "28c4d2eb-bbea-40e7-a9f0-b2fdb56b2c2e" {
+		t.Errorf("Unexpected associate trades: %v", order.AssociateTrades)
+	}
+	if order.CreatedAt.Time == nil || order.CreatedAt.Time.Unix() != 1672290687 {
+		t.Errorf("Unexpected created_at: %v", order.CreatedAt.Time)
+	}
+	if order.Expiration.Time != nil {
+		t.Errorf("Expected no expiration for GTC order, got %v", order.Expiration.Time)
+	}
+	if order.RemainingSize() != 64.5 || math.Abs(order.FilledRatio()-0.355) > 1e-9 {
+		t.Errorf("Unexpected fill state: remaining=%v ratio=%v", order.RemainingSize(), order.FilledRatio())
+	}
+}
+
+// TestDecodeOrdersPage 验证 /data/orders 的各种响应格式
+func TestDecodeOrdersPage(t *testing.T) {
+	const order = `{"id":"0xb816482a5187a3d3db49cbaf6fe3ddf24f53e6c712b5a4bf5e01d0ec7b11dabc","status":"LIVE","side":"BUY","original_size":"100","size_matched":"0","price":"0.57"}`
+
+	cases := []struct {
+		name       string
+		body       string
+		wantOrders int
+		wantCursor string
+	}{
+		{"Paginated", `{"data":[` + order + `],"next_cursor":"MTAw","limit":100,"count":1}`, 1, "MTAw"},
+		{"LastPage", `{"data":[` + order + `],"next_cursor":"LTE="}`, 1, internal.EndCursor},
+		{"NullData", `{"data":null,"next_cursor":"LTE="}`, 0, internal.EndCursor},
+		{"MissingCursor", `{"data":[]}`, 0, internal.EndCursor},
+		{"BareArray", `[` + order + `,` + order + `]`, 2, internal.EndCursor},
+		{"SingleOrder", order, 1, internal.EndCursor},
+		{"Empty", ``, 0, internal.EndCursor},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			orders, cursor, err := decodeOrdersPage([]byte(c.body))
+			if err != nil {
+				t.Fatalf("decodeOrdersPage failed: %v", err)
+			}
+			if len(orders) != c.wantOrders || cursor != c.wantCursor {
+				t.Fatalf("Expected %d orders and cursor %q, got %d and %q", c.wantOrders, c.wantCursor, len(orders), cursor)
+			}
+			for _, o := range orders {
+				if o.Status != types.OrderStatusLive || float64(o.Price) != 0.57 {
+					t.Errorf("Unexpected order: %+v", o)
+				}
+			}
+		})
+	}
+
+	if _, _, err := decodeOrdersPage([]byte(`"unexpected"`)); err == nil {
+		t.Error("Expected error for non-object response")
+	}
 }
 
 func TestCreateAndPostOrders(t *testing.T) {
@@ -319,6 +431,11 @@ func TestPostOrder(t *testing.T) {
 			t.Logf("Order creation returned error (may be expected): %s", response.ErrorMsg)
 		} else {
 			t.Logf("Order created successfully: %+v", response)
+			if response.Fill == nil {
+				t.Error("Expected fill result for successful order")
+			} else if response.Fill.MatchedSize+response.Fill.RemainingSize < orderArgs.Size-1e-9 {
+				t.Errorf("Fill result does not cover requested size: %+v", response.Fill)
+			}
 		}
 	})
 
@@ -441,6 +558,336 @@ func TestPostOrder(t *testing.T) {
 	})
 }
 
+func TestPostSignedOrder(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	orderArgs := types.OrderArgs{
+		TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		Side:    types.OrderSideSELL,
+		Price:   0.55,
+		Size:    10,
+	}
+	signed, err := client.createSignedOrder(orderArgs, "0.001", false, 0, types.OrderTypeGTC)
+	if err != nil {
+		t.Fatalf("createSignedOrder failed: %v", err)
+	}
+
+	// 外部签名的订单与本地签名的订单生成相同的请求体
+	t.Run("Body", func(t *testing.T) {
+		order, err := newOrderedOrder(signed)
+		if err != nil {
+			t.Fatalf("newOrderedOrder failed: %v", err)
+		}
+		if order.Side != string(types.OrderSideSELL) || order.TokenId != orderArgs.TokenID {
+			t.Errorf("Unexpected order fields: side=%s token=%s", order.Side, order.TokenId)
+		}
+		if order.Signature != fmt.Sprintf("0x%x", signed.Signature) || order.Maker != signed.Order.Maker.Hex() {
+			t.Errorf("Unexpected signature or maker: %s %s", order.Signature, order.Maker)
+		}
+
+		requestBody, _, _ := client.buildOrderRequests([]types.OrderArgs{orderArgs}, []types.OrderType{types.OrderTypeGTC}, false)
+		if len(requestBody) != 1 {
+			t.Fatalf("Expected 1 order request, got %d", len(requestBody))
+		}
+		if requestBody[0].Order != order {
+			t.Errorf("Expected built order to equal externally signed order:\n%+v\n%+v", requestBody[0].Order, order)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := client.PostSignedOrder(nil, types.OrderTypeGTC, ""); err == nil {
+			t.Error("Expected error for nil signed order")
+		}
+
+		unsigned := *signed
+		unsigned.Signature = nil
+		if _, err := client.PostSignedOrder(&unsigned, types.OrderTypeGTC, ""); err == nil {
+			t.Error("Expected error for order without signature")
+		}
+
+		badSide := *signed
+		badSide.Order.Side = big.NewInt(7)
+		if _, err := newOrderedOrder(&badSide); err == nil {
+			t.Error("Expected error for invalid side")
+		}
+
+		if _, err := client.PostSignedOrder(signed, "", ""); err == nil {
+			t.Error("Expected error for empty order type")
+		}
+	})
+}
+
+func TestPostRawOrder(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+	client.baseClient.keyScope = &keyScopeCache{resolved: true, scope: types.APIKeyScopeTrade}
+	client.baseClient.tickSizes = newLRUCache[types.TickSize](0)
+	client.baseClient.tickSizes.add(test.FixtureYesTokenID, "0.01")
+	client.baseClient.negRisk = newNegRiskCache(0)
+	client.baseClient.negRisk.store(test.FixtureYesTokenID, true)
+
+	transport := test.NewFixtureTransport(t,
+		test.Fixture{Method: "POST", Path: "/order", Body: `{"orderID":"0xabc","status":"matched","success":true,"makingAmount":"2.2049","takingAmount":"4"}`},
+	)
+	client.baseClient.baseURL = internal.ClobAPIDomain
+	client.baseClient.httpOptions = []sdkhttp.HTTPOption{sdkhttp.WithTransport(transport)}
+
+	// 数量不是 tick size 的整数倍，也原样签名
+	orderArgs := types.OrderArgsRaw{
+		TokenID:     test.FixtureYesTokenID,
+		Side:        types.OrderSideBUY,
+		MakerAmount: big.NewInt(5512345),
+		TakerAmount: big.NewInt(10000000),
+	}
+	resp, err := client.PostRawOrder(orderArgs, types.OrderTypeGTC)
+	if err != nil {
+		t.Fatalf("PostRawOrder failed: %v", err)
+	}
+	if resp.OrderID != "0xabc" || resp.Fill == nil || resp.Fill.MatchedSize != 4 || resp.Fill.RemainingSize != 6 {
+		t.Errorf("Unexpected response: %+v (fill %+v)", resp, resp.Fill)
+	}
+
+	requests := transport.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	body := requests[0].RequestBody
+	if !strings.Contains(body, `"makerAmount": "5512345"`) || !strings.Contains(body, `"takerAmount": "10000000"`) {
+		t.Errorf("Expected exact amounts in request body, got %s", body)
+	}
+
+	// 负风险代币使用 NegRiskCTFExchange 签名
+	signed, err := client.createSignedRawOrder(orderArgs, "0.01", true, types.OrderTypeGTC)
+	if err != nil {
+		t.Fatalf("createSignedRawOrder failed: %v", err)
+	}
+	if valid, err := client.VerifyOrderSignature(signed); err != nil || !valid {
+		t.Errorf("Expected valid neg-risk signature, got %v (err=%v)", valid, err)
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		invalid := []types.OrderArgsRaw{
+			// 隐含价格 0.000001 低于 tick size
+			{TokenID: test.FixtureYesTokenID, Side: types.OrderSideBUY, MakerAmount: big.NewInt(10), TakerAmount: big.NewInt(10000000)},
+			// SELL 隐含价格 = taker / maker = 1
+			{TokenID: test.FixtureYesTokenID, Side: types.OrderSideSELL, MakerAmount: big.NewInt(1000000), TakerAmount: big.NewInt(1000000)},
+			{TokenID: test.FixtureYesTokenID, Side: types.OrderSideBUY, MakerAmount: big.NewInt(0), TakerAmount: big.NewInt(1000000)},
+			{TokenID: test.FixtureYesTokenID, Side: types.OrderSideBUY, TakerAmount: big.NewInt(1000000)},
+			{TokenID: test.FixtureYesTokenID, Side: "buy", MakerAmount: big.NewInt(500000), TakerAmount: big.NewInt(1000000)},
+		}
+		for i, args := range invalid {
+			if _, err := client.PostRawOrder(args, types.OrderTypeGTC); err == nil {
+				t.Errorf("Case %d: expected validation error", i)
+			}
+		}
+		if _, err := client.PostRawOrder(orderArgs, ""); err == nil {
+			t.Error("Expected error for empty order type")
+		}
+		if len(transport.Requests()) != 1 {
+			t.Errorf("Expected invalid orders not to be posted, got %d requests", len(transport.Requests()))
+		}
+	})
+
+	t.Run("BalancePrecheck", func(t *testing.T) {
+		// 离线客户端的 USDC 余额为 0，按签名的 makerAmount 检查
+		client.baseClient.balancePrecheck = true
+		defer func() { client.baseClient.balancePrecheck = false }()
+
+		_, err := client.PostRawOrder(orderArgs, types.OrderTypeGTC)
+		var balanceErr *types.InsufficientBalanceError
+		if !errors.As(err, &balanceErr) || balanceErr.Asset != "USDC" || balanceErr.Required != 5.512345 {
+			t.Fatalf("Expected USDC shortfall for raw order, got %v", err)
+		}
+		if _, err := client.PostSignedOrder(signed, types.OrderTypeGTC, ""); !errors.Is(err, types.ErrInsufficientBalance) {
+			t.Errorf("Expected PostSignedOrder to run the balance precheck, got %v", err)
+		}
+		if len(transport.Requests()) != 1 {
+			t.Errorf("Expected prechecked orders not to be posted, got %d requests", len(transport.Requests()))
+		}
+	})
+}
+
+func TestVerifyOrderSignature(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	orderArgs := types.OrderArgs{
+		TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		Side:    types.OrderSideBUY,
+		Price:   0.45,
+		Size:    10,
+	}
+	signed, err := client.createSignedOrder(orderArgs, "0.001", false, 0, types.OrderTypeGTC)
+	if err != nil {
+		t.Fatalf("createSignedOrder failed: %v", err)
+	}
+	negRiskSigned, err := client.createSignedOrder(orderArgs, "0.001", true, 0, types.OrderTypeGTC)
+	if err != nil {
+		t.Fatalf("createSignedOrder failed: %v", err)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, order := range []*ordermodel.SignedOrder{signed, negRiskSigned} {
+			if valid, err := client.VerifyOrderSignature(order); err != nil || !valid {
+				t.Errorf("Expected valid signature, got %v (err=%v)", valid, err)
+			}
+		}
+	})
+
+	// 已缓存负风险状态时按对应的验证合约校验
+	t.Run("CachedNegRisk", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.negRisk = newNegRiskCache(0)
+		client.baseClient.negRisk.store(orderArgs.TokenID, true)
+		if valid, err := client.VerifyOrderSignature(signed); err != nil || valid {
+			t.Errorf("Expected CTFExchange signature to fail for neg-risk token, got %v (err=%v)", valid, err)
+		}
+		if valid, err := client.VerifyOrderSignature(negRiskSigned); err != nil || !valid {
+			t.Errorf("Expected neg-risk signature to pass, got %v (err=%v)", valid, err)
+		}
+	})
+
+	// 设置 nonce 的订单同样可以校验（nonce 参与订单哈希）
+	t.Run("Nonce", func(t *testing.T) {
+		nonce := int64(3)
+		args := orderArgs
+		args.Nonce = &nonce
+		withNonce, err := client.createSignedOrder(args, "0.001", false, 0, types.OrderTypeGTC)
+		if err != nil {
+			t.Fatalf("createSignedOrder failed: %v", err)
+		}
+		if withNonce.Nonce.Int64() != 3 || signed.Nonce.Int64() != 0 {
+			t.Errorf("Expected nonce 3 (default 0), got %s (%s)", withNonce.Nonce, signed.Nonce)
+		}
+		if valid, err := client.VerifyOrderSignature(withNonce); err != nil || !valid {
+			t.Errorf("Expected valid signature, got %v (err=%v)", valid, err)
+		}
+	})
+
+	// 未设置 nonce 时使用 web3 客户端缓存的交易所 nonce，查询失败时使用 0
+	t.Run("DefaultNonce", func(t *testing.T) {
+		web3Client := client.baseClient.web3Client.(*offlineWeb3Client)
+		defer func() { web3Client.orderNonce, web3Client.nonceErr = 0, nil }()
+
+		web3Client.orderNonce = 7
+		current, err := client.createSignedOrder(orderArgs, "0.001", false, 0, types.OrderTypeGTC)
+		if err != nil {
+			t.Fatalf("createSignedOrder failed: %v", err)
+		}
+		if current.Nonce.Int64() != 7 {
+			t.Errorf("Expected exchange nonce 7, got %s", current.Nonce)
+		}
+
+		// 无法查询交易所 nonce 时不能用 0 签名（CancelAllOnchain 之后会被拒绝），显式设置的 nonce 不受影响
+		web3Client.nonceErr = errors.New("rpc unavailable")
+		if _, err := client.createSignedOrder(orderArgs, "0.001", false, 0, types.OrderTypeGTC); err == nil || !strings.Contains(err.Error(), "rpc unavailable") {
+			t.Errorf("Expected nonce read error, got %v", err)
+		}
+		explicit := orderArgs
+		zero := int64(0)
+		explicit.Nonce = &zero
+		signedZero, err := client.createSignedOrder(explicit, "0.001", false, 0, types.OrderTypeGTC)
+		if err != nil {
+			t.Fatalf("createSignedOrder with explicit nonce failed: %v", err)
+		}
+		if signedZero.Nonce.Int64() != 0 {
+			t.Errorf("Expected explicit nonce 0, got %s", signedZero.Nonce)
+		}
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		tampered := *signed
+		tampered.MakerAmount = new(big.Int).Add(signed.MakerAmount, big.NewInt(1))
+		if valid, err := client.VerifyOrderSignature(&tampered); err != nil || valid {
+			t.Errorf("Expected tampered order to fail, got %v (err=%v)", valid, err)
+		}
+	})
+
+	// 代理钱包下 maker 应为代理地址
+	t.Run("WrongMaker", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.signatureType = types.ProxySignatureType
+		client.baseClient.proxyAddress = types.EthAddress("0x0000000000000000000000000000000000000001")
+		if valid, err := client.VerifyOrderSignature(signed); err != nil || valid {
+			t.Errorf("Expected maker mismatch to fail, got %v (err=%v)", valid, err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := client.VerifyOrderSignature(nil); err == nil {
+			t.Error("Expected error for nil order")
+		}
+		short := *signed
+		short.Signature = signed.Signature[:64]
+		if _, err := client.VerifyOrderSignature(&short); err == nil {
+			t.Error("Expected error for short signature")
+		}
+	})
+}
+
+func TestEstimateOrderFee(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+	config := test.LoadTestConfig()
+
+	// 显式指定手续费率：fee = 100bps * min(0.4, 0.6) * 10 = 0.04
+	t.Run("ExplicitFeeRate", func(t *testing.T) {
+		feeRateBps := 100
+		orderArgs := types.OrderArgs{
+			TokenID:    "1",
+			Side:       types.OrderSideBUY,
+			Price:      0.4,
+			Size:       10.0,
+			FeeRateBps: &feeRateBps,
+		}
+
+		fee, err := client.EstimateOrderFee(orderArgs)
+		if err != nil {
+			t.Fatalf("EstimateOrderFee failed: %v", err)
+		}
+		if math.Abs(fee-0.04) > 1e-9 {
+			t.Errorf("Expected fee 0.04, got %f", fee)
+		}
+	})
+
+	// 手续费率为 0 时返回 0
+	t.Run("ZeroFeeRate", func(t *testing.T) {
+		feeRateBps := 0
+		orderArgs := types.OrderArgs{
+			TokenID:    "1",
+			Side:       types.OrderSideSELL,
+			Price:      0.7,
+			Size:       10.0,
+			FeeRateBps: &feeRateBps,
+		}
+
+		fee, err := client.EstimateOrderFee(orderArgs)
+		if err != nil {
+			t.Fatalf("EstimateOrderFee failed: %v", err)
+		}
+		if fee != 0 {
+			t.Errorf("Expected zero fee, got %f", fee)
+		}
+	})
+
+	// 使用 API 返回的手续费率
+	if config.TestTokenID != "" {
+		t.Run("FetchedFeeRate", func(t *testing.T) {
+			orderArgs := types.OrderArgs{
+				TokenID: config.TestTokenID,
+				Side:    types.OrderSideBUY,
+				Price:   0.5,
+				Size:    10.0,
+			}
+
+			fee, err := client.EstimateOrderFee(orderArgs)
+			if err != nil {
+				t.Fatalf("EstimateOrderFee failed: %v", err)
+			}
+			if fee < 0 {
+				t.Errorf("Expected non-negative fee, got %f", fee)
+			}
+			t.Logf("EstimateOrderFee returned: %f", fee)
+		})
+	}
+}
+
 func TestCancelOrders(t *testing.T) {
 	client := newTestClobClientWithAuth(t)
 
@@ -535,8 +982,10 @@ func TestCancelOrders(t *testing.T) {
 			return
 		}
 
-		// 等待一下确保订单已取消
-		time.Sleep(1 * time.Second)
+		// 等待订单进入取消状态
+		if _, err := client.WaitForOrderStatus(orderID, types.OrderStatusCanceled, 10*time.Second); err != nil {
+			t.Logf("WaitForOrderStatus returned error: %v", err)
+		}
 
 		// 再次尝试取消同一个订单
 		response, err := client.CancelOrders([]types.Keccak256{orderID})
@@ -548,6 +997,229 @@ func TestCancelOrders(t *testing.T) {
 	})
 }
 
+func TestCancelOrdersInChunks(t *testing.T) {
+	// 构造 2500 个模拟订单 ID
+	orderIDs := make([]types.Keccak256, 2500)
+	for i := range orderIDs {
+		orderIDs[i] = types.Keccak256(fmt.Sprintf("0x%064x", i))
+	}
+
+	// 模拟撤单接口：每 100 个订单中有 1 个撤单失败，并记录最大并发数
+	newMockCancel := func(failBatch int) (func([]types.Keccak256) (*types.OrderCancelResponse, error), *int32) {
+		var inFlight, maxInFlight, batch int32
+		return func(chunk []types.Keccak256) (*types.OrderCancelResponse, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				peak := atomic.LoadInt32(&maxInFlight)
+				if current <= peak || atomic.CompareAndSwapInt32(&maxInFlight, peak, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			if int(atomic.AddInt32(&batch, 1)) == failBatch {
+				return nil, errors.New("HTTP 429: too many requests")
+			}
+			resp := &types.OrderCancelResponse{NotCanceled: make(map[types.Keccak256]string)}
+			for _, orderID := range chunk {
+				var index int
+				fmt.Sscanf(string(orderID), "0x%x", &index)
+				if index%100 == 0 {
+					resp.NotCanceled[orderID] = fmt.Sprintf("order %d already matched", index)
+				} else {
+					resp.Canceled = append(resp.Canceled, orderID)
+				}
+			}
+			return resp, nil
+		}, &maxInFlight
+	}
+
+	t.Run("Concurrent", func(t *testing.T) {
+		cancel, maxInFlight := newMockCancel(0)
+		resp, err := cancelOrdersInChunks(orderIDs, 1000, 2, cancel)
+		if err != nil {
+			t.Fatalf("cancelOrdersInChunks failed: %v", err)
+		}
+		if len(resp.Canceled) != 2475 || len(resp.NotCanceled) != 25 {
+			t.Errorf("Expected 2475 canceled and 25 not canceled, got %d and %d", len(resp.Canceled), len(resp.NotCanceled))
+		}
+		if reason := resp.NotCanceled[orderIDs[1200]]; reason != "order 1200 already matched" {
+			t.Errorf("Expected reason to be preserved, got %q", reason)
+		}
+		// 按批次顺序合并
+		if resp.Canceled[0] != orderIDs[1] || resp.Canceled[len(resp.Canceled)-1] != orderIDs[2499] {
+			t.Error("Expected canceled IDs to keep input order")
+		}
+		if *maxInFlight > 2 {
+			t.Errorf("Expected at most 2 concurrent batches, got %d", *maxInFlight)
+		}
+	})
+
+	// 失败批次的订单记入 NotCanceled，其他批次结果保留
+	t.Run("BatchFailure", func(t *testing.T) {
+		cancel, _ := newMockCancel(2)
+		resp, err := cancelOrdersInChunks(orderIDs, 1000, 1, cancel)
+		if err == nil {
+			t.Fatal("Expected error for failed batch")
+		}
+		if len(resp.Canceled)+len(resp.NotCanceled) != len(orderIDs) {
+			t.Errorf("Expected every order to be accounted for, got %d canceled and %d not canceled", len(resp.Canceled), len(resp.NotCanceled))
+		}
+		if reason := resp.NotCanceled[orderIDs[1500]]; !strings.Contains(reason, "429") {
+			t.Errorf("Expected failed batch reason, got %q", reason)
+		}
+	})
+}
+
+func TestCancelMarketsOrders(t *testing.T) {
+	marketA := types.Keccak256("0x" + strings.Repeat("a", 64))
+	marketB := types.Keccak256("0x" + strings.Repeat("b", 64))
+	marketC := types.Keccak256("0x" + strings.Repeat("c", 64))
+
+	var calls int32
+	cancel := func(conditionID types.Keccak256) (*types.OrderCancelResponse, error) {
+		atomic.AddInt32(&calls, 1)
+		switch conditionID {
+		case marketA:
+			return &types.OrderCancelResponse{Canceled: []types.Keccak256{"0xa1", "0xa2"}}, nil
+		case marketB:
+			return nil, errors.New("HTTP 429: too many requests")
+		default:
+			return &types.OrderCancelResponse{
+				Canceled:    []types.Keccak256{"0xc1"},
+				NotCanceled: map[types.Keccak256]string{"0xc2": "order already matched"},
+			}, nil
+		}
+	}
+
+	resp, err := cancelMarkets([]types.Keccak256{marketA, marketB, marketC, marketA}, 2, cancel)
+	if err == nil || !strings.Contains(err.Error(), string(marketB)) {
+		t.Errorf("Expected error naming the failed market, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected duplicate markets to be requested once, got %d requests", calls)
+	}
+	if !reflect.DeepEqual(resp.Canceled, []types.Keccak256{"0xa1", "0xa2", "0xc1"}) {
+		t.Errorf("Expected canceled orders merged in market order, got %v", resp.Canceled)
+	}
+	if resp.NotCanceled["0xc2"] != "order already matched" {
+		t.Errorf("Expected not canceled reason to be preserved, got %v", resp.NotCanceled)
+	}
+	if reason := resp.FailedMarkets[marketB]; !strings.Contains(reason, "429") || len(resp.FailedMarkets) != 1 {
+		t.Errorf("Expected failure attributed to market B, got %v", resp.FailedMarkets)
+	}
+	if len(resp.Markets) != 2 || len(resp.Markets[marketC].Canceled) != 1 {
+		t.Errorf("Expected per-market results for A and C, got %v", resp.Markets)
+	}
+
+	// 没有市场时不发送请求
+	if empty, err := cancelMarkets(nil, 1, cancel); err != nil || len(empty.Canceled) != 0 || calls != 3 {
+		t.Errorf("Expected empty result without requests, got %+v (err=%v)", empty, err)
+	}
+}
+
+func TestCancelResponseClassification(t *testing.T) {
+	resp := &types.OrderCancelResponse{
+		Canceled: []types.Keccak256{"0x01"},
+		NotCanceled: map[types.Keccak256]string{
+			"0x03": "Order not found or already canceled",
+			"0x02": "order can't be found - already canceled or matched",
+			"0x04": "order already matched",
+			"0x05": "Order is fully filled",
+			"0x06": "order not owned by the requesting address",
+			"0x07": "批次撤单失败: HTTP 429",
+		},
+	}
+
+	if got := resp.NotFound(); !reflect.DeepEqual(got, []types.Keccak256{"0x02", "0x03"}) {
+		t.Errorf("Unexpected not found orders: %v", got)
+	}
+	if got := resp.AlreadyFilled(); !reflect.DeepEqual(got, []types.Keccak256{"0x04", "0x05"}) {
+		t.Errorf("Unexpected already filled orders: %v", got)
+	}
+	if got := resp.NotOwned(); !reflect.DeepEqual(got, []types.Keccak256{"0x06"}) {
+		t.Errorf("Unexpected not owned orders: %v", got)
+	}
+	if reason := types.ClassifyCancelReason(resp.NotCanceled["0x07"]); reason != types.CancelReasonOther {
+		t.Errorf("Expected batch failure to be classified as other, got %s", reason)
+	}
+}
+
+func TestReplaceOrders(t *testing.T) {
+	oldIDs := []types.Keccak256{
+		types.Keccak256("0x" + strings.Repeat("1", 64)),
+		types.Keccak256("0x" + strings.Repeat("2", 64)),
+	}
+	newOrders := []types.OrderArgs{
+		{TokenID: "token", Price: 0.45, Size: 10, Side: types.OrderSideBUY},
+		{TokenID: "token", Price: 0.55, Size: 10, Side: types.OrderSideSELL},
+	}
+	orderTypes := []types.OrderType{types.OrderTypeGTC, types.OrderTypeGTC}
+
+	var calls []string
+	cancelOK := func(ids []types.Keccak256) (*types.OrderCancelResponse, error) {
+		calls = append(calls, "cancel")
+		return &types.OrderCancelResponse{
+			Canceled:    ids[:1],
+			NotCanceled: map[types.Keccak256]string{ids[1]: "already filled"},
+		}, nil
+	}
+	postOK := func(args []types.OrderArgs, orderTypes []types.OrderType) ([]types.OrderPostResponse, error) {
+		calls = append(calls, "post")
+		return []types.OrderPostResponse{{Success: true, OrderID: "a"}, {Success: false, ErrorMsg: "not enough balance"}}, nil
+	}
+
+	// 先撤单后下单，结果分别记录
+	t.Run("CancelThenPost", func(t *testing.T) {
+		calls = nil
+		result, err := replaceOrders(oldIDs, newOrders, orderTypes, cancelOK, postOK)
+		if err != nil {
+			t.Fatalf("replaceOrders failed: %v", err)
+		}
+		if strings.Join(calls, ",") != "cancel,post" {
+			t.Errorf("Expected cancel before post, got %v", calls)
+		}
+		if len(result.Canceled) != 1 || result.NotCanceled[oldIDs[1]] != "already filled" {
+			t.Errorf("Unexpected cancel result: %+v", result)
+		}
+		if len(result.Posted) != 2 || !result.Posted[0].Success || result.Posted[1].Success {
+			t.Errorf("Unexpected post result: %+v", result.Posted)
+		}
+		if result.AllSucceeded() {
+			t.Error("Expected AllSucceeded to be false")
+		}
+	})
+
+	// 撤单失败时不提交新订单
+	t.Run("CancelFailed", func(t *testing.T) {
+		calls = nil
+		cancelErr := func(ids []types.Keccak256) (*types.OrderCancelResponse, error) {
+			calls = append(calls, "cancel")
+			return nil, errors.New("network error")
+		}
+		result, err := replaceOrders(oldIDs, newOrders, orderTypes, cancelErr, postOK)
+		if err == nil {
+			t.Fatal("Expected error when cancel fails")
+		}
+		if strings.Join(calls, ",") != "cancel" || len(result.Posted) != 0 {
+			t.Errorf("Expected no post after failed cancel, got calls %v and posted %+v", calls, result.Posted)
+		}
+	})
+
+	// 参数无效时不撤单
+	t.Run("InvalidOrders", func(t *testing.T) {
+		calls = nil
+		invalid := []types.OrderArgs{{TokenID: "token", Price: 1.5, Size: 10, Side: types.OrderSideBUY}}
+		if _, err := replaceOrders(oldIDs, invalid, []types.OrderType{types.OrderTypeGTC}, cancelOK, postOK); err == nil {
+			t.Fatal("Expected validation error")
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected no requests for invalid orders, got %v", calls)
+		}
+	})
+}
+
 func TestCancelOrder(t *testing.T) {
 	client := newTestClobClientWithAuth(t)
 
@@ -604,8 +1276,10 @@ func TestCancelOrder(t *testing.T) {
 			return
 		}
 
-		// 等待一下确保订单已取消
-		time.Sleep(1 * time.Second)
+		// 等待订单进入取消状态
+		if _, err := client.WaitForOrderStatus(orderID, types.OrderStatusCanceled, 10*time.Second); err != nil {
+			t.Logf("WaitForOrderStatus returned error: %v", err)
+		}
 
 		// 再次尝试取消同一个订单
 		response, err := client.CancelOrder(orderID)
@@ -617,43 +1291,135 @@ func TestCancelOrder(t *testing.T) {
 	})
 }
 
-func TestCancelAll(t *testing.T) {
+func TestWaitForOrderStatus(t *testing.T) {
 	client := newTestClobClientWithAuth(t)
 
-	// 注意：这个测试会取消所有订单
+	// 已存在的挂单应立即满足 LIVE 状态
 	t.Run("Basic", func(t *testing.T) {
-		response, err := client.CancelAll()
+		orders, err := client.GetOrders(nil, nil, nil, WithOrdersStatus(types.OrderStatusLive))
 		if err != nil {
-			t.Fatalf("CancelAll failed: %v", err)
+			t.Fatalf("GetOrders failed: %v", err)
 		}
-		if response == nil {
-			t.Fatal("CancelAll returned nil")
+		if len(orders) == 0 {
+			t.Skip("Skipping test: No live orders")
 		}
-		t.Logf("CancelAll response: %+v", response)
-	})
-}
 
-func TestCancelMarketOrders(t *testing.T) {
-	client := newTestClobClientWithAuth(t)
-	config := test.LoadTestConfig()
-
-	if config.TestConditionID == "" {
-		t.Skip("Skipping test: POLY_TEST_CONDITION_ID not set")
-	}
-
-	// 注意：这个测试会取消指定市场的所有订单
-	t.Run("Basic", func(t *testing.T) {
-		response, err := client.CancelMarketOrders(config.TestConditionID)
+		order, err := client.WaitForOrderStatus(orders[0].OrderID, types.OrderStatusLive, 10*time.Second)
 		if err != nil {
-			t.Fatalf("CancelMarketOrders failed: %v", err)
+			t.Fatalf("WaitForOrderStatus failed: %v", err)
 		}
-		if response == nil {
-			t.Fatal("CancelMarketOrders returned nil")
+		if order == nil || order.OrderID != orders[0].OrderID {
+			t.Fatalf("WaitForOrderStatus returned unexpected order: %+v", order)
 		}
-		t.Logf("CancelMarketOrders response: %+v", response)
 	})
 
-	// 测试无效conditionID
+	// 超时应返回错误
+	t.Run("Timeout", func(t *testing.T) {
+		unknownOrderID := types.Keccak256("0x0000000000000000000000000000000000000000000000000000000000000000")
+		start := time.Now()
+		_, err := client.WaitForOrderStatus(unknownOrderID, types.OrderStatusMatched, 1*time.Second)
+		if err == nil {
+			t.Fatal("Expected timeout error for unknown order")
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("WaitForOrderStatus did not respect timeout, took %v", elapsed)
+		}
+	})
+
+	// 已取消的 ctx 应立即返回
+	t.Run("ContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		unknownOrderID := types.Keccak256("0x0000000000000000000000000000000000000000000000000000000000000000")
+		_, err := client.WaitForOrderStatusContext(ctx, unknownOrderID, types.OrderStatusMatched, time.Minute)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestGetTrade(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := client.GetTrade("00000000-0000-0000-0000-000000000000")
+		if !errors.Is(err, types.ErrTradeNotFound) {
+			t.Errorf("Expected ErrTradeNotFound, got %v", err)
+		}
+	})
+
+	t.Run("EmptyID", func(t *testing.T) {
+		if _, err := client.GetTrade(""); err == nil {
+			t.Error("Expected error for empty trade ID")
+		}
+	})
+}
+
+func TestFindTrade(t *testing.T) {
+	// /data/trades 返回的结算交易示例
+	data := `{"data":[{"id":"28c4d2eb-bbea-40e7-a9f0-b2fdb56b2c2e","taker_order_id":"0x06bc63e346ed4ceddce9efd6b3af37c8f8f440c92fe7da6b2d0f9e4ccbc50c42","market":"0xbd31dc8a20211944f6b70f31557f1001557b59905b7738480ca09bd4532f84af","asset_id":"52114319501245915516055106046884209969926127482827954674443846427813813222426","side":"BUY","size":"40","fee_rate_bps":"0","price":"0.57","status":"CONFIRMED","match_time":"1672290701","last_update":"1672290701","outcome":"YES","bucket_index":0,"owner":"9180014b-33c8-9240-a14b-bdca11c0a465","maker_address":"0x9d84ce0306f8551e02efef1680475fc0f1dc1344","maker_orders":[{"order_id":"0xff354cd7ca7539dfa9c28d90943ab5779a4eac34b9b37a757d7b32bdfb11790b","owner":"9180014b-33c8-9240-a14b-bdca11c0a465","maker_address":"0x9d84ce0306f8551e02efef1680475fc0f1dc1344","matched_amount":"10","price":"0.57","fee_rate_bps":"0","asset_id":"52114319501245915516055106046884209969926127482827954674443846427813813222426","outcome":"YES"}],"transaction_hash":"0xff354cd7ca7539dfa9c28d90943ab5779a4eac34b9b37a757d7b32bdfb11790b","trader_side":"TAKER"}],"next_cursor":"LTE="}`
+
+	var response types.PaginatedResponse[types.ClobTrade]
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("Failed to decode trades: %v", err)
+	}
+
+	trade, err := findTrade(response.Data, "28c4d2eb-bbea-40e7-a9f0-b2fdb56b2c2e")
+	if err != nil {
+		t.Fatalf("findTrade failed: %v", err)
+	}
+	if trade.Status != "CONFIRMED" || float64(trade.Price) != 0.57 || float64(trade.Size) != 40 {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+	if trade.MatchTime.Time == nil || trade.MatchTime.Time.Unix() != 1672290701 {
+		t.Errorf("Unexpected match time: %v", trade.MatchTime.Time)
+	}
+	if len(trade.MakerOrders) != 1 || float64(trade.MakerOrders[0].MatchedAmount) != 10 {
+		t.Errorf("Unexpected maker orders: %+v", trade.MakerOrders)
+	}
+
+	if _, err := findTrade(response.Data, "unknown"); !errors.Is(err, types.ErrTradeNotFound) {
+		t.Errorf("Expected ErrTradeNotFound, got %v", err)
+	}
+}
+
+func TestCancelAll(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+
+	// 注意：这个测试会取消所有订单
+	t.Run("Basic", func(t *testing.T) {
+		response, err := client.CancelAll()
+		if err != nil {
+			t.Fatalf("CancelAll failed: %v", err)
+		}
+		if response == nil {
+			t.Fatal("CancelAll returned nil")
+		}
+		t.Logf("CancelAll response: %+v", response)
+	})
+}
+
+func TestCancelMarketOrders(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+	config := test.LoadTestConfig()
+
+	if config.TestConditionID == "" {
+		t.Skip("Skipping test: POLY_TEST_CONDITION_ID not set")
+	}
+
+	// 注意：这个测试会取消指定市场的所有订单
+	t.Run("Basic", func(t *testing.T) {
+		response, err := client.CancelMarketOrders(config.TestConditionID)
+		if err != nil {
+			t.Fatalf("CancelMarketOrders failed: %v", err)
+		}
+		if response == nil {
+			t.Fatal("CancelMarketOrders returned nil")
+		}
+		t.Logf("CancelMarketOrders response: %+v", response)
+	})
+
+	// 测试无效conditionID
 	t.Run("InvalidConditionID", func(t *testing.T) {
 		invalidConditionID := types.Keccak256("invalid-condition-id")
 		response, err := client.CancelMarketOrders(invalidConditionID)
@@ -710,6 +1476,52 @@ func TestGetUSDCBalance(t *testing.T) {
 	})
 }
 
+func TestGetBalanceAllowanceOf(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+	config := test.LoadTestConfig()
+	address := test.GetTestUserAddress(config)
+
+	t.Run("Balance", func(t *testing.T) {
+		balance, err := client.GetUSDCBalanceOf(address)
+		if err != nil {
+			t.Fatalf("GetUSDCBalanceOf failed: %v", err)
+		}
+		if balance < 0 {
+			t.Errorf("Expected non-negative balance, got %f", balance)
+		}
+	})
+
+	t.Run("BalanceAllowance", func(t *testing.T) {
+		result, err := client.GetBalanceAllowanceOf(address)
+		if err != nil {
+			t.Fatalf("GetBalanceAllowanceOf failed: %v", err)
+		}
+		t.Logf("GetBalanceAllowanceOf returned: %+v", result)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		if _, err := client.GetUSDCBalanceOf(types.EthAddress("invalid-address")); err == nil {
+			t.Error("Expected error for invalid address")
+		}
+	})
+}
+
+func TestRefreshBalances(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+
+	// 基本功能测试
+	t.Run("Basic", func(t *testing.T) {
+		if err := client.RefreshBalances(); err != nil {
+			t.Fatalf("RefreshBalances failed: %v", err)
+		}
+		balance, err := client.GetUSDCBalance()
+		if err != nil {
+			t.Fatalf("GetUSDCBalance after refresh failed: %v", err)
+		}
+		t.Logf("GetUSDCBalance after refresh returned: %f", balance)
+	})
+}
+
 func TestGetBalanceAllowance(t *testing.T) {
 	client := newTestClobClientWithAuth(t)
 
@@ -881,6 +1693,41 @@ func TestGetNotifications(t *testing.T) {
 	})
 }
 
+func TestGetAllNotifications(t *testing.T) {
+	newAccount := func(t *testing.T, body string) (*accountClientImpl, *sdkhttp.Replayer) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+		transport := test.NewFixtureTransport(t, test.Fixture{Path: internal.GetNotifications, Body: body})
+		client.baseClient.baseURL = internal.ClobAPIDomain
+		client.baseClient.httpOptions = []sdkhttp.HTTPOption{sdkhttp.WithTransport(transport)}
+		return &accountClientImpl{baseClient: client.baseClient}, transport
+	}
+
+	// 一次请求获取全部通知，不带分页参数
+	t.Run("SingleRequest", func(t *testing.T) {
+		account, transport := newAccount(t, `[{"id":"1","type":"resolution"},{"id":"2"}]`)
+		notifications, err := account.GetAllNotifications()
+		if err != nil {
+			t.Fatalf("GetAllNotifications failed: %v", err)
+		}
+		if len(notifications) != 2 || notifications[0].ID != "1" {
+			t.Errorf("Expected 2 notifications, got %+v", notifications)
+		}
+		requests := transport.Requests()
+		if len(requests) != 1 || strings.Contains(requests[0].URL, "?") {
+			t.Errorf("Expected a single request without query parameters, got %+v", requests)
+		}
+	})
+
+	// 只接受文档中的数组格式
+	t.Run("RejectsObject", func(t *testing.T) {
+		account, _ := newAccount(t, `{"data":[{"id":"1"}],"next_cursor":"LTE="}`)
+		if _, err := account.GetAllNotifications(); err == nil || !strings.Contains(err.Error(), "failed to get notifications") {
+			t.Errorf("Expected parse error for non-array response, got %v", err)
+		}
+	})
+}
+
 func TestDropNotifications(t *testing.T) {
 	client := newTestClobClientWithAuth(t)
 
@@ -955,6 +1802,30 @@ func TestDropNotifications(t *testing.T) {
 	})
 }
 
+func TestDropAllNotifications(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+
+	// 注意：这个测试会清空账户的所有通知
+	t.Run("Basic", func(t *testing.T) {
+		if err := client.DropAllNotifications(); err != nil {
+			t.Fatalf("DropAllNotifications failed: %v", err)
+		}
+
+		notifications, err := client.GetNotifications(10, 0)
+		if err != nil {
+			t.Fatalf("GetNotifications failed: %v", err)
+		}
+		t.Logf("GetNotifications after DropAllNotifications returned %d notifications", len(notifications))
+	})
+
+	// 没有通知时应直接返回
+	t.Run("Empty", func(t *testing.T) {
+		if err := client.DropAllNotifications(); err != nil {
+			t.Fatalf("DropAllNotifications on empty inbox failed: %v", err)
+		}
+	})
+}
+
 func TestIsOrderScoring(t *testing.T) {
 	client := newTestClobClientWithAuth(t)
 
@@ -1284,6 +2155,91 @@ func TestGetReadonlyAPIKeys(t *testing.T) {
 	})
 }
 
+func TestCurrentKeyScope(t *testing.T) {
+	client := newTestClobClientWithAuth(t)
+
+	// 基本功能测试
+	t.Run("Basic", func(t *testing.T) {
+		scope, err := client.CurrentKeyScope()
+		if err != nil {
+			t.Fatalf("CurrentKeyScope failed: %v", err)
+		}
+		if scope != types.APIKeyScopeRead && scope != types.APIKeyScopeTrade {
+			t.Errorf("Unexpected scope: %q", scope)
+		}
+		t.Logf("CurrentKeyScope returned: %s", scope)
+	})
+}
+
+func TestScopeForKey(t *testing.T) {
+	// 根据密钥列表推断权限范围（无需网络）
+	t.Run("Basic", func(t *testing.T) {
+		keys := []types.APIKey{
+			{Key: "trade-key"},
+			{Key: "flag-key", Readonly: true},
+			{Key: "scope-key", Scope: "READ"},
+		}
+		readonlyKeys := []types.APIKey{{Key: "readonly-key"}}
+
+		cases := map[string]string{
+			"trade-key":    types.APIKeyScopeTrade,
+			"flag-key":     types.APIKeyScopeRead,
+			"scope-key":    types.APIKeyScopeRead,
+			"readonly-key": types.APIKeyScopeRead,
+		}
+		for key, expected := range cases {
+			scope, ok := scopeForKey(key, keys, readonlyKeys)
+			if !ok || scope != expected {
+				t.Errorf("scopeForKey(%q) = %q, %v; expected %q", key, scope, ok, expected)
+			}
+		}
+		if _, ok := scopeForKey("missing-key", keys, readonlyKeys); ok {
+			t.Error("Expected missing key to be reported as not found")
+		}
+	})
+}
+
+func TestCurrentKeyScopeErrorCache(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+	now := time.Unix(1700000000, 0)
+	client.baseClient.keyScope = &keyScopeCache{now: func() time.Time { return now }}
+
+	failing := test.NewFixtureTransport(t,
+		test.Fixture{Path: internal.GetAPIKeys, StatusCode: 400, Body: `{"error":"temporarily unavailable"}`},
+	)
+	client.baseClient.baseURL = internal.ClobAPIDomain
+	client.baseClient.httpOptions = []sdkhttp.HTTPOption{sdkhttp.WithTransport(failing)}
+
+	// 临时错误在 KeyScopeErrorTTL 内直接返回，不重复请求密钥列表
+	for i := 0; i < 3; i++ {
+		if _, err := client.baseClient.currentKeyScope(); err == nil {
+			t.Fatalf("call %d: expected error", i)
+		}
+	}
+	if n := len(failing.Requests()); n != 1 {
+		t.Errorf("Expected 1 request while the error is cached, got %d", n)
+	}
+
+	// 过期后重新查询，成功结果永久缓存
+	now = now.Add(internal.KeyScopeErrorTTL)
+	ok := test.NewFixtureTransport(t,
+		test.Fixture{Path: internal.GetAPIKeys, Body: `[{"key":"offline-key"}]`},
+		test.Fixture{Path: internal.GetReadonlyAPIKeys, Body: `[]`},
+	)
+	client.baseClient.httpOptions = []sdkhttp.HTTPOption{sdkhttp.WithTransport(ok)}
+	scope, err := client.baseClient.currentKeyScope()
+	if err != nil || scope != types.APIKeyScopeTrade {
+		t.Fatalf("Expected trade scope after the error expired, got %q (err=%v)", scope, err)
+	}
+	if _, err := client.baseClient.currentKeyScope(); err != nil {
+		t.Fatalf("Expected cached scope, got %v", err)
+	}
+	if n := len(ok.Requests()); n != 2 {
+		t.Errorf("Expected the resolved scope to be cached (2 requests), got %d", n)
+	}
+}
+
 func TestDeleteReadonlyAPIKey(t *testing.T) {
 	client := newTestClobClientWithAuth(t)
 
@@ -1328,3 +2284,1296 @@ func TestDeleteReadonlyAPIKey(t *testing.T) {
 		}
 	})
 }
+
+// offlineWeb3Client 离线测试用的 web3.Client 实现，只提供签名相关信息
+type offlineWeb3Client struct {
+	signer      *signing.Signer
+	holdings    *types.TokenHoldings       // GetTokenHoldings 的返回值，nil 时返回错误
+	collateral  *types.CollateralHoldings  // GetCollateralHoldings 的返回值，nil 时返回错误
+	usdcBalance float64                    // GetUSDCBalance 的返回值
+	resolution  *types.ConditionResolution // GetConditionResolution 的返回值，nil 时返回错误
+	chainID     types.ChainID              // GetChainID 的返回值，为 0 时返回 Polygon
+	orderNonce  int64                      // OrderNonce 的返回值
+	nonceErr    error                      // OrderNonce 返回的错误
+}
+
+func (f *offlineWeb3Client) GetSigner() *signing.Signer       { return f.signer }
+func (f *offlineWeb3Client) GetPrivateKey() *ecdsa.PrivateKey { return f.signer.PrivateKey() }
+func (f *offlineWeb3Client) GetBaseAddress() types.EthAddress { return f.signer.Address() }
+func (f *offlineWeb3Client) GetPolyProxyAddress() (types.EthAddress, error) {
+	return f.signer.Address(), nil
+}
+func (f *offlineWeb3Client) GetChainID() types.ChainID {
+	if f.chainID == 0 {
+		return types.Polygon
+	}
+	return f.chainID
+}
+func (f *offlineWeb3Client) GetSignatureType() types.SignatureType {
+	return types.EOASignatureType
+}
+func (f *offlineWeb3Client) GetPOLBalance() (float64, error) { return 0, nil }
+func (f *offlineWeb3Client) GetUSDCBalance(address types.EthAddress) (float64, error) {
+	return f.usdcBalance, nil
+}
+func (f *offlineWeb3Client) GetUSDCBalanceRaw(address types.EthAddress) (*big.Int, error) {
+	return big.NewInt(int64(f.usdcBalance * 1e6)), nil
+}
+func (f *offlineWeb3Client) GetUSDCBalanceAllowance(address types.EthAddress) (*types.BalanceAllowance, error) {
+	return nil, errors.New("offline web3 client cannot read balances")
+}
+func (f *offlineWeb3Client) WalletInfo() (*types.WalletInfo, error) {
+	return &types.WalletInfo{BaseAddress: f.signer.Address(), ProxyAddress: f.signer.Address(), SignatureType: types.EOASignatureType}, nil
+}
+func (f *offlineWeb3Client) GetTokenBalance(tokenID string, address types.EthAddress) (float64, error) {
+	return 0, nil
+}
+func (f *offlineWeb3Client) GetTokenHoldings(address types.EthAddress, tokenIDs []string) (*types.TokenHoldings, error) {
+	if f.holdings == nil {
+		return nil, errors.New("offline web3 client cannot read token holdings")
+	}
+	return f.holdings, nil
+}
+func (f *offlineWeb3Client) GetCollateralHoldings(address types.EthAddress) (*types.CollateralHoldings, error) {
+	if f.collateral == nil {
+		return nil, errors.New("offline web3 client cannot read allowances")
+	}
+	return f.collateral, nil
+}
+func (f *offlineWeb3Client) WaitForReceipt(ctx context.Context, txHash types.Keccak256) (*types.TransactionReceipt, error) {
+	return nil, errors.New("offline web3 client cannot wait for receipts")
+}
+func (f *offlineWeb3Client) SuggestGasFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	return nil, nil, errors.New("offline web3 client cannot suggest gas fees")
+}
+func (f *offlineWeb3Client) GetConditionResolution(conditionID types.Keccak256) (*types.ConditionResolution, error) {
+	if f.resolution == nil {
+		return nil, errors.New("offline web3 client cannot query condition resolution")
+	}
+	return f.resolution, nil
+}
+func (f *offlineWeb3Client) GetConditionResolutions(conditionIDs []types.Keccak256) (map[types.Keccak256]*types.ConditionResolution, error) {
+	return nil, errors.New("offline web3 client cannot query condition resolutions")
+}
+func (f *offlineWeb3Client) WatchResolution(ctx context.Context, conditionID types.Keccak256) (<-chan types.ConditionResolution, error) {
+	return nil, errors.New("offline web3 client cannot watch condition resolution")
+}
+func (f *offlineWeb3Client) Multicall(calls []types.Call) ([][]byte, error) {
+	return nil, errors.New("offline web3 client cannot execute multicall")
+}
+func (f *offlineWeb3Client) GetExchangeNonce(negRisk bool) (int64, error) {
+	return 0, errors.New("offline web3 client cannot query exchange nonce")
+}
+func (f *offlineWeb3Client) OrderNonce(negRisk bool) (int64, error) {
+	return f.orderNonce, f.nonceErr
+}
+func (f *offlineWeb3Client) Close() {}
+
+// 测试中开启签名断言：签名请求发送前校验 HMAC 与实际发送的字节一致
+func init() {
+	internal.SetSignatureAssertions(true)
+}
+
+// newOfflineOrderClient 创建不访问网络的订单客户端（固定 salt，便于比较签名结果）
+func newOfflineOrderClient(t *testing.T) *orderClientImpl {
+	// Hardhat 默认测试私钥，仅用于离线签名测试
+	signer, err := signing.NewSigner("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", types.Polygon)
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+
+	web3Client := &offlineWeb3Client{signer: signer}
+	base := &baseClient{
+		address:       signer.Address(),
+		proxyAddress:  signer.Address(),
+		signatureType: types.EOASignatureType,
+		orderBuilder: builder.NewExchangeOrderBuilderImpl(big.NewInt(int64(types.Polygon)), func() int64 {
+			return 123456789
+		}),
+		web3Client:  web3Client,
+		deriveCreds: &types.ApiCreds{Key: "offline-key", Secret: "offline-secret", Passphrase: "offline-passphrase"},
+		balances:    &balanceCache{},
+	}
+	return &orderClientImpl{baseClient: base}
+}
+
+func TestClientEnvironment(t *testing.T) {
+	t.Run("Readonly", func(t *testing.T) {
+		client := NewReadonlyClient()
+		if client.ChainID() != types.Polygon || client.Environment() != types.EnvironmentMainnet {
+			t.Errorf("Expected readonly client on mainnet, got %d (%s)", client.ChainID(), client.Environment())
+		}
+	})
+
+	// 完整客户端的链ID来自 web3 客户端
+	t.Run("Client", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		if client.baseClient.ChainID() != types.Polygon || client.baseClient.Environment() != types.EnvironmentMainnet {
+			t.Errorf("Expected client on mainnet, got %d (%s)", client.baseClient.ChainID(), client.baseClient.Environment())
+		}
+	})
+
+	// 只有主网有 CLOB API，测试网配置不能把订单提交到主网
+	t.Run("TestnetRejected", func(t *testing.T) {
+		signer, err := signing.NewSigner("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", types.Amoy)
+		if err != nil {
+			t.Fatalf("Failed to create signer: %v", err)
+		}
+		if _, err := NewClient(&offlineWeb3Client{signer: signer, chainID: types.Amoy}); err == nil {
+			t.Error("Expected NewClient to reject Amoy configuration")
+		}
+	})
+
+	t.Run("ChainEnvironment", func(t *testing.T) {
+		if types.Amoy.Environment() != types.EnvironmentTestnet {
+			t.Errorf("Expected Amoy to be testnet, got %s", types.Amoy.Environment())
+		}
+		if types.ChainID(1).Environment() != types.EnvironmentUnknown {
+			t.Errorf("Expected unknown environment, got %s", types.ChainID(1).Environment())
+		}
+	})
+}
+
+func TestNegRiskOrderSigning(t *testing.T) {
+	client := newOfflineOrderClient(t)
+
+	orderArgs := types.OrderArgs{
+		TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		Side:    types.OrderSideBUY,
+		Price:   0.57,
+		Size:    10.53,
+	}
+
+	// neg-risk 与普通市场的数量计算和舍入规则相同（只与 tickSize 有关）
+	t.Run("SameAmounts", func(t *testing.T) {
+		normal, err := client.createSignedOrder(orderArgs, "0.001", false, 0, types.OrderTypeGTC)
+		if err != nil {
+			t.Fatalf("createSignedOrder (negRisk=false) failed: %v", err)
+		}
+		negRisk, err := client.createSignedOrder(orderArgs, "0.001", true, 0, types.OrderTypeGTC)
+		if err != nil {
+			t.Fatalf("createSignedOrder (negRisk=true) failed: %v", err)
+		}
+
+		if normal.Order.MakerAmount.String() != "6002100" || normal.Order.TakerAmount.String() != "10530000" {
+			t.Errorf("Unexpected amounts: maker=%s taker=%s", normal.Order.MakerAmount, normal.Order.TakerAmount)
+		}
+		if negRisk.Order.MakerAmount.Cmp(normal.Order.MakerAmount) != 0 || negRisk.Order.TakerAmount.Cmp(normal.Order.TakerAmount) != 0 {
+			t.Errorf("neg-risk amounts differ: maker=%s/%s taker=%s/%s",
+				negRisk.Order.MakerAmount, normal.Order.MakerAmount, negRisk.Order.TakerAmount, normal.Order.TakerAmount)
+		}
+		if bytes.Equal(negRisk.Signature, normal.Signature) {
+			t.Error("Expected neg-risk signature to differ (different verifying contract)")
+		}
+	})
+
+	// neg-risk 订单签名必须基于 NegRiskCTFExchange 合约域
+	t.Run("VerifyingContract", func(t *testing.T) {
+		signed, err := client.createSignedOrder(orderArgs, "0.001", true, 0, types.OrderTypeGTC)
+		if err != nil {
+			t.Fatalf("createSignedOrder failed: %v", err)
+		}
+
+		hash, err := client.orderBuilder.BuildOrderHash(&signed.Order, ordermodel.NegRiskCTFExchange)
+		if err != nil {
+			t.Fatalf("BuildOrderHash failed: %v", err)
+		}
+		valid, err := ordersigner.ValidateSignature(signed.Order.Signer, hash, signed.Signature)
+		if err != nil {
+			t.Fatalf("ValidateSignature failed: %v", err)
+		}
+		if !valid {
+			t.Error("neg-risk order signature is not valid for NegRiskCTFExchange")
+		}
+	})
+
+	// neg-risk 市场 SELL 订单的舍入规则（tickSize=0.01）
+	t.Run("SELLRounding", func(t *testing.T) {
+		sellArgs := orderArgs
+		sellArgs.Side = types.OrderSideSELL
+		sellArgs.Size = 5.129
+
+		signed, err := client.createSignedOrder(sellArgs, "0.01", true, 0, types.OrderTypeGTC)
+		if err != nil {
+			t.Fatalf("createSignedOrder failed: %v", err)
+		}
+		// size 向下取整到 2 位小数：5.12；taker = 5.12 * 0.57 = 2.9184
+		if signed.Order.MakerAmount.String() != "5120000" || signed.Order.TakerAmount.String() != "2918400" {
+			t.Errorf("Unexpected amounts: maker=%s taker=%s", signed.Order.MakerAmount, signed.Order.TakerAmount)
+		}
+	})
+}
+
+func TestExpirationFromNow(t *testing.T) {
+	client := newOfflineOrderClient(t)
+
+	// 使用服务器时间偏差计算过期时间（模拟本地时钟慢 1 小时）
+	t.Run("WithTimeOffset", func(t *testing.T) {
+		client.baseClient.setTimeOffset(time.Hour)
+
+		expiration := client.ExpirationFromNow(30 * time.Second)
+		expected := time.Now().Add(time.Hour + time.Minute + 30*time.Second).Unix()
+		if diff := expiration - expected; diff < -1 || diff > 1 {
+			t.Errorf("Expected expiration around %d, got %d", expected, expiration)
+		}
+	})
+
+	// GTD 订单必须设置过期时间
+	t.Run("GTDRequiresExpiration", func(t *testing.T) {
+		orderArgs := types.OrderArgs{
+			TokenID: "1",
+			Side:    types.OrderSideBUY,
+			Price:   0.5,
+			Size:    10,
+		}
+		if _, err := client.createSignedOrder(orderArgs, "0.01", false, 0, types.OrderTypeGTD); err == nil {
+			t.Error("Expected error for GTD order without expiration")
+		}
+
+		orderArgs.Expiration = client.ExpirationFromNow(time.Minute)
+		signed, err := client.createSignedOrder(orderArgs, "0.01", false, 0, types.OrderTypeGTD)
+		if err != nil {
+			t.Fatalf("createSignedOrder failed: %v", err)
+		}
+		if signed.Order.Expiration.Int64() != orderArgs.Expiration {
+			t.Errorf("Expected expiration %d, got %s", orderArgs.Expiration, signed.Order.Expiration)
+		}
+	})
+}
+
+func TestBuildOrderRequestsAlignment(t *testing.T) {
+	client := newOfflineOrderClient(t)
+
+	orderArgsList := []types.OrderArgs{
+		{
+			TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+			Side:    types.OrderSideBUY,
+			Price:   0.5,
+			Size:    10,
+		},
+		// GTD 订单缺少过期时间，本地无法签名
+		{
+			TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+			Side:    types.OrderSideSELL,
+			Price:   0.6,
+			Size:    10,
+		},
+	}
+	orderTypes := []types.OrderType{types.OrderTypeGTC, types.OrderTypeGTD}
+
+	requestBody, postedIndices, results := client.buildOrderRequests(orderArgsList, orderTypes, false)
+
+	t.Run("Build", func(t *testing.T) {
+		if len(results) != len(orderArgsList) {
+			t.Fatalf("Expected %d results, got %d", len(orderArgsList), len(results))
+		}
+		if len(requestBody) != 1 || len(postedIndices) != 1 || postedIndices[0] != 0 {
+			t.Fatalf("Expected only order 0 to be posted, got indices %v", postedIndices)
+		}
+		if results[0].ErrorMsg != "" {
+			t.Errorf("Expected no error for valid order, got %q", results[0].ErrorMsg)
+		}
+		if results[1].ErrorMsg == "" {
+			t.Error("Expected ErrorMsg for unsignable order")
+		}
+	})
+
+	// API 响应应放回对应的输入位置
+	t.Run("Align", func(t *testing.T) {
+		posted := []types.OrderPostResponse{{OrderID: "0xabc", Status: "live", Success: true}}
+		aligned := alignOrderResponses(results, postedIndices, posted)
+		if len(aligned) != 2 {
+			t.Fatalf("Expected 2 aligned results, got %d", len(aligned))
+		}
+		if aligned[0].OrderID != "0xabc" {
+			t.Errorf("Expected order 0 to get the API response, got %+v", aligned[0])
+		}
+		if aligned[1].OrderID != "" || aligned[1].ErrorMsg == "" {
+			t.Errorf("Expected order 1 to keep its local error, got %+v", aligned[1])
+		}
+	})
+
+	// API 返回的响应数量不足时，缺失的订单填充错误
+	t.Run("MissingResponse", func(t *testing.T) {
+		_, indices, res := client.buildOrderRequests(orderArgsList[:1], orderTypes[:1], false)
+		aligned := alignOrderResponses(res, indices, nil)
+		if aligned[0].ErrorMsg == "" {
+			t.Error("Expected ErrorMsg for order without API response")
+		}
+	})
+}
+
+func TestOrderArgsValidate(t *testing.T) {
+	const tokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	valid := types.OrderArgs{TokenID: tokenID, Side: types.OrderSideBUY, Price: 0.5, Size: 10}
+	with := func(modify func(*types.OrderArgs)) types.OrderArgs {
+		args := valid
+		modify(&args)
+		return args
+	}
+
+	cases := []struct {
+		name     string
+		args     types.OrderArgs
+		tickSize types.TickSize
+		wantErr  string
+	}{
+		{"Valid", valid, "0.01", ""},
+		{"MinPrice", with(func(a *types.OrderArgs) { a.Price = 0.01 }), "0.01", ""},
+		{"MaxPrice", with(func(a *types.OrderArgs) { a.Price = 0.99 }), "0.01", ""},
+		{"MinPriceFineTick", with(func(a *types.OrderArgs) { a.Price = 0.001 }), "0.001", ""},
+		{"MaxPriceFineTick", with(func(a *types.OrderArgs) { a.Price = 0.999 }), "0.001", ""},
+		{"MaxPriceComputed", with(func(a *types.OrderArgs) { a.Price = 1 - 0.0001 }), "0.0001", ""},
+		{"CoarseTick", with(func(a *types.OrderArgs) { a.Price = 0.1 }), "0.1", ""},
+		{"BelowMinPrice", with(func(a *types.OrderArgs) { a.Price = 0.009 }), "0.01", "must be in range"},
+		{"AboveMaxPrice", with(func(a *types.OrderArgs) { a.Price = 0.991 }), "0.01", "must be in range"},
+		{"ZeroPrice", with(func(a *types.OrderArgs) { a.Price = 0 }), "0.01", "must be in range"},
+		{"OnePrice", with(func(a *types.OrderArgs) { a.Price = 1 }), "0.01", "must be in range"},
+		{"NaNPrice", with(func(a *types.OrderArgs) { a.Price = math.NaN() }), "0.01", "must be in range"},
+		{"ZeroSize", with(func(a *types.OrderArgs) { a.Size = 0 }), "0.01", "must be positive"},
+		{"NegativeSize", with(func(a *types.OrderArgs) { a.Size = -1 }), "0.01", "must be positive"},
+		{"InfSize", with(func(a *types.OrderArgs) { a.Size = math.Inf(1) }), "0.01", "must be positive"},
+		{"Sell", with(func(a *types.OrderArgs) { a.Side = types.OrderSideSELL }), "0.01", ""},
+		{"LowercaseSide", with(func(a *types.OrderArgs) { a.Side = "buy" }), "0.01", "invalid order side"},
+		{"EmptySide", with(func(a *types.OrderArgs) { a.Side = "" }), "0.01", "invalid order side"},
+		{"FutureExpiration", with(func(a *types.OrderArgs) { a.Expiration = time.Now().Add(time.Hour).Unix() }), "0.01", ""},
+		{"PastExpiration", with(func(a *types.OrderArgs) { a.Expiration = time.Now().Add(-time.Hour).Unix() }), "0.01", "in the past"},
+		{"NegativeExpiration", with(func(a *types.OrderArgs) { a.Expiration = -1 }), "0.01", "must not be negative"},
+		{"Nonce", with(func(a *types.OrderArgs) { n := int64(3); a.Nonce = &n }), "0.01", ""},
+		{"NegativeNonce", with(func(a *types.OrderArgs) { n := int64(-1); a.Nonce = &n }), "0.01", "must not be negative"},
+		{"InvalidTickSize", valid, "abc", "invalid tick size"},
+		{"ZeroTickSize", valid, "0", "invalid tick size"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.args.Validate(c.tickSize)
+			if c.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected valid order, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", c.wantErr, err)
+			}
+		})
+	}
+
+	// 批量校验和签名使用相同的规则
+	t.Run("Shared", func(t *testing.T) {
+		invalid := with(func(a *types.OrderArgs) { a.Size = 0 })
+		if err := validateOrderBatch([]types.OrderArgs{valid, invalid}, []types.OrderType{types.OrderTypeGTC, types.OrderTypeGTC}); err == nil || !strings.Contains(err.Error(), "订单 2") {
+			t.Errorf("Expected batch error for order 2, got %v", err)
+		}
+		client := newOfflineOrderClient(t)
+		if _, err := client.createSignedOrder(invalid, "0.001", false, 0, types.OrderTypeGTC); err == nil {
+			t.Error("Expected createSignedOrder to reject zero size")
+		}
+	})
+}
+
+func TestApplyMinOrderSize(t *testing.T) {
+	marketMin := 1.0
+
+	t.Run("DefaultClamp", func(t *testing.T) {
+		args, err := applyMinOrderSize(types.OrderArgs{Size: 2}, internal.DefaultMarketMinOrderSize, "")
+		if err != nil {
+			t.Fatalf("Expected clamp, got error: %v", err)
+		}
+		if args.Size != internal.DefaultMarketMinOrderSize {
+			t.Errorf("Expected size %v, got %v", internal.DefaultMarketMinOrderSize, args.Size)
+		}
+	})
+
+	t.Run("MarketMinimum", func(t *testing.T) {
+		args, err := applyMinOrderSize(types.OrderArgs{Size: 2}, marketMin, types.MinOrderSizeReject)
+		if err != nil || args.Size != 2 {
+			t.Errorf("Expected size 2 to pass market minimum 1, got %v (err=%v)", args.Size, err)
+		}
+		args, err = applyMinOrderSize(types.OrderArgs{Size: 0.5}, marketMin, types.MinOrderSizeClamp)
+		if err != nil || args.Size != 1 {
+			t.Errorf("Expected size clamped to 1, got %v (err=%v)", args.Size, err)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		_, err := applyMinOrderSize(types.OrderArgs{Size: 2}, internal.DefaultMarketMinOrderSize, types.MinOrderSizeReject)
+		if !errors.Is(err, types.ErrOrderBelowMinSize) {
+			t.Errorf("Expected ErrOrderBelowMinSize, got %v", err)
+		}
+	})
+
+	t.Run("MinimumSource", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.tokenMeta = newTokenMetaCache(0)
+		if got := client.baseClient.marketMinOrderSize(types.OrderArgs{TokenID: test.FixtureYesTokenID}); got != internal.DefaultMarketMinOrderSize {
+			t.Errorf("Expected default minimum without cached metadata, got %v", got)
+		}
+
+		// 已缓存的代币元数据优先于默认值，OrderArgs.MinSize 优先于缓存
+		client.baseClient.tokenMeta.entries.add(test.FixtureYesTokenID, tokenMetaEntry{meta: types.TokenMeta{TokenID: test.FixtureYesTokenID, MinOrderSize: 15}})
+		if got := client.baseClient.marketMinOrderSize(types.OrderArgs{TokenID: test.FixtureYesTokenID}); got != 15 {
+			t.Errorf("Expected cached market minimum 15, got %v", got)
+		}
+		if got := client.baseClient.marketMinOrderSize(types.OrderArgs{TokenID: test.FixtureYesTokenID, MinSize: &marketMin}); got != marketMin {
+			t.Errorf("Expected explicit minimum %v, got %v", marketMin, got)
+		}
+	})
+
+	t.Run("RejectedOrderNotPosted", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.minSizePolicy = types.MinOrderSizeReject
+		orderArgsList := []types.OrderArgs{{
+			TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+			Side:    types.OrderSideBUY,
+			Price:   0.5,
+			Size:    1,
+		}}
+		requestBody, _, results := client.buildOrderRequests(orderArgsList, []types.OrderType{types.OrderTypeGTC}, false)
+		if len(requestBody) != 0 {
+			t.Errorf("Expected rejected order not to be posted, got %d requests", len(requestBody))
+		}
+		if !strings.Contains(results[0].ErrorMsg, types.ErrOrderBelowMinSize.Error()) {
+			t.Errorf("Expected min size error, got %q", results[0].ErrorMsg)
+		}
+	})
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		if _, err := parseClientOptions([]ClientOption{WithMinOrderSizePolicy("round")}); err == nil {
+			t.Error("Expected error for invalid policy")
+		}
+	})
+}
+
+func TestRequiredCollateral(t *testing.T) {
+	const tokenA, tokenB = "1001", "1002"
+	orders := []types.OrderArgs{
+		{TokenID: tokenA, Side: types.OrderSideBUY, Price: 0.56, Size: 100},
+		{TokenID: tokenA, Side: types.OrderSideBUY, Price: 0.58, Size: 21.04},
+		{TokenID: tokenB, Side: types.OrderSideBUY, Price: 0.5, Size: 1}, // 低于最小下单数量
+		{TokenID: tokenA, Side: types.OrderSideSELL, Price: 0.6, Size: 10},
+		{TokenID: tokenA, Side: types.OrderSideSELL, Price: 0.7, Size: 5.555},
+		{TokenID: tokenB, Side: types.OrderSideSELL, Price: 0.4, Size: 20},
+	}
+
+	t.Run("Clamp", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		usdc, err := client.RequiredCollateral(orders)
+		if err != nil {
+			t.Fatalf("RequiredCollateral failed: %v", err)
+		}
+		// 56 + 12.2032 + 5*0.5（提高到最小下单数量）
+		if math.Abs(usdc-70.7032) > 1e-9 {
+			t.Errorf("Expected 70.7032 USDC, got %v", usdc)
+		}
+
+		tokens, err := client.RequiredTokenBalances(orders)
+		if err != nil {
+			t.Fatalf("RequiredTokenBalances failed: %v", err)
+		}
+		if len(tokens) != 2 || math.Abs(tokens[tokenA]-15.55) > 1e-9 || math.Abs(tokens[tokenB]-20) > 1e-9 {
+			t.Errorf("Unexpected token balances: %v", tokens)
+		}
+	})
+
+	t.Run("RejectedOrderExcluded", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.minSizePolicy = types.MinOrderSizeReject
+		usdc, err := client.RequiredCollateral(orders)
+		if err != nil || math.Abs(usdc-68.2032) > 1e-9 {
+			t.Errorf("Expected 68.2032 USDC, got %v (err=%v)", usdc, err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		if _, err := client.RequiredCollateral([]types.OrderArgs{{TokenID: tokenA, Side: types.OrderSideBUY, Price: 1.5, Size: 10}}); err == nil {
+			t.Error("Expected error for invalid price")
+		}
+		if _, err := client.RequiredCollateral([]types.OrderArgs{{TokenID: tokenA, Side: "HOLD", Price: 0.5, Size: 10}}); err == nil {
+			t.Error("Expected error for invalid side")
+		}
+	})
+}
+
+func TestOrderSource(t *testing.T) {
+	opts, err := parseClientOptions([]ClientOption{WithOrderSource("mm-bot")})
+	if err != nil || opts.orderSource != "mm-bot" {
+		t.Fatalf("Expected order source mm-bot, got %q (err=%v)", opts.orderSource, err)
+	}
+
+	orderArgs := types.OrderArgs{
+		TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		Side:    types.OrderSideBUY,
+		Price:   0.5,
+		Size:    10,
+	}
+
+	t.Run("Posted", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.orderSource = "mm-bot"
+		client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+		client.baseClient.baseURL = internal.ClobAPIDomain
+		client.baseClient.httpOptions = []sdkhttp.HTTPOption{sdkhttp.WithTransport(sdkhttp.NewReplayerFromInteractions([]sdkhttp.Interaction{{
+			Method:     "POST",
+			URL:        internal.ClobAPIDomain + internal.PostOrders,
+			StatusCode: 200,
+			Body:       `[{"orderID":"0x01","status":"live","success":true}]`,
+		}}))}
+
+		results, err := client.postOrdersBatch([]types.OrderArgs{orderArgs}, []types.OrderType{types.OrderTypeGTC})
+		if err != nil {
+			t.Fatalf("postOrdersBatch failed: %v", err)
+		}
+		if len(results) != 1 || results[0].OrderID != "0x01" || results[0].Source != "mm-bot" {
+			t.Errorf("Expected tagged result, got %+v", results)
+		}
+	})
+
+	// 本地拒绝、未提交的订单同样带有来源标签；整批都未提交时返回错误
+	t.Run("NotPosted", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.orderSource = "mm-bot"
+		client.baseClient.minSizePolicy = types.MinOrderSizeReject
+		small := orderArgs
+		small.Size = 1
+
+		results, err := client.postOrdersBatch([]types.OrderArgs{small}, []types.OrderType{types.OrderTypeGTC})
+		if err == nil {
+			t.Fatal("Expected error when no order in the batch could be signed")
+		}
+		if len(results) != 1 || results[0].ErrorMsg == "" || results[0].Source != "mm-bot" {
+			t.Errorf("Expected tagged rejected result, got %+v", results)
+		}
+	})
+}
+
+func TestConcurrentBatches(t *testing.T) {
+	opts, err := parseClientOptions([]ClientOption{WithConcurrentBatches(3)})
+	if err != nil || opts.concurrentBatches != 3 {
+		t.Fatalf("Expected concurrent batches 3, got %d (err=%v)", opts.concurrentBatches, err)
+	}
+	if _, err := parseClientOptions([]ClientOption{WithConcurrentBatches(-1)}); err == nil {
+		t.Error("Expected error for negative concurrent batches")
+	}
+
+	t.Run("Sequential", func(t *testing.T) {
+		var order []int
+		runOrderBatches(4, 0, time.Millisecond, func(batch int) {
+			order = append(order, batch)
+		})
+		if fmt.Sprint(order) != "[0 1 2 3]" {
+			t.Errorf("Expected sequential batches, got %v", order)
+		}
+	})
+
+	// 并发数不超过上限，所有批次都会执行
+	t.Run("Bounded", func(t *testing.T) {
+		var running, peak, done atomic.Int32
+		runOrderBatches(8, 3, time.Millisecond, func(batch int) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			done.Add(1)
+		})
+		if done.Load() != 8 {
+			t.Errorf("Expected 8 batches, got %d", done.Load())
+		}
+		if peak.Load() > 3 {
+			t.Errorf("Expected at most 3 concurrent batches, got %d", peak.Load())
+		}
+	})
+
+	// 并发生成的 salt 不重复
+	t.Run("UniqueSalt", func(t *testing.T) {
+		salt := newSaltGenerator()
+		const workers, perWorker = 8, 200
+		results := make(chan int64, workers*perWorker)
+		runOrderBatches(workers, workers, 0, func(int) {
+			for i := 0; i < perWorker; i++ {
+				results <- salt()
+			}
+		})
+		close(results)
+		seen := make(map[int64]bool, workers*perWorker)
+		for s := range results {
+			if seen[s] {
+				t.Fatalf("Duplicate salt %d", s)
+			}
+			seen[s] = true
+		}
+	})
+}
+
+func TestSharesToUSDC(t *testing.T) {
+	cases := []struct {
+		tickSize     types.TickSize
+		shares       float64
+		price        float64
+		wantUSDC     float64
+		wantFromUSDC float64 // USDCToShares(wantUSDC, price)
+	}{
+		{"0.01", 100, 0.56, 56, 100},
+		{"0.01", 21.049, 0.58, 12.2032, 21.04},
+		{"0.001", 33.333, 0.123, 4.09959, 33.33},
+		{"0.0001", 7.777, 0.0567, 0.440559, 7.77},
+		{"0.1", 15.5, 0.5, 7.75, 15.5},
+	}
+	client := &orderClientImpl{}
+	for _, c := range cases {
+		got := SharesToUSDC(c.shares, c.price)
+		if math.Abs(got-c.wantUSDC) > 1e-9 {
+			t.Errorf("SharesToUSDC(%v, %v) = %v, want %v", c.shares, c.price, got, c.wantUSDC)
+		}
+
+		// 与签名的 BUY 订单 makerAmount 一致
+		makerAmount, _, err := client.calculateOrderAmounts(types.OrderSideBUY, c.shares, c.price, c.tickSize)
+		if err != nil {
+			t.Fatalf("calculateOrderAmounts failed: %v", err)
+		}
+		if signed := float64(makerAmount.Int64()) / 1e6; math.Abs(signed-got) > 1e-9 {
+			t.Errorf("SharesToUSDC(%v, %v) = %v, signed order uses %v", c.shares, c.price, got, signed)
+		}
+
+		if shares := USDCToShares(c.wantUSDC, c.price); math.Abs(shares-c.wantFromUSDC) > 1e-9 {
+			t.Errorf("USDCToShares(%v, %v) = %v, want %v", c.wantUSDC, c.price, shares, c.wantFromUSDC)
+		}
+	}
+
+	// 金额不足一档时向下取整，且不会超出预算
+	if shares := USDCToShares(100, 0.33); math.Abs(shares-303.03) > 1e-9 || SharesToUSDC(shares, 0.33) > 100 {
+		t.Errorf("USDCToShares(100, 0.33) = %v", shares)
+	}
+	if SharesToUSDC(0, 0.5) != 0 || USDCToShares(10, 0) != 0 {
+		t.Error("Expected 0 for non-positive inputs")
+	}
+}
+
+func TestPeriodicTimeSync(t *testing.T) {
+	// 同步后签名时间戳使用服务器时间
+	t.Run("SignsWithServerTime", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+		client.baseClient.setTimeOffset(time.Hour)
+
+		headers, err := client.baseClient.level2Headers(&types.RequestArgs{Method: "GET", RequestPath: "/data/orders"})
+		if err != nil {
+			t.Fatalf("level2Headers failed: %v", err)
+		}
+		timestamp, err := strconv.ParseInt(headers["POLY_TIMESTAMP"], 10, 64)
+		if err != nil {
+			t.Fatalf("Invalid POLY_TIMESTAMP %q: %v", headers["POLY_TIMESTAMP"], err)
+		}
+		expected := time.Now().Add(time.Hour).Unix()
+		if timestamp < expected-5 || timestamp > expected+5 {
+			t.Errorf("Expected timestamp near %d, got %d", expected, timestamp)
+		}
+	})
+
+	// Close 后后台同步停止，重复调用 Close 不应 panic
+	t.Run("CloseStopsSync", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.stopTimeSync = make(chan struct{})
+
+		var calls int32
+		client.baseClient.startTimeSync(5*time.Millisecond, func() (time.Time, error) {
+			atomic.AddInt32(&calls, 1)
+			client.baseClient.setTimeOffset(time.Second)
+			return time.Now(), nil
+		})
+
+		deadline := time.Now().Add(2 * time.Second)
+		for atomic.LoadInt32(&calls) == 0 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if atomic.LoadInt32(&calls) == 0 {
+			t.Fatal("Expected periodic sync to run")
+		}
+		if client.baseClient.ServerTimeOffset() != time.Second {
+			t.Errorf("Expected offset 1s, got %v", client.baseClient.ServerTimeOffset())
+		}
+
+		client.baseClient.Close()
+		client.baseClient.Close()
+		time.Sleep(20 * time.Millisecond)
+		stopped := atomic.LoadInt32(&calls)
+		time.Sleep(50 * time.Millisecond)
+		if atomic.LoadInt32(&calls) != stopped {
+			t.Error("Expected periodic sync to stop after Close")
+		}
+	})
+}
+
+func TestCreateSignedOrderSignatureTypes(t *testing.T) {
+	orderArgs := types.OrderArgs{
+		TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		Side:    types.OrderSideBUY,
+		Price:   0.5,
+		Size:    10,
+	}
+	const proxyAddress = types.EthAddress("0x1111111111111111111111111111111111111111")
+
+	cases := []struct {
+		signatureType types.SignatureType
+		expected      ordermodel.SignatureType
+		makerIsProxy  bool
+	}{
+		{types.EOASignatureType, ordermodel.EOA, false},
+		{types.ProxySignatureType, ordermodel.POLY_PROXY, true},
+		{types.SafeSignatureType, ordermodel.POLY_GNOSIS_SAFE, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.signatureType.String(), func(t *testing.T) {
+			client := newOfflineOrderClient(t)
+			client.baseClient.signatureType = tc.signatureType
+			client.baseClient.proxyAddress = proxyAddress
+
+			signedOrder, err := client.createSignedOrder(orderArgs, "0.01", false, 0, types.OrderTypeGTC)
+			if err != nil {
+				t.Fatalf("createSignedOrder failed: %v", err)
+			}
+			if signedOrder.SignatureType.Int64() != int64(tc.expected) {
+				t.Errorf("Expected signature type %d, got %s", tc.expected, signedOrder.SignatureType)
+			}
+
+			baseAddress := client.baseClient.web3Client.GetBaseAddress().String()
+			expectedMaker := baseAddress
+			if tc.makerIsProxy {
+				expectedMaker = proxyAddress.String()
+			}
+			if !strings.EqualFold(signedOrder.Maker.Hex(), expectedMaker) {
+				t.Errorf("Expected maker %s, got %s", expectedMaker, signedOrder.Maker.Hex())
+			}
+			if !strings.EqualFold(signedOrder.Signer.Hex(), baseAddress) {
+				t.Errorf("Expected signer %s, got %s", baseAddress, signedOrder.Signer.Hex())
+			}
+		})
+	}
+
+	// 未知签名类型应返回错误，而不是按 EOA 签名
+	t.Run("Invalid", func(t *testing.T) {
+		client := newOfflineOrderClient(t)
+		client.baseClient.signatureType = types.SignatureType(7)
+
+		if _, err := client.createSignedOrder(orderArgs, "0.01", false, 0, types.OrderTypeGTC); err == nil {
+			t.Fatal("Expected error for invalid signature type")
+		}
+	})
+}
+
+func TestSignedBodyMatchesSentBody(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.deriveCreds = &types.ApiCreds{
+		Key:        "offline-key",
+		Secret:     base64.URLEncoding.EncodeToString([]byte("offline-secret-32-bytes-long!!!!")),
+		Passphrase: "offline-passphrase",
+	}
+
+	orderArgsList := []types.OrderArgs{
+		{
+			TokenID: "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+			Side:    types.OrderSideBUY,
+			Price:   0.5,
+			Size:    10,
+		},
+	}
+	orderRequests, _, _ := client.buildOrderRequests(orderArgsList, []types.OrderType{types.OrderTypeGTC}, false)
+	if len(orderRequests) != 1 {
+		t.Fatalf("Expected 1 order request, got %d", len(orderRequests))
+	}
+
+	bodies := map[string]interface{}{
+		"PostOrders":         orderRequests,
+		"CancelOrders":       []string{"0xabc", "0xdef"},
+		"DropNotifications":  map[string][]string{"notification_ids": {"1", "2"}},
+		"UpdateAllowance":    map[string]float64{"amount": 1.5},
+		"CancelMarketOrders": map[string]string{"market": "0x1", "asset_id": "2"},
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			sent, err := internal.MarshalPythonJSON(body)
+			if err != nil {
+				t.Fatalf("Failed to marshal body: %v", err)
+			}
+			if bytes.Contains(sent, []byte(`":"`)) || bytes.Contains(sent, []byte(`","`)) || bytes.Contains(sent, []byte(`},{`)) {
+				t.Errorf("Expected Python-style spacing, got %s", sent)
+			}
+			if again := signing.FormatPythonJSON(string(sent)); again != string(sent) {
+				t.Errorf("Expected formatting to be idempotent, got %s", again)
+			}
+
+			requestBody := types.RequestBody(sent)
+			requestArgs := &types.RequestArgs{
+				Method:      "POST",
+				RequestPath: internal.PostOrders,
+				Body:        &requestBody,
+				Timestamp:   1700000000,
+			}
+			headers, err := client.baseClient.level2Headers(requestArgs)
+			if err != nil {
+				t.Fatalf("Failed to create headers: %v", err)
+			}
+
+			// 直接对发送的字节计算 HMAC，签名必须与请求头一致
+			secret, _ := base64.URLEncoding.DecodeString(client.baseClient.deriveCreds.Secret)
+			mac := hmac.New(sha256.New, secret)
+			mac.Write([]byte("1700000000" + "POST" + internal.PostOrders + string(sent)))
+			expected := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+			if headers[internal.PolySignature] != expected {
+				t.Errorf("Signed bytes differ from sent bytes: header %s, expected %s", headers[internal.PolySignature], expected)
+			}
+		})
+	}
+}
+
+func TestAssertSignedBody(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.deriveCreds.Secret = base64.URLEncoding.EncodeToString([]byte("offline-secret-32-bytes-long!!!!"))
+
+	sent, err := internal.MarshalPythonJSON([]string{"0xabc", "0xdef"})
+	if err != nil {
+		t.Fatalf("Failed to marshal body: %v", err)
+	}
+	requestBody := types.RequestBody(sent)
+	requestArgs := &types.RequestArgs{
+		Method:      "DELETE",
+		RequestPath: internal.CancelOrders,
+		Body:        &requestBody,
+	}
+	headers, err := client.baseClient.level2Headers(requestArgs)
+	if err != nil {
+		t.Fatalf("Failed to create headers: %v", err)
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		client.baseClient.assertSignedBody(requestArgs, headers, sent)
+	})
+
+	// 发送紧凑格式而签名使用 Python 格式时必须 panic
+	t.Run("Mismatch", func(t *testing.T) {
+		compact, _ := json.Marshal([]string{"0xabc", "0xdef"})
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic when transmitted body differs from signed body")
+			}
+		}()
+		client.baseClient.assertSignedBody(requestArgs, headers, compact)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		internal.SetSignatureAssertions(false)
+		defer internal.SetSignatureAssertions(true)
+		client.baseClient.assertSignedBody(requestArgs, headers, []byte("{}"))
+	})
+}
+
+func TestParseClobError(t *testing.T) {
+	cases := []struct {
+		msg  string
+		want error
+	}{
+		{"not enough balance / allowance", types.ErrInsufficientBalance},
+		{"INVALID_ORDER_NOT_ENOUGH_BALANCE", types.ErrInsufficientBalance},
+		{"INVALID_ORDER_MIN_TICK_SIZE", types.ErrInvalidTickSize},
+		{"order is invalid. Size lower than the minimum: 5", types.ErrOrderBelowMinSize},
+		{"INVALID_ORDER_DUPLICATED", types.ErrDuplicateOrder},
+		{"order couldn't be fully filled, FOK orders are fully filled/killed", types.ErrFOKNotFilled},
+		{"invalid signature", types.ErrInvalidSignature},
+		{"the orderbook 123 does not exist", types.ErrOrderBookNotFound},
+		{"order is invalid. Duplicated. Same order has already been placed, can't be placed again", types.ErrDuplicateOrder},
+		{"the market is not yet ready to process new orders", types.ErrMarketNotReady},
+	}
+	for _, tc := range cases {
+		resp := types.OrderPostResponse{ErrorMsg: tc.msg}
+		err := resp.Err()
+		if !errors.Is(err, tc.want) {
+			t.Errorf("Expected %q to map to %v, got %v", tc.msg, tc.want, err)
+		}
+		if !strings.Contains(err.Error(), tc.msg) {
+			t.Errorf("Expected original message to be kept, got %v", err)
+		}
+	}
+
+	if err := (&types.OrderPostResponse{Success: true}).Err(); err != nil {
+		t.Errorf("Expected nil error for successful response, got %v", err)
+	}
+	if err := types.ParseClobError("something else"); err == nil || errors.Is(err, types.ErrInsufficientBalance) || err.Error() != "something else" {
+		t.Errorf("Expected unknown message to be returned as is, got %v", err)
+	}
+
+	// 其他接口的相似信息不映射为下单错误
+	for _, msg := range []string{"order 0x01 does not exist", "could not update allowance", "duplicate request id"} {
+		err := types.ParseClobError(msg)
+		if errors.Is(err, types.ErrOrderBookNotFound) || errors.Is(err, types.ErrInsufficientBalance) || errors.Is(err, types.ErrDuplicateOrder) {
+			t.Errorf("Expected %q not to map to an order error, got %v", msg, err)
+		}
+	}
+}
+
+func TestBalancePrecheck(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.balancePrecheck = true
+
+	// 离线客户端的 USDC 余额为 0：BUY 10 @ 0.5 需要 5 USDC
+	_, err := client.CreateAndPostOrders(
+		[]types.OrderArgs{{TokenID: test.FixtureYesTokenID, Price: 0.5, Size: 10, Side: types.OrderSideBUY}},
+		[]types.OrderType{types.OrderTypeGTC},
+	)
+	if !errors.Is(err, types.ErrInsufficientBalance) {
+		t.Fatalf("Expected ErrInsufficientBalance, got %v", err)
+	}
+	var balanceErr *types.InsufficientBalanceError
+	if !errors.As(err, &balanceErr) || balanceErr.Asset != "USDC" || balanceErr.Required != 5 || balanceErr.Shortfall() != 5 {
+		t.Errorf("Unexpected balance error: %+v", balanceErr)
+	}
+
+	// SELL 订单不需要 USDC，不会被余额检查拦截
+	sell := []types.OrderArgs{{TokenID: test.FixtureYesTokenID, Price: 0.5, Size: 10, Side: types.OrderSideSELL}}
+	sellUSDC, sellTokens, err := client.requiredBalances(sell)
+	if err != nil {
+		t.Fatalf("requiredBalances failed: %v", err)
+	}
+	if err := client.checkCollateral(sellUSDC); err != nil {
+		t.Errorf("Expected SELL orders to pass collateral check, got %v", err)
+	}
+
+	// SELL 订单检查代币余额和 ERC1155 授权
+	web3Client := client.baseClient.web3Client.(*offlineWeb3Client)
+	client.baseClient.negRisk = newNegRiskCache(0)
+	client.baseClient.negRisk.store(test.FixtureYesTokenID, true)
+
+	t.Run("HoldingsUnavailable", func(t *testing.T) {
+		if err := client.checkTokenHoldings(sellTokens); err != nil {
+			t.Errorf("Expected check to be skipped when holdings cannot be read, got %v", err)
+		}
+	})
+
+	t.Run("InsufficientTokens", func(t *testing.T) {
+		web3Client.holdings = &types.TokenHoldings{
+			Balances:                map[string]*big.Int{test.FixtureYesTokenID: big.NewInt(4_000_000)},
+			NegRiskExchangeApproved: true,
+		}
+		err := client.checkTokenHoldings(sellTokens)
+		var balanceErr *types.InsufficientBalanceError
+		if !errors.As(err, &balanceErr) || balanceErr.Asset != test.FixtureYesTokenID || balanceErr.Required != 10 || balanceErr.Shortfall() != 6 {
+			t.Errorf("Expected token shortfall of 6, got %v", err)
+		}
+	})
+
+	t.Run("NotApproved", func(t *testing.T) {
+		// 只授权了 CTFExchange，而该代币属于负风险市场
+		web3Client.holdings = &types.TokenHoldings{
+			Balances:         map[string]*big.Int{test.FixtureYesTokenID: big.NewInt(10_000_000)},
+			ExchangeApproved: true,
+		}
+		_, err := client.CreateAndPostOrders(sell, []types.OrderType{types.OrderTypeGTC})
+		var approvalErr *types.TokenNotApprovedError
+		if !errors.As(err, &approvalErr) || !approvalErr.NegRisk || approvalErr.TokenID != test.FixtureYesTokenID {
+			t.Fatalf("Expected TokenNotApprovedError for neg risk exchange, got %v", err)
+		}
+		if !errors.Is(err, types.ErrTokenNotApproved) || !errors.Is(err, types.ErrInsufficientBalance) {
+			t.Errorf("Expected error to match ErrTokenNotApproved and ErrInsufficientBalance: %v", err)
+		}
+	})
+
+	t.Run("Sufficient", func(t *testing.T) {
+		web3Client.holdings = &types.TokenHoldings{
+			Balances:                map[string]*big.Int{test.FixtureYesTokenID: big.NewInt(10_000_000)},
+			NegRiskExchangeApproved: true,
+		}
+		if err := client.checkTokenHoldings(sellTokens); err != nil {
+			t.Errorf("Expected SELL orders to pass token check, got %v", err)
+		}
+	})
+
+	// BUY 订单检查对应交易所的 USDC 授权：负风险市场需要同时授权 NegRiskCTFExchange 和 NegRiskAdapter
+	buy := []types.OrderArgs{{TokenID: test.FixtureYesTokenID, Price: 0.5, Size: 10, Side: types.OrderSideBUY}}
+	web3Client.usdcBalance = 100
+
+	t.Run("NegRiskAllowance", func(t *testing.T) {
+		web3Client.collateral = &types.CollateralHoldings{
+			ExchangeAllowance:        big.NewInt(100_000_000),
+			NegRiskExchangeAllowance: big.NewInt(100_000_000),
+			NegRiskAdapterAllowance:  big.NewInt(1_000_000),
+		}
+		_, err := client.CreateAndPostOrders(buy, []types.OrderType{types.OrderTypeGTC})
+		var allowanceErr *types.InsufficientAllowanceError
+		if !errors.As(err, &allowanceErr) || !allowanceErr.NegRisk || allowanceErr.Required != 5 || allowanceErr.Available != 1 {
+			t.Fatalf("Expected neg risk InsufficientAllowanceError, got %v", err)
+		}
+		if !errors.Is(err, types.ErrInsufficientBalance) {
+			t.Errorf("Expected error to match ErrInsufficientBalance: %v", err)
+		}
+	})
+
+	t.Run("AllowanceSufficient", func(t *testing.T) {
+		// 只有 CTFExchange 授权不足，不影响负风险市场的订单
+		web3Client.collateral = &types.CollateralHoldings{
+			ExchangeAllowance:        new(big.Int),
+			NegRiskExchangeAllowance: big.NewInt(5_000_000),
+			NegRiskAdapterAllowance:  big.NewInt(5_000_000),
+		}
+		usdc, _, err := client.requiredBalances(buy)
+		if err != nil {
+			t.Fatalf("requiredBalances failed: %v", err)
+		}
+		if err := client.checkCollateral(usdc); err != nil {
+			t.Errorf("Expected BUY orders to pass collateral check, got %v", err)
+		}
+	})
+}
+
+func TestGetPortfolioSummary(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	user := client.baseClient.proxyAddress
+	client.baseClient.web3Client.(*offlineWeb3Client).usdcBalance = 100
+	client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+
+	transport := test.NewFixtureTransport(t,
+		// BUY 剩余 8 @ 0.5 占用 4 USDC；SELL 挂单不占用 USDC
+		test.Fixture{Path: "/data/orders", Body: `{"data":[
+			{"id":"0x01","side":"BUY","original_size":"10","size_matched":"2","price":"0.5","asset_id":"` + test.FixtureYesTokenID + `"},
+			{"id":"0x02","side":"SELL","original_size":"5","size_matched":"0","price":"0.6","asset_id":"` + test.FixtureNoTokenID + `"}
+		],"next_cursor":"LTE="}`},
+		test.Fixture{Path: "/positions", Body: `[
+			{"asset":"` + test.FixtureYesTokenID + `","size":20,"curPrice":0.4,"title":"Market","outcome":"Yes"},
+			{"asset":"resolved-token","size":10,"curPrice":1,"redeemable":true,"outcome":"No"},
+			{"asset":"` + test.FixtureNoTokenID + `","size":0,"curPrice":0.49}
+		]`},
+		test.MidpointsFixture(),
+	)
+	client.baseClient.baseURL = internal.ClobAPIDomain
+	client.baseClient.httpOptions = []sdkhttp.HTTPOption{sdkhttp.WithTransport(transport)}
+	client.baseClient.dataClient = data.NewClient(data.WithTransport(transport))
+	account := &accountClientImpl{baseClient: client.baseClient}
+
+	portfolio, err := account.GetPortfolioSummary(user)
+	if err != nil {
+		t.Fatalf("GetPortfolioSummary failed: %v", err)
+	}
+	// 持仓：20 × 中间价 0.51 + 已结算代币 10 × 1（没有订单簿，使用当前价格），数量为 0 的持仓忽略
+	if len(portfolio.Positions) != 2 || portfolio.Positions[0].MarkPrice != 0.51 || portfolio.Positions[1].MarkPrice != 1 {
+		t.Fatalf("Unexpected positions: %+v", portfolio.Positions)
+	}
+	want := types.Portfolio{USDCBalance: 100, ReservedInOrders: 4, AvailableUSDC: 96, PositionsValue: 20.2, NetWorth: 120.2}
+	if math.Abs(portfolio.PositionsValue-want.PositionsValue) > 1e-9 || math.Abs(portfolio.NetWorth-want.NetWorth) > 1e-9 ||
+		portfolio.USDCBalance != want.USDCBalance || portfolio.ReservedInOrders != want.ReservedInOrders || portfolio.AvailableUSDC != want.AvailableUSDC {
+		t.Errorf("Expected %+v, got %+v", want, portfolio)
+	}
+
+	// 其他地址无法查询挂单，占用金额为 0
+	other := types.EthAddress("0x0000000000000000000000000000000000000001")
+	portfolio, err = account.GetPortfolioSummary(other)
+	if err != nil {
+		t.Fatalf("GetPortfolioSummary for other address failed: %v", err)
+	}
+	if portfolio.ReservedInOrders != 0 || portfolio.AvailableUSDC != 100 {
+		t.Errorf("Expected no reserved collateral for other address, got %+v", portfolio)
+	}
+}
+
+func TestAuthDebugDescription(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	secret := "c2VjcmV0c2VjcmV0c2VjcmV0"
+	client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: secret, Passphrase: "offline-passphrase"}
+	client.baseClient.authDebug = true
+
+	t.Run("Level2", func(t *testing.T) {
+		body := types.RequestBody(`{"orderID": "0x01"}`)
+		requestArgs := &types.RequestArgs{Method: "DELETE", RequestPath: "/order", Body: &body, Timestamp: 1700000000}
+		headers, err := client.baseClient.level2Headers(requestArgs)
+		if err != nil {
+			t.Fatalf("level2Headers failed: %v", err)
+		}
+		description := internal.DescribeLevel2Auth(requestArgs, headers)
+
+		// 输出的消息就是实际签名的字节
+		message := `1700000000DELETE/order{"orderID": "0x01"}`
+		if !strings.Contains(description, strconv.Quote(message)) {
+			t.Errorf("Expected signed message %q in description: %s", message, description)
+		}
+		secretBytes, _ := base64.URLEncoding.DecodeString(secret)
+		mac := hmac.New(sha256.New, secretBytes)
+		mac.Write([]byte(message))
+		if signature := base64.URLEncoding.EncodeToString(mac.Sum(nil)); signature != headers[internal.PolySignature] || !strings.Contains(description, signature) {
+			t.Errorf("Expected description to contain signature of the dumped message: %s", description)
+		}
+		if strings.Contains(description, secret) || strings.Contains(description, "offline-passphrase") {
+			t.Errorf("Expected secret and passphrase to be redacted: %s", description)
+		}
+	})
+
+	t.Run("Level1", func(t *testing.T) {
+		headers, err := client.baseClient.level1Headers()
+		if err != nil {
+			t.Fatalf("level1Headers failed: %v", err)
+		}
+		description := internal.DescribeLevel1Auth(client.baseClient.web3Client.GetSigner(), headers)
+		for _, want := range []string{`"ClobAuthDomain"`, `chainId: 137`, strconv.Quote(headers[internal.PolyTimestamp]), strconv.Quote(signing.MsgToSign), headers[internal.PolySignature]} {
+			if !strings.Contains(description, want) {
+				t.Errorf("Expected %s in description: %s", want, description)
+			}
+		}
+
+		// 输出的摘要就是签名的哈希：用它恢复出的地址与签名者一致
+		_, digestHex, _ := strings.Cut(description, "digest=")
+		digestHex, _, _ = strings.Cut(digestHex, " ")
+		signature := common.FromHex(headers[internal.PolySignature])
+		signature[64] -= 27
+		pub, err := crypto.SigToPub(common.FromHex(digestHex), signature)
+		if err != nil {
+			t.Fatalf("Failed to recover signer: %v", err)
+		}
+		if got := crypto.PubkeyToAddress(*pub).Hex(); !strings.EqualFold(got, string(client.baseClient.address)) {
+			t.Errorf("Expected digest signed by %s, recovered %s", client.baseClient.address, got)
+		}
+	})
+}
+
+func TestGetWinningToken(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	web3Client := client.baseClient.web3Client.(*offlineWeb3Client)
+	transport := test.NewFixtureTransport(t, test.MarketsFixture())
+	client.baseClient.gammaClient = gamma.NewClient(gamma.WithTransport(transport))
+	account := &accountClientImpl{baseClient: client.baseClient}
+	conditionID := types.Keccak256(test.FixtureConditionID)
+
+	resolution := func(numerators ...uint64) *types.ConditionResolution {
+		var denominator uint64
+		for _, n := range numerators {
+			denominator += n
+		}
+		return &types.ConditionResolution{
+			ConditionID:       conditionID,
+			Resolved:          denominator > 0,
+			OutcomeSlotCount:  len(numerators),
+			PayoutNumerators:  numerators,
+			PayoutDenominator: denominator,
+		}
+	}
+
+	t.Run("No", func(t *testing.T) {
+		web3Client.resolution = resolution(0, 1)
+		tokenID, outcome, err := account.GetWinningToken(conditionID)
+		if err != nil {
+			t.Fatalf("GetWinningToken failed: %v", err)
+		}
+		if tokenID != test.FixtureNoTokenID || outcome != "No" {
+			t.Errorf("Expected No token, got %s (%s)", tokenID, outcome)
+		}
+	})
+
+	t.Run("NotResolved", func(t *testing.T) {
+		web3Client.resolution = resolution(0, 0)
+		if _, _, err := account.GetWinningToken(conditionID); !errors.Is(err, types.ErrMarketNotResolved) {
+			t.Errorf("Expected ErrMarketNotResolved, got %v", err)
+		}
+	})
+
+	t.Run("Split", func(t *testing.T) {
+		web3Client.resolution = resolution(1, 1)
+		if _, _, err := account.GetWinningToken(conditionID); !errors.Is(err, types.ErrSplitResolution) {
+			t.Errorf("Expected ErrSplitResolution, got %v", err)
+		}
+	})
+
+	t.Run("OutcomeCountMismatch", func(t *testing.T) {
+		web3Client.resolution = resolution(0, 0, 1)
+		if _, _, err := account.GetWinningToken(conditionID); err == nil {
+			t.Error("Expected error when outcome slot count differs from market tokens")
+		}
+	})
+}
+
+func TestRFQ(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+	client.baseClient.keyScope = &keyScopeCache{resolved: true, scope: types.APIKeyScopeTrade}
+	future := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	transport := test.NewFixtureTransport(t,
+		test.Fixture{Method: "POST", Path: "/rfq/request", Body: `{"request_id":"req-1","status":"open"}`},
+		test.Fixture{Path: "/rfq/quotes", Body: `[
+			{"quote_id":"q-expired","request_id":"req-1","price":0.40,"size":1000,"expires_at":"` + past + `"},
+			{"quote_id":"q-high","request_id":"req-1","price":0.55,"size":1000,"expires_at":"` + future + `"},
+			{"quote_id":"q-low","request_id":"req-1","price":0.52,"size":1000,"expires_at":"` + future + `"}
+		]`},
+		test.Fixture{Method: "POST", Path: "/rfq/accept", Body: `{"quote_id":"q-low","order_id":"0xabc","status":"matched"}`},
+	)
+	client.baseClient.rfqClient = (&clientOptions{ConnectionOptions: internal.ConnectionOptions{Transport: transport}}).buildRFQClient(client.baseClient.level2Headers)
+	rfq := &rfqClientImpl{baseClient: client.baseClient}
+
+	quote, err := rfq.RequestQuote(context.Background(), test.FixtureYesTokenID, types.OrderSideBUY, 1000)
+	if err != nil {
+		t.Fatalf("RequestQuote failed: %v", err)
+	}
+	// 过期报价忽略，BUY 取价格最低的报价
+	if quote.QuoteID != "q-low" {
+		t.Errorf("Expected best quote q-low, got %+v", quote)
+	}
+
+	resp, err := rfq.AcceptQuote(quote.QuoteID)
+	if err != nil {
+		t.Fatalf("AcceptQuote failed: %v", err)
+	}
+	if resp.OrderID != "0xabc" || !resp.Success || resp.Status != "matched" {
+		t.Errorf("Unexpected accept response: %+v", resp)
+	}
+
+	requests := transport.Requests()
+	if len(requests) != 3 || !strings.Contains(requests[0].RequestBody, `"side": "BUY"`) || !strings.Contains(requests[1].URL, "request_id=req-1") {
+		t.Errorf("Unexpected requests: %+v", requests)
+	}
+
+	if _, err := rfq.RequestQuote(context.Background(), test.FixtureYesTokenID, types.OrderSideBUY, 0); err == nil {
+		t.Error("Expected error for zero size")
+	}
+}
+
+func TestGetOrdersCount(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Secret: "c2VjcmV0c2VjcmV0c2VjcmV0", Passphrase: "offline-passphrase"}
+	market := types.Keccak256(test.FixtureConditionID)
+
+	transport := test.NewFixtureTransport(t,
+		test.Fixture{Path: "/data/orders?next_cursor=MA%3D%3D", Body: `{"data":[{"id":"0x01"},{"id":"0x02"}],"next_cursor":"Mg=="}`},
+		test.Fixture{Path: "/data/orders?next_cursor=Mg%3D%3D", Body: `{"data":[{"id":"0x03"}],"next_cursor":"LTE="}`},
+		test.Fixture{Path: "/data/orders?market=" + test.FixtureConditionID + "&next_cursor=MA%3D%3D", Body: `{"data":[{"id":"0x01"}],"next_cursor":"LTE="}`},
+	)
+	client.baseClient.baseURL = internal.ClobAPIDomain
+	client.baseClient.httpOptions = []sdkhttp.HTTPOption{sdkhttp.WithTransport(transport)}
+
+	count, err := client.GetOrdersCount(nil)
+	if err != nil {
+		t.Fatalf("GetOrdersCount failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 orders across two pages, got %d", count)
+	}
+
+	count, err = client.GetOrdersCount(&market)
+	if err != nil {
+		t.Fatalf("GetOrdersCount for market failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 order in market, got %d", count)
+	}
+}
+
+func TestGetOrdersCountIncompleteCredentials(t *testing.T) {
+	client := newOfflineOrderClient(t)
+	client.baseClient.deriveCreds = &types.ApiCreds{Key: "offline-key", Passphrase: "offline-passphrase"}
+
+	_, err := client.GetOrdersCount(nil)
+	if err == nil || !strings.Contains(err.Error(), "API credentials incomplete") {
+		t.Errorf("Expected incomplete credentials error, got %v", err)
+	}
+}
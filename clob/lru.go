@@ -0,0 +1,83 @@
+package clob
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// lruCache 并发安全、容量有限的 LRU 缓存，按 key 索引
+// 超过容量时淘汰最久未使用的条目；容量 <= 0 时不限制条目数量
+type lruCache[V any] struct {
+	mu        sync.Mutex
+	capacity  int
+	items     map[string]*list.Element
+	order     *list.List // 头部为最近使用的条目
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// lruEntry LRU 缓存中的一个条目
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+// newLRUCache 创建容量为 capacity 的 LRU 缓存
+func newLRUCache[V any](capacity int) *lruCache[V] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &lruCache[V]{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get 读取缓存的值并将条目标记为最近使用
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.order.MoveToFront(elem)
+		c.hits++
+		return elem.Value.(*lruEntry[V]).value, true
+	}
+	c.misses++
+	var zero V
+	return zero, false
+}
+
+// add 写入或更新条目，超过容量时淘汰最久未使用的条目
+func (c *lruCache[V]) add(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruEntry[V]).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value})
+	for c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[V]).key)
+		c.evictions++
+	}
+}
+
+// stats 返回命中、未命中、淘汰次数和当前条目数
+func (c *lruCache[V]) stats() types.CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return types.CacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Size:      c.order.Len(),
+		Capacity:  c.capacity,
+	}
+}
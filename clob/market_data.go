@@ -1,9 +1,12 @@
 package clob
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/polymas/go-polymarket-sdk/http"
@@ -13,7 +16,17 @@ import (
 
 // GetTickSize 获取代币的tick大小
 func (c *marketDataClientImpl) GetTickSize(tokenID string) (types.TickSize, error) {
-	if tickSize, ok := c.baseClient.tickSizes[tokenID]; ok {
+	return getTickSize(c.baseClient.tickSizes, c.baseClient.baseURL, tokenID, c.requestOptions())
+}
+
+// GetTickSize 获取代币的tick大小（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetTickSize(tokenID string) (types.TickSize, error) {
+	return getTickSize(c.readonlyBaseClient.tickSizes, c.readonlyBaseClient.baseURL, tokenID, c.requestOptions())
+}
+
+// getTickSize 查询代币的tick大小并写入 cache，已缓存时直接返回
+func getTickSize(cache *lruCache[types.TickSize], baseURL, tokenID string, options []http.HTTPOption) (types.TickSize, error) {
+	if tickSize, ok := cache.get(tokenID); ok {
 		return tickSize, nil
 	}
 
@@ -21,7 +34,8 @@ func (c *marketDataClientImpl) GetTickSize(tokenID string) (types.TickSize, erro
 
 	// API may return minimum_tick_size as number or string, so we need to handle both
 	var rawResponse map[string]interface{}
-	resp, err := http.Get[map[string]interface{}](c.baseClient.baseURL, internal.GetTickSize, params)
+	// 数字保留为 json.Number，原样得到响应中的小数位
+	resp, err := http.Get[map[string]interface{}](baseURL, internal.GetTickSize, params, append(append([]http.HTTPOption{}, options...), http.WithUseNumber())...)
 	if err != nil {
 		return "", fmt.Errorf("failed to get tick size: %w", err)
 	}
@@ -30,26 +44,18 @@ func (c *marketDataClientImpl) GetTickSize(tokenID string) (types.TickSize, erro
 	// Extract minimum_tick_size and convert to string
 	var tickSizeStr string
 	if val, ok := rawResponse["minimum_tick_size"]; ok {
-		switch v := val.(type) {
-		case string:
-			tickSizeStr = v
-		case float64:
-			// Convert number to string, preserving precision
-			tickSizeStr = strconv.FormatFloat(v, 'f', -1, 64)
-		case int:
-			tickSizeStr = strconv.Itoa(v)
-		case int64:
-			tickSizeStr = strconv.FormatInt(v, 10)
-		default:
+		if s, ok := types.NumberString(val); ok {
+			tickSizeStr = s
+		} else {
 			// Try to convert to string
-			tickSizeStr = fmt.Sprintf("%v", v)
+			tickSizeStr = fmt.Sprintf("%v", val)
 		}
 	} else {
 		return "", fmt.Errorf("minimum_tick_size not found in response")
 	}
 
 	tickSize := types.TickSize(tickSizeStr)
-	c.baseClient.tickSizes[tokenID] = tickSize
+	cache.add(tokenID, tickSize)
 	return tickSize, nil
 }
 
@@ -69,7 +75,20 @@ func (c *marketDataClientImpl) ResolveTickSize(tokenID string, userTickSize *typ
 	if err != nil {
 		return "", fmt.Errorf("failed to get minimum tick size: %w", err)
 	}
+	return resolveTickSize(minTickSize, userTickSize)
+}
 
+// ResolveTickSize 解析并验证 tick size（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) ResolveTickSize(tokenID string, userTickSize *types.TickSize) (types.TickSize, error) {
+	minTickSize, err := c.GetTickSize(tokenID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get minimum tick size: %w", err)
+	}
+	return resolveTickSize(minTickSize, userTickSize)
+}
+
+// resolveTickSize 用户提供的 tick size 不小于市场最小值时使用它，否则返回错误；未提供时使用市场最小值
+func resolveTickSize(minTickSize types.TickSize, userTickSize *types.TickSize) (types.TickSize, error) {
 	// 如果用户提供了 tick size，验证它是否有效
 	if userTickSize != nil {
 		// 检查用户提供的 tick size 是否小于最小 tick size
@@ -86,37 +105,504 @@ func (c *marketDataClientImpl) ResolveTickSize(tokenID string, userTickSize *typ
 
 // GetNegRisk 获取代币的负风险状态
 func (c *marketDataClientImpl) GetNegRisk(tokenID string) (bool, error) {
-	if negRisk, ok := c.baseClient.negRisk[tokenID]; ok {
+	return c.baseClient.negRisk.get(c.baseClient.baseURL, tokenID, c.requestOptions())
+}
+
+// GetNegRisk 获取代币的负风险状态（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetNegRisk(tokenID string) (bool, error) {
+	return c.readonlyBaseClient.negRisk.get(c.readonlyBaseClient.baseURL, tokenID, c.requestOptions())
+}
+
+// GetNegRisks 批量获取多个代币的负风险状态
+// 已缓存的代币直接返回，其余代币并发请求（并发数受 internal.NegRiskMaxConcurrency 限制）并写入缓存
+// 适合在策略启动时预取，避免下单时逐个串行查询
+// 任一代币查询失败时返回错误，已成功查询的结果仍会写入缓存
+func (c *marketDataClientImpl) GetNegRisks(tokenIDs []string) (map[string]bool, error) {
+	return c.baseClient.negRisk.getMany(c.baseClient.baseURL, tokenIDs, c.requestOptions())
+}
+
+// GetNegRisks 批量获取多个代币的负风险状态（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetNegRisks(tokenIDs []string) (map[string]bool, error) {
+	return c.readonlyBaseClient.negRisk.getMany(c.readonlyBaseClient.baseURL, tokenIDs, c.requestOptions())
+}
+
+// negRiskCache 代币负风险状态的缓存
+// 负风险状态在市场创建后不会改变，因此不设置过期时间，超过容量时淘汰最久未使用的代币
+type negRiskCache struct {
+	values *lruCache[bool]
+}
+
+// newNegRiskCache 创建最多保存 capacity 个代币的负风险状态缓存，capacity <= 0 时不限制
+func newNegRiskCache(capacity int) *negRiskCache {
+	return &negRiskCache{values: newLRUCache[bool](capacity)}
+}
+
+// lookup 读取缓存的负风险状态
+func (nc *negRiskCache) lookup(tokenID string) (bool, bool) {
+	return nc.values.get(tokenID)
+}
+
+// store 写入负风险状态
+func (nc *negRiskCache) store(tokenID string, negRisk bool) {
+	nc.values.add(tokenID, negRisk)
+}
+
+// get 返回代币的负风险状态，未缓存时请求 API
+func (nc *negRiskCache) get(baseURL string, tokenID string, options []http.HTTPOption) (bool, error) {
+	if negRisk, ok := nc.lookup(tokenID); ok {
 		return negRisk, nil
 	}
 
-	params := map[string]string{"token_id": tokenID}
-	var result struct {
-		NegRisk bool `json:"neg_risk"`
+	negRisk, err := fetchNegRisk(baseURL, tokenID, options)
+	if err != nil {
+		return false, err
+	}
+
+	nc.store(tokenID, negRisk)
+	return negRisk, nil
+}
+
+// getMany 批量返回代币的负风险状态，未缓存的代币并发请求
+func (nc *negRiskCache) getMany(baseURL string, tokenIDs []string, options []http.HTTPOption) (map[string]bool, error) {
+	result := make(map[string]bool, len(tokenIDs))
+	missing := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if _, ok := result[tokenID]; ok {
+			continue
+		}
+		if negRisk, ok := nc.lookup(tokenID); ok {
+			result[tokenID] = negRisk
+			continue
+		}
+		result[tokenID] = false
+		missing = append(missing, tokenID)
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, internal.NegRiskMaxConcurrency)
+
+	for _, tokenID := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(tokenID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			negRisk, err := fetchNegRisk(baseURL, tokenID, options)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("token %s: %w", tokenID, err)
+				}
+				delete(result, tokenID)
+				return
+			}
+			result[tokenID] = negRisk
+			nc.store(tokenID, negRisk)
+		}(tokenID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
 	}
+	return result, nil
+}
+
+// fetchNegRisk 从 API 获取代币的负风险状态（不使用缓存）
+func fetchNegRisk(baseURL string, tokenID string, options []http.HTTPOption) (bool, error) {
+	params := map[string]string{"token_id": tokenID}
 
 	resp, err := http.Get[struct {
 		NegRisk bool `json:"neg_risk"`
-	}](c.baseClient.baseURL, internal.GetNegRisk, params)
+	}](baseURL, internal.GetNegRisk, params, options...)
 	if err != nil {
 		return false, fmt.Errorf("failed to get neg risk: %w", err)
 	}
-	result = *resp
 
-	c.baseClient.negRisk[tokenID] = result.NegRisk
-	return result.NegRisk, nil
+	return resp.NegRisk, nil
+}
+
+// GetTokenMeta 获取代币的下单元数据：tick size、负风险、手续费率、最小下单数量和是否接受订单
+// CLOB 没有按 token ID 查询市场的接口，未命中缓存时需要两次请求：先从订单簿取得代币所属市场，再从 /markets/{condition_id} 取得其余信息；
+// 结果按 internal.TokenMetaCacheTTL 缓存，同一市场的其他代币直接命中缓存，负风险和手续费率同时写入对应的缓存
+func (c *marketDataClientImpl) GetTokenMeta(tokenID string) (*types.TokenMeta, error) {
+	return c.baseClient.tokenMeta.get(c.baseClient.baseURL, tokenID, c.requestOptions(), c.baseClient.negRisk, c.baseClient.feeRates)
+}
+
+// GetTokenMeta 获取代币的下单元数据（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetTokenMeta(tokenID string) (*types.TokenMeta, error) {
+	return c.readonlyBaseClient.tokenMeta.get(c.readonlyBaseClient.baseURL, tokenID, c.requestOptions(), c.readonlyBaseClient.negRisk, c.readonlyBaseClient.feeRates)
+}
+
+// tokenMetaCache 代币元数据的缓存，按 token ID 索引，超过容量时淘汰最久未使用的代币
+type tokenMetaCache struct {
+	entries *lruCache[tokenMetaEntry]
+	now     func() time.Time
+}
+
+// tokenMetaEntry 缓存的代币元数据及获取时间
+type tokenMetaEntry struct {
+	meta      types.TokenMeta
+	fetchedAt time.Time
+}
+
+// newTokenMetaCache 创建最多保存 capacity 个代币的元数据缓存，capacity <= 0 时不限制
+func newTokenMetaCache(capacity int) *tokenMetaCache {
+	return &tokenMetaCache{entries: newLRUCache[tokenMetaEntry](capacity), now: time.Now}
+}
+
+// get 返回代币的元数据，未缓存或已过期时请求 API，并缓存同一市场所有代币的元数据
+func (tc *tokenMetaCache) get(baseURL string, tokenID string, options []http.HTTPOption, negRisk *negRiskCache, feeRates *feeRateCache) (*types.TokenMeta, error) {
+	if tokenID == "" {
+		return nil, fmt.Errorf("tokenID is required")
+	}
+
+	entry, ok := tc.entries.get(tokenID)
+	if ok && tc.now().Sub(entry.fetchedAt) < internal.TokenMetaCacheTTL {
+		meta := entry.meta
+		return &meta, nil
+	}
+
+	metas, err := fetchTokenMeta(baseURL, tokenID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchedAt := tc.now()
+	for _, meta := range metas {
+		tc.entries.add(meta.TokenID, tokenMetaEntry{meta: meta, fetchedAt: fetchedAt})
+	}
+
+	var result *types.TokenMeta
+	for i := range metas {
+		negRisk.store(metas[i].TokenID, metas[i].NegRisk)
+		feeRates.store(metas[i].TokenID, metas[i].FeeRateBps)
+		if metas[i].TokenID == tokenID {
+			result = &metas[i]
+		}
+	}
+	if result == nil {
+		return nil, fmt.Errorf("token %s not found in market %s", tokenID, metas[0].ConditionID)
+	}
+	return result, nil
+}
+
+// cached 返回已缓存的代币元数据（不检查是否过期），未缓存时返回 false
+func (tc *tokenMetaCache) cached(tokenID string) (types.TokenMeta, bool) {
+	entry, ok := tc.entries.get(tokenID)
+	return entry.meta, ok
+}
+
+// fetchTokenMeta 从 API 获取代币所属市场中所有代币的元数据（订单簿和市场接口两次请求）
+func fetchTokenMeta(baseURL string, tokenID string, options []http.HTTPOption) ([]types.TokenMeta, error) {
+	// 订单簿响应中的 market 即代币所属市场的 condition ID
+	book, err := http.Get[types.OrderBookSummaryResponse](baseURL, internal.GetOrderBook, map[string]string{"token_id": tokenID}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market of token %s: %w", tokenID, err)
+	}
+	if book.Market == "" {
+		return nil, fmt.Errorf("market not found for token %s", tokenID)
+	}
+
+	market, err := http.Get[types.ClobMarket](baseURL, internal.GetMarket+book.Market, nil, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", book.Market, err)
+	}
+	if len(market.TokenIDs) == 0 {
+		return nil, fmt.Errorf("market %s has no tokens", book.Market)
+	}
+	return tokenMetasFromMarket(market), nil
+}
+
+// tokenMetasFromMarket 将 CLOB 市场信息转换为其中每个代币的元数据
+func tokenMetasFromMarket(market *types.ClobMarket) []types.TokenMeta {
+	metas := make([]types.TokenMeta, 0, len(market.TokenIDs))
+	for _, token := range market.TokenIDs {
+		metas = append(metas, types.TokenMeta{
+			TokenID:         token.TokenID,
+			ConditionID:     market.ConditionID,
+			Outcome:         token.Outcome,
+			TickSize:        types.TickSize(strconv.FormatFloat(market.MinimumTickSize, 'f', -1, 64)),
+			NegRisk:         market.NegRisk,
+			FeeRateBps:      market.TakerBaseFee,
+			MinOrderSize:    market.MinimumOrderSize,
+			AcceptingOrders: market.AcceptingOrders,
+			EnableOrderBook: market.EnableOrderBook,
+		})
+	}
+	return metas
+}
+
+// GetOrderBookOptions GetOrderBook 的可选参数
+type GetOrderBookOptions struct {
+	Depth int // 只保留最优的 Depth 档，0 表示返回完整订单簿
+}
+
+// GetOrderBookOption 函数选项类型
+type GetOrderBookOption func(*GetOrderBookOptions)
+
+// WithDepth 只返回最优的 levels 档买卖盘
+// CLOB API 不支持按深度请求，仍会下载完整订单簿，截断在客户端完成（节省的是后续处理而非网络传输）
+func WithDepth(levels int) GetOrderBookOption {
+	return func(opts *GetOrderBookOptions) {
+		opts.Depth = levels
+	}
 }
 
 // GetOrderBook 获取代币的订单簿
-func (c *marketDataClientImpl) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+// 可通过 WithDepth 只保留最优的若干档。设置了 WithOrderBookSource 且来源中有该代币的订单簿时直接返回本地数据，
+// 否则通过 REST 查询；返回值的 Source 标明数据来源
+func (c *marketDataClientImpl) GetOrderBook(tokenID string, options ...GetOrderBookOption) (*types.OrderBookSummary, error) {
+	if book, ok := localOrderBook(c.baseClient.bookSource, tokenID); ok {
+		return applyOrderBookOptions(book, options), nil
+	}
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.OrderBookSummary](c.baseClient.baseURL, internal.GetOrderBook, params)
+	book, err := http.Get[types.OrderBookSummary](c.baseClient.baseURL, internal.GetOrderBook, params, c.requestOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	book.Source = types.BookSourceREST
+	return applyOrderBookOptions(book, options), nil
 }
 
 // GetOrderBook 获取代币的订单簿（只读客户端实现）
-func (c *readonlyMarketDataClientImpl) GetOrderBook(tokenID string) (*types.OrderBookSummary, error) {
+func (c *readonlyMarketDataClientImpl) GetOrderBook(tokenID string, options ...GetOrderBookOption) (*types.OrderBookSummary, error) {
+	if book, ok := localOrderBook(c.readonlyBaseClient.bookSource, tokenID); ok {
+		return applyOrderBookOptions(book, options), nil
+	}
+	params := map[string]string{"token_id": tokenID}
+	book, err := http.Get[types.OrderBookSummary](c.readonlyBaseClient.baseURL, internal.GetOrderBook, params, c.requestOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	book.Source = types.BookSourceREST
+	return applyOrderBookOptions(book, options), nil
+}
+
+// localOrderBook 从本地订单簿来源读取代币的订单簿，未设置来源或没有本地数据时返回 false
+func localOrderBook(src OrderBookSource, tokenID string) (*types.OrderBookSummary, bool) {
+	if src == nil {
+		return nil, false
+	}
+	book, ok := src.OrderBook(tokenID)
+	if !ok || book == nil {
+		return nil, false
+	}
+	book.TokenID = tokenID
+	book.Source = types.BookSourceWSS
+	return book, true
+}
+
+// PollOrderBookChanges 按 interval 轮询订单簿，与上一次快照比较后只发送变化的档位
+// 用于无法使用 WSS 的环境：第一次发送完整订单簿，之后没有变化时不发送；
+// 查询失败只记录日志并继续轮询。ctx 结束时关闭通道
+func (c *marketDataClientImpl) PollOrderBookChanges(ctx context.Context, tokenID string, interval time.Duration) (<-chan types.BookDiff, error) {
+	return pollOrderBookChanges(ctx, tokenID, interval, func(tokenID string) (*types.OrderBookSummary, error) {
+		return c.GetOrderBook(tokenID)
+	})
+}
+
+// PollOrderBookChanges 按 interval 轮询订单簿并只发送变化的档位（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) PollOrderBookChanges(ctx context.Context, tokenID string, interval time.Duration) (<-chan types.BookDiff, error) {
+	return pollOrderBookChanges(ctx, tokenID, interval, func(tokenID string) (*types.OrderBookSummary, error) {
+		return c.GetOrderBook(tokenID)
+	})
+}
+
+// pollOrderBookChanges PollOrderBookChanges 的轮询实现，订单簿查询通过参数传入便于测试
+func pollOrderBookChanges(
+	ctx context.Context,
+	tokenID string,
+	interval time.Duration,
+	fetch func(tokenID string) (*types.OrderBookSummary, error),
+) (<-chan types.BookDiff, error) {
+	if tokenID == "" {
+		return nil, fmt.Errorf("token ID is required")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive, got %s", interval)
+	}
+
+	ch := make(chan types.BookDiff, 1)
+	go func() {
+		defer close(ch)
+
+		var prev *types.OrderBookSummary
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			book, err := fetch(tokenID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				internal.LogWarn("轮询订单簿 %s 失败: %v", tokenID, err)
+			} else {
+				diff := types.DiffOrderBooks(prev, book)
+				diff.TokenID = tokenID
+				// 首个快照即使为空也发送，便于调用方确认轮询已开始
+				if prev == nil || !diff.IsEmpty() {
+					select {
+					case ch <- diff:
+					case <-ctx.Done():
+						return
+					}
+				}
+				prev = book
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// GetOrderBookDepth 获取代币订单簿中最优的 levels 档
+// 等价于 GetOrderBook(tokenID, WithDepth(levels))，截断在客户端完成
+func (c *marketDataClientImpl) GetOrderBookDepth(tokenID string, levels int) (*types.OrderBookSummary, error) {
+	return c.GetOrderBook(tokenID, WithDepth(levels))
+}
+
+// GetOrderBookDepth 获取代币订单簿中最优的 levels 档（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetOrderBookDepth(tokenID string, levels int) (*types.OrderBookSummary, error) {
+	return c.GetOrderBook(tokenID, WithDepth(levels))
+}
+
+// applyOrderBookOptions 按选项处理订单簿，未设置深度时原样返回
+func applyOrderBookOptions(book *types.OrderBookSummary, options []GetOrderBookOption) *types.OrderBookSummary {
+	opts := &GetOrderBookOptions{}
+	for _, opt := range options {
+		if opt != nil {
+			opt(opts)
+		}
+	}
+	if opts.Depth <= 0 || book == nil {
+		return book
+	}
+	return book.TopLevels(opts.Depth)
+}
+
+// GetOrderBookRaw 获取代币的订单簿，同时返回原始 JSON 响应
+// 可用于读取 OrderBookSummary 尚未建模的字段，无需重复请求
+func (c *marketDataClientImpl) GetOrderBookRaw(tokenID string) (*types.OrderBookSummary, []byte, error) {
+	var raw []byte
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.OrderBookSummary](c.readonlyBaseClient.baseURL, internal.GetOrderBook, params)
+	book, err := http.Get[types.OrderBookSummary](c.baseClient.baseURL, internal.GetOrderBook, params, c.requestOptions(http.WithRawResponse(&raw))...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return book, raw, nil
+}
+
+// GetOrderBookRaw 获取代币的订单簿，同时返回原始 JSON 响应（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetOrderBookRaw(tokenID string) (*types.OrderBookSummary, []byte, error) {
+	var raw []byte
+	params := map[string]string{"token_id": tokenID}
+	book, err := http.Get[types.OrderBookSummary](c.readonlyBaseClient.baseURL, internal.GetOrderBook, params, c.requestOptions(http.WithRawResponse(&raw))...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return book, raw, nil
+}
+
+// GetQuote 获取代币的完整报价（最优买卖价、中间价、价差和最后成交价）
+// 只请求一次订单簿，代替分别调用 GetPrice、GetMidpoint、GetSpread 和 GetLastTradePrice
+func (c *marketDataClientImpl) GetQuote(tokenID string) (*types.Quote, error) {
+	book, err := c.GetOrderBook(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return bookQuote(tokenID, book), nil
+}
+
+// GetQuote 获取代币的完整报价（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetQuote(tokenID string) (*types.Quote, error) {
+	book, err := c.GetOrderBook(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return bookQuote(tokenID, book), nil
+}
+
+// GetQuotes 批量获取多个代币的完整报价，结果与 tokenIDs 顺序一致
+// 通过一次 POST /books 请求获取所有订单簿（最多 500 个代币），响应中缺少的代币不包含在结果中。
+// 设置了 WithOrderBookSource 时有本地订单簿的代币不再请求，全部命中时不发送请求
+func (c *marketDataClientImpl) GetQuotes(tokenIDs []string) ([]types.Quote, error) {
+	return getQuotes(c.baseClient.bookSource, tokenIDs, c.GetMultipleOrderBooks)
+}
+
+// GetQuotes 批量获取多个代币的完整报价（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetQuotes(tokenIDs []string) ([]types.Quote, error) {
+	return getQuotes(c.readonlyBaseClient.bookSource, tokenIDs, c.GetMultipleOrderBooks)
+}
+
+// getQuotes GetQuotes 的实现：先读取本地订单簿，其余代币通过 fetch 批量查询
+func getQuotes(
+	src OrderBookSource,
+	tokenIDs []string,
+	fetch func(requests []types.BookParams) ([]types.OrderBookSummaryResponse, error),
+) ([]types.Quote, error) {
+	byToken := make(map[string]*types.OrderBookSummary, len(tokenIDs))
+	var missing []string
+	for _, tokenID := range tokenIDs {
+		if book, ok := localOrderBook(src, tokenID); ok {
+			byToken[tokenID] = book
+		} else {
+			missing = append(missing, tokenID)
+		}
+	}
+	if len(missing) > 0 {
+		books, err := fetch(quoteBookParams(missing))
+		if err != nil {
+			return nil, err
+		}
+		for i := range books {
+			book := books[i].Summary()
+			book.Source = types.BookSourceREST
+			byToken[books[i].AssetID] = book
+		}
+	}
+
+	quotes := make([]types.Quote, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if book, ok := byToken[tokenID]; ok {
+			quotes = append(quotes, *bookQuote(tokenID, book))
+		}
+	}
+	return quotes, nil
+}
+
+// bookQuote 根据订单簿计算报价，单个订单簿响应中不包含 token_id，使用请求的 tokenID
+func bookQuote(tokenID string, book *types.OrderBookSummary) *types.Quote {
+	quote := book.Quote()
+	quote.TokenID = tokenID
+	return &quote
+}
+
+// quoteBookParams 构建 GetQuotes 的订单簿请求
+func quoteBookParams(tokenIDs []string) []types.BookParams {
+	params := make([]types.BookParams, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		params[i] = types.BookParams{TokenID: tokenID}
+	}
+	return params
 }
 
 // GetMultipleOrderBooks 批量获取多个订单簿摘要
@@ -132,6 +618,10 @@ func (c *marketDataClientImpl) GetMultipleOrderBooks(requests []types.BookParams
 	if len(requests) > 500 {
 		return nil, fmt.Errorf("请求数组长度不能超过500，当前: %d", len(requests))
 	}
+	requests, err := normalizeBookParams(requests)
+	if err != nil {
+		return nil, err
+	}
 
 	// 构建请求体（只包含必需的字段）
 	requestBody := make([]map[string]string, len(requests))
@@ -146,7 +636,7 @@ func (c *marketDataClientImpl) GetMultipleOrderBooks(requests []types.BookParams
 	}
 
 	// 发送 POST 请求
-	result, err := http.Post[[]types.OrderBookSummaryResponse](c.baseClient.baseURL, internal.GetOrderBooks, requestBody)
+	result, err := http.Post[[]types.OrderBookSummaryResponse](c.baseClient.baseURL, internal.GetOrderBooks, requestBody, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取订单簿失败: %w", err)
 	}
@@ -163,6 +653,10 @@ func (c *readonlyMarketDataClientImpl) GetMultipleOrderBooks(requests []types.Bo
 	if len(requests) > 500 {
 		return nil, fmt.Errorf("请求数组长度不能超过500，当前: %d", len(requests))
 	}
+	requests, err := normalizeBookParams(requests)
+	if err != nil {
+		return nil, err
+	}
 
 	// 构建请求体（只包含必需的字段）
 	requestBody := make([]map[string]string, len(requests))
@@ -177,7 +671,7 @@ func (c *readonlyMarketDataClientImpl) GetMultipleOrderBooks(requests []types.Bo
 	}
 
 	// 发送 POST 请求
-	result, err := http.Post[[]types.OrderBookSummaryResponse](c.readonlyBaseClient.baseURL, internal.GetOrderBooks, requestBody)
+	result, err := http.Post[[]types.OrderBookSummaryResponse](c.readonlyBaseClient.baseURL, internal.GetOrderBooks, requestBody, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取订单簿失败: %w", err)
 	}
@@ -185,10 +679,74 @@ func (c *readonlyMarketDataClientImpl) GetMultipleOrderBooks(requests []types.Bo
 	return *result, nil
 }
 
+// normalizeBookParams 校验并规范化请求中的 Side，无效方向返回错误而不是被服务端静默忽略
+func normalizeBookParams(requests []types.BookParams) ([]types.BookParams, error) {
+	normalized := make([]types.BookParams, len(requests))
+	for i, req := range requests {
+		p, err := req.Normalize()
+		if err != nil {
+			return nil, fmt.Errorf("请求 %d 无效: %w", i+1, err)
+		}
+		normalized[i] = p
+	}
+	return normalized, nil
+}
+
+// GetMidpointOptions GetMidpoint 的可选参数
+type GetMidpointOptions struct {
+	BookFallback bool // 中间价接口无结果时用订单簿最优买卖价计算
+}
+
+// GetMidpointOption 函数选项类型
+type GetMidpointOption func(*GetMidpointOptions)
+
+// WithMidpointBookFallback 中间价接口返回空结果时，改用订单簿的 (最优买价+最优卖价)/2
+// 回退会额外请求一次订单簿，对延迟敏感的调用方不要开启
+func WithMidpointBookFallback() GetMidpointOption {
+	return func(opts *GetMidpointOptions) {
+		opts.BookFallback = true
+	}
+}
+
 // GetMidpoint 获取单个代币的中间价
-func (c *marketDataClientImpl) GetMidpoint(tokenID string) (*types.Midpoint, error) {
+// 可通过 WithMidpointBookFallback 在接口无结果时回退到订单簿计算
+func (c *marketDataClientImpl) GetMidpoint(tokenID string, options ...GetMidpointOption) (*types.Midpoint, error) {
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.Midpoint](c.baseClient.baseURL, internal.MidPoint, params)
+	midpoint, err := http.Get[types.Midpoint](c.baseClient.baseURL, internal.MidPoint, params, c.requestOptions()...)
+	return midpointWithFallback(tokenID, midpoint, err, options, func() (*types.OrderBookSummary, error) {
+		return c.GetOrderBook(tokenID)
+	})
+}
+
+// midpointWithFallback 处理中间价接口结果，开启回退且接口无结果时从订单簿计算中间价
+// 订单簿任一侧为空时返回错误
+func midpointWithFallback(
+	tokenID string,
+	midpoint *types.Midpoint,
+	err error,
+	options []GetMidpointOption,
+	getBook func() (*types.OrderBookSummary, error),
+) (*types.Midpoint, error) {
+	opts := &GetMidpointOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+	if !opts.BookFallback || (err == nil && midpoint != nil && midpoint.Value > 0) {
+		return midpoint, err
+	}
+
+	book, bookErr := getBook()
+	if bookErr != nil {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get midpoint: %w (order book fallback failed: %v)", err, bookErr)
+		}
+		return nil, fmt.Errorf("failed to get order book for midpoint fallback: %w", bookErr)
+	}
+	value, ok := book.Midpoint()
+	if !ok {
+		return nil, fmt.Errorf("no midpoint for token %s: order book has %d bids and %d asks", tokenID, len(book.Bids), len(book.Asks))
+	}
+	return &types.Midpoint{TokenID: tokenID, Value: value}, nil
 }
 
 // GetMidpoints 批量获取多个代币的中间价
@@ -215,8 +773,8 @@ func (c *marketDataClientImpl) GetMidpoints(tokenIDs []string) ([]types.Midpoint
 	if err != nil {
 		return nil, fmt.Errorf("批量获取中间价失败: failed to marshal request body: %w", err)
 	}
-	
-	rawBytes, err := http.PostRaw(c.baseClient.baseURL, internal.MidPoints, bodyBytes)
+
+	rawBytes, err := http.PostRaw(c.baseClient.baseURL, internal.MidPoints, bodyBytes, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取中间价失败: %w", err)
 	}
@@ -247,11 +805,23 @@ func (c *marketDataClientImpl) GetMidpoints(tokenIDs []string) ([]types.Midpoint
 
 // GetPrice 获取指定方向的价格
 func (c *marketDataClientImpl) GetPrice(tokenID string, side types.OrderSide) (*types.Price, error) {
+	side, err := types.ParseOrderSide(string(side))
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"token_id": tokenID,
 		"side":     string(side),
 	}
-	return http.Get[types.Price](c.baseClient.baseURL, internal.Price, params)
+	price, err := http.Get[types.Price](c.baseClient.baseURL, internal.Price, params, c.requestOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	// 响应只包含价格，补充代币和方向
+	price.TokenID = tokenID
+	price.Side = string(side)
+	price.ResolvedSide = side
+	return price, nil
 }
 
 // GetPrices 批量获取多个代币的价格
@@ -262,6 +832,10 @@ func (c *marketDataClientImpl) GetPrices(requests []types.BookParams) ([]types.P
 	if len(requests) > 500 {
 		return nil, fmt.Errorf("请求数组长度不能超过500，当前: %d", len(requests))
 	}
+	requests, err := normalizeBookParams(requests)
+	if err != nil {
+		return nil, err
+	}
 
 	// 构建请求体
 	requestBody := make([]map[string]string, len(requests))
@@ -279,15 +853,34 @@ func (c *marketDataClientImpl) GetPrices(requests []types.BookParams) ([]types.P
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价格失败: failed to marshal request body: %w", err)
 	}
-	
-	rawBytes, err := http.PostRaw(c.baseClient.baseURL, internal.GetPrices, bodyBytes)
+
+	rawBytes, err := http.PostRaw(c.baseClient.baseURL, internal.GetPrices, bodyBytes, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价格失败: %w", err)
 	}
 
+	return decodePricesResponse(requests, rawBytes)
+}
+
+// decodePricesResponse 解析 POST /prices 的响应，兼容数组和嵌套对象 map[token_id]map[side]price_string
+// 请求未指定方向时优先使用 BUY，否则使用 SELL；返回的 Side 和 ResolvedSide 为价格实际对应的方向
+func decodePricesResponse(requests []types.BookParams, rawBytes []byte) ([]types.Price, error) {
 	// 先尝试解析为数组（向后兼容）
 	var priceArray []types.Price
 	if err := json.Unmarshal(rawBytes, &priceArray); err == nil {
+		// 响应未给出方向时，使用同一代币请求中指定的方向
+		requestedSides := make(map[string]string, len(requests))
+		for _, req := range requests {
+			requestedSides[req.TokenID] = req.Side
+		}
+		for i := range priceArray {
+			if priceArray[i].ResolvedSide == "" {
+				if side, err := types.ParseOrderSide(requestedSides[priceArray[i].TokenID]); err == nil {
+					priceArray[i].Side = string(side)
+					priceArray[i].ResolvedSide = side
+				}
+			}
+		}
 		return priceArray, nil
 	}
 
@@ -300,38 +893,39 @@ func (c *marketDataClientImpl) GetPrices(requests []types.BookParams) ([]types.P
 	// 转换为数组格式
 	result := make([]types.Price, 0, len(requests))
 	for _, req := range requests {
-		if tokenMap, ok := responseMap[req.TokenID]; ok {
-			// 如果指定了side，使用指定的side；否则尝试BUY或SELL
-			var priceStr string
-			var found bool
-			if req.Side != "" {
-				if p, ok := tokenMap[req.Side]; ok {
-					priceStr = p
-					found = true
-				}
-			} else {
-				// 没有指定side，尝试BUY或SELL
-				if p, ok := tokenMap["BUY"]; ok {
-					priceStr = p
-					found = true
-					req.Side = "BUY"
-				} else if p, ok := tokenMap["SELL"]; ok {
-					priceStr = p
-					found = true
-					req.Side = "SELL"
-				}
+		tokenMap, ok := responseMap[req.TokenID]
+		if !ok {
+			continue
+		}
+
+		// 方向键统一为大写，兼容 "buy"/"sell"
+		sidePrices := make(map[types.OrderSide]string, len(tokenMap))
+		for side, p := range tokenMap {
+			if parsed, err := types.ParseOrderSide(side); err == nil {
+				sidePrices[parsed] = p
 			}
-			
-			if found {
-				price, err := strconv.ParseFloat(priceStr, 64)
-				if err != nil {
-					return nil, fmt.Errorf("批量获取价格失败: failed to parse price for token %s: %w", req.TokenID, err)
-				}
-				result = append(result, types.Price{
-					BookParams: req,
-					Price:      price,
-				})
+		}
+
+		// 如果指定了side，使用指定的side；否则尝试BUY或SELL
+		candidates := []types.OrderSide{types.OrderSideBUY, types.OrderSideSELL}
+		if req.Side != "" {
+			candidates = []types.OrderSide{types.OrderSide(req.Side)}
+		}
+		for _, side := range candidates {
+			priceStr, found := sidePrices[side]
+			if !found {
+				continue
+			}
+			price, err := strconv.ParseFloat(priceStr, 64)
+			if err != nil {
+				return nil, fmt.Errorf("批量获取价格失败: failed to parse price for token %s: %w", req.TokenID, err)
 			}
+			result = append(result, types.Price{
+				BookParams:   types.BookParams{TokenID: req.TokenID, Side: string(side)},
+				Price:        price,
+				ResolvedSide: side,
+			})
+			break
 		}
 	}
 
@@ -341,7 +935,7 @@ func (c *marketDataClientImpl) GetPrices(requests []types.BookParams) ([]types.P
 // GetSpread 获取单个代币的价差
 func (c *marketDataClientImpl) GetSpread(tokenID string) (*types.Spread, error) {
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.Spread](c.baseClient.baseURL, internal.GetSpread, params)
+	return http.Get[types.Spread](c.baseClient.baseURL, internal.GetSpread, params, c.requestOptions()...)
 }
 
 // GetSpreads 批量获取多个代币的价差
@@ -367,8 +961,8 @@ func (c *marketDataClientImpl) GetSpreads(tokenIDs []string) ([]types.Spread, er
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价差失败: failed to marshal request body: %w", err)
 	}
-	
-	rawBytes, err := http.PostRaw(c.baseClient.baseURL, internal.GetSpreads, bodyBytes)
+
+	rawBytes, err := http.PostRaw(c.baseClient.baseURL, internal.GetSpreads, bodyBytes, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价差失败: %w", err)
 	}
@@ -400,7 +994,15 @@ func (c *marketDataClientImpl) GetSpreads(tokenIDs []string) ([]types.Spread, er
 // GetLastTradePrice 获取单个代币的最后成交价
 func (c *marketDataClientImpl) GetLastTradePrice(tokenID string) (*types.LastTradePrice, error) {
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.LastTradePrice](c.baseClient.baseURL, internal.GetLastTradePrice, params)
+	result, err := http.Get[types.LastTradePrice](c.baseClient.baseURL, internal.GetLastTradePrice, params, c.requestOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	// 单个查询的响应不包含 token_id
+	if result.TokenID == "" {
+		result.TokenID = tokenID
+	}
+	return result, nil
 }
 
 // GetLastTradesPrices 批量获取多个代币的最后成交价
@@ -420,7 +1022,7 @@ func (c *marketDataClientImpl) GetLastTradesPrices(tokenIDs []string) ([]types.L
 		}
 	}
 
-	result, err := http.Post[[]types.LastTradePrice](c.baseClient.baseURL, internal.GetLastTradesPrices, requestBody)
+	result, err := http.Post[[]types.LastTradePrice](c.baseClient.baseURL, internal.GetLastTradesPrices, requestBody, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取最后成交价失败: %w", err)
 	}
@@ -429,54 +1031,173 @@ func (c *marketDataClientImpl) GetLastTradesPrices(tokenIDs []string) ([]types.L
 }
 
 // GetFeeRate 获取代币的手续费率（以 bps 为单位，1 bps = 0.01%）
+// 响应中没有 fee_rate 时使用 base_fee，结果会被缓存
 func (c *marketDataClientImpl) GetFeeRate(tokenID string) (int, error) {
-	// 检查缓存
-	if feeRate, ok := c.baseClient.feeRates[tokenID]; ok {
+	return c.baseClient.feeRates.get(c.baseClient.baseURL, tokenID, c.requestOptions())
+}
+
+// GetFeeRates 批量获取多个代币的手续费率（bps）
+// 已缓存的代币直接返回，其余代币并发请求（并发数受 internal.FeeRateMaxConcurrency 限制）并写入缓存；
+// 任一代币查询失败时返回错误，已成功查询的结果仍会写入缓存
+func (c *marketDataClientImpl) GetFeeRates(tokenIDs []string) (map[string]int, error) {
+	return c.baseClient.feeRates.getMany(c.baseClient.baseURL, tokenIDs, c.requestOptions())
+}
+
+// feeRateCache 代币手续费率的缓存，超过容量时淘汰最久未使用的代币
+type feeRateCache struct {
+	values *lruCache[int]
+}
+
+// newFeeRateCache 创建最多保存 capacity 个代币的手续费率缓存，capacity <= 0 时不限制
+func newFeeRateCache(capacity int) *feeRateCache {
+	return &feeRateCache{values: newLRUCache[int](capacity)}
+}
+
+// lookup 读取缓存的手续费率
+func (fc *feeRateCache) lookup(tokenID string) (int, bool) {
+	return fc.values.get(tokenID)
+}
+
+// store 写入手续费率
+func (fc *feeRateCache) store(tokenID string, feeRate int) {
+	fc.values.add(tokenID, feeRate)
+}
+
+// get 返回代币的手续费率，未缓存时请求 API
+func (fc *feeRateCache) get(baseURL string, tokenID string, options []http.HTTPOption) (int, error) {
+	if feeRate, ok := fc.lookup(tokenID); ok {
 		return feeRate, nil
 	}
 
+	feeRate, err := fetchFeeRate(baseURL, tokenID, options)
+	if err != nil {
+		return 0, err
+	}
+
+	fc.store(tokenID, feeRate)
+	return feeRate, nil
+}
+
+// getMany 批量返回代币的手续费率，未缓存的代币并发请求
+func (fc *feeRateCache) getMany(baseURL string, tokenIDs []string, options []http.HTTPOption) (map[string]int, error) {
+	return getManyFeeRates(fc, tokenIDs, func(tokenID string) (int, error) {
+		return fetchFeeRate(baseURL, tokenID, options)
+	})
+}
+
+// getManyFeeRates getMany 的实现，单个代币的查询通过参数传入便于测试
+func getManyFeeRates(fc *feeRateCache, tokenIDs []string, fetch func(tokenID string) (int, error)) (map[string]int, error) {
+	result := make(map[string]int, len(tokenIDs))
+	missing := make([]string, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if _, ok := result[tokenID]; ok {
+			continue
+		}
+		if feeRate, ok := fc.lookup(tokenID); ok {
+			result[tokenID] = feeRate
+			continue
+		}
+		result[tokenID] = 0
+		missing = append(missing, tokenID)
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, internal.FeeRateMaxConcurrency)
+
+	for _, tokenID := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(tokenID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			feeRate, err := fetch(tokenID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("token %s: %w", tokenID, err)
+				}
+				delete(result, tokenID)
+				return
+			}
+			result[tokenID] = feeRate
+			fc.store(tokenID, feeRate)
+		}(tokenID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// fetchFeeRate 从 API 获取代币的手续费率（不使用缓存）
+func fetchFeeRate(baseURL string, tokenID string, options []http.HTTPOption) (int, error) {
 	params := map[string]string{"token_id": tokenID}
 
-	// API 可能返回数字或字符串格式的 fee_rate
-	var rawResponse map[string]interface{}
-	resp, err := http.Get[map[string]interface{}](c.baseClient.baseURL, internal.GetFeeRate, params)
+	resp, err := http.Get[map[string]interface{}](baseURL, internal.GetFeeRate, params, append(append([]http.HTTPOption{}, options...), http.WithUseNumber())...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get fee rate: %w", err)
 	}
-	rawResponse = *resp
 
-	// 提取 fee_rate 并转换为 int
-	var feeRate int
-	if val, ok := rawResponse["fee_rate"]; ok {
+	return parseFeeRate(*resp)
+}
+
+// parseFeeRate 从响应中提取手续费率
+// API 可能返回 fee_rate 或 base_fee 字段（数字或字符串格式），优先使用 fee_rate
+func parseFeeRate(rawResponse map[string]interface{}) (int, error) {
+	for _, field := range []string{"fee_rate", "base_fee"} {
+		val, ok := rawResponse[field]
+		if !ok {
+			continue
+		}
 		switch v := val.(type) {
 		case float64:
-			feeRate = int(v)
-		case int:
-			feeRate = v
-		case int64:
-			feeRate = int(v)
+			return int(v), nil
 		case string:
 			parsed, err := strconv.Atoi(v)
 			if err != nil {
-				return 0, fmt.Errorf("failed to parse fee_rate as int: %w", err)
+				return 0, fmt.Errorf("failed to parse %s as int: %w", field, err)
 			}
-			feeRate = parsed
+			return parsed, nil
 		default:
-			return 0, fmt.Errorf("unexpected fee_rate type: %T", v)
+			// json.Number 及整数类型
+			if n, ok := types.NumberInt64(v); ok {
+				return int(n), nil
+			}
+			return 0, fmt.Errorf("unexpected %s type: %T", field, v)
 		}
-	} else {
-		return 0, fmt.Errorf("fee_rate not found in response")
 	}
-
-	// 缓存结果
-	c.baseClient.feeRates[tokenID] = feeRate
-	return feeRate, nil
+	return 0, fmt.Errorf("fee_rate or base_fee not found in response")
 }
 
 // GetTime 获取服务器时间
+// 同时记录服务器时间与本地时间的偏差，用于计算 GTD 订单的过期时间
 func (c *marketDataClientImpl) GetTime() (time.Time, error) {
+	serverTime, err := c.getServerTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	c.baseClient.setTimeOffset(time.Until(serverTime))
+	return serverTime, nil
+}
+
+// getServerTime 请求并解析服务器时间
+func (c *marketDataClientImpl) getServerTime() (time.Time, error) {
 	// API返回的是纯数字（Unix时间戳），不是JSON对象
-	rawBytes, err := http.GetRaw(c.baseClient.baseURL, "GET", internal.Time, nil)
+	rawBytes, err := http.GetRaw(c.baseClient.baseURL, "GET", internal.Time, nil, c.requestOptions()...)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to get server time: %w", err)
 	}
@@ -520,9 +1241,12 @@ func (c *marketDataClientImpl) GetTime() (time.Time, error) {
 // ========== 只读客户端实现 ==========
 
 // GetMidpoint 获取单个代币的中间价（只读客户端实现）
-func (c *readonlyMarketDataClientImpl) GetMidpoint(tokenID string) (*types.Midpoint, error) {
+func (c *readonlyMarketDataClientImpl) GetMidpoint(tokenID string, options ...GetMidpointOption) (*types.Midpoint, error) {
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.Midpoint](c.readonlyBaseClient.baseURL, internal.MidPoint, params)
+	midpoint, err := http.Get[types.Midpoint](c.readonlyBaseClient.baseURL, internal.MidPoint, params, c.requestOptions()...)
+	return midpointWithFallback(tokenID, midpoint, err, options, func() (*types.OrderBookSummary, error) {
+		return c.GetOrderBook(tokenID)
+	})
 }
 
 // GetMidpoints 批量获取多个代币的中间价（只读客户端实现）
@@ -549,8 +1273,8 @@ func (c *readonlyMarketDataClientImpl) GetMidpoints(tokenIDs []string) ([]types.
 	if err != nil {
 		return nil, fmt.Errorf("批量获取中间价失败: failed to marshal request body: %w", err)
 	}
-	
-	rawBytes, err := http.PostRaw(c.readonlyBaseClient.baseURL, internal.MidPoints, bodyBytes)
+
+	rawBytes, err := http.PostRaw(c.readonlyBaseClient.baseURL, internal.MidPoints, bodyBytes, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取中间价失败: %w", err)
 	}
@@ -581,11 +1305,23 @@ func (c *readonlyMarketDataClientImpl) GetMidpoints(tokenIDs []string) ([]types.
 
 // GetPrice 获取指定方向的价格（只读客户端实现）
 func (c *readonlyMarketDataClientImpl) GetPrice(tokenID string, side types.OrderSide) (*types.Price, error) {
+	side, err := types.ParseOrderSide(string(side))
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"token_id": tokenID,
 		"side":     string(side),
 	}
-	return http.Get[types.Price](c.readonlyBaseClient.baseURL, internal.Price, params)
+	price, err := http.Get[types.Price](c.readonlyBaseClient.baseURL, internal.Price, params, c.requestOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	// 响应只包含价格，补充代币和方向
+	price.TokenID = tokenID
+	price.Side = string(side)
+	price.ResolvedSide = side
+	return price, nil
 }
 
 // GetPrices 批量获取多个代币的价格（只读客户端实现）
@@ -596,6 +1332,10 @@ func (c *readonlyMarketDataClientImpl) GetPrices(requests []types.BookParams) ([
 	if len(requests) > 500 {
 		return nil, fmt.Errorf("请求数组长度不能超过500，当前: %d", len(requests))
 	}
+	requests, err := normalizeBookParams(requests)
+	if err != nil {
+		return nil, err
+	}
 
 	// 构建请求体
 	requestBody := make([]map[string]string, len(requests))
@@ -613,69 +1353,19 @@ func (c *readonlyMarketDataClientImpl) GetPrices(requests []types.BookParams) ([
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价格失败: failed to marshal request body: %w", err)
 	}
-	
-	rawBytes, err := http.PostRaw(c.readonlyBaseClient.baseURL, internal.GetPrices, bodyBytes)
+
+	rawBytes, err := http.PostRaw(c.readonlyBaseClient.baseURL, internal.GetPrices, bodyBytes, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价格失败: %w", err)
 	}
 
-	// 先尝试解析为数组（向后兼容）
-	var priceArray []types.Price
-	if err := json.Unmarshal(rawBytes, &priceArray); err == nil {
-		return priceArray, nil
-	}
-
-	// 如果解析为数组失败，尝试解析为嵌套对象 map[token_id]map[side]price_string
-	var responseMap map[string]map[string]string
-	if err := json.Unmarshal(rawBytes, &responseMap); err != nil {
-		return nil, fmt.Errorf("批量获取价格失败: failed to decode response: %w", err)
-	}
-
-	// 转换为数组格式
-	result := make([]types.Price, 0, len(requests))
-	for _, req := range requests {
-		if tokenMap, ok := responseMap[req.TokenID]; ok {
-			// 如果指定了side，使用指定的side；否则尝试BUY或SELL
-			var priceStr string
-			var found bool
-			if req.Side != "" {
-				if p, ok := tokenMap[req.Side]; ok {
-					priceStr = p
-					found = true
-				}
-			} else {
-				// 没有指定side，尝试BUY或SELL
-				if p, ok := tokenMap["BUY"]; ok {
-					priceStr = p
-					found = true
-					req.Side = "BUY"
-				} else if p, ok := tokenMap["SELL"]; ok {
-					priceStr = p
-					found = true
-					req.Side = "SELL"
-				}
-			}
-			
-			if found {
-				price, err := strconv.ParseFloat(priceStr, 64)
-				if err != nil {
-					return nil, fmt.Errorf("批量获取价格失败: failed to parse price for token %s: %w", req.TokenID, err)
-				}
-				result = append(result, types.Price{
-					BookParams: req,
-					Price:      price,
-				})
-			}
-		}
-	}
-
-	return result, nil
+	return decodePricesResponse(requests, rawBytes)
 }
 
 // GetSpread 获取单个代币的价差（只读客户端实现）
 func (c *readonlyMarketDataClientImpl) GetSpread(tokenID string) (*types.Spread, error) {
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.Spread](c.readonlyBaseClient.baseURL, internal.GetSpread, params)
+	return http.Get[types.Spread](c.readonlyBaseClient.baseURL, internal.GetSpread, params, c.requestOptions()...)
 }
 
 // GetSpreads 批量获取多个代币的价差（只读客户端实现）
@@ -701,8 +1391,8 @@ func (c *readonlyMarketDataClientImpl) GetSpreads(tokenIDs []string) ([]types.Sp
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价差失败: failed to marshal request body: %w", err)
 	}
-	
-	rawBytes, err := http.PostRaw(c.readonlyBaseClient.baseURL, internal.GetSpreads, bodyBytes)
+
+	rawBytes, err := http.PostRaw(c.readonlyBaseClient.baseURL, internal.GetSpreads, bodyBytes, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取价差失败: %w", err)
 	}
@@ -734,7 +1424,15 @@ func (c *readonlyMarketDataClientImpl) GetSpreads(tokenIDs []string) ([]types.Sp
 // GetLastTradePrice 获取单个代币的最后成交价（只读客户端实现）
 func (c *readonlyMarketDataClientImpl) GetLastTradePrice(tokenID string) (*types.LastTradePrice, error) {
 	params := map[string]string{"token_id": tokenID}
-	return http.Get[types.LastTradePrice](c.readonlyBaseClient.baseURL, internal.GetLastTradePrice, params)
+	result, err := http.Get[types.LastTradePrice](c.readonlyBaseClient.baseURL, internal.GetLastTradePrice, params, c.requestOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	// 单个查询的响应不包含 token_id
+	if result.TokenID == "" {
+		result.TokenID = tokenID
+	}
+	return result, nil
 }
 
 // GetLastTradesPrices 批量获取多个代币的最后成交价（只读客户端实现）
@@ -754,7 +1452,7 @@ func (c *readonlyMarketDataClientImpl) GetLastTradesPrices(tokenIDs []string) ([
 		}
 	}
 
-	result, err := http.Post[[]types.LastTradePrice](c.readonlyBaseClient.baseURL, internal.GetLastTradesPrices, requestBody)
+	result, err := http.Post[[]types.LastTradePrice](c.readonlyBaseClient.baseURL, internal.GetLastTradesPrices, requestBody, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("批量获取最后成交价失败: %w", err)
 	}
@@ -764,79 +1462,18 @@ func (c *readonlyMarketDataClientImpl) GetLastTradesPrices(tokenIDs []string) ([
 
 // GetFeeRate 获取代币的手续费率（只读客户端实现）
 func (c *readonlyMarketDataClientImpl) GetFeeRate(tokenID string) (int, error) {
-	// 检查缓存
-	if feeRate, ok := c.readonlyBaseClient.feeRates[tokenID]; ok {
-		return feeRate, nil
-	}
-
-	params := map[string]string{"token_id": tokenID}
-
-	// API 可能返回数字或字符串格式的 fee_rate
-	var rawResponse map[string]interface{}
-	resp, err := http.Get[map[string]interface{}](c.readonlyBaseClient.baseURL, internal.GetFeeRate, params)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get fee rate: %w", err)
-	}
-	rawResponse = *resp
-
-	// 提取 fee_rate 或 base_fee 并转换为 int
-	// API可能返回 fee_rate 或 base_fee 字段
-	var feeRate int
-	var found bool
-	
-	// 优先查找 fee_rate
-	if val, ok := rawResponse["fee_rate"]; ok {
-		found = true
-		switch v := val.(type) {
-		case float64:
-			feeRate = int(v)
-		case int:
-			feeRate = v
-		case int64:
-			feeRate = int(v)
-		case string:
-			parsed, err := strconv.Atoi(v)
-			if err != nil {
-				return 0, fmt.Errorf("failed to parse fee_rate as int: %w", err)
-			}
-			feeRate = parsed
-		default:
-			return 0, fmt.Errorf("unexpected fee_rate type: %T", v)
-		}
-	} else if val, ok := rawResponse["base_fee"]; ok {
-		// 如果没有 fee_rate，尝试 base_fee
-		found = true
-		switch v := val.(type) {
-		case float64:
-			feeRate = int(v)
-		case int:
-			feeRate = v
-		case int64:
-			feeRate = int(v)
-		case string:
-			parsed, err := strconv.Atoi(v)
-			if err != nil {
-				return 0, fmt.Errorf("failed to parse base_fee as int: %w", err)
-			}
-			feeRate = parsed
-		default:
-			return 0, fmt.Errorf("unexpected base_fee type: %T", v)
-		}
-	}
-	
-	if !found {
-		return 0, fmt.Errorf("fee_rate or base_fee not found in response")
-	}
+	return c.readonlyBaseClient.feeRates.get(c.readonlyBaseClient.baseURL, tokenID, c.requestOptions())
+}
 
-	// 缓存结果
-	c.readonlyBaseClient.feeRates[tokenID] = feeRate
-	return feeRate, nil
+// GetFeeRates 批量获取多个代币的手续费率（只读客户端实现）
+func (c *readonlyMarketDataClientImpl) GetFeeRates(tokenIDs []string) (map[string]int, error) {
+	return c.readonlyBaseClient.feeRates.getMany(c.readonlyBaseClient.baseURL, tokenIDs, c.requestOptions())
 }
 
 // GetTime 获取服务器时间（只读客户端实现）
 func (c *readonlyMarketDataClientImpl) GetTime() (time.Time, error) {
 	// API返回的是纯数字（Unix时间戳），不是JSON对象
-	rawBytes, err := http.GetRaw(c.readonlyBaseClient.baseURL, "GET", internal.Time, nil)
+	rawBytes, err := http.GetRaw(c.readonlyBaseClient.baseURL, "GET", internal.Time, nil, c.requestOptions()...)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to get server time: %w", err)
 	}
@@ -876,3 +1513,129 @@ func (c *readonlyMarketDataClientImpl) GetTime() (time.Time, error) {
 
 	return time.Time{}, fmt.Errorf("failed to parse server time response")
 }
+
+// GetPricesHistory 获取单个代币的价格历史
+// interval 为截至当前时间的时间范围，为空时使用服务端默认值；fidelity 为数据点的间隔（分钟），0 表示使用服务端默认值
+func (c *marketDataClientImpl) GetPricesHistory(tokenID string, interval types.PriceHistoryInterval, fidelity int) (*types.PriceHistory, error) {
+	return fetchPricesHistory(c.baseClient.baseURL, tokenID, interval, fidelity, c.requestOptions())
+}
+
+// GetPricesHistory 获取单个代币的价格历史
+func (c *readonlyMarketDataClientImpl) GetPricesHistory(tokenID string, interval types.PriceHistoryInterval, fidelity int) (*types.PriceHistory, error) {
+	return fetchPricesHistory(c.readonlyBaseClient.baseURL, tokenID, interval, fidelity, c.requestOptions())
+}
+
+// GetPricesHistoryBatch 并发获取多个代币的价格历史，返回以代币 ID 为键的 map
+// 并发数受 internal.PricesHistoryMaxConcurrency 限制，任一代币失败时返回错误
+// fidelity 大于 0 时各序列的时间戳对齐到 fidelity 分钟的整点，同一时间桶的数据点在不同序列中时间戳相同，可直接按时间戳合并
+func (c *marketDataClientImpl) GetPricesHistoryBatch(tokenIDs []string, interval types.PriceHistoryInterval, fidelity int) (map[string]types.PriceHistory, error) {
+	return getPricesHistoryBatch(tokenIDs, fidelity, func(tokenID string) (*types.PriceHistory, error) {
+		return fetchPricesHistory(c.baseClient.baseURL, tokenID, interval, fidelity, c.requestOptions())
+	})
+}
+
+// GetPricesHistoryBatch 并发获取多个代币的价格历史，返回以代币 ID 为键的 map
+func (c *readonlyMarketDataClientImpl) GetPricesHistoryBatch(tokenIDs []string, interval types.PriceHistoryInterval, fidelity int) (map[string]types.PriceHistory, error) {
+	return getPricesHistoryBatch(tokenIDs, fidelity, func(tokenID string) (*types.PriceHistory, error) {
+		return fetchPricesHistory(c.readonlyBaseClient.baseURL, tokenID, interval, fidelity, c.requestOptions())
+	})
+}
+
+// fetchPricesHistory 从 API 获取单个代币的价格历史
+func fetchPricesHistory(baseURL string, tokenID string, interval types.PriceHistoryInterval, fidelity int, options []http.HTTPOption) (*types.PriceHistory, error) {
+	if tokenID == "" {
+		return nil, fmt.Errorf("tokenID is required")
+	}
+	params := map[string]string{"market": tokenID}
+	if interval != "" {
+		params["interval"] = string(interval)
+	}
+	if fidelity > 0 {
+		params["fidelity"] = strconv.Itoa(fidelity)
+	}
+
+	result, err := http.Get[types.PriceHistory](baseURL, internal.GetPricesHistory, params, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices history: %w", err)
+	}
+	// 响应不包含 token_id
+	result.TokenID = tokenID
+	if result.History == nil {
+		result.History = []types.TimeseriesPoint{}
+	}
+	return result, nil
+}
+
+// getPricesHistoryBatch GetPricesHistoryBatch 的实现，单个代币的查询通过参数传入便于测试
+func getPricesHistoryBatch(tokenIDs []string, fidelity int, fetch func(tokenID string) (*types.PriceHistory, error)) (map[string]types.PriceHistory, error) {
+	result := make(map[string]types.PriceHistory, len(tokenIDs))
+	unique := make([]string, 0, len(tokenIDs))
+	seen := make(map[string]bool, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		if seen[tokenID] {
+			continue
+		}
+		seen[tokenID] = true
+		unique = append(unique, tokenID)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, internal.PricesHistoryMaxConcurrency)
+
+	for _, tokenID := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(tokenID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			history, err := fetch(tokenID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("token %s: %w", tokenID, err)
+				}
+				return
+			}
+			history.History = alignPriceHistory(history.History, fidelity)
+			result[tokenID] = *history
+		}(tokenID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// alignPriceHistory 将时间戳截断到 fidelity 分钟的整点（UTC），并按时间升序排列
+// 截断后落在同一时间桶的多个数据点只保留最后一个；fidelity 不大于 0 时只排序
+func alignPriceHistory(points []types.TimeseriesPoint, fidelity int) []types.TimeseriesPoint {
+	aligned := make([]types.TimeseriesPoint, 0, len(points))
+	for _, point := range points {
+		if fidelity > 0 {
+			point.Timestamp = point.Timestamp.Truncate(time.Duration(fidelity) * time.Minute)
+		}
+		aligned = append(aligned, point)
+	}
+	sort.SliceStable(aligned, func(i, j int) bool {
+		return aligned[i].Timestamp.Before(aligned[j].Timestamp)
+	})
+
+	result := aligned[:0]
+	for _, point := range aligned {
+		if n := len(result); n > 0 && result[n-1].Timestamp.Equal(point.Timestamp) {
+			result[n-1] = point
+			continue
+		}
+		result = append(result, point)
+	}
+	return result
+}
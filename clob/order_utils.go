@@ -2,10 +2,13 @@ package clob
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"strconv"
-	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/polymas/go-polymarket-sdk/internal/rounding"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
 
@@ -34,8 +37,11 @@ func (c *orderClientImpl) calculateOrderAmounts(
 		return nil, nil, fmt.Errorf("invalid tick size: %w", err)
 	}
 
+	// Rounding precision for this tick size (matching Python ROUNDING_CONFIG)
+	roundConfig := rounding.ConfigForTickSize(tickSizeFloat)
+
 	// Round price to tick size using round_normal (ROUND_HALF_UP) matching Python
-	roundedPrice := roundNormal(price, tickSizeFloat)
+	roundedPrice := roundedPriceForTickSize(price, tickSizeFloat)
 
 	// Convert to token decimals (1e6) - matching Python's to_token_decimals
 	// Python: to_token_decimals(x) = int(Decimal(str(x)) * Decimal(10**6).quantize(exp=Decimal(1), rounding=ROUND_HALF_UP))
@@ -54,8 +60,8 @@ func (c *orderClientImpl) calculateOrderAmounts(
 		return intResult
 	}
 
-	// Round down size to 2 decimal places (matching Python's round_config.size = 2)
-	roundedSize := roundDown(size, 2)
+	// Round down size to round_config.size (2) decimal places
+	roundedSize := rounding.RoundDown(size, roundConfig.Size)
 
 	if side == types.OrderSideBUY {
 		// BUY: taker_amount = size, maker_amount = size * price
@@ -65,7 +71,7 @@ func (c *orderClientImpl) calculateOrderAmounts(
 		// Round maker amount following Python logic:
 		// 1. If decimal places > round_config.amount (6), try round_up to (amount + 4) = 10
 		// 2. If still > amount, round_down to amount = 6
-		makerAmount = roundMakerAmount(makerAmount, tickSizeFloat)
+		makerAmount = rounding.RoundAmount(makerAmount, roundConfig.Amount)
 
 		return toTokenDecimals(makerAmount), toTokenDecimals(takerAmount), nil
 	} else {
@@ -76,114 +82,67 @@ func (c *orderClientImpl) calculateOrderAmounts(
 		// Round taker amount following Python logic:
 		// 1. If decimal places > round_config.amount (6), try round_up to (amount + 4) = 10
 		// 2. If still > amount, round_down to amount = 6
-		takerAmount = roundMakerAmount(takerAmount, tickSizeFloat)
+		takerAmount = rounding.RoundAmount(takerAmount, roundConfig.Amount)
 
 		return toTokenDecimals(makerAmount), toTokenDecimals(takerAmount), nil
 	}
 }
 
-// roundNormal rounds a price to tick size using ROUND_HALF_UP (matching Python's round_normal)
-// Python: round_normal(x, sig_digits) uses Decimal.quantize(exp=Decimal(1).scaleb(-sig_digits), rounding=ROUND_HALF_UP)
-func roundNormal(price float64, tickSize float64) float64 {
+// roundedPriceForTickSize rounds a price to the tick size precision using ROUND_HALF_UP
+// Non-positive tick sizes leave the price unchanged
+func roundedPriceForTickSize(price float64, tickSize float64) float64 {
 	if tickSize <= 0 {
 		return price
 	}
-	// Calculate number of decimal places from tick size
-	// For tick size 0.0001, we need 4 decimal places
-	decimals := getDecimalPlacesFromTickSize(tickSize)
-
-	// Round using ROUND_HALF_UP (standard math.Round)
-	multiplier := 1.0
-	for i := 0; i < decimals; i++ {
-		multiplier *= 10
-	}
-	rounded := float64(int64(price*multiplier+0.5)) / multiplier
-	return rounded
-}
-
-// getDecimalPlacesFromTickSize calculates decimal places from tick size
-// tick_size 0.1 -> 1 decimal, 0.01 -> 2 decimals, 0.001 -> 3 decimals, 0.0001 -> 4 decimals
-func getDecimalPlacesFromTickSize(tickSize float64) int {
-	if tickSize >= 0.1 {
-		return 1
-	} else if tickSize >= 0.01 {
-		return 2
-	} else if tickSize >= 0.001 {
-		return 3
-	} else {
-		return 4 // Default for 0.0001
-	}
+	return rounding.RoundNormal(price, rounding.ConfigForTickSize(tickSize).Price)
 }
 
-// roundDown rounds down to specified decimal places
-func roundDown(val float64, decimals int) float64 {
-	multiplier := 1.0
-	for i := 0; i < decimals; i++ {
-		multiplier *= 10
+// SharesToUSDC 计算以 price 买入（或卖出）shares 份额对应的 USDC 金额
+// 与签名订单的金额计算一致：份额按 tick size 配置向下截断到 2 位小数，金额按 RoundAmount 规则取整，
+// price 应为 tick size 的整数倍（tick size 按 price 的小数位数推断）；shares 或 price 不大于 0 时返回 0
+func SharesToUSDC(shares float64, price float64) float64 {
+	if shares <= 0 || price <= 0 {
+		return 0
 	}
-	return float64(int64(val*multiplier)) / multiplier
+	roundConfig := roundingConfigForPrice(price)
+	roundedSize := rounding.RoundDown(shares, roundConfig.Size)
+	return rounding.RoundAmount(roundedSize*price, roundConfig.Amount)
 }
 
-// roundMakerAmount rounds maker/taker amount following Python's logic:
-// 1. Get round_config.amount based on tick size (6 for 0.0001, 5 for 0.001, etc.)
-// 2. If decimal places > amount, try round_up to (amount + 4)
-// 3. If still > amount, round_down to amount
-func roundMakerAmount(amount float64, tickSize float64) float64 {
-	// Determine round_config.amount from tick size (matching Python ROUNDING_CONFIG)
-	var amountDecimals int
-	if tickSize >= 0.1 {
-		amountDecimals = 3
-	} else if tickSize >= 0.01 {
-		amountDecimals = 4
-	} else if tickSize >= 0.001 {
-		amountDecimals = 5
-	} else {
-		amountDecimals = 6 // Default for 0.0001
-	}
-
-	// Count decimal places
-	decimalPlaces := countDecimalPlaces(amount)
-
-	// If decimal places <= amount, no rounding needed
-	if decimalPlaces <= amountDecimals {
-		return amount
+// USDCToShares 计算 usdc 金额在 price 价格下最多可买入的份额
+// 返回的份额已按订单精度截断，且 SharesToUSDC(shares, price) 不超过 usdc；usdc 或 price 不大于 0 时返回 0
+func USDCToShares(usdc float64, price float64) float64 {
+	if usdc <= 0 || price <= 0 {
+		return 0
 	}
-
-	// Try round_up to (amount + 4) first
-	roundedUp := roundUp(amount, amountDecimals+4)
-	decimalPlacesAfterRoundUp := countDecimalPlaces(roundedUp)
-
-	// If still > amount, round_down to amount
-	if decimalPlacesAfterRoundUp > amountDecimals {
-		return roundDown(amount, amountDecimals)
+	roundConfig := roundingConfigForPrice(price)
+	// 加上极小值避免 56/0.56 = 99.99999999999999 之类的浮点误差截断掉一档
+	shares := rounding.RoundDown(usdc/price+1e-9, roundConfig.Size)
+	if SharesToUSDC(shares, price) > usdc+1e-9 {
+		shares = rounding.RoundNormal(shares-math.Pow10(-roundConfig.Size), roundConfig.Size)
 	}
-
-	return roundedUp
+	return math.Max(shares, 0)
 }
 
-// countDecimalPlaces counts the number of decimal places in a float
-func countDecimalPlaces(val float64) int {
-	// Convert to string to count decimal places
-	str := fmt.Sprintf("%.10f", val)
-	str = strings.TrimRight(str, "0")
-	str = strings.TrimRight(str, ".")
-	if !strings.Contains(str, ".") {
-		return 0
-	}
-	parts := strings.Split(str, ".")
-	if len(parts) != 2 {
-		return 0
-	}
-	return len(parts[1])
+// roundingConfigForPrice 按价格的小数位数推断 tick size 并返回对应的取整配置
+func roundingConfigForPrice(price float64) rounding.Config {
+	return rounding.ConfigForTickSize(math.Pow10(-rounding.DecimalPlaces(price)))
 }
 
-// roundUp rounds up to specified decimal places
-func roundUp(val float64, decimals int) float64 {
-	multiplier := 1.0
-	for i := 0; i < decimals; i++ {
-		multiplier *= 10
+// newSaltGenerator 返回订单 salt 生成函数，以纳秒时间戳为基础并保证严格递增
+// 并发签名（如 WithConcurrentBatches）时多个订单可能取到相同的时间戳，导致订单哈希重复
+func newSaltGenerator() func() int64 {
+	var last atomic.Int64
+	return func() int64 {
+		for {
+			prev := last.Load()
+			next := time.Now().UnixNano()
+			if next <= prev {
+				next = prev + 1
+			}
+			if last.CompareAndSwap(prev, next) {
+				return next
+			}
+		}
 	}
-	// Round up: add a small epsilon before truncating
-	epsilon := 0.5 / multiplier
-	return float64(int64((val+epsilon)*multiplier)) / multiplier
 }
@@ -1,21 +1,50 @@
 package clob
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"regexp"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	ordermodel "github.com/polymarket/go-order-utils/pkg/model"
+	ordersigner "github.com/polymarket/go-order-utils/pkg/signer"
 	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
 
+// GetOrdersOptions GetOrders 的可选参数
+type GetOrdersOptions struct {
+	Status *string
+}
+
+// GetOrdersOption 函数选项类型
+type GetOrdersOption func(*GetOrdersOptions)
+
+// WithOrdersStatus 按订单状态过滤（如 types.OrderStatusLive、types.OrderStatusMatched、types.OrderStatusDelayed）
+func WithOrdersStatus(status string) GetOrdersOption {
+	return func(opts *GetOrdersOptions) {
+		opts.Status = &status
+	}
+}
+
 // GetOrders 获取活跃订单
-func (c *orderClientImpl) GetOrders(orderID *types.Keccak256, conditionID *types.Keccak256, tokenID *string) ([]types.OpenOrder, error) {
+// 可通过 WithOrdersStatus 只返回指定状态的订单
+func (c *orderClientImpl) GetOrders(orderID *types.Keccak256, conditionID *types.Keccak256, tokenID *string, options ...GetOrdersOption) ([]types.OpenOrder, error) {
+	opts := &GetOrdersOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
 	// Validate API credentials
 	if c.deriveCreds == nil {
 		return nil, fmt.Errorf("API credentials not set")
@@ -35,35 +64,277 @@ func (c *orderClientImpl) GetOrders(orderID *types.Keccak256, conditionID *types
 	if tokenID != nil {
 		params["asset_id"] = *tokenID
 	}
+	if opts.Status != nil {
+		params["status"] = *opts.Status
+	}
+
+	var allOrders []types.OpenOrder
+	err := c.forEachOrdersPage(params, func(orders []types.OpenOrder) {
+		allOrders = append(allOrders, orders...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// API 可能忽略 status 参数，在本地再过滤一次
+	if opts.Status != nil {
+		filtered := make([]types.OpenOrder, 0, len(allOrders))
+		for _, order := range allOrders {
+			if strings.EqualFold(order.Status, *opts.Status) {
+				filtered = append(filtered, order)
+			}
+		}
+		allOrders = filtered
+	}
+
+	return allOrders, nil
+}
+
+// GetOrdersCount 返回活跃订单数量，conditionID 不为 nil 时只统计该市场的订单
+// API 没有计数接口，按游标逐页查询但只累加每页的数量，不在内存中保存完整的订单列表
+func (c *orderClientImpl) GetOrdersCount(conditionID *types.Keccak256) (int, error) {
+	if c.deriveCreds == nil {
+		return 0, fmt.Errorf("API credentials not set")
+	}
+	if c.deriveCreds.Key == "" || c.deriveCreds.Secret == "" || c.deriveCreds.Passphrase == "" {
+		return 0, fmt.Errorf("API credentials incomplete: key=%v, secret=%v, passphrase=%v",
+			c.deriveCreds.Key != "", c.deriveCreds.Secret != "", c.deriveCreds.Passphrase != "")
+	}
+
+	params := make(map[string]string)
+	if conditionID != nil {
+		params["market"] = string(*conditionID)
+	}
 
-	// Set up authentication headers (same as Python version - set once, reuse)
+	count := 0
+	err := c.forEachOrdersPage(params, func(orders []types.OpenOrder) {
+		count += len(orders)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// forEachOrdersPage 按游标逐页查询 /data/orders，每页调用一次 fn
+// 认证头只生成一次，所有分页请求复用（与 Python 版本一致）
+func (c *orderClientImpl) forEachOrdersPage(params map[string]string, fn func(orders []types.OpenOrder)) error {
 	requestArgs := &types.RequestArgs{
 		Method:      "GET",
 		RequestPath: internal.Orders,
 		Body:        nil, // GET request has no body
 	}
 
-	headers, err := internal.CreateLevel2Headers(c.web3Client.GetSigner(), c.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create headers: %w", err)
+		return fmt.Errorf("failed to create headers: %w", err)
 	}
 
-	var allOrders []types.OpenOrder
 	nextCursor := "MA=="
-
 	for nextCursor != internal.EndCursor {
 		params["next_cursor"] = nextCursor
 
-		response, err := http.Get[types.PaginatedResponse[types.OpenOrder]](c.baseClient.baseURL, internal.Orders, params, http.WithHeaders(headers))
+		rawBytes, err := http.GetRaw(c.baseClient.baseURL, "GET", internal.Orders, params, c.requestOptions(http.WithHeaders(headers))...)
+		if err != nil {
+			return fmt.Errorf("failed to get orders: %w", err)
+		}
+
+		orders, cursor, err := decodeOrdersPage(rawBytes)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get orders: %w", err)
+			return fmt.Errorf("failed to get orders: %w", err)
 		}
+		fn(orders)
+		nextCursor = cursor
+	}
+	return nil
+}
 
-		allOrders = append(allOrders, response.Data...)
-		nextCursor = response.NextCursor
+// decodeOrdersPage 解析 /data/orders 的一页响应，返回订单和下一页游标
+// 通常响应为分页对象 {"data": [...], "next_cursor": "..."}；按订单 ID 查询时 API 可能直接返回订单数组或单个订单对象，
+// 这两种情况以及缺少 next_cursor 的响应都视为最后一页
+func decodeOrdersPage(rawBytes []byte) ([]types.OpenOrder, string, error) {
+	trimmed := bytes.TrimSpace(rawBytes)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, internal.EndCursor, nil
 	}
 
-	return allOrders, nil
+	if trimmed[0] == '[' {
+		var orders []types.OpenOrder
+		if err := json.Unmarshal(trimmed, &orders); err != nil {
+			return nil, "", fmt.Errorf("failed to decode orders: %w", err)
+		}
+		return orders, internal.EndCursor, nil
+	}
+
+	var page struct {
+		Data       json.RawMessage `json:"data"`
+		NextCursor string          `json:"next_cursor"`
+		OrderID    string          `json:"id"`
+	}
+	if err := json.Unmarshal(trimmed, &page); err != nil {
+		return nil, "", fmt.Errorf("failed to decode orders: %w", err)
+	}
+
+	// 单个订单对象
+	if page.Data == nil && page.OrderID != "" {
+		var order types.OpenOrder
+		if err := json.Unmarshal(trimmed, &order); err != nil {
+			return nil, "", fmt.Errorf("failed to decode order: %w", err)
+		}
+		return []types.OpenOrder{order}, internal.EndCursor, nil
+	}
+
+	var orders []types.OpenOrder
+	if len(page.Data) > 0 && !bytes.Equal(page.Data, []byte("null")) {
+		if err := json.Unmarshal(page.Data, &orders); err != nil {
+			return nil, "", fmt.Errorf("failed to decode orders: %w", err)
+		}
+	}
+	nextCursor := page.NextCursor
+	if nextCursor == "" {
+		nextCursor = internal.EndCursor
+	}
+	return orders, nextCursor, nil
+}
+
+// GetOrder 获取单个订单的详情
+func (c *orderClientImpl) GetOrder(orderID types.Keccak256) (*types.OpenOrder, error) {
+	// Validate API credentials
+	if c.deriveCreds == nil {
+		return nil, fmt.Errorf("API credentials not set")
+	}
+	if c.deriveCreds.Key == "" || c.deriveCreds.Secret == "" || c.deriveCreds.Passphrase == "" {
+		return nil, fmt.Errorf("API credentials incomplete: key=%v, secret=%v, passphrase=%v",
+			c.deriveCreds.Key != "", c.deriveCreds.Secret != "", c.deriveCreds.Passphrase != "")
+	}
+
+	requestPath := internal.GetOrder + string(orderID)
+	requestArgs := &types.RequestArgs{
+		Method:      "GET",
+		RequestPath: requestPath,
+		Body:        nil,
+	}
+
+	headers, err := c.baseClient.level2Headers(requestArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+
+	order, err := http.Get[types.OpenOrder](c.baseClient.baseURL, requestPath, nil, c.requestOptions(http.WithHeaders(headers))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return order, nil
+}
+
+// GetTrade 根据交易ID获取单笔结算交易的详情（需要 L2 认证）
+// 交易不存在时返回包装了 types.ErrTradeNotFound 的错误
+func (c *orderClientImpl) GetTrade(tradeID string) (*types.ClobTrade, error) {
+	if tradeID == "" {
+		return nil, fmt.Errorf("trade ID is required")
+	}
+
+	// Validate API credentials
+	if c.deriveCreds == nil {
+		return nil, fmt.Errorf("API credentials not set")
+	}
+	if c.deriveCreds.Key == "" || c.deriveCreds.Secret == "" || c.deriveCreds.Passphrase == "" {
+		return nil, fmt.Errorf("API credentials incomplete: key=%v, secret=%v, passphrase=%v",
+			c.deriveCreds.Key != "", c.deriveCreds.Secret != "", c.deriveCreds.Passphrase != "")
+	}
+
+	requestArgs := &types.RequestArgs{
+		Method:      "GET",
+		RequestPath: internal.Trades,
+		Body:        nil,
+	}
+
+	headers, err := c.baseClient.level2Headers(requestArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+
+	params := map[string]string{"id": tradeID}
+	response, err := http.Get[types.PaginatedResponse[types.ClobTrade]](c.baseClient.baseURL, internal.Trades, params, c.requestOptions(http.WithHeaders(headers))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade: %w", err)
+	}
+	return findTrade(response.Data, tradeID)
+}
+
+// findTrade 在查询结果中查找指定ID的交易，未找到时返回 types.ErrTradeNotFound
+func findTrade(trades []types.ClobTrade, tradeID string) (*types.ClobTrade, error) {
+	for i := range trades {
+		if trades[i].TradeID == tradeID {
+			return &trades[i], nil
+		}
+	}
+	return nil, fmt.Errorf("trade %s: %w", tradeID, types.ErrTradeNotFound)
+}
+
+// WaitForOrderStatus 轮询订单直到达到目标状态或超时
+// 等价于 WaitForOrderStatusContext(context.Background(), ...)
+func (c *orderClientImpl) WaitForOrderStatus(orderID types.Keccak256, target string, timeout time.Duration) (*types.OpenOrder, error) {
+	return c.WaitForOrderStatusContext(context.Background(), orderID, target, timeout)
+}
+
+// WaitForOrderStatusContext 轮询订单直到达到目标状态、进入终态、超时或 ctx 被取消
+// 轮询间隔从 internal.OrderStatusPollInitialInterval 开始指数退避，最大为 internal.OrderStatusPollMaxInterval
+// 订单进入与目标不同的终态（如等待成交但订单已取消）时立即返回订单和错误
+// 超时或 ctx 取消时返回最后一次查询到的订单状态（可能为 nil）和错误
+func (c *orderClientImpl) WaitForOrderStatusContext(ctx context.Context, orderID types.Keccak256, target string, timeout time.Duration) (*types.OpenOrder, error) {
+	if target == "" {
+		return nil, fmt.Errorf("target status is required")
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var lastOrder *types.OpenOrder
+	var lastErr error
+	interval := internal.OrderStatusPollInitialInterval
+
+	for {
+		order, err := c.GetOrder(orderID)
+		if err != nil {
+			// 查询失败（网络抖动等）继续轮询，超时后返回最后的错误
+			lastErr = err
+		} else if order != nil && order.OrderID != "" {
+			lastOrder = order
+			lastErr = nil
+
+			if strings.EqualFold(order.Status, target) {
+				return order, nil
+			}
+			if isTerminalOrderStatus(order.Status) {
+				return order, fmt.Errorf("order %s reached terminal status %s before %s", orderID, order.Status, target)
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if lastErr != nil {
+				return lastOrder, fmt.Errorf("waiting for order %s status %s: %w (last error: %v)", orderID, target, ctx.Err(), lastErr)
+			}
+			return lastOrder, fmt.Errorf("waiting for order %s status %s: %w", orderID, target, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > internal.OrderStatusPollMaxInterval {
+			interval = internal.OrderStatusPollMaxInterval
+		}
+	}
+}
+
+// isTerminalOrderStatus 判断订单状态是否为终态（不会再变化）
+func isTerminalOrderStatus(status string) bool {
+	return strings.EqualFold(status, types.OrderStatusMatched) || strings.EqualFold(status, types.OrderStatusCanceled)
 }
 
 // CreateAndPostOrders 使用go-order-utils创建并提交多个订单
@@ -80,65 +351,197 @@ func (c *orderClientImpl) CreateAndPostOrders(
 		return []types.OrderPostResponse{}, nil
 	}
 
-	if len(orderArgsList) != len(orderTypes) {
-		return nil, fmt.Errorf("orderArgsList and orderTypes must have the same length")
+	if err := validateOrderBatch(orderArgsList, orderTypes); err != nil {
+		return nil, err
 	}
 
-	// 统一检查所有订单的 price 是否符合条件（使用 tickSize=0.001）
-	const defaultTickSize = 0.001
-	for i, orderArgs := range orderArgsList {
-		if orderArgs.Price < defaultTickSize || orderArgs.Price > 1.0-defaultTickSize {
-			return nil, fmt.Errorf("订单 %d 价格无效: price=%.3f 必须在范围 [%.3f, %.3f] 内",
-				i+1, orderArgs.Price, defaultTickSize, 1.0-defaultTickSize)
+	// 只读密钥无法下单，提前返回明确的错误而不是等待服务端拒绝
+	if err := c.baseClient.ensureCanTrade(); err != nil {
+		return nil, err
+	}
+	if c.baseClient.balancePrecheck {
+		usdc, tokens, err := c.requiredBalances(orderArgsList)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkBalances(usdc, tokens); err != nil {
+			return nil, err
 		}
 	}
 
 	const maxBatchSize = 15 // 每批最多15个订单
 
+	// 提交订单后余额可能已变化，使余额缓存失效
+	defer c.baseClient.balances.invalidate()
+
 	// 如果订单数量不超过15个，直接提交
 	if len(orderArgsList) <= maxBatchSize {
 		return c.postOrdersBatch(orderArgsList, orderTypes)
 	}
 
-	// 分批提交
-	allResults := make([]types.OrderPostResponse, 0, len(orderArgsList))
+	// 分批提交，默认逐批顺序提交，WithConcurrentBatches 设置后并发提交
 	totalBatches := (len(orderArgsList) + maxBatchSize - 1) / maxBatchSize
-	for i := 0; i < len(orderArgsList); i += maxBatchSize {
-		end := i + maxBatchSize
+	batchResults := make([][]types.OrderPostResponse, totalBatches)
+	runOrderBatches(totalBatches, c.baseClient.concurrentBatches, internal.OrderBatchJitter, func(batch int) {
+		start := batch * maxBatchSize
+		end := start + maxBatchSize
 		if end > len(orderArgsList) {
 			end = len(orderArgsList)
 		}
+		batchResults[batch] = c.postOrderBatch(orderArgsList[start:end], orderTypes[start:end], batch+1, totalBatches, start)
+	})
+
+	// 按输入顺序合并结果
+	allResults := make([]types.OrderPostResponse, 0, len(orderArgsList))
+	for _, results := range batchResults {
+		allResults = append(allResults, results...)
+	}
+	return allResults, nil
+}
+
+// postOrderBatch 提交一批订单，提交失败时为该批每个订单返回错误响应
+// batchNum 从 1 开始，offset 为该批第一个订单在输入中的索引（用于日志）
+func (c *orderClientImpl) postOrderBatch(
+	batchOrderArgs []types.OrderArgs,
+	batchOrderTypes []types.OrderType,
+	batchNum int,
+	totalBatches int,
+	offset int,
+) []types.OrderPostResponse {
+	first, last := offset+1, offset+len(batchOrderArgs)
+	internal.LogDebug("提交订单批次 %d/%d (订单 %d-%d，共 %d 个订单)", batchNum, totalBatches, first, last, len(batchOrderArgs))
+	batchStart := time.Now()
+
+	batchResults, err := c.postOrdersBatch(batchOrderArgs, batchOrderTypes)
+	batchDuration := time.Since(batchStart)
+	if err != nil {
+		// 如果某批失败，记录错误但继续处理下一批
+		internal.LogError("批次 %d/%d (订单 %d-%d) 提交失败 (耗时: %v): %v", batchNum, totalBatches, first, last, batchDuration, err)
+		// 整批无法签名时结果中已有每个订单的失败原因
+		if len(batchResults) == len(batchOrderArgs) {
+			return batchResults
+		}
+		// 为失败的批次创建错误响应
+		failed := make([]types.OrderPostResponse, len(batchOrderArgs))
+		for j := range failed {
+			failed[j] = types.OrderPostResponse{
+				ErrorMsg: fmt.Sprintf("批次提交失败: %v", err),
+				Source:   c.baseClient.orderSource,
+			}
+		}
+		return failed
+	}
 
-		batchNum := (i / maxBatchSize) + 1
-		batchOrderArgs := orderArgsList[i:end]
-		batchOrderTypes := orderTypes[i:end]
+	if batchDuration > 5*time.Second {
+		internal.LogWarn("批次 %d/%d 耗时过长: %v，可能发生阻塞", batchNum, totalBatches, batchDuration)
+	} else {
+		internal.LogDebug("批次 %d/%d 完成 (耗时: %v)", batchNum, totalBatches, batchDuration)
+	}
+	return batchResults
+}
 
-		internal.LogDebug("提交订单批次 %d/%d (订单 %d-%d，共 %d 个订单)", batchNum, totalBatches, i+1, end, len(batchOrderArgs))
-		batchStart := time.Now()
+// runOrderBatches 执行 total 个批次，concurrency 不大于 1 时按顺序执行
+// 并发执行时最多同时执行 concurrency 个批次，除第一批外每批开始前随机等待 [0, jitter)，避免请求集中触发限流
+func runOrderBatches(total int, concurrency int, jitter time.Duration, post func(batch int)) {
+	if concurrency <= 1 {
+		for batch := 0; batch < total; batch++ {
+			post(batch)
+		}
+		return
+	}
 
-		batchResults, err := c.postOrdersBatch(batchOrderArgs, batchOrderTypes)
-		batchDuration := time.Since(batchStart)
-		if err != nil {
-			// 如果某批失败，记录错误但继续处理下一批
-			internal.LogError("批次 %d/%d (订单 %d-%d) 提交失败 (耗时: %v): %v", batchNum, totalBatches, i+1, end, batchDuration, err)
-			// 为失败的批次创建错误响应
-			for j := 0; j < len(batchOrderArgs); j++ {
-				allResults = append(allResults, types.OrderPostResponse{
-					ErrorMsg: fmt.Sprintf("批次提交失败: %v", err),
-				})
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for batch := 0; batch < total; batch++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(batch int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if batch > 0 && jitter > 0 {
+				time.Sleep(rand.N(jitter))
 			}
-			continue
+			post(batch)
+		}(batch)
+	}
+	wg.Wait()
+}
+
+// validateOrderBatch 检查批量下单参数：orderTypes 与订单一一对应，价格在有效范围内
+func validateOrderBatch(orderArgsList []types.OrderArgs, orderTypes []types.OrderType) error {
+	if len(orderArgsList) != len(orderTypes) {
+		return fmt.Errorf("orderArgsList and orderTypes must have the same length")
+	}
+	return validateOrderPrices(orderArgsList)
+}
+
+// validateOrderPrices 按默认 tickSize 检查所有订单的参数（价格、数量、方向和过期时间），见 OrderArgs.Validate
+func validateOrderPrices(orderArgsList []types.OrderArgs) error {
+	for i, orderArgs := range orderArgsList {
+		if err := orderArgs.Validate(types.TickSize(strconv.FormatFloat(internal.DefaultTickSize, 'f', -1, 64))); err != nil {
+			return fmt.Errorf("订单 %d 参数无效: %w", i+1, err)
 		}
+	}
+	return nil
+}
 
-		allResults = append(allResults, batchResults...)
-		if batchDuration > 5*time.Second {
-			internal.LogWarn("批次 %d/%d 耗时过长: %v，可能发生阻塞", batchNum, totalBatches, batchDuration)
-		} else {
-			internal.LogDebug("批次 %d/%d 完成 (耗时: %v)", batchNum, totalBatches, batchDuration)
+// ReplaceOrders 撤销 cancelIDs 中的旧订单后立即提交新订单，用于做市商调整报价
+// 新订单参数在撤单前校验，避免撤单后因参数错误无法挂出新单；
+// 撤单请求失败时不提交新订单（避免新旧订单同时挂出），返回已知的撤单结果和错误；
+// 部分订单撤销失败（NotCanceled 非空）时仍会提交新订单，由调用方根据结果处理
+func (c *orderClientImpl) ReplaceOrders(
+	cancelIDs []types.Keccak256,
+	newOrders []types.OrderArgs,
+	orderTypes []types.OrderType,
+) (*types.ReplaceResult, error) {
+	return replaceOrders(cancelIDs, newOrders, orderTypes, func(ids []types.Keccak256) (*types.OrderCancelResponse, error) {
+		return c.CancelOrders(ids)
+	}, c.CreateAndPostOrders)
+}
+
+// replaceOrders ReplaceOrders 的流程实现，撤单和下单通过参数传入便于测试
+func replaceOrders(
+	cancelIDs []types.Keccak256,
+	newOrders []types.OrderArgs,
+	orderTypes []types.OrderType,
+	cancel func([]types.Keccak256) (*types.OrderCancelResponse, error),
+	post func([]types.OrderArgs, []types.OrderType) ([]types.OrderPostResponse, error),
+) (*types.ReplaceResult, error) {
+	if err := validateOrderBatch(newOrders, orderTypes); err != nil {
+		return nil, err
+	}
+
+	result := &types.ReplaceResult{
+		Canceled:    []types.Keccak256{},
+		NotCanceled: make(map[types.Keccak256]string),
+		Posted:      []types.OrderPostResponse{},
+	}
+
+	if len(cancelIDs) > 0 {
+		cancelResp, err := cancel(cancelIDs)
+		if cancelResp != nil {
+			result.Canceled = append(result.Canceled, cancelResp.Canceled...)
+			for id, reason := range cancelResp.NotCanceled {
+				result.NotCanceled[id] = reason
+			}
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to cancel orders, new orders not posted: %w", err)
 		}
 	}
 
-	return allResults, nil
+	if len(newOrders) == 0 {
+		return result, nil
+	}
+	posted, err := post(newOrders, orderTypes)
+	if posted != nil {
+		result.Posted = posted
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to post new orders: %w", err)
+	}
+	return result, nil
 }
 
 // postOrdersBatch 提交一批订单（内部方法，最多15个订单）
@@ -147,6 +550,8 @@ func (c *orderClientImpl) CreateAndPostOrders(
 //   - negRisk 默认使用 false，如果是重试调用则使用 true
 //
 // isRetry: 是否为重试调用，如果是则使用 negRisk=true，且不再进行重试（避免无限递归）
+// 返回结果与输入订单按索引一一对应，本地签名失败的订单不会提交，对应位置填充 ErrorMsg；
+// 所有订单都无法签名时同时返回结果和错误
 func (c *orderClientImpl) postOrdersBatch(
 	orderArgsList []types.OrderArgs,
 	orderTypes []types.OrderType,
@@ -163,169 +568,45 @@ func (c *orderClientImpl) postOrdersBatch(
 	}
 
 	// 统一使用默认值
-	const defaultTickSize = "0.001"
 	defaultNegRisk := false
 	if isRetryCall {
 		defaultNegRisk = true
 	}
 
-	// Build request body: array of {order: {...}, owner: api_key, orderType: "GTC"}
-	// IMPORTANT: Field order must match Python: order, owner, orderType
-	// Python's order_to_json returns: {"order": order.dict(), "owner": owner, "orderType": order_type.value}
-
-	// Define OrderedOrder struct to preserve field order (matching Python's order.dict())
-	// IMPORTANT: According to Polymarket API docs:
-	//   - salt: integer (not string)
-	//   - signatureType: integer (not string)
-	//   - All other numeric fields are strings
-	type OrderedOrder struct {
-		Salt          int64  `json:"salt"` // integer per API docs
-		TokenId       string `json:"tokenId"`
-		MakerAmount   string `json:"makerAmount"`
-		TakerAmount   string `json:"takerAmount"`
-		Side          string `json:"side"`
-		Expiration    string `json:"expiration"`
-		Nonce         string `json:"nonce"`
-		FeeRateBps    string `json:"feeRateBps"`
-		SignatureType int    `json:"signatureType"` // integer per API docs
-		Maker         string `json:"maker"`
-		Taker         string `json:"taker"`
-		Signer        string `json:"signer"`
-		Signature     string `json:"signature"`
-	}
-
-	type OrderRequest struct {
-		Order     OrderedOrder `json:"order"`     // Use struct to preserve field order
-		Owner     string       `json:"owner"`     // Second field
-		OrderType string       `json:"orderType"` // Third field
-	}
-
 	// 所有token统一使用默认值
 	internal.LogDebug("所有token使用默认值: TickSize=0.001, NegRisk=%v (不请求API)", defaultNegRisk)
 
-	// Use append instead of fixed-size slice to avoid empty orders
-	requestBody := make([]OrderRequest, 0, len(orderArgsList))
-
-	for i, orderArgs := range orderArgsList {
-		// 如果订单share小于5，则设置为5
-		if orderArgs.Size < 5.0 {
-			orderArgs.Size = 5.0
-		}
-
-		// 统一使用默认值
-		tickSize := types.TickSize(defaultTickSize)
-		negRisk := defaultNegRisk
-
-		// 记录使用的tickSize和negRisk值（用于调试签名问题）
-		// 注意：不记录完整的订单参数，避免泄露敏感信息
-		internal.LogDebug("订单签名参数: token=%s, tickSize=%s, negRisk=%v",
-			orderArgs.TokenID, tickSize, negRisk)
-
-		// Get fee rate (default to 0 if not specified)
-		feeRateBps := 0
-		if orderArgs.FeeRateBps != nil {
-			feeRateBps = *orderArgs.FeeRateBps
-		}
-
-		// Create signed order using order builder
-		signedOrder, err := c.createSignedOrder(orderArgs, tickSize, negRisk, feeRateBps, orderTypes[i])
-		if err != nil {
-			// Skip this order (can't create empty OrderedOrder, so we'll skip it)
-			// We'll handle this by reducing the slice size later
-			continue
-		}
-
-		// Convert SignedOrder to OrderedOrder struct (matching Python's order.dict() field order)
-		// Python: order_to_json returns {"order": order.dict(), "owner": owner, "orderType": order_type.value}
-		// Field order: salt, tokenId, makerAmount, takerAmount, side, expiration, nonce, feeRateBps, signatureType, maker, taker, signer, signature
-		// IMPORTANT: Per Polymarket API docs, salt and signatureType must be integers, not strings
-		orderedOrder := OrderedOrder{
-			Salt:          signedOrder.Order.Salt.Int64(), // integer per API docs
-			TokenId:       signedOrder.Order.TokenId.String(),
-			MakerAmount:   signedOrder.Order.MakerAmount.String(),
-			TakerAmount:   signedOrder.Order.TakerAmount.String(),
-			Side:          string(orderArgs.Side),
-			Expiration:    signedOrder.Order.Expiration.String(),
-			Nonce:         signedOrder.Order.Nonce.String(),
-			FeeRateBps:    signedOrder.Order.FeeRateBps.String(),
-			SignatureType: int(signedOrder.Order.SignatureType.Int64()), // integer per API docs
-			Maker:         signedOrder.Order.Maker.Hex(),
-			Taker:         signedOrder.Order.Taker.Hex(),
-			Signer:        signedOrder.Order.Signer.Hex(),
-			Signature:     "0x" + fmt.Sprintf("%x", signedOrder.Signature),
-		}
-
-		requestBody = append(requestBody, OrderRequest{
-			Order:     orderedOrder,
-			Owner:     c.baseClient.deriveCreds.Key,
-			OrderType: string(orderTypes[i]),
-		})
-	}
-
+	// results 与输入订单一一对应，本地签名失败的订单直接填充 ErrorMsg
+	requestBody, postedIndices, results := c.buildOrderRequests(orderArgsList, orderTypes, defaultNegRisk)
 	if len(requestBody) == 0 {
-		return []types.OrderPostResponse{}, fmt.Errorf("no valid orders to post")
-	}
-
-	// Marshal body to JSON for logging and actual request
-	// IMPORTANT: Python uses json.dumps(body) which adds spaces after colons and commas
-	// We need to match this format exactly for Cloudflare validation
-	bodyJSON, err := json.Marshal(requestBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
-	}
-
-	// Convert compact JSON to Python's json.dumps format (with spaces)
-	// Python: json.dumps produces {"key": "value", "key2": "value2"}
-	// Go: json.Marshal produces {"key":"value","key2":"value2"}
-	// We need to add spaces to match Python format (same as HMAC signature)
-	bodyJSONStr := string(bodyJSON)
-	// Add space after colon: "key":"value" -> "key": "value"
-	bodyJSONStr = regexp.MustCompile(`":(\S)`).ReplaceAllString(bodyJSONStr, `": $1`)
-	// Add space after comma: "a","b" -> "a", "b"
-	// Also handle comma followed by { or [ (for nested structures)
-	bodyJSONStr = regexp.MustCompile(`,(")`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSONStr = regexp.MustCompile(`,(\{|\[)`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSON = []byte(bodyJSONStr)
-
-	// Create request args for signing
-	// Python: body = [order_to_json(...) for ...] (list of dicts)
-	// Then: create_level_2_headers(..., body=body) - body is list, not JSON string
-	requestArgs := &types.RequestArgs{
-		Method:      "POST",
-		RequestPath: internal.PostOrders,
-		Body:        nil, // Not used when passing body directly
-	}
-
-	// Create Level 2 headers (HMAC signature)
-	// Pass requestBody directly (struct/slice) to match Python behavior
-	headers, err := internal.CreateLevel2HeadersWithBody(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, requestBody, false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create headers: %w", err)
+		// 没有任何订单可以提交时返回错误，results 中保留每个订单的失败原因
+		return tagOrderSource(results, c.baseClient.orderSource),
+			fmt.Errorf("no order in batch could be signed (%d orders), first error: %s", len(results), results[0].ErrorMsg)
 	}
 
-	// Make POST request using PostRaw to send pre-formatted JSON (with spaces matching Python's json.dumps)
-	responseBody, err := http.PostRaw(c.baseClient.baseURL, internal.PostOrders, bodyJSON, http.WithHeaders(headers))
+	// 签名和发送使用同一份 Python 格式的 JSON 字节
+	responseBody, err := c.postSignedJSON(internal.PostOrders, requestBody)
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse response
-	var resp []types.OrderPostResponse
+	var posted []types.OrderPostResponse
 	if len(responseBody) > 0 {
-		if err := json.Unmarshal(responseBody, &resp); err != nil {
+		if err := json.Unmarshal(responseBody, &posted); err != nil {
 			return nil, fmt.Errorf("failed to parse response: %w", err)
 		}
 	}
 
-	if len(resp) == 0 {
-		return []types.OrderPostResponse{}, nil
-	}
+	// 将 API 响应按输入索引放回结果中
+	resp := alignOrderResponses(results, postedIndices, posted)
 
 	// 检查失败的订单，特别是invalid signature错误
 	// 对于这些订单，使用negRisk=true重试
 	failedOrders := make([]int, 0) // 存储失败订单的索引
 	orderbookNotExistCount := 0    // 统计订单簿不存在的错误（token进入结算过期，正常情况）
-	for i, result := range resp {
+	for _, i := range postedIndices {
+		result := resp[i]
 		if result.ErrorMsg != "" {
 			// 如果是签名错误，尝试使用negRisk=true重试（正常业务流程，不记录日志）
 			if strings.Contains(result.ErrorMsg, "invalid signature") {
@@ -391,81 +672,318 @@ func (c *orderClientImpl) postOrdersBatch(
 		}
 	}
 
-	return resp, nil
+	return tagOrderSource(resp, c.baseClient.orderSource), nil
 }
 
-// createSignedOrder creates a signed order using go-order-utils
-func (c *orderClientImpl) createSignedOrder(
-	orderArgs types.OrderArgs,
-	tickSize types.TickSize,
-	negRisk bool,
-	feeRateBps int,
-	orderType types.OrderType,
-) (*ordermodel.SignedOrder, error) {
-	// Get private key from signer
-
-	// Parse tick size
-	tickSizeFloat, err := strconv.ParseFloat(string(tickSize), 64)
-	if err != nil {
-		return nil, fmt.Errorf("invalid tick size: %w", err)
+// tagOrderSource 为提交结果设置来源标签，source 为空时不做修改
+func tagOrderSource(results []types.OrderPostResponse, source string) []types.OrderPostResponse {
+	if source == "" {
+		return results
 	}
-
-	// Validate price range: tick_size <= price <= 1 - tick_size (per Python price_valid function)
-	if orderArgs.Price < tickSizeFloat || orderArgs.Price > 1.0-tickSizeFloat {
-		return nil, fmt.Errorf("price (%.6f) must be in range [%.6f, %.6f], min: %s - max: %.6f",
-			orderArgs.Price, tickSizeFloat, 1.0-tickSizeFloat, tickSize, 1.0-tickSizeFloat)
+	for i := range results {
+		results[i].Source = source
 	}
+	return results
+}
 
-	// Calculate maker and taker amounts based on side
-	makerAmount, takerAmount, err := c.calculateOrderAmounts(orderArgs.Side, orderArgs.Size, orderArgs.Price, tickSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to calculate order amounts: %w", err)
-	}
+// orderedOrder 提交订单时的订单结构，字段顺序与 Python 的 order.dict() 一致
+// IMPORTANT: According to Polymarket API docs:
+//   - salt: integer (not string)
+//   - signatureType: integer (not string)
+//   - All other numeric fields are strings
+type orderedOrder struct {
+	Salt          int64  `json:"salt"` // integer per API docs
+	TokenId       string `json:"tokenId"`
+	MakerAmount   string `json:"makerAmount"`
+	TakerAmount   string `json:"takerAmount"`
+	Side          string `json:"side"`
+	Expiration    string `json:"expiration"`
+	Nonce         string `json:"nonce"`
+	FeeRateBps    string `json:"feeRateBps"`
+	SignatureType int    `json:"signatureType"` // integer per API docs
+	Maker         string `json:"maker"`
+	Taker         string `json:"taker"`
+	Signer        string `json:"signer"`
+	Signature     string `json:"signature"`
+}
 
-	// Determine verifying contract
-	var verifyingContract ordermodel.VerifyingContract
-	if negRisk {
-		verifyingContract = ordermodel.NegRiskCTFExchange
-	} else {
-		verifyingContract = ordermodel.CTFExchange
-	}
+// orderRequest 批量提交订单的请求体元素
+// IMPORTANT: Field order must match Python: order, owner, orderType
+// Python's order_to_json returns: {"order": order.dict(), "owner": owner, "orderType": order_type.value}
+type orderRequest struct {
+	Order     orderedOrder `json:"order"`     // Use struct to preserve field order
+	Owner     string       `json:"owner"`     // Second field
+	OrderType string       `json:"orderType"` // Third field
+}
+
+// buildOrderRequests 为一批订单签名并构建请求体
+// 返回的 results 与输入订单一一对应，本地签名失败的订单已填充 ErrorMsg
+// postedIndices[j] 为 requestBody[j] 对应的输入订单索引
+func (c *orderClientImpl) buildOrderRequests(
+	orderArgsList []types.OrderArgs,
+	orderTypes []types.OrderType,
+	negRisk bool,
+) ([]orderRequest, []int, []types.OrderPostResponse) {
+	const defaultTickSize = "0.001"
+
+	requestBody := make([]orderRequest, 0, len(orderArgsList))
+	postedIndices := make([]int, 0, len(orderArgsList))
+	results := make([]types.OrderPostResponse, len(orderArgsList))
+
+	for i, orderArgs := range orderArgsList {
+		orderArgs, err := applyMinOrderSize(orderArgs, c.baseClient.marketMinOrderSize(orderArgs), c.baseClient.minSizePolicy)
+		if err != nil {
+			results[i] = types.OrderPostResponse{ErrorMsg: err.Error()}
+			continue
+		}
+
+		// 统一使用默认值
+		tickSize := types.TickSize(defaultTickSize)
+
+		// 记录使用的tickSize和negRisk值（用于调试签名问题）
+		// 注意：不记录完整的订单参数，避免泄露敏感信息
+		internal.LogDebug("订单签名参数: token=%s, tickSize=%s, negRisk=%v, source=%s",
+			orderArgs.TokenID, tickSize, negRisk, c.baseClient.orderSource)
+
+		// Get fee rate (default to 0 if not specified)
+		feeRateBps := 0
+		if orderArgs.FeeRateBps != nil {
+			feeRateBps = *orderArgs.FeeRateBps
+		}
+
+		// Create signed order using order builder
+		signedOrder, err := c.createSignedOrder(orderArgs, tickSize, negRisk, feeRateBps, orderTypes[i])
+		if err != nil {
+			// 本地无法签名的订单不提交，在对应位置返回错误信息
+			results[i] = types.OrderPostResponse{
+				ErrorMsg: fmt.Sprintf("failed to create signed order: %v", err),
+			}
+			continue
+		}
+
+		// Convert SignedOrder to orderedOrder struct (matching Python's order.dict() field order)
+		order, err := newOrderedOrder(signedOrder)
+		if err != nil {
+			results[i] = types.OrderPostResponse{
+				ErrorMsg: fmt.Sprintf("failed to create signed order: %v", err),
+			}
+			continue
+		}
+		requestBody = append(requestBody, orderRequest{
+			Order:     order,
+			Owner:     c.baseClient.deriveCreds.Key,
+			OrderType: string(orderTypes[i]),
+		})
+		postedIndices = append(postedIndices, i)
+	}
+
+	return requestBody, postedIndices, results
+}
+
+// marketMinOrderSize 返回订单所在市场的最小下单数量
+// 依次取 OrderArgs.MinSize、已缓存的代币元数据（GetTokenMeta）中的 MinOrderSize，都没有时为 internal.DefaultMarketMinOrderSize；
+// 只读取缓存，不为下单额外请求 API
+func (c *baseClient) marketMinOrderSize(orderArgs types.OrderArgs) float64 {
+	if orderArgs.MinSize != nil {
+		return *orderArgs.MinSize
+	}
+	if c.tokenMeta != nil {
+		if meta, ok := c.tokenMeta.cached(orderArgs.TokenID); ok && meta.MinOrderSize > 0 {
+			return meta.MinOrderSize
+		}
+	}
+	return internal.DefaultMarketMinOrderSize
+}
+
+// applyMinOrderSize 按市场最小下单数量 minSize 处理订单数量
+// policy 为 MinOrderSizeReject 时返回 ErrOrderBelowMinSize，否则将数量提高到最小值
+func applyMinOrderSize(orderArgs types.OrderArgs, minSize float64, policy types.MinOrderSizePolicy) (types.OrderArgs, error) {
+	if orderArgs.Size >= minSize {
+		return orderArgs, nil
+	}
+	if policy == types.MinOrderSizeReject {
+		return orderArgs, fmt.Errorf("%w: size %v < %v", types.ErrOrderBelowMinSize, orderArgs.Size, minSize)
+	}
+	internal.LogDebug("订单数量 %v 低于最小下单数量 %v，已调整为 %v", orderArgs.Size, minSize, minSize)
+	orderArgs.Size = minSize
+	return orderArgs, nil
+}
+
+// alignOrderResponses 将 API 返回的响应按输入索引放回 results
+// API 返回的响应数量少于提交数量时，缺失的订单填充 ErrorMsg
+func alignOrderResponses(results []types.OrderPostResponse, postedIndices []int, posted []types.OrderPostResponse) []types.OrderPostResponse {
+	for j, idx := range postedIndices {
+		if j < len(posted) {
+			results[idx] = posted[j]
+		} else {
+			results[idx] = types.OrderPostResponse{ErrorMsg: "no response returned for order"}
+		}
+	}
+	return results
+}
+
+// postSignedJSON 以 Python json.dumps 格式序列化 body，生成 L2 签名后 POST 到 requestPath
+// IMPORTANT: Python uses json.dumps(body) which adds spaces after colons and commas
+// We need to match this format exactly for Cloudflare validation
+// 签名和发送使用同一份字节，保证 HMAC 签名与请求体逐字节一致
+func (c *orderClientImpl) postSignedJSON(requestPath string, body interface{}) ([]byte, error) {
+	bodyJSON, err := internal.MarshalPythonJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	requestBodyForSigning := types.RequestBody(bodyJSON)
+	requestArgs := &types.RequestArgs{
+		Method:      "POST",
+		RequestPath: requestPath,
+		Body:        &requestBodyForSigning,
+	}
+
+	// Create Level 2 headers (HMAC signature)
+	headers, err := c.baseClient.level2Headers(requestArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create headers: %w", err)
+	}
+
+	c.baseClient.assertSignedBody(requestArgs, headers, bodyJSON)
+
+	// Make POST request using PostRaw to send pre-formatted JSON (with spaces matching Python's json.dumps)
+	return http.PostRaw(c.baseClient.baseURL, requestPath, bodyJSON, c.requestOptions(http.WithHeaders(headers))...)
+}
+
+// newOrderedOrder 将签名订单转换为请求体中的订单结构
+// Field order: salt, tokenId, makerAmount, takerAmount, side, expiration, nonce, feeRateBps, signatureType, maker, taker, signer, signature
+func newOrderedOrder(signedOrder *ordermodel.SignedOrder) (orderedOrder, error) {
+	if signedOrder == nil {
+		return orderedOrder{}, fmt.Errorf("signed order is nil")
+	}
+	order := signedOrder.Order
+	if order.Salt == nil || order.TokenId == nil || order.MakerAmount == nil || order.TakerAmount == nil ||
+		order.Side == nil || order.Expiration == nil || order.Nonce == nil || order.FeeRateBps == nil || order.SignatureType == nil {
+		return orderedOrder{}, fmt.Errorf("signed order has missing fields")
+	}
+	if len(signedOrder.Signature) == 0 {
+		return orderedOrder{}, fmt.Errorf("signed order has no signature")
+	}
+
+	var side string
+	switch int(order.Side.Int64()) {
+	case ordermodel.BUY:
+		side = string(types.OrderSideBUY)
+	case ordermodel.SELL:
+		side = string(types.OrderSideSELL)
+	default:
+		return orderedOrder{}, fmt.Errorf("invalid order side: %s", order.Side)
+	}
+
+	return orderedOrder{
+		Salt:          order.Salt.Int64(), // integer per API docs
+		TokenId:       order.TokenId.String(),
+		MakerAmount:   order.MakerAmount.String(),
+		TakerAmount:   order.TakerAmount.String(),
+		Side:          side,
+		Expiration:    order.Expiration.String(),
+		Nonce:         order.Nonce.String(),
+		FeeRateBps:    order.FeeRateBps.String(),
+		SignatureType: int(order.SignatureType.Int64()), // integer per API docs
+		Maker:         order.Maker.Hex(),
+		Taker:         order.Taker.Hex(),
+		Signer:        order.Signer.Hex(),
+		Signature:     "0x" + fmt.Sprintf("%x", signedOrder.Signature),
+	}, nil
+}
+
+// createSignedOrder creates a signed order using go-order-utils
+func (c *orderClientImpl) createSignedOrder(
+	orderArgs types.OrderArgs,
+	tickSize types.TickSize,
+	negRisk bool,
+	feeRateBps int,
+	orderType types.OrderType,
+) (*ordermodel.SignedOrder, error) {
+	// Get private key from signer
+
+	// Validate price range (tick_size <= price <= 1 - tick_size, per Python price_valid function), size, side and expiration
+	if err := orderArgs.Validate(tickSize); err != nil {
+		return nil, err
+	}
+
+	// Calculate maker and taker amounts based on side
+	makerAmount, takerAmount, err := c.calculateOrderAmounts(orderArgs.Side, orderArgs.Size, orderArgs.Price, tickSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate order amounts: %w", err)
+	}
+
+	return c.signOrderAmounts(orderArgs.TokenID, orderArgs.Side, makerAmount, takerAmount, orderArgs.Nonce, orderArgs.Expiration, negRisk, feeRateBps, orderType)
+}
+
+// signOrderAmounts 按给定的 maker/taker 数量构建订单并签名，不再做任何换算或舍入
+// createSignedOrder（价格/数量换算后）和 createSignedRawOrder（调用方指定数量）共用
+func (c *orderClientImpl) signOrderAmounts(
+	tokenID string,
+	orderSide types.OrderSide,
+	makerAmount *big.Int,
+	takerAmount *big.Int,
+	orderNonce *int64,
+	expiration int64,
+	negRisk bool,
+	feeRateBps int,
+	orderType types.OrderType,
+) (*ordermodel.SignedOrder, error) {
+	// Determine verifying contract
+	// neg-risk 市场与普通市场的数量计算、舍入规则和最小数量相同（舍入只与 tickSize 有关），
+	// 唯一区别是签名使用 NegRiskCTFExchange 作为 EIP712 验证合约
+	var verifyingContract ordermodel.VerifyingContract
+	if negRisk {
+		verifyingContract = ordermodel.NegRiskCTFExchange
+	} else {
+		verifyingContract = ordermodel.CTFExchange
+	}
 
 	// Get nonce
-	// For proxy wallets, nonce should typically be 0 (per Python default)
-	// Nonce is used for onchain cancellations, and 0 is the default value
-	// Using 0 matches Python's OrderArgs.nonce default value
-	nonce := "0"
+	// Nonce is used for onchain cancellations: the exchange only accepts orders whose nonce equals the maker's
+	// current nonce, and incrementNonce (GaslessClient.CancelAllOnchain) invalidates every order signed with the old one.
+	// Defaults to the maker's current exchange nonce (cached by web3.Client.OrderNonce, refreshed after CancelAllOnchain);
+	// an order signed with a stale nonce would be rejected, so a failed nonce read is an error (pass Nonce explicitly to skip it)
+	var nonceValue int64
+	if orderNonce != nil {
+		nonceValue = *orderNonce
+	} else {
+		current, err := c.baseClient.web3Client.OrderNonce(negRisk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get exchange nonce: %w", err)
+		}
+		nonceValue = current
+	}
+	nonce := strconv.FormatInt(nonceValue, 10)
 
 	// Get expiration based on order type
 	// GTC: expiration = "0" (per API requirement: "it should be equal to '0' as the order is not a GTD order")
 	// FOK/FAK/IOC: also use "0" (they are immediate execution orders)
+	// GTD: use orderArgs.Expiration (Unix seconds, see ExpirationFromNow)
 	var expirationStr string
-	if orderType == types.OrderTypeGTC {
-		expirationStr = "0"
-	} else {
-		// For FOK/FAK/IOC, also use "0" (they are immediate execution orders)
+	switch orderType {
+	case types.OrderTypeGTD:
+		if expiration <= 0 {
+			return nil, fmt.Errorf("GTD order requires a positive expiration")
+		}
+		expirationStr = strconv.FormatInt(expiration, 10)
+	default:
 		expirationStr = "0"
 	}
 
 	// Determine side
 	var side ordermodel.Side
-	if orderArgs.Side == types.OrderSideBUY {
+	if orderSide == types.OrderSideBUY {
 		side = ordermodel.BUY
 	} else {
 		side = ordermodel.SELL
 	}
 
 	// Determine signature type
-	var sigType ordermodel.SignatureType
-	switch c.baseClient.signatureType {
-	case 0:
-		sigType = ordermodel.EOA
-	case 1:
-		sigType = ordermodel.POLY_PROXY
-	case 2:
-		sigType = ordermodel.POLY_GNOSIS_SAFE
-	default:
-		sigType = ordermodel.EOA
+	sigType, err := orderSignatureType(c.baseClient.signatureType)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create OrderData (Maker and Taker are strings, not Address)
@@ -491,7 +1009,7 @@ func (c *orderClientImpl) createSignedOrder(
 	orderData := &ordermodel.OrderData{
 		Maker:         makerAddr,
 		Taker:         "0x0000000000000000000000000000000000000000", // Zero address for public orders
-		TokenId:       orderArgs.TokenID,
+		TokenId:       tokenID,
 		MakerAmount:   makerAmount.String(),
 		TakerAmount:   takerAmount.String(),
 		Side:          side,
@@ -511,6 +1029,92 @@ func (c *orderClientImpl) createSignedOrder(
 	return signedOrder, nil
 }
 
+// createSignedRawOrder 按调用方指定的 maker/taker 数量签名订单，跳过 calculateOrderAmounts 的换算和舍入
+func (c *orderClientImpl) createSignedRawOrder(
+	orderArgs types.OrderArgsRaw,
+	tickSize types.TickSize,
+	negRisk bool,
+	orderType types.OrderType,
+) (*ordermodel.SignedOrder, error) {
+	if err := orderArgs.Validate(tickSize); err != nil {
+		return nil, err
+	}
+	feeRateBps := 0
+	if orderArgs.FeeRateBps != nil {
+		feeRateBps = *orderArgs.FeeRateBps
+	}
+	return c.signOrderAmounts(orderArgs.TokenID, orderArgs.Side, orderArgs.MakerAmount, orderArgs.TakerAmount,
+		orderArgs.Nonce, orderArgs.Expiration, negRisk, feeRateBps, orderType)
+}
+
+// orderSignatureType 将客户端签名类型转换为订单中的签名类型
+func orderSignatureType(signatureType types.SignatureType) (ordermodel.SignatureType, error) {
+	switch signatureType {
+	case types.EOASignatureType:
+		return ordermodel.EOA, nil
+	case types.ProxySignatureType:
+		return ordermodel.POLY_PROXY, nil
+	case types.SafeSignatureType:
+		return ordermodel.POLY_GNOSIS_SAFE, nil
+	default:
+		return 0, fmt.Errorf("invalid signature type: %s", signatureType)
+	}
+}
+
+// VerifyOrderSignature 在本地校验已签名订单的 EIP712 签名，不发送请求
+// 从订单哈希和签名中恢复签名地址，并检查签名地址、maker 和签名类型与客户端配置一致（maker 在代理钱包下为代理地址）
+// 订单中不包含验证合约，代币的负风险状态已缓存时只按对应的合约校验，否则按 CTFExchange 或 NegRiskCTFExchange 任一通过即可
+// 签名与客户端配置不符时返回 false；订单字段缺失或签名格式错误时返回错误
+func (c *orderClientImpl) VerifyOrderSignature(signed *ordermodel.SignedOrder) (bool, error) {
+	if signed == nil {
+		return false, fmt.Errorf("signed order is nil")
+	}
+	if _, err := newOrderedOrder(signed); err != nil {
+		return false, fmt.Errorf("invalid signed order: %w", err)
+	}
+
+	sigType, err := orderSignatureType(c.baseClient.signatureType)
+	if err != nil {
+		return false, err
+	}
+	signerAddr := common.HexToAddress(string(c.baseClient.web3Client.GetBaseAddress()))
+	makerAddr := signerAddr
+	if c.baseClient.signatureType == types.ProxySignatureType || c.baseClient.signatureType == types.SafeSignatureType {
+		makerAddr = common.HexToAddress(string(c.baseClient.proxyAddress))
+	}
+	if signed.Signer != signerAddr || signed.Maker != makerAddr || signed.SignatureType.Int64() != int64(sigType) {
+		internal.LogDebug("订单签名参数与客户端配置不符: signer=%s maker=%s signatureType=%s",
+			signed.Signer.Hex(), signed.Maker.Hex(), signed.SignatureType)
+		return false, nil
+	}
+
+	contracts := []ordermodel.VerifyingContract{ordermodel.CTFExchange, ordermodel.NegRiskCTFExchange}
+	if c.baseClient.negRisk != nil {
+		if negRisk, ok := c.baseClient.negRisk.lookup(signed.TokenId.String()); ok {
+			if negRisk {
+				contracts = []ordermodel.VerifyingContract{ordermodel.NegRiskCTFExchange}
+			} else {
+				contracts = []ordermodel.VerifyingContract{ordermodel.CTFExchange}
+			}
+		}
+	}
+
+	for _, contract := range contracts {
+		orderHash, err := c.baseClient.orderBuilder.BuildOrderHash(&signed.Order, contract)
+		if err != nil {
+			return false, fmt.Errorf("failed to build order hash: %w", err)
+		}
+		valid, err := ordersigner.ValidateSignature(signerAddr, orderHash, signed.Signature)
+		if err != nil {
+			return false, fmt.Errorf("invalid signature: %w", err)
+		}
+		if valid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // PostOrder 提交单个订单
 func (c *orderClientImpl) PostOrder(orderArgs types.OrderArgs, orderType types.OrderType) (*types.OrderPostResponse, error) {
 	results, err := c.CreateAndPostOrders([]types.OrderArgs{orderArgs}, []types.OrderType{orderType})
@@ -520,12 +1124,392 @@ func (c *orderClientImpl) PostOrder(orderArgs types.OrderArgs, orderType types.O
 	if len(results) == 0 {
 		return nil, fmt.Errorf("no response from server")
 	}
-	return &results[0], nil
+
+	// 根据响应中的 making/taking 数量计算成交结果
+	result := &results[0]
+	if result.ErrorMsg == "" {
+		fill := result.ToFillResult(orderArgs.Side, orderArgs.Size)
+		result.Fill = &fill
+	}
+	return result, nil
+}
+
+// PostSignedOrder 提交调用方已签名的订单（例如 HSM 或离线签名），不在本地重新签名
+// owner 为空时使用当前 API Key；请求体格式和 L2 认证与 CreateAndPostOrders 相同
+func (c *orderClientImpl) PostSignedOrder(signed *ordermodel.SignedOrder, orderType types.OrderType, owner string) (*types.OrderPostResponse, error) {
+	order, err := newOrderedOrder(signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed order: %w", err)
+	}
+	if orderType == "" {
+		return nil, fmt.Errorf("order type is required")
+	}
+
+	// 只读密钥无法下单，提前返回明确的错误而不是等待服务端拒绝
+	if err := c.baseClient.ensureCanTrade(); err != nil {
+		return nil, err
+	}
+	if owner == "" {
+		owner = c.baseClient.deriveCreds.Key
+	}
+	if c.baseClient.balancePrecheck {
+		usdc, tokens := signedOrderBalances(signed)
+		if err := c.checkBalances(usdc, tokens); err != nil {
+			return nil, err
+		}
+	}
+
+	// 提交订单后余额可能已变化，使余额缓存失效
+	defer c.baseClient.balances.invalidate()
+
+	responseBody, err := c.postSignedJSON(internal.PostOrder, orderRequest{
+		Order:     order,
+		Owner:     owner,
+		OrderType: string(orderType),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.OrderPostResponse{}
+	if len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, result); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	result.Source = c.baseClient.orderSource
+	return result, nil
+}
+
+// PostRawOrder 按调用方指定的 maker/taker 数量签名并提交单个订单
+// 数量原样签名，不经过价格/数量换算和舍入，适合在外部精确计算数量的场景；
+// 签名前按代币的 tick size 检查隐含价格范围，并按代币的负风险状态选择验证合约（两者均有缓存）
+func (c *orderClientImpl) PostRawOrder(orderArgs types.OrderArgsRaw, orderType types.OrderType) (*types.OrderPostResponse, error) {
+	if orderType == "" {
+		return nil, fmt.Errorf("order type is required")
+	}
+	marketData := &marketDataClientImpl{baseClient: c.baseClient}
+	tickSize, err := marketData.GetTickSize(orderArgs.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tick size: %w", err)
+	}
+	negRisk, err := marketData.GetNegRisk(orderArgs.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get neg risk: %w", err)
+	}
+
+	signed, err := c.createSignedRawOrder(orderArgs, tickSize, negRisk, orderType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signed order: %w", err)
+	}
+	result, err := c.PostSignedOrder(signed, orderType, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// 根据响应中的 making/taking 数量计算成交结果
+	if result.ErrorMsg == "" {
+		fill := result.ToFillResult(orderArgs.Side, orderArgs.Shares())
+		result.Fill = &fill
+	}
+	return result, nil
+}
+
+// ExpirationFromNow 基于服务器时间计算 GTD 订单的过期时间（Unix 秒）
+// 返回值可直接用于 OrderArgs.Expiration，已包含 API 要求的 1 分钟安全阈值，
+// 订单将在 d 之后过期。尚未同步服务器时间时会先调用 GetTime，失败则退回本地时间
+func (c *orderClientImpl) ExpirationFromNow(d time.Duration) int64 {
+	if !c.baseClient.isTimeSynced() {
+		marketData := &marketDataClientImpl{baseClient: c.baseClient}
+		if _, err := marketData.GetTime(); err != nil {
+			internal.LogWarn("同步服务器时间失败，使用本地时间计算过期时间: %v", err)
+		}
+	}
+
+	return c.baseClient.serverNow().Add(internal.GTDExpirationThreshold + d).Unix()
+}
+
+// EstimateOrderFee 估算订单提交后将产生的手续费（USDC）
+// 手续费率优先使用 orderArgs.FeeRateBps，否则从 API 获取；市场未开启手续费时返回 0
+// 计算公式与 Polymarket 一致：fee = feeRate * min(price, 1-price) * size
+func (c *orderClientImpl) EstimateOrderFee(orderArgs types.OrderArgs) (float64, error) {
+	var feeRateBps int
+	if orderArgs.FeeRateBps != nil {
+		feeRateBps = *orderArgs.FeeRateBps
+	} else {
+		marketData := &marketDataClientImpl{baseClient: c.baseClient}
+		rate, err := marketData.GetFeeRate(orderArgs.TokenID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get fee rate: %w", err)
+		}
+		feeRateBps = rate
+	}
+
+	if feeRateBps <= 0 {
+		return 0, nil
+	}
+
+	return c.calculateOrderFee(orderArgs, types.TickSize(strconv.FormatFloat(internal.DefaultTickSize, 'f', -1, 64)), feeRateBps)
+}
+
+// RequiredCollateral 计算提交一批订单所需的 USDC（BUY 订单签名时 makerAmount 之和）
+// 数量按客户端的最小下单数量策略调整，会被本地拒绝的订单不计入；金额取整规则与签名一致
+// SELL 订单需要的代币数量见 RequiredTokenBalances
+func (c *orderClientImpl) RequiredCollateral(orders []types.OrderArgs) (float64, error) {
+	usdc, _, err := c.requiredBalances(orders)
+	if err != nil {
+		return 0, err
+	}
+	return types.USDCToFloat(sumAmounts(usdc)), nil
+}
+
+// RequiredTokenBalances 计算提交一批订单所需的代币数量（SELL 订单的 makerAmount），以 token ID 为键
+// 只包含有 SELL 订单的代币，计算规则与 RequiredCollateral 相同
+func (c *orderClientImpl) RequiredTokenBalances(orders []types.OrderArgs) (map[string]float64, error) {
+	_, tokens, err := c.requiredBalances(orders)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]float64, len(tokens))
+	for tokenID, amount := range tokens {
+		// 条件代币与 USDC 一样使用 6 位小数
+		result[tokenID] = types.USDCToFloat(amount)
+	}
+	return result, nil
+}
+
+// checkBalances 下单前检查（WithBalancePrecheck）：BUY 订单的 USDC 余额和授权额度、SELL 订单的代币余额和 ERC1155 授权
+// usdc 和 tokens 为各代币需要的链上数量（6 位小数整数），见 requiredBalances、signedOrderBalances
+func (c *orderClientImpl) checkBalances(usdc map[string]*big.Int, tokens map[string]*big.Int) error {
+	if err := c.checkCollateral(usdc); err != nil {
+		return err
+	}
+	return c.checkTokenHoldings(tokens)
+}
+
+// checkCollateral 检查 USDC 余额是否足够支付 BUY 订单、对应交易所的 USDC 授权额度是否足够
+// required 以 BUY 订单的 token ID 为键；余额不足时返回 *types.InsufficientBalanceError，
+// 授权不足时返回 *types.InsufficientAllowanceError（负风险市场检查 NegRiskCTFExchange 和 NegRiskAdapter）
+// 余额或授权查询失败时不阻止下单，由服务端最终判断
+func (c *orderClientImpl) checkCollateral(required map[string]*big.Int) error {
+	total := sumAmounts(required)
+	if total.Sign() == 0 {
+		return nil
+	}
+	account := &accountClientImpl{baseClient: c.baseClient}
+	balance, err := account.GetUSDCBalanceRaw()
+	if err != nil {
+		internal.LogDebug("无法查询 USDC 余额，跳过余额检查: %v", err)
+		return nil
+	}
+	if balance.Cmp(total) < 0 {
+		return &types.InsufficientBalanceError{
+			Asset:     "USDC",
+			Required:  types.USDCToFloat(total),
+			Available: types.USDCToFloat(balance),
+		}
+	}
+
+	holdings, err := c.baseClient.web3Client.GetCollateralHoldings(c.baseClient.proxyAddress)
+	if err != nil {
+		internal.LogDebug("无法查询 USDC 授权额度，跳过授权检查: %v", err)
+		return nil
+	}
+	if holdings.Allowance(false).Cmp(total) >= 0 && holdings.Allowance(true).Cmp(total) >= 0 {
+		return nil
+	}
+
+	tokenIDs := make([]string, 0, len(required))
+	for tokenID := range required {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+	marketData := &marketDataClientImpl{baseClient: c.baseClient}
+	negRisks, err := marketData.GetNegRisks(tokenIDs)
+	if err != nil {
+		// 不知道代币属于哪个交易所时，只要有一个交易所的授权足够就放行
+		if holdings.Allowance(false).Cmp(total) >= 0 || holdings.Allowance(true).Cmp(total) >= 0 {
+			return nil
+		}
+		return c.allowanceError(holdings, false, total)
+	}
+
+	byExchange := map[bool]*big.Int{false: new(big.Int), true: new(big.Int)}
+	for _, tokenID := range tokenIDs {
+		byExchange[negRisks[tokenID]].Add(byExchange[negRisks[tokenID]], required[tokenID])
+	}
+	for _, negRisk := range []bool{false, true} {
+		if holdings.Allowance(negRisk).Cmp(byExchange[negRisk]) < 0 {
+			return c.allowanceError(holdings, negRisk, byExchange[negRisk])
+		}
+	}
+	return nil
+}
+
+// allowanceError 构造 USDC 授权额度不足的错误
+func (c *orderClientImpl) allowanceError(holdings *types.CollateralHoldings, negRisk bool, required *big.Int) error {
+	return &types.InsufficientAllowanceError{
+		Owner:     c.baseClient.proxyAddress,
+		NegRisk:   negRisk,
+		Required:  types.USDCToFloat(required),
+		Available: types.USDCToFloat(holdings.Allowance(negRisk)),
+	}
+}
+
+// sumAmounts 返回各代币数量之和
+func sumAmounts(amounts map[string]*big.Int) *big.Int {
+	total := new(big.Int)
+	for _, amount := range amounts {
+		total.Add(total, amount)
+	}
+	return total
+}
+
+// checkTokenHoldings 检查 SELL 订单需要的代币余额是否足够、交易所是否为代币的 ERC1155 授权操作员
+// 余额不足时返回 *types.InsufficientBalanceError，未授权时返回 *types.TokenNotApprovedError
+// 余额和授权通过一次 Multicall 查询；查询失败时不阻止下单，由服务端最终判断
+func (c *orderClientImpl) checkTokenHoldings(required map[string]*big.Int) error {
+	if len(required) == 0 {
+		return nil
+	}
+	tokenIDs := make([]string, 0, len(required))
+	for tokenID := range required {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	sort.Strings(tokenIDs)
+
+	holdings, err := c.baseClient.web3Client.GetTokenHoldings(c.baseClient.proxyAddress, tokenIDs)
+	if err != nil {
+		internal.LogDebug("无法查询代币余额和授权，跳过代币检查: %v", err)
+		return nil
+	}
+	for _, tokenID := range tokenIDs {
+		balance := holdings.Balances[tokenID]
+		if balance == nil {
+			balance = new(big.Int)
+		}
+		if balance.Cmp(required[tokenID]) < 0 {
+			return &types.InsufficientBalanceError{
+				Asset:     tokenID,
+				Required:  types.USDCToFloat(required[tokenID]),
+				Available: types.USDCToFloat(balance),
+			}
+		}
+	}
+
+	if holdings.ExchangeApproved && holdings.NegRiskExchangeApproved {
+		return nil
+	}
+	marketData := &marketDataClientImpl{baseClient: c.baseClient}
+	negRisks, err := marketData.GetNegRisks(tokenIDs)
+	for _, tokenID := range tokenIDs {
+		if err != nil {
+			// 不知道代币属于哪个交易所时，只要有一个交易所已授权就放行
+			if holdings.ExchangeApproved || holdings.NegRiskExchangeApproved {
+				return nil
+			}
+			return &types.TokenNotApprovedError{Owner: c.baseClient.proxyAddress, TokenID: tokenID}
+		}
+		if !holdings.Approved(negRisks[tokenID]) {
+			return &types.TokenNotApprovedError{Owner: c.baseClient.proxyAddress, TokenID: tokenID, NegRisk: negRisks[tokenID]}
+		}
+	}
+	return nil
+}
+
+// requiredBalances 按签名时的 makerAmount 汇总一批订单需要的 USDC 和各代币数量（链上 6 位小数整数）
+// 两者都以 token ID 为键：usdc 为该代币 BUY 订单需要的 USDC，tokens 为 SELL 订单需要的代币
+func (c *orderClientImpl) requiredBalances(orders []types.OrderArgs) (map[string]*big.Int, map[string]*big.Int, error) {
+	if err := validateOrderPrices(orders); err != nil {
+		return nil, nil, err
+	}
+
+	tickSize := types.TickSize(strconv.FormatFloat(internal.DefaultTickSize, 'f', -1, 64))
+	usdc := make(map[string]*big.Int)
+	tokens := make(map[string]*big.Int)
+	for i, orderArgs := range orders {
+		if orderArgs.Side != types.OrderSideBUY && orderArgs.Side != types.OrderSideSELL {
+			return nil, nil, fmt.Errorf("订单 %d 方向无效: %q", i+1, orderArgs.Side)
+		}
+		orderArgs, err := applyMinOrderSize(orderArgs, c.baseClient.marketMinOrderSize(orderArgs), c.baseClient.minSizePolicy)
+		if err != nil {
+			// 该订单不会被提交
+			continue
+		}
+		makerAmount, _, err := c.calculateOrderAmounts(orderArgs.Side, orderArgs.Size, orderArgs.Price, tickSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("订单 %d: failed to calculate order amounts: %w", i+1, err)
+		}
+
+		amounts := tokens
+		if orderArgs.Side == types.OrderSideBUY {
+			amounts = usdc
+		}
+		if amounts[orderArgs.TokenID] == nil {
+			amounts[orderArgs.TokenID] = new(big.Int)
+		}
+		amounts[orderArgs.TokenID].Add(amounts[orderArgs.TokenID], makerAmount)
+	}
+	return usdc, tokens, nil
+}
+
+// signedOrderBalances 返回已签名订单需要的 USDC（BUY）或代币数量（SELL），即签名的 makerAmount
+// 返回值以 token ID 为键，与 requiredBalances 相同
+func signedOrderBalances(signed *ordermodel.SignedOrder) (map[string]*big.Int, map[string]*big.Int) {
+	amounts := map[string]*big.Int{signed.TokenId.String(): new(big.Int).Set(signed.MakerAmount)}
+	if int(signed.Side.Int64()) == ordermodel.BUY {
+		return amounts, nil
+	}
+	return nil, amounts
+}
+
+// calculateOrderFee 根据签名时使用的 maker/taker 数量计算手续费（USDC）
+func (c *orderClientImpl) calculateOrderFee(orderArgs types.OrderArgs, tickSize types.TickSize, feeRateBps int) (float64, error) {
+	makerAmount, takerAmount, err := c.calculateOrderAmounts(orderArgs.Side, orderArgs.Size, orderArgs.Price, tickSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate order amounts: %w", err)
+	}
+
+	// BUY: maker 为 USDC，taker 为 shares；SELL 相反
+	makerFloat, _ := new(big.Float).SetInt(makerAmount).Float64()
+	takerFloat, _ := new(big.Float).SetInt(takerAmount).Float64()
+	var usdcAmount, shares float64
+	if orderArgs.Side == types.OrderSideBUY {
+		usdcAmount, shares = makerFloat/1e6, takerFloat/1e6
+	} else {
+		usdcAmount, shares = takerFloat/1e6, makerFloat/1e6
+	}
+
+	if shares <= 0 {
+		return 0, nil
+	}
+
+	price := usdcAmount / shares
+	return float64(feeRateBps) / 10000 * math.Min(price, 1-price) * shares, nil
+}
+
+// CancelOrdersOptions CancelOrders 的可选参数
+type CancelOrdersOptions struct {
+	Concurrency int // 同时提交的撤单批次数，默认 1（按顺序提交）
+}
+
+// CancelOrdersOption 函数选项类型
+type CancelOrdersOption func(*CancelOrdersOptions)
+
+// WithCancelConcurrency 设置同时提交的撤单批次数
+// 撤单数量较多时会按 internal.CancelOrdersBatchSize 分批，并发提交可缩短整体耗时，
+// 但仍受撤单频率限制约束，不宜设置过大。对 CancelMarketsOrders 表示同时撤单的市场数
+func WithCancelConcurrency(concurrency int) CancelOrdersOption {
+	return func(opts *CancelOrdersOptions) {
+		opts.Concurrency = concurrency
+	}
 }
 
 // CancelOrders cancels multiple orders
 // According to Polymarket API docs: DELETE /orders with body as string[] (orderID array)
-func (c *orderClientImpl) CancelOrders(orderIDs []types.Keccak256) (*types.OrderCancelResponse, error) {
+// 订单数量超过 internal.CancelOrdersBatchSize 时自动分批提交，结果合并后返回；
+// 某一批请求失败时，该批订单记入 NotCanceled 并附带失败原因，同时返回第一个错误
+func (c *orderClientImpl) CancelOrders(orderIDs []types.Keccak256, options ...CancelOrdersOption) (*types.OrderCancelResponse, error) {
 	if len(orderIDs) == 0 {
 		return &types.OrderCancelResponse{
 			Canceled:    []types.Keccak256{},
@@ -533,6 +1517,84 @@ func (c *orderClientImpl) CancelOrders(orderIDs []types.Keccak256) (*types.Order
 		}, nil
 	}
 
+	opts := &CancelOrdersOptions{Concurrency: 1}
+	for _, opt := range options {
+		if opt != nil {
+			opt(opts)
+		}
+	}
+
+	if len(orderIDs) <= internal.CancelOrdersBatchSize {
+		return c.cancelOrdersBatch(orderIDs)
+	}
+	return cancelOrdersInChunks(orderIDs, internal.CancelOrdersBatchSize, opts.Concurrency, c.cancelOrdersBatch)
+}
+
+// cancelOrdersInChunks 将订单分批撤销，最多 concurrency 个批次同时进行，按批次顺序合并结果
+func cancelOrdersInChunks(
+	orderIDs []types.Keccak256,
+	chunkSize int,
+	concurrency int,
+	cancel func([]types.Keccak256) (*types.OrderCancelResponse, error),
+) (*types.OrderCancelResponse, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	chunks := make([][]types.Keccak256, 0, (len(orderIDs)+chunkSize-1)/chunkSize)
+	for i := 0; i < len(orderIDs); i += chunkSize {
+		end := i + chunkSize
+		if end > len(orderIDs) {
+			end = len(orderIDs)
+		}
+		chunks = append(chunks, orderIDs[i:end])
+	}
+
+	responses := make([]*types.OrderCancelResponse, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk []types.Keccak256) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			responses[i], errs[i] = cancel(chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	merged := &types.OrderCancelResponse{
+		Canceled:    make([]types.Keccak256, 0, len(orderIDs)),
+		NotCanceled: make(map[types.Keccak256]string),
+	}
+	var firstErr error
+	for i, chunk := range chunks {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("cancel batch %d/%d failed: %w", i+1, len(chunks), errs[i])
+			}
+			for _, orderID := range chunk {
+				merged.NotCanceled[orderID] = fmt.Sprintf("批次撤单失败: %v", errs[i])
+			}
+			continue
+		}
+		if responses[i] == nil {
+			continue
+		}
+		merged.Canceled = append(merged.Canceled, responses[i].Canceled...)
+		for orderID, reason := range responses[i].NotCanceled {
+			merged.NotCanceled[orderID] = reason
+		}
+	}
+
+	return merged, firstErr
+}
+
+// cancelOrdersBatch 提交一批撤单请求（不超过 internal.CancelOrdersBatchSize 个订单）
+func (c *orderClientImpl) cancelOrdersBatch(orderIDs []types.Keccak256) (*types.OrderCancelResponse, error) {
+
 	// Convert Keccak256 to string array for request body
 	// According to API docs, the body should be a string array directly, not wrapped in an object
 	orderIDStrings := make([]string, len(orderIDs))
@@ -542,19 +1604,11 @@ func (c *orderClientImpl) CancelOrders(orderIDs []types.Keccak256) (*types.Order
 
 	// Marshal body to JSON (array of strings)
 	// IMPORTANT: For DELETE /orders, body should be formatted JSON string for HMAC signature
-	bodyJSON, err := json.Marshal(orderIDStrings)
+	bodyJSON, err := internal.MarshalPythonJSON(orderIDStrings)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal body: %w", err)
 	}
 
-	// Convert compact JSON to Python's json.dumps format (with spaces)
-	// This matches the format used in HMAC signature calculation
-	bodyJSONStr := string(bodyJSON)
-	bodyJSONStr = regexp.MustCompile(`":(\S)`).ReplaceAllString(bodyJSONStr, `": $1`)
-	bodyJSONStr = regexp.MustCompile(`,(")`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSONStr = regexp.MustCompile(`,(\{|\[)`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSON = []byte(bodyJSONStr)
-
 	// Use RequestBody to pass formatted JSON string to CreateLevel2Headers
 	// This matches how CancelAll works (using CreateLevel2Headers)
 	requestBody := types.RequestBody(bodyJSON)
@@ -566,13 +1620,15 @@ func (c *orderClientImpl) CancelOrders(orderIDs []types.Keccak256) (*types.Order
 
 	// 使用 CreateLevel2Headers，传入格式化后的 JSON 字符串 body
 	// 这样与 CancelAll 的处理方式一致，都使用 CreateLevel2Headers
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
+	c.baseClient.assertSignedBody(requestArgs, headers, bodyJSON)
+
 	// 执行请求，使用格式化后的 JSON body
-	return http.DeleteRaw[types.OrderCancelResponse](c.baseClient.baseURL, internal.CancelOrders, bodyJSON, http.WithHeaders(headers))
+	return http.DeleteRaw[types.OrderCancelResponse](c.baseClient.baseURL, internal.CancelOrders, bodyJSON, c.requestOptions(http.WithHeaders(headers))...)
 }
 
 // CancelOrder 取消单个订单
@@ -586,11 +1642,76 @@ func (c *orderClientImpl) CancelAll() (*types.OrderCancelResponse, error) {
 		Method:      "DELETE",
 		RequestPath: internal.CancelAll,
 	}
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
-	return http.Delete[types.OrderCancelResponse](c.baseClient.baseURL, internal.CancelAll, nil, http.WithHeaders(headers))
+	return http.Delete[types.OrderCancelResponse](c.baseClient.baseURL, internal.CancelAll, nil, c.requestOptions(http.WithHeaders(headers))...)
+}
+
+// CancelMarketsOrders 取消多个市场（conditionID）的所有订单
+// CLOB 没有跨市场的批量撤单接口，每个市场单独请求 DELETE /cancel-market-orders，
+// 默认按顺序提交，可通过 WithCancelConcurrency 并发提交；重复的 conditionID 只请求一次。
+// 各市场结果合并后返回，某个市场请求失败时记入 FailedMarkets，其他市场结果保留，同时返回第一个错误
+func (c *orderClientImpl) CancelMarketsOrders(conditionIDs []types.Keccak256, options ...CancelOrdersOption) (*types.MarketsCancelResponse, error) {
+	opts := &CancelOrdersOptions{Concurrency: 1}
+	for _, opt := range options {
+		if opt != nil {
+			opt(opts)
+		}
+	}
+	return cancelMarkets(conditionIDs, opts.Concurrency, c.CancelMarketOrders)
+}
+
+// cancelMarkets 逐个市场撤单，最多 concurrency 个请求同时进行，按 conditionIDs 的顺序合并结果
+func cancelMarkets(
+	conditionIDs []types.Keccak256,
+	concurrency int,
+	cancel func(types.Keccak256) (*types.OrderCancelResponse, error),
+) (*types.MarketsCancelResponse, error) {
+	markets := make([]types.Keccak256, 0, len(conditionIDs))
+	seen := make(map[types.Keccak256]bool, len(conditionIDs))
+	for _, conditionID := range conditionIDs {
+		if !seen[conditionID] {
+			seen[conditionID] = true
+			markets = append(markets, conditionID)
+		}
+	}
+
+	responses := make([]*types.OrderCancelResponse, len(markets))
+	errs := make([]error, len(markets))
+	runOrderBatches(len(markets), concurrency, 0, func(i int) {
+		responses[i], errs[i] = cancel(markets[i])
+	})
+
+	merged := &types.MarketsCancelResponse{
+		OrderCancelResponse: types.OrderCancelResponse{
+			Canceled:    []types.Keccak256{},
+			NotCanceled: make(map[types.Keccak256]string),
+		},
+		Markets:       make(map[types.Keccak256]*types.OrderCancelResponse, len(markets)),
+		FailedMarkets: make(map[types.Keccak256]string),
+	}
+	var firstErr error
+	for i, conditionID := range markets {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("cancel market %s failed: %w", conditionID, errs[i])
+			}
+			merged.FailedMarkets[conditionID] = errs[i].Error()
+			continue
+		}
+		if responses[i] == nil {
+			continue
+		}
+		merged.Markets[conditionID] = responses[i]
+		merged.Canceled = append(merged.Canceled, responses[i].Canceled...)
+		for orderID, reason := range responses[i].NotCanceled {
+			merged.NotCanceled[orderID] = reason
+		}
+	}
+
+	return merged, firstErr
 }
 
 // CancelMarketOrders 取消指定市场的所有订单
@@ -616,29 +1737,24 @@ func (c *orderClientImpl) CancelMarketOrders(conditionID types.Keccak256) (*type
 		Body:        nil, // DELETE request body will be marshaled
 	}
 
-	// Marshal body to JSON
-	bodyJSON, err := json.Marshal(requestBody)
+	// Marshal body to JSON in Python's json.dumps format (with spaces)
+	bodyJSON, err := internal.MarshalPythonJSON(requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal body: %w", err)
 	}
 
-	// Convert compact JSON to Python's json.dumps format (with spaces)
-	bodyJSONStr := string(bodyJSON)
-	bodyJSONStr = regexp.MustCompile(`":(\S)`).ReplaceAllString(bodyJSONStr, `": $1`)
-	bodyJSONStr = regexp.MustCompile(`,(")`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSONStr = regexp.MustCompile(`,(\{|\[)`).ReplaceAllString(bodyJSONStr, `, $1`)
-	bodyJSON = []byte(bodyJSONStr)
-
 	// Use RequestBody for signing
 	requestBodyForSigning := types.RequestBody(bodyJSON)
 	requestArgs.Body = &requestBodyForSigning
 
 	// Create Level 2 headers
-	headers, err := internal.CreateLevel2Headers(c.baseClient.web3Client.GetSigner(), c.baseClient.deriveCreds, requestArgs, false)
+	headers, err := c.baseClient.level2Headers(requestArgs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
 
+	c.baseClient.assertSignedBody(requestArgs, headers, bodyJSON)
+
 	// Execute DELETE request with body
-	return http.DeleteRaw[types.OrderCancelResponse](c.baseClient.baseURL, internal.CancelMarketOrders, bodyJSON, http.WithHeaders(headers))
+	return http.DeleteRaw[types.OrderCancelResponse](c.baseClient.baseURL, internal.CancelMarketOrders, bodyJSON, c.requestOptions(http.WithHeaders(headers))...)
 }
@@ -0,0 +1,116 @@
+package clob
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// portfolioMidpointBatchSize 单次批量查询中间价的最大代币数（GetMidpoints 的上限）
+const portfolioMidpointBatchSize = 500
+
+// GetPortfolioSummary 汇总地址的资产净值：链上 USDC 余额 + 持仓按中间价计算的市值
+//
+// 计算方式：
+//   - USDCBalance 为链上 USDC 余额（GetUSDCBalanceOf），挂单不会转走 USDC，因此余额已包含挂单占用的部分
+//   - ReservedInOrders 为当前账户所有 BUY 挂单未成交部分的金额之和（剩余数量 × 挂单价格），SELL 挂单占用的是代币而不是 USDC；
+//     CLOB 只能查询当前凭证对应账户的挂单，user 不是当前账户（代理地址）时为 0
+//   - PositionsValue 为各持仓数量 × 标记价格之和，标记价格优先使用中间价（GetMidpoints），
+//     没有订单簿的代币（如已结算的市场）使用 Data API 返回的当前价格
+//   - NetWorth = USDCBalance + PositionsValue，挂单占用的 USDC 已包含在余额中，不重复计算
+//
+// 持仓来自 Data API 的 GetPositions（单次最多返回 500 个持仓）
+func (c *accountClientImpl) GetPortfolioSummary(user types.EthAddress) (*types.Portfolio, error) {
+	if err := user.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", user, err)
+	}
+
+	balance, err := c.GetUSDCBalanceOf(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get USDC balance: %w", err)
+	}
+	portfolio := &types.Portfolio{User: user, USDCBalance: balance}
+
+	if strings.EqualFold(string(user), string(c.baseClient.proxyAddress)) {
+		orders := &orderClientImpl{baseClient: c.baseClient}
+		openOrders, err := orders.GetOrders(nil, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get open orders: %w", err)
+		}
+		portfolio.ReservedInOrders = reservedCollateral(openOrders)
+	}
+	portfolio.AvailableUSDC = max(portfolio.USDCBalance-portfolio.ReservedInOrders, 0)
+
+	positions, err := c.baseClient.dataClient.GetPositions(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get positions: %w", err)
+	}
+	midpoints, err := c.positionMidpoints(positions)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio.Positions = make([]types.PortfolioPosition, 0, len(positions))
+	for _, position := range positions {
+		if position.Size <= 0 {
+			continue
+		}
+		markPrice, ok := midpoints[position.TokenID]
+		if !ok {
+			markPrice = position.CurrentPrice
+		}
+		value := position.Size * markPrice
+		portfolio.Positions = append(portfolio.Positions, types.PortfolioPosition{
+			TokenID:     position.TokenID,
+			ConditionID: position.ConditionID,
+			Title:       position.Title,
+			Outcome:     position.Outcome,
+			Size:        position.Size,
+			MarkPrice:   markPrice,
+			Value:       value,
+		})
+		portfolio.PositionsValue += value
+	}
+	portfolio.NetWorth = portfolio.USDCBalance + portfolio.PositionsValue
+	return portfolio, nil
+}
+
+// positionMidpoints 分批查询持仓代币的中间价，没有订单簿的代币不在结果中
+func (c *accountClientImpl) positionMidpoints(positions []types.Position) (map[string]float64, error) {
+	tokenIDs := make([]string, 0, len(positions))
+	seen := make(map[string]bool, len(positions))
+	for _, position := range positions {
+		if position.Size <= 0 || position.Redeemable || position.TokenID == "" || seen[position.TokenID] {
+			continue
+		}
+		seen[position.TokenID] = true
+		tokenIDs = append(tokenIDs, position.TokenID)
+	}
+
+	marketData := &marketDataClientImpl{baseClient: c.baseClient}
+	result := make(map[string]float64, len(tokenIDs))
+	for start := 0; start < len(tokenIDs); start += portfolioMidpointBatchSize {
+		end := min(start+portfolioMidpointBatchSize, len(tokenIDs))
+		midpoints, err := marketData.GetMidpoints(tokenIDs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get midpoints: %w", err)
+		}
+		for _, midpoint := range midpoints {
+			result[midpoint.TokenID] = midpoint.Value
+		}
+	}
+	return result, nil
+}
+
+// reservedCollateral 计算 BUY 挂单未成交部分占用的 USDC（剩余数量 × 价格）
+func reservedCollateral(orders []types.OpenOrder) float64 {
+	var reserved float64
+	for i := range orders {
+		if orders[i].Side != types.OrderSideBUY {
+			continue
+		}
+		reserved += orders[i].RemainingSize() * float64(orders[i].Price)
+	}
+	return reserved
+}
@@ -0,0 +1,45 @@
+package clob
+
+import (
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// GetRateLimits 返回客户端使用的 CLOB API 限流配置，客户端的请求按它限速
+// 返回 WithRateLimits 配置的值，未配置的字段使用 Polymarket 文档公布的默认值；
+// 服务端在响应头中返回的实际限流状态见 RateLimitStatus
+func (c *baseClient) GetRateLimits() types.RateLimits {
+	return defaultRateLimits(c.rateLimits)
+}
+
+// GetRateLimits 返回 CLOB API 的限流配置（只读客户端实现）
+func (c *readonlyBaseClient) GetRateLimits() types.RateLimits {
+	return defaultRateLimits(c.rateLimits)
+}
+
+// defaultRateLimits 为未配置的字段填充默认值
+func defaultRateLimits(configured types.RateLimits) types.RateLimits {
+	limits := types.RateLimits{
+		Window:      internal.RateLimitWindow,
+		General:     internal.RateLimitGeneral,
+		MarketData:  internal.RateLimitMarketData,
+		PostOrder:   internal.RateLimitPostOrder,
+		CancelOrder: internal.RateLimitCancelOrder,
+	}
+	if configured.Window > 0 {
+		limits.Window = configured.Window
+	}
+	if configured.General > 0 {
+		limits.General = configured.General
+	}
+	if configured.MarketData > 0 {
+		limits.MarketData = configured.MarketData
+	}
+	if configured.PostOrder > 0 {
+		limits.PostOrder = configured.PostOrder
+	}
+	if configured.CancelOrder > 0 {
+		limits.CancelOrder = configured.CancelOrder
+	}
+	return limits
+}
@@ -0,0 +1,49 @@
+package clob
+
+import (
+	"fmt"
+
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// GetWinningToken 查询已结算条件的获胜代币：链上赔付分子（GetConditionResolution）中全额赔付的结果序号，
+// 对应 Gamma 市场 clobTokenIds / outcomes 中相同位置的代币ID和结果名称
+//
+// 说明：
+//   - 普通二元市场和多结果市场都按结果序号对应，结果数量以链上 OutcomeSlotCount 为准
+//   - 负风险（neg-risk）事件中每个选项是独立的 Yes/No 条件，传入该选项的条件ID，获胜代币为该选项的 Yes 或 No 代币
+//   - 未结算时返回 types.ErrMarketNotResolved；按比例拆分赔付（如 50/50，没有单一获胜代币）时返回 types.ErrSplitResolution，
+//     此时可通过 GetConditionResolution 的 Payout 查看各结果的赔付比例
+func (c *accountClientImpl) GetWinningToken(conditionID types.Keccak256) (string, string, error) {
+	resolution, err := c.baseClient.web3Client.GetConditionResolution(conditionID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get condition resolution: %w", err)
+	}
+	if !resolution.Resolved {
+		return "", "", fmt.Errorf("condition %s: %w", conditionID, types.ErrMarketNotResolved)
+	}
+	index, ok := resolution.WinningIndex()
+	if !ok {
+		return "", "", fmt.Errorf("condition %s payouts %v/%d: %w",
+			conditionID, resolution.PayoutNumerators, resolution.PayoutDenominator, types.ErrSplitResolution)
+	}
+
+	markets, err := c.baseClient.gammaClient.GetMarketsByConditionIDs([]string{string(conditionID)})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get market: %w", err)
+	}
+	if len(markets) == 0 {
+		return "", "", fmt.Errorf("market not found for condition %s", conditionID)
+	}
+	market := markets[0]
+	if len(market.TokenIDs) != resolution.OutcomeSlotCount {
+		return "", "", fmt.Errorf("condition %s has %d outcome slots but market has %d tokens",
+			conditionID, resolution.OutcomeSlotCount, len(market.TokenIDs))
+	}
+
+	var outcome string
+	if index < len(market.Outcomes) {
+		outcome = market.Outcomes[index]
+	}
+	return market.TokenIDs[index], outcome, nil
+}
@@ -2,6 +2,8 @@ package clob
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
@@ -18,7 +20,7 @@ func (c *rewardClientImpl) IsOrderScoring(orderID types.Keccak256) (bool, error)
 
 	resp, err := http.Get[struct {
 		Scoring bool `json:"scoring"`
-	}](c.baseClient.baseURL, internal.IsOrderScoring, params)
+	}](c.baseClient.baseURL, internal.IsOrderScoring, params, c.requestOptions()...)
 	if err != nil {
 		return false, fmt.Errorf("failed to check order scoring: %w", err)
 	}
@@ -45,7 +47,7 @@ func (c *rewardClientImpl) AreOrdersScoring(orderIDs []types.Keccak256) (map[typ
 
 	// Make POST request
 	var result map[string]bool
-	resp, err := http.Post[map[string]bool](c.baseClient.baseURL, internal.AreOrdersScoring, requestBody)
+	resp, err := http.Post[map[string]bool](c.baseClient.baseURL, internal.AreOrdersScoring, requestBody, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check orders scoring: %w", err)
 	}
@@ -60,6 +62,23 @@ func (c *rewardClientImpl) AreOrdersScoring(orderIDs []types.Keccak256) (map[typ
 	return resultMap, nil
 }
 
+// GetRewardMarkets 获取当前提供流动性奖励的市场列表
+func (c *rewardClientImpl) GetRewardMarkets() ([]types.RewardMarket, error) {
+	return c.baseClient.rewardMarkets.get(c.baseClient.baseURL, c.requestOptions())
+}
+
+// GetRewardsConfig 获取流动性奖励计划的全局参数（奖励池、起止日期、当前周期）
+// 由 /rewards/markets/current 返回的各市场奖励配置汇总，需要分页拉取全部奖励市场
+func (c *rewardClientImpl) GetRewardsConfig() (*types.RewardsConfig, error) {
+	return fetchRewardsConfig(c.baseClient.baseURL, c.requestOptions())
+}
+
+// GetRewardsEpoch 获取包含指定日期的奖励周期（UTC 日），date 为空时返回当前周期
+// date 支持 YYYY-MM-DD、RFC3339 和 Unix 时间戳；接口只返回当前的奖励配置，过去日期的结果不包含已下线的奖励
+func (c *rewardClientImpl) GetRewardsEpoch(date string) (*types.RewardsEpoch, error) {
+	return fetchRewardsEpoch(c.baseClient.baseURL, date, c.requestOptions())
+}
+
 // ========== 只读客户端实现 ==========
 
 // IsOrderScoring 检查订单是否计分（只读客户端实现）
@@ -72,7 +91,7 @@ func (c *readonlyRewardClientImpl) IsOrderScoring(orderID types.Keccak256) (bool
 
 	resp, err := http.Get[struct {
 		Scoring bool `json:"scoring"`
-	}](c.readonlyBaseClient.baseURL, internal.IsOrderScoring, params)
+	}](c.readonlyBaseClient.baseURL, internal.IsOrderScoring, params, c.requestOptions()...)
 	if err != nil {
 		return false, fmt.Errorf("failed to check order scoring: %w", err)
 	}
@@ -99,7 +118,7 @@ func (c *readonlyRewardClientImpl) AreOrdersScoring(orderIDs []types.Keccak256)
 
 	// Make POST request
 	var result map[string]bool
-	resp, err := http.Post[map[string]bool](c.readonlyBaseClient.baseURL, internal.AreOrdersScoring, requestBody)
+	resp, err := http.Post[map[string]bool](c.readonlyBaseClient.baseURL, internal.AreOrdersScoring, requestBody, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check orders scoring: %w", err)
 	}
@@ -113,3 +132,211 @@ func (c *readonlyRewardClientImpl) AreOrdersScoring(orderIDs []types.Keccak256)
 
 	return resultMap, nil
 }
+
+// GetRewardMarkets 获取当前提供流动性奖励的市场列表（只读客户端实现）
+func (c *readonlyRewardClientImpl) GetRewardMarkets() ([]types.RewardMarket, error) {
+	return c.readonlyBaseClient.rewardMarkets.get(c.readonlyBaseClient.baseURL, c.requestOptions())
+}
+
+// GetRewardsConfig 获取流动性奖励计划的全局参数（只读客户端实现）
+func (c *readonlyRewardClientImpl) GetRewardsConfig() (*types.RewardsConfig, error) {
+	return fetchRewardsConfig(c.readonlyBaseClient.baseURL, c.requestOptions())
+}
+
+// GetRewardsEpoch 获取包含指定日期的奖励周期（只读客户端实现）
+func (c *readonlyRewardClientImpl) GetRewardsEpoch(date string) (*types.RewardsEpoch, error) {
+	return fetchRewardsEpoch(c.readonlyBaseClient.baseURL, date, c.requestOptions())
+}
+
+// currentRewardsMarket /rewards/markets/current 返回的市场奖励配置
+type currentRewardsMarket struct {
+	ConditionID   types.Keccak256 `json:"condition_id"`
+	RewardsConfig []struct {
+		AssetAddress types.EthAddress   `json:"asset_address"`
+		StartDate    types.NullableTime `json:"start_date"`
+		EndDate      types.NullableTime `json:"end_date"` // 最后一个生效日（含）
+		RatePerDay   types.FloatString  `json:"rate_per_day"`
+		TotalRewards types.FloatString  `json:"total_rewards"`
+	} `json:"rewards_config"`
+}
+
+// fetchCurrentRewardsMarkets 分页拉取当前所有奖励市场的奖励配置
+func fetchCurrentRewardsMarkets(baseURL string, options []http.HTTPOption) ([]currentRewardsMarket, error) {
+	params := make(map[string]string)
+	markets := make([]currentRewardsMarket, 0)
+	nextCursor := "MA=="
+
+	for nextCursor != internal.EndCursor && nextCursor != "" {
+		params["next_cursor"] = nextCursor
+
+		response, err := http.Get[types.PaginatedResponse[currentRewardsMarket]](baseURL, internal.GetCurrentRewardsMarkets, params, options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current rewards: %w", err)
+		}
+		markets = append(markets, response.Data...)
+		nextCursor = response.NextCursor
+	}
+
+	return markets, nil
+}
+
+// fetchRewardsConfig 拉取当前奖励配置并汇总为全局参数
+func fetchRewardsConfig(baseURL string, options []http.HTTPOption) (*types.RewardsConfig, error) {
+	markets, err := fetchCurrentRewardsMarkets(baseURL, options)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeRewards(markets, time.Now()), nil
+}
+
+// fetchRewardsEpoch 解析日期后拉取当前奖励配置，计算该日的奖励周期
+func fetchRewardsEpoch(baseURL, date string, options []http.HTTPOption) (*types.RewardsEpoch, error) {
+	day := time.Now()
+	if date != "" {
+		parsed := types.ParseNullableTime(date)
+		if parsed.Time == nil {
+			return nil, fmt.Errorf("invalid epoch date %q", date)
+		}
+		day = *parsed.Time
+	}
+	markets, err := fetchCurrentRewardsMarkets(baseURL, options)
+	if err != nil {
+		return nil, err
+	}
+	epoch := rewardsEpoch(markets, day)
+	return &epoch, nil
+}
+
+// summarizeRewards 汇总各市场的奖励配置，当前周期为 now 所在的 UTC 日
+func summarizeRewards(markets []currentRewardsMarket, now time.Time) *types.RewardsConfig {
+	config := &types.RewardsConfig{CurrentEpoch: rewardsEpoch(markets, now)}
+	for _, market := range markets {
+		if len(market.RewardsConfig) > 0 {
+			config.MarketsCount++
+		}
+		for _, rc := range market.RewardsConfig {
+			if config.AssetAddress == "" {
+				config.AssetAddress = rc.AssetAddress
+			}
+			config.TotalPool += rc.TotalRewards.Float64()
+			if start := rc.StartDate.Time; start != nil && (config.StartDate.Time == nil || start.Before(*config.StartDate.Time)) {
+				config.StartDate = rc.StartDate
+			}
+			if end := rc.EndDate.Time; end != nil && (config.EndDate.Time == nil || end.After(*config.EndDate.Time)) {
+				config.EndDate = rc.EndDate
+			}
+		}
+	}
+	config.DailyPool = config.CurrentEpoch.TotalRewards
+	return config
+}
+
+// rewardsEpoch 计算 day 所在 UTC 日的奖励周期：开始日期不晚于该日、结束日期不早于该日的奖励配置生效
+// 没有开始或结束日期的配置视为不受该边界限制
+func rewardsEpoch(markets []currentRewardsMarket, day time.Time) types.RewardsEpoch {
+	day = day.UTC()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	epoch := types.RewardsEpoch{
+		Epoch:     start.Format("2006-01-02"),
+		StartDate: start,
+		EndDate:   start.AddDate(0, 0, 1),
+	}
+	for _, market := range markets {
+		active := false
+		for _, rc := range market.RewardsConfig {
+			if rc.StartDate.Time != nil && !rc.StartDate.Time.Before(epoch.EndDate) {
+				continue
+			}
+			if rc.EndDate.Time != nil && rc.EndDate.Time.Before(start) {
+				continue
+			}
+			epoch.TotalRewards += rc.RatePerDay.Float64()
+			active = true
+		}
+		if active {
+			epoch.MarketsCount++
+		}
+	}
+	return epoch
+}
+
+// rewardMarketsCache 奖励市场列表的短期缓存
+// sampling-markets 需要分页拉取全部数据，短时间内重复调用直接返回缓存结果
+type rewardMarketsCache struct {
+	mu        sync.Mutex
+	markets   []types.RewardMarket
+	fetchedAt time.Time
+}
+
+// get 返回缓存的奖励市场列表，缓存过期时重新拉取
+func (rc *rewardMarketsCache) get(baseURL string, options []http.HTTPOption) ([]types.RewardMarket, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.markets != nil && time.Since(rc.fetchedAt) < internal.RewardMarketsCacheTTL {
+		return rc.markets, nil
+	}
+
+	markets, err := fetchRewardMarkets(baseURL, options)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.markets = markets
+	rc.fetchedAt = time.Now()
+	return markets, nil
+}
+
+// samplingMarket sampling-markets 接口返回的市场结构
+type samplingMarket struct {
+	ConditionID types.Keccak256 `json:"condition_id"`
+	Question    string          `json:"question"`
+	MarketSlug  string          `json:"market_slug"`
+	Image       string          `json:"image"`
+	Tokens      []types.Token   `json:"tokens"`
+	Rewards     struct {
+		Rates     []types.RewardRate `json:"rates"`
+		MinSize   float64            `json:"min_size"`
+		MaxSpread float64            `json:"max_spread"`
+	} `json:"rewards"`
+}
+
+// fetchRewardMarkets 通过游标分页拉取 sampling-markets 的全部结果
+func fetchRewardMarkets(baseURL string, options []http.HTTPOption) ([]types.RewardMarket, error) {
+	params := make(map[string]string)
+	markets := make([]types.RewardMarket, 0)
+	nextCursor := "MA=="
+
+	for nextCursor != internal.EndCursor && nextCursor != "" {
+		params["next_cursor"] = nextCursor
+
+		response, err := http.Get[types.PaginatedResponse[samplingMarket]](baseURL, internal.GetSamplingMarkets, params, options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reward markets: %w", err)
+		}
+
+		for _, m := range response.Data {
+			rewardsConfig := make([]types.RewardConfig, 0, len(m.Rewards.Rates))
+			for _, rate := range m.Rewards.Rates {
+				rewardsConfig = append(rewardsConfig, types.RewardConfig{
+					AssetAddress:     rate.AssetAddress,
+					RewardsDailyRate: rate.RewardsDailyRate,
+				})
+			}
+
+			markets = append(markets, types.RewardMarket{
+				ConditionID:      m.ConditionID,
+				Question:         m.Question,
+				MarketSlug:       m.MarketSlug,
+				Image:            m.Image,
+				Tokens:           m.Tokens,
+				RewardsConfig:    rewardsConfig,
+				RewardsMaxSpread: m.Rewards.MaxSpread,
+				RewardsMinSize:   m.Rewards.MinSize,
+			})
+		}
+		nextCursor = response.NextCursor
+	}
+
+	return markets, nil
+}
@@ -0,0 +1,57 @@
+package clob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/polymas/go-polymarket-sdk/rfq"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// rfqClientImpl 询价（RFQ）相关操作的实现，请求由 rfq 包的客户端发送并附加 CLOB 客户端的 L2 认证
+type rfqClientImpl struct {
+	baseClient *baseClient
+}
+
+// buildRFQClient 按 CLOB 客户端的网络配置创建 RFQ 客户端，authHeaders 为 CLOB 客户端的 L2 认证
+func (opts *clientOptions) buildRFQClient(authHeaders rfq.AuthHeadersFunc) rfq.Client {
+	return rfq.NewClient(
+		rfq.WithProxyURL(opts.ProxyURL),
+		rfq.WithTimeouts(opts.Timeouts),
+		rfq.WithTransport(opts.Transport),
+		rfq.WithAuthHeaders(authHeaders),
+	)
+}
+
+// RequestQuote 发起询价并返回最优报价（BUY 取价格最低、SELL 取价格最高的报价）
+// 适用于直接下单会明显推动订单簿的大额订单：做市商按整笔数量报价，接受报价（AcceptQuote）后按报价成交，没有滑点。
+// 等待报价直到 ctx 结束（ctx 没有截止时间时最多 internal.RFQQuoteTimeout），超时返回 types.ErrNoRFQQuote，
+// 没有拿到报价时会取消询价请求。使用 L2 认证，只读 API 密钥无法询价
+func (c *rfqClientImpl) RequestQuote(ctx context.Context, tokenID string, side types.OrderSide, size float64) (*types.RFQQuote, error) {
+	if err := c.baseClient.ensureCanTrade(); err != nil {
+		return nil, err
+	}
+	return c.baseClient.rfqClient.RequestBestQuote(ctx, types.RFQRequest{TokenID: tokenID, Side: side, Size: size})
+}
+
+// AcceptQuote 接受报价，按报价的价格和数量成交
+// 返回的 OrderPostResponse 中 OrderID 为成交产生的订单，使用 L2 认证
+func (c *rfqClientImpl) AcceptQuote(quoteID string) (*types.OrderPostResponse, error) {
+	if quoteID == "" {
+		return nil, fmt.Errorf("quote ID is required")
+	}
+	if err := c.baseClient.ensureCanTrade(); err != nil {
+		return nil, err
+	}
+
+	// 成交后余额可能已变化，使余额缓存失效
+	defer c.baseClient.balances.invalidate()
+
+	accepted, err := c.baseClient.rfqClient.AcceptQuote(quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept quote: %w", err)
+	}
+	result := accepted.OrderPostResponse()
+	result.Source = c.baseClient.orderSource
+	return result, nil
+}
@@ -22,17 +22,57 @@ type Client interface {
 // polymarketDataClient 处理数据API操作
 // 不允许直接导出，只能通过 NewPolymarketDataClient 创建
 type polymarketDataClient struct {
-	baseURL string // API 基础 URL
+	baseURL     string            // API 基础 URL
+	httpOptions []http.HTTPOption // 客户端级别的 HTTP 选项（如代理）
+}
+
+// ClientOption 数据客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions 数据客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+}
+
+// WithProxyURL 设置客户端使用的代理地址
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
+}
+
+// WithTimeouts 设置客户端的超时配置，未设置的字段使用默认值
+// 只使用其中的 HTTP 超时
+func WithTimeouts(timeouts types.Timeouts) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Timeouts = timeouts
+	}
+}
+
+// WithTransport 设置 Data API 请求使用的传输层（见 http.WithTransport）
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Transport = transport
+	}
 }
 
 // NewClient 创建新的数据客户端
+// 代理地址无效时后续请求都返回该错误
 // 返回 Client 接口，不允许直接访问实现类型
-func NewClient() Client {
+func NewClient(options ...ClientOption) Client {
+	opts := internal.ApplyOptions(&clientOptions{}, options)
 	return &polymarketDataClient{
-		baseURL: internal.DataAPIDomain,
+		baseURL:     internal.DataAPIDomain,
+		httpOptions: http.ConnectionHTTPOptions(opts.ConnectionOptions),
 	}
 }
 
+// requestOptions 合并客户端级别的 HTTP 选项和请求级别的选项
+func (c *polymarketDataClient) requestOptions(options ...http.HTTPOption) []http.HTTPOption {
+	return append(append([]http.HTTPOption{}, c.httpOptions...), options...)
+}
+
 // GetPositionsOptions 包含 GetPositions 的所有可选参数
 type GetPositionsOptions struct {
 	Limit         int         // limit, 默认值为 500
@@ -179,7 +219,7 @@ func (c *polymarketDataClient) GetPositions(user types.EthAddress, options ...Ge
 		params["sortDirection"] = opts.SortDirection
 	}
 
-	return http.GetSlice[types.Position](c.baseURL, "/positions", params)
+	return http.GetSlice[types.Position](c.baseURL, "/positions", params, c.requestOptions()...)
 }
 
 // GetTradesOptions 包含 GetTrades 的所有可选参数
@@ -294,7 +334,7 @@ func (c *polymarketDataClient) GetTrades(limit int, offset int, options ...GetTr
 		params["side"] = string(*opts.Side)
 	}
 
-	return http.GetSlice[types.Trade](c.baseURL, "/trades", params)
+	return http.GetSlice[types.Trade](c.baseURL, "/trades", params, c.requestOptions()...)
 }
 
 // GetActivityOptions 包含 GetActivity 的所有可选参数
@@ -423,7 +463,7 @@ func (c *polymarketDataClient) GetActivity(user types.EthAddress, limit int, off
 		params["sortDirection"] = opts.SortDirection
 	}
 
-	return http.GetSlice[types.Activity](c.baseURL, "/activity", params)
+	return http.GetSlice[types.Activity](c.baseURL, "/activity", params, c.requestOptions()...)
 }
 
 // GetValue 获取仓位价值
@@ -452,7 +492,7 @@ func (c *polymarketDataClient) GetValue(
 	}
 
 	var responses []types.ValueResponse
-	resp, err := http.Get[[]types.ValueResponse](c.baseURL, "/value", params)
+	resp, err := http.Get[[]types.ValueResponse](c.baseURL, "/value", params, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get value: %w", err)
 	}
@@ -1,6 +1,10 @@
 package gamma
 
 import (
+	"context"
+	"time"
+
+	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
@@ -10,11 +14,18 @@ type Client interface {
 	// 市场相关方法
 	GetMarket(marketID string) (*types.GammaMarket, error)
 	GetMarketBySlug(slug string, includeTag *bool) (*types.GammaMarket, error)
+	GetMarketBySlugRaw(slug string, includeTag *bool) (*types.GammaMarket, []byte, error) // 同时返回原始 JSON 响应
 	GetMarketsByConditionIDs(conditionIDs []string) ([]types.GammaMarket, error)
+	LookupMarketsByConditionIDs(conditionIDs []string) ([]types.GammaMarket, []string, error)
+	GetMarketsByIDs(ids []string) ([]types.GammaMarket, error)
+	LookupMarketsByIDs(ids []string) ([]types.GammaMarket, []string, error)
 	GetMarkets(limit int, options ...GetMarketsOption) ([]types.GammaMarket, error) // 获取市场列表（支持分页和过滤）
 	GetCertaintyMarkets() ([]types.GammaMarket, error)                              // 获取 Certainty 市场（尾盘市场）
 	GetDisputeMarkets() ([]types.GammaMarket, error)                                // 获取争议市场（在 Certainty 市场基础上过滤）
 	GetAllMarkets() ([]types.GammaMarket, error)                                    // 获取所有历史市场数据（自动分页）
+	GetRecentlyClosedMarkets(since time.Time) ([]types.GammaMarket, error)          // 获取 since 之后关闭的市场（自动分页）
+	// 自动分页逐个回调市场，不在内存中保存全部市场
+	IterateMarkets(ctx context.Context, fn func(market types.GammaMarket) error, options ...GetMarketsOption) error
 
 	// 事件相关方法
 	GetEvent(eventID int, includeChat *bool, includeTemplate *bool) (*types.Event, error)
@@ -41,18 +52,72 @@ type Client interface {
 	GetSamplingMarkets(limit int) ([]types.GammaMarket, error)
 	GetSimplifiedMarkets(limit int, offset int, options ...GetMarketsOption) ([]types.SimplifiedMarket, error)
 	GetMarketTradesEvents(marketID string, limit int, offset int) ([]types.MarketTradesEvent, error)
+	// 网络环境
+	ChainID() types.ChainID
+	Environment() types.Environment
 }
 
 // polymarketGammaClient 处理Gamma API操作
 // 不允许直接导出，只能通过 NewPolymarketGammaClient 创建
 type polymarketGammaClient struct {
-	baseURL string // API 基础 URL
+	baseURL     string            // API 基础 URL
+	httpOptions []http.HTTPOption // 客户端级别的 HTTP 选项（如代理）
+}
+
+// ClientOption Gamma客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions Gamma客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+}
+
+// WithProxyURL 设置客户端使用的代理地址
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
+}
+
+// WithTimeouts 设置客户端的超时配置，未设置的字段使用默认值
+// 只使用其中的 HTTP 超时
+func WithTimeouts(timeouts types.Timeouts) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Timeouts = timeouts
+	}
+}
+
+// WithTransport 替换 Gamma API 请求的传输层，例如在测试中传入 http.Replayer（见 http.WithTransport）
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Transport = transport
+	}
 }
 
 // NewClient 创建新的Gamma客户端
+// 代理地址无效时后续请求都返回该错误
 // 返回 Client 接口，不允许直接访问实现类型
-func NewClient() Client {
+func NewClient(options ...ClientOption) Client {
+	opts := internal.ApplyOptions(&clientOptions{}, options)
 	return &polymarketGammaClient{
-		baseURL: internal.GammaAPIDomain,
+		baseURL:     internal.GammaAPIDomain,
+		httpOptions: http.ConnectionHTTPOptions(opts.ConnectionOptions),
 	}
 }
+
+// ChainID 返回 Gamma API 数据对应的链ID
+// Gamma API 只提供主网数据，始终返回 Polygon
+func (c *polymarketGammaClient) ChainID() types.ChainID {
+	return internal.Polygon
+}
+
+// Environment 返回 Gamma 客户端所在的网络环境
+func (c *polymarketGammaClient) Environment() types.Environment {
+	return c.ChainID().Environment()
+}
+
+// requestOptions 合并客户端级别的 HTTP 选项和请求级别的选项
+func (c *polymarketGammaClient) requestOptions(options ...http.HTTPOption) []http.HTTPOption {
+	return append(append([]http.HTTPOption{}, c.httpOptions...), options...)
+}
@@ -1,10 +1,21 @@
 package gamma
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	sdkhttp "github.com/polymas/go-polymarket-sdk/http"
+	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/test"
+	"github.com/polymas/go-polymarket-sdk/types"
 )
 
 func TestGetMarket(t *testing.T) {
@@ -57,6 +68,24 @@ func TestGetMarketBySlug(t *testing.T) {
 		t.Logf("GetMarketBySlug returned market: %s", market.Slug)
 	})
 
+	// 同时返回原始 JSON，可读取结构体未建模的字段
+	t.Run("Raw", func(t *testing.T) {
+		market, raw, err := client.GetMarketBySlugRaw(config.TestMarketSlug, nil)
+		if err != nil {
+			t.Fatalf("GetMarketBySlugRaw failed: %v", err)
+		}
+		if market == nil || len(raw) == 0 {
+			t.Fatal("GetMarketBySlugRaw returned empty result")
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			t.Fatalf("Raw response is not valid JSON: %v", err)
+		}
+		if fields["slug"] != market.Slug {
+			t.Errorf("Expected raw slug %q, got %v", market.Slug, fields["slug"])
+		}
+	})
+
 	// 不带includeTag测试
 	t.Run("WithoutIncludeTag", func(t *testing.T) {
 		market, err := client.GetMarketBySlug(config.TestMarketSlug, nil)
@@ -105,6 +134,67 @@ func TestGetMarketsByConditionIDs(t *testing.T) {
 	})
 }
 
+func TestOrderMarketsByConditionIDs(t *testing.T) {
+	markets := []types.GammaMarket{
+		{MarketID: "2", ConditionID: "0xBBB"},
+		{MarketID: "1", ConditionID: "0xaaa"},
+		{MarketID: "3", ConditionID: "0xccc"},
+	}
+
+	// 按输入顺序排列，大小写不敏感，重复输入只返回一次，并报告未找到的条件ID
+	ordered, missing := orderMarketsByConditionIDs(markets, []string{"0xAAA", "0xddd", "0xbbb", "0xaaa"})
+	ids := make([]string, len(ordered))
+	for i, m := range ordered {
+		ids[i] = m.MarketID
+	}
+	if got := strings.Join(ids, ","); got != "1,2" {
+		t.Errorf("Expected markets 1,2, got %s", got)
+	}
+	if len(missing) != 1 || missing[0] != "0xddd" {
+		t.Errorf("Expected missing [0xddd], got %v", missing)
+	}
+}
+
+func TestLookupMarketsByIDs(t *testing.T) {
+	query := url.Values{}
+	query.Set("include_tag", "true")
+	query.Set("limit", "500")
+	query.Set("offset", "0")
+	query["id"] = []string{"12", "7", "99", "12"}
+
+	// API 返回顺序与请求不同，且不包含不存在的市场
+	replayer := sdkhttp.NewReplayerFromInteractions([]sdkhttp.Interaction{{
+		Method:     "GET",
+		URL:        internal.GammaAPIDomain + "/markets?" + query.Encode(),
+		StatusCode: 200,
+		Body: `[
+			{"id": "7", "conditionId": "0x07"},
+			{"id": "12", "conditionId": "0x12"}
+		]`,
+	}})
+	client := NewClient(WithTransport(replayer))
+
+	markets, missing, err := client.LookupMarketsByIDs([]string{"12", "7", "99", "12"})
+	if err != nil {
+		t.Fatalf("LookupMarketsByIDs failed: %v", err)
+	}
+	ids := make([]string, len(markets))
+	for i, m := range markets {
+		ids[i] = m.MarketID
+	}
+	if got := strings.Join(ids, ","); got != "12,7" {
+		t.Errorf("Expected markets 12,7, got %s", got)
+	}
+	if len(missing) != 1 || missing[0] != "99" {
+		t.Errorf("Expected missing [99], got %v", missing)
+	}
+
+	empty, err := client.GetMarketsByIDs(nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected empty result for no IDs, got %v (err=%v)", empty, err)
+	}
+}
+
 func TestGetMarkets(t *testing.T) {
 	client := NewClient()
 
@@ -188,6 +278,35 @@ func TestGetMarkets(t *testing.T) {
 	})
 }
 
+func TestGetMarketsEnableOrderBook(t *testing.T) {
+	query := url.Values{}
+	query.Set("include_tag", "true")
+	query.Set("limit", "5")
+	query.Set("offset", "0")
+	query.Set("active", "true")
+	query.Set("enableOrderBook", "true")
+
+	// 模拟服务端忽略 enableOrderBook 参数，返回了 AMM 市场
+	replayer := sdkhttp.NewReplayerFromInteractions([]sdkhttp.Interaction{{
+		Method:     "GET",
+		URL:        internal.GammaAPIDomain + "/markets?" + query.Encode(),
+		StatusCode: 200,
+		Body: `[
+			{"id": "1", "conditionId": "0x01", "clobTokenIds": "[\"101\", \"102\"]", "active": true, "enableOrderBook": true},
+			{"id": "2", "conditionId": "0x02", "clobTokenIds": "[]", "active": true, "enableOrderBook": false}
+		]`,
+	}})
+	client := NewClient(WithTransport(replayer))
+
+	markets, err := client.GetMarkets(5, WithActive(true), WithEnableOrderBook(true))
+	if err != nil {
+		t.Fatalf("GetMarkets failed: %v", err)
+	}
+	if len(markets) != 1 || markets[0].MarketID != "1" || !markets[0].EnableOrderBook {
+		t.Errorf("Expected only the order-book market, got %+v", markets)
+	}
+}
+
 func TestGetCertaintyMarkets(t *testing.T) {
 	client := NewClient()
 
@@ -223,7 +342,7 @@ func TestGetDisputeMarkets(t *testing.T) {
 func TestGetAllMarkets(t *testing.T) {
 	// 此测试已被标记为不测试，因为获取所有历史市场数据需要很长时间且容易超时
 	t.Skip("TestGetAllMarkets is disabled - requires very long timeout and large dataset")
-	
+
 	client := NewClient()
 
 	// 基本功能测试（这个测试可能很慢，所以只在非short模式下运行）
@@ -248,6 +367,105 @@ func TestGetAllMarkets(t *testing.T) {
 	})
 }
 
+func TestIterateMarkets(t *testing.T) {
+	page := func(offset string, body string) test.Fixture {
+		return test.Fixture{Path: "/markets?active=true&enableOrderBook=true&include_tag=true&limit=2&offset=" + offset, Body: body}
+	}
+	// 第一页中的 AMM 市场被本地过滤，但页面仍是满的，需要继续请求；市场 2 在第二页重复出现
+	transport := test.NewFixtureTransport(t,
+		page("0", `[{"id": "1", "enableOrderBook": true}, {"id": "9", "enableOrderBook": false}]`),
+		page("2", `[{"id": "2", "enableOrderBook": true}, {"id": "3", "enableOrderBook": true}]`),
+		page("4", `[{"id": "2", "enableOrderBook": true}]`),
+	)
+	client := NewClient(WithTransport(transport)).(*polymarketGammaClient)
+
+	t.Run("AllPages", func(t *testing.T) {
+		var ids []string
+		err := client.iterateMarkets(context.Background(), 2, func(market types.GammaMarket) error {
+			ids = append(ids, market.MarketID)
+			return nil
+		}, WithActive(true), WithEnableOrderBook(true))
+		if err != nil {
+			t.Fatalf("iterateMarkets failed: %v", err)
+		}
+		if strings.Join(ids, ",") != "1,2,3" {
+			t.Errorf("Expected markets 1,2,3 across pages, got %v", ids)
+		}
+	})
+
+	// 回调返回错误时停止，不再请求后续页面
+	t.Run("StopOnError", func(t *testing.T) {
+		before := len(transport.Requests())
+		stop := errors.New("stop")
+		err := client.iterateMarkets(context.Background(), 2, func(market types.GammaMarket) error {
+			return stop
+		}, WithActive(true), WithEnableOrderBook(true))
+		if !errors.Is(err, stop) {
+			t.Errorf("Expected callback error, got %v", err)
+		}
+		if requests := len(transport.Requests()) - before; requests != 1 {
+			t.Errorf("Expected 1 request before stopping, got %d", requests)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := client.IterateMarkets(ctx, func(market types.GammaMarket) error { return nil })
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestGetRecentlyClosedMarkets(t *testing.T) {
+	// 按 closedTime 降序的一页已关闭市场：closedTime 有 RFC3339 和 API 的非标准格式，也可能缺失
+	transport := test.NewFixtureTransport(t, test.Fixture{
+		Path: "/markets?ascending=false&closed=true&include_tag=true&limit=500&offset=0&order=closedTime",
+		Body: `[
+			{"id": "1", "closed": true, "closedTime": "2025-12-03T10:00:00Z"},
+			{"id": "2", "closed": true, "closedTime": "2025-12-02 23:49:11+00"},
+			{"id": "3", "closed": true},
+			{"id": "4", "closed": true, "closedTime": "2025-11-30T00:00:00Z"}
+		]`,
+	})
+	client := NewClient(WithTransport(transport))
+
+	// 基本功能测试
+	t.Run("Basic", func(t *testing.T) {
+		since := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+		markets, err := client.GetRecentlyClosedMarkets(since)
+		if err != nil {
+			t.Fatalf("GetRecentlyClosedMarkets failed: %v", err)
+		}
+		var ids []string
+		for _, market := range markets {
+			if market.ClosedTime == nil || market.ClosedTime.Before(since) {
+				t.Errorf("Market %s closed before %v: %v", market.MarketID, since, market.ClosedTime)
+			}
+			ids = append(ids, market.MarketID)
+		}
+		if strings.Join(ids, ",") != "1,2" {
+			t.Errorf("Expected markets 1,2 closed after %v, got %v", since, ids)
+		}
+	})
+
+	// 边界条件测试 - 未来时间应返回空结果，且不再请求后续页面
+	t.Run("FutureSince", func(t *testing.T) {
+		before := len(transport.Requests())
+		markets, err := client.GetRecentlyClosedMarkets(time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("GetRecentlyClosedMarkets failed: %v", err)
+		}
+		if len(markets) != 0 {
+			t.Errorf("Expected no markets closed in the future, got %d", len(markets))
+		}
+		if requests := len(transport.Requests()) - before; requests != 1 {
+			t.Errorf("Expected 1 request, got %d", requests)
+		}
+	})
+}
+
 func TestGetEvent(t *testing.T) {
 	client := NewClient()
 	config := test.LoadTestConfig()
@@ -592,8 +810,8 @@ func TestGetProfile(t *testing.T) {
 		profile, err := client.GetProfile(userAddr)
 		if err != nil {
 			// 如果API端点不存在（404/405），跳过测试
-			if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "405") || 
-			   strings.Contains(err.Error(), "Not Found") || strings.Contains(err.Error(), "Method Not Allowed") {
+			if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "405") ||
+				strings.Contains(err.Error(), "Not Found") || strings.Contains(err.Error(), "Method Not Allowed") {
 				t.Skip("Skipping test: GetProfile API endpoint not found or method not allowed (may be deprecated)")
 				return
 			}
@@ -726,3 +944,251 @@ func TestGetMarketTradesEvents(t *testing.T) {
 		t.Logf("GetMarketTradesEvents returned %d events", len(events))
 	})
 }
+
+// loadMarketsFixture 读取 testdata 中的市场 fixture（不访问网络）
+func loadMarketsFixture(t *testing.T, fixture string) []types.GammaMarket {
+	data, err := os.ReadFile(filepath.Join("testdata", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", fixture, err)
+	}
+
+	var markets []types.GammaMarket
+	if err := json.Unmarshal(data, &markets); err != nil {
+		t.Fatalf("Failed to parse fixture %s: %v", fixture, err)
+	}
+	return markets
+}
+
+func TestMarketIsTradeable(t *testing.T) {
+	markets := loadMarketsFixture(t, "markets_undeployed.json")
+
+	expected := map[string]bool{
+		"tradeable":              true,
+		"null-token-ids":         false,
+		"empty-string-token-ids": false,
+		"empty-array-token-ids":  false,
+		"missing-token-ids":      false,
+		"not-accepting":          false,
+		"closed":                 false,
+	}
+	if len(markets) != len(expected) {
+		t.Fatalf("Expected %d markets, got %d", len(expected), len(markets))
+	}
+
+	for _, m := range markets {
+		t.Run(m.Slug, func(t *testing.T) {
+			if got := m.IsTradeable(); got != expected[m.Slug] {
+				t.Errorf("Expected IsTradeable=%v, got %v", expected[m.Slug], got)
+			}
+			// 未部署的市场 TokenIDs 必须为空，不能包含空字符串
+			for _, tokenID := range m.TokenIDs {
+				if tokenID == "" {
+					t.Errorf("Expected no empty token IDs, got %q", m.TokenIDs)
+				}
+			}
+		})
+	}
+
+	var nilMarket *types.GammaMarket
+	if nilMarket.IsTradeable() {
+		t.Error("Expected nil market not to be tradeable")
+	}
+}
+
+func TestWaitUntilAcceptingOrders(t *testing.T) {
+	t.Run("NoTimestamp", func(t *testing.T) {
+		market := &types.GammaMarket{}
+		if market.AcceptingOrdersAt() != nil {
+			t.Error("Expected nil accepting-orders time")
+		}
+		if err := market.WaitUntilAcceptingOrders(context.Background()); err != nil {
+			t.Errorf("Expected immediate return, got %v", err)
+		}
+	})
+
+	t.Run("AlreadyOpen", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+		market := &types.GammaMarket{AcceptingOrdersTimestamp: &past}
+		if err := market.WaitUntilAcceptingOrders(context.Background()); err != nil {
+			t.Errorf("Expected immediate return, got %v", err)
+		}
+	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		openAt := time.Now().Add(50 * time.Millisecond)
+		market := &types.GammaMarket{AcceptingOrdersTimestamp: &openAt}
+		if got := market.AcceptingOrdersAt(); got == nil || !got.Equal(openAt) {
+			t.Fatalf("Expected accepting-orders time %v, got %v", openAt, got)
+		}
+		if err := market.WaitUntilAcceptingOrders(context.Background()); err != nil {
+			t.Fatalf("WaitUntilAcceptingOrders failed: %v", err)
+		}
+		if time.Now().Before(openAt) {
+			t.Error("Returned before the market opened")
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		openAt := time.Now().Add(time.Hour)
+		market := &types.GammaMarket{AcceptingOrdersTimestamp: &openAt}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := market.WaitUntilAcceptingOrders(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+	})
+}
+
+func TestTokenIDPrecision(t *testing.T) {
+	// 超过 2^53 的 token ID，经过 float64 会丢失精度
+	const tokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	const otherID = "48331043336612883890938759509493159234755048973500640148014422747788308965732"
+
+	cases := map[string]string{
+		"StringArrayInString": `{"id":"1","clobTokenIds":"[\"` + tokenID + `\", \"` + otherID + `\"]"}`,
+		"NumberArrayInString": `{"id":"1","clobTokenIds":"[` + tokenID + `, ` + otherID + `]"}`,
+		"NumberArray":         `{"id":"1","clobTokenIds":[` + tokenID + `, ` + otherID + `]}`,
+		"StringArray":         `{"id":"1","clobTokenIds":["` + tokenID + `", "` + otherID + `"]}`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			var market types.GammaMarket
+			if err := json.Unmarshal([]byte(data), &market); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if len(market.TokenIDs) != 2 || market.TokenIDs[0] != tokenID || market.TokenIDs[1] != otherID {
+				t.Errorf("Token IDs lost precision: %v", market.TokenIDs)
+			}
+
+			// 重新编码后再解析，token ID 保持不变
+			encoded, err := json.Marshal(market)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var decoded types.GammaMarket
+			if err := json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("Unmarshal of re-encoded market failed: %v", err)
+			}
+			if len(decoded.TokenIDs) != 2 || decoded.TokenIDs[0] != tokenID || decoded.TokenIDs[1] != otherID {
+				t.Errorf("Token IDs changed after round trip: %v", decoded.TokenIDs)
+			}
+		})
+	}
+}
+
+func TestParseOutcomePrices(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   interface{}
+		want    []float64
+		wantErr bool
+	}{
+		{"JSONString", `["0.95", "0.05"]`, []float64{0.95, 0.05}, false},
+		{"NumberArrayInString", `[0.95, 0.05]`, []float64{0.95, 0.05}, false},
+		{"ScientificNotation", `["1e-3", "9.99E-1"]`, []float64{0.001, 0.999}, false},
+		{"ScientificNumbers", `[1e-3, 0.999]`, []float64{0.001, 0.999}, false},
+		{"CommaSeparated", `0.2, 0.3, 0.5`, []float64{0.2, 0.3, 0.5}, false},
+		{"ManyOutcomes", `["0.01","0.02","0.03","0.04","0.05","0.06","0.07","0.08","0.09","0.1","0.45"]`,
+			[]float64{0.01, 0.02, 0.03, 0.04, 0.05, 0.06, 0.07, 0.08, 0.09, 0.1, 0.45}, false},
+		{"Array", []interface{}{0.4, "0.6"}, []float64{0.4, 0.6}, false},
+		{"EmptyString", ``, nil, false},
+		{"EmptyElement", `["0.5", "", "0.5"]`, nil, true},
+		{"MissingElement", `0.5,,0.5`, nil, true},
+		{"Garbage", `["0.5", "abc"]`, nil, true},
+		{"NaN", `["NaN", "0.5"]`, nil, true},
+		{"NullElement", `[0.5, null]`, nil, true},
+		{"NotArray", `{"yes": 0.5}`, nil, true},
+		{"UnsupportedType", 0.5, nil, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := types.ParseOutcomePrices(c.value)
+			if c.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOutcomePrices failed: %v", err)
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("Expected %v, got %v", c.want, got)
+			}
+			for i := range got {
+				if math.Abs(got[i]-c.want[i]) > 1e-12 {
+					t.Errorf("Expected %v, got %v", c.want, got)
+					break
+				}
+			}
+		})
+	}
+
+	// 市场解析时无法解析的价格按 0 保留位置，价格数量与 TokenIDs 一致
+	t.Run("MarketKeepsAlignment", func(t *testing.T) {
+		data := `{"id":"1","clobTokenIds":"[\"1\", \"2\", \"3\"]","outcomePrices":"[\"0.4\", \"\", \"0.6\"]"}`
+		var market types.GammaMarket
+		if err := json.Unmarshal([]byte(data), &market); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if len(market.OutcomePrices) != 3 || market.OutcomePrices[1] != 0 || market.OutcomePrices[2] != 0.6 {
+			t.Errorf("Expected aligned prices, got %v", market.OutcomePrices)
+		}
+	})
+
+	// 价格数量少于 TokenIDs 时不会越界
+	t.Run("ShortPrices", func(t *testing.T) {
+		market := &types.GammaMarket{TokenIDs: []string{"1", "2", "3"}, OutcomePrices: []float64{0.5}, Outcomes: []string{"A"}}
+		if prices := types.GetOutcomePrices(market); len(prices) != 1 || prices["1"] != 0.5 {
+			t.Errorf("Unexpected prices: %v", prices)
+		}
+		if names := types.GetOutcomeNames(market); len(names) != 1 || names["1"] != "A" {
+			t.Errorf("Unexpected names: %v", names)
+		}
+	})
+}
+
+func TestNormalizeMarkets(t *testing.T) {
+	marketIDs := func(markets []types.GammaMarket) string {
+		ids := make([]string, len(markets))
+		for i, m := range markets {
+			ids[i] = m.MarketID
+		}
+		return strings.Join(ids, ",")
+	}
+
+	// 未指定排序时去重并按 MarketID 数值升序排序
+	t.Run("SortByID", func(t *testing.T) {
+		markets := normalizeMarkets(loadMarketsFixture(t, "markets_duplicates.json"), true)
+		if got := marketIDs(markets); got != "3,7,12" {
+			t.Errorf("Expected markets 3,7,12, got %s", got)
+		}
+	})
+
+	// 指定排序时保持 API 返回顺序，只去重
+	t.Run("KeepOrder", func(t *testing.T) {
+		markets := normalizeMarkets(loadMarketsFixture(t, "markets_duplicates.json"), false)
+		if got := marketIDs(markets); got != "12,3,7" {
+			t.Errorf("Expected markets 12,3,7, got %s", got)
+		}
+	})
+
+	// 重复调用结果一致
+	t.Run("Stable", func(t *testing.T) {
+		first := marketIDs(normalizeMarkets(loadMarketsFixture(t, "markets_duplicates.json"), true))
+		second := marketIDs(normalizeMarkets(loadMarketsFixture(t, "markets_duplicates.json"), true))
+		if first != second {
+			t.Errorf("Expected stable ordering, got %s and %s", first, second)
+		}
+	})
+}
+
+func TestInvalidProxyURL(t *testing.T) {
+	// 无效代理地址不能回退到环境变量代理或直连，即使同时设置了传输层也不发出请求
+	transport := sdkhttp.NewReplayerFromInteractions(nil)
+	client := NewClient(WithProxyURL("ftp://127.0.0.1:1080"), WithTransport(transport))
+	_, err := client.GetMarket("1")
+	if err == nil || !strings.Contains(err.Error(), "invalid client options") {
+		t.Errorf("Expected invalid proxy error, got %v", err)
+	}
+}
@@ -17,7 +17,7 @@ func (c *polymarketGammaClient) GetComments(marketID string, limit int, offset i
 		"offset":    strconv.Itoa(offset),
 	}
 
-	result, err := http.Get[[]types.Comment](c.baseURL, internal.GetComments, params)
+	result, err := http.Get[[]types.Comment](c.baseURL, internal.GetComments, params, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
@@ -31,5 +31,5 @@ func (c *polymarketGammaClient) GetComments(marketID string, limit int, offset i
 
 // GetComment 获取单个评论
 func (c *polymarketGammaClient) GetComment(commentID string) (*types.Comment, error) {
-	return http.Get[types.Comment](c.baseURL, fmt.Sprintf("%s%s", internal.GetComment, commentID), nil)
+	return http.Get[types.Comment](c.baseURL, fmt.Sprintf("%s%s", internal.GetComment, commentID), nil, c.requestOptions()...)
 }
@@ -19,7 +19,7 @@ func (c *polymarketGammaClient) GetEvent(eventID int, includeChat *bool, include
 	if includeTemplate != nil {
 		params["include_template"] = strconv.FormatBool(*includeTemplate)
 	}
-	return http.Get[types.Event](c.baseURL, fmt.Sprintf("/events/%d", eventID), params)
+	return http.Get[types.Event](c.baseURL, fmt.Sprintf("/events/%d", eventID), params, c.requestOptions()...)
 }
 
 // GetEventBySlug 通过slug获取事件
@@ -31,7 +31,7 @@ func (c *polymarketGammaClient) GetEventBySlug(slug string, includeChat *bool, i
 	if includeTemplate != nil {
 		params["include_template"] = strconv.FormatBool(*includeTemplate)
 	}
-	return http.Get[types.Event](c.baseURL, fmt.Sprintf("/events/slug/%s", slug), params)
+	return http.Get[types.Event](c.baseURL, fmt.Sprintf("/events/slug/%s", slug), params, c.requestOptions()...)
 }
 
 // GetEventsOptions 包含 GetEvents 的所有可选参数
@@ -241,5 +241,5 @@ func (c *polymarketGammaClient) GetEvents(limit int, offset int, options ...GetE
 		params["tag_slug"] = *opts.TagSlug
 	}
 
-	return http.GetSlice[types.Event](c.baseURL, "/events", params)
+	return http.GetSlice[types.Event](c.baseURL, "/events", params, c.requestOptions()...)
 }
@@ -1,9 +1,13 @@
 package gamma
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
@@ -12,7 +16,7 @@ import (
 
 // GetMarket 通过市场ID获取市场
 func (c *polymarketGammaClient) GetMarket(marketID string) (*types.GammaMarket, error) {
-	return http.Get[types.GammaMarket](c.baseURL, fmt.Sprintf("/markets/%s", marketID), nil)
+	return http.Get[types.GammaMarket](c.baseURL, fmt.Sprintf("/markets/%s", marketID), nil, c.requestOptions()...)
 }
 
 // GetMarketBySlug 通过slug获取市场
@@ -21,7 +25,22 @@ func (c *polymarketGammaClient) GetMarketBySlug(slug string, includeTag *bool) (
 	if includeTag != nil {
 		params["include_tag"] = strconv.FormatBool(*includeTag)
 	}
-	return http.Get[types.GammaMarket](c.baseURL, fmt.Sprintf("/markets/slug/%s", slug), params)
+	return http.Get[types.GammaMarket](c.baseURL, fmt.Sprintf("/markets/slug/%s", slug), params, c.requestOptions()...)
+}
+
+// GetMarketBySlugRaw 通过slug获取市场，同时返回原始 JSON 响应
+// GammaMarket 字段众多且 API 会不断新增字段，可从原始 JSON 中读取结构体尚未建模的字段
+func (c *polymarketGammaClient) GetMarketBySlugRaw(slug string, includeTag *bool) (*types.GammaMarket, []byte, error) {
+	params := make(map[string]string)
+	if includeTag != nil {
+		params["include_tag"] = strconv.FormatBool(*includeTag)
+	}
+	var raw []byte
+	market, err := http.Get[types.GammaMarket](c.baseURL, fmt.Sprintf("/markets/slug/%s", slug), params, c.requestOptions(http.WithRawResponse(&raw))...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return market, raw, nil
 }
 
 // GetMarketsOptions 包含 GetMarkets 的所有可选参数
@@ -39,6 +58,9 @@ type GetMarketsOptions struct {
 	TagID               *int
 	RelatedTags         *bool
 	UmaResolutionStatus *string
+	EnableOrderBook     *bool
+	NoDedup             bool     // 为 true 时返回原始分页数据，不去重也不排序
+	ids                 []string // Gamma 市场ID（id 参数），由 LookupMarketsByIDs 设置
 }
 
 // GetMarketsOption 函数选项类型
@@ -123,6 +145,22 @@ func WithUmaResolutionStatus(status string) GetMarketsOption {
 	}
 }
 
+// WithEnableOrderBook 设置是否只获取启用了 CLOB 订单簿的市场
+// 与 WithActive(true) 组合可排除无法挂限价单的 AMM 市场；返回结果还会按 EnableOrderBook 字段再次过滤
+func WithEnableOrderBook(enabled bool) GetMarketsOption {
+	return func(opts *GetMarketsOptions) {
+		opts.EnableOrderBook = &enabled
+	}
+}
+
+// WithDedup 设置是否对结果去重（默认开启）
+// 关闭后返回 API 的原始分页数据，可能包含重复市场
+func WithDedup(dedup bool) GetMarketsOption {
+	return func(opts *GetMarketsOptions) {
+		opts.NoDedup = !dedup
+	}
+}
+
 // GetDisputeMarkets 获取争议市场
 // 在 Certainty 市场基础上，过滤出有 dispute 状态的市场
 func (c *polymarketGammaClient) GetDisputeMarkets() ([]types.GammaMarket, error) {
@@ -143,6 +181,42 @@ func (c *polymarketGammaClient) GetDisputeMarkets() ([]types.GammaMarket, error)
 	return disputeMarkets, nil
 }
 
+// GetMarketsByIDs 根据 Gamma 市场ID（GammaMarket.MarketID，不同于条件ID）列表获取市场
+// 使用 id 参数批量查询（每次最多 500 个），结果按输入顺序排列，未找到的市场ID会被跳过
+// 需要知道哪些市场ID未找到时使用 LookupMarketsByIDs
+func (c *polymarketGammaClient) GetMarketsByIDs(ids []string) ([]types.GammaMarket, error) {
+	markets, _, err := c.LookupMarketsByIDs(ids)
+	return markets, err
+}
+
+// LookupMarketsByIDs 根据 Gamma 市场ID列表获取市场，同时返回未找到的市场ID
+// 返回的市场按输入顺序排列（重复ID只保留一次），missing 按输入顺序列出未找到的市场ID
+func (c *polymarketGammaClient) LookupMarketsByIDs(ids []string) ([]types.GammaMarket, []string, error) {
+	if len(ids) == 0 {
+		return []types.GammaMarket{}, []string{}, nil
+	}
+
+	const batchSize = 500
+	fetched := make([]types.GammaMarket, 0, len(ids))
+	for i := 0; i < len(ids); i += batchSize {
+		end := i + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[i:end]
+		markets, err := c.getMarkets(batchSize, func(opts *GetMarketsOptions) {
+			opts.ids = batch
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		fetched = append(fetched, markets...)
+	}
+
+	markets, missing := orderMarketsByIDs(fetched, ids)
+	return markets, missing, nil
+}
+
 // GetCertaintyMarkets 获取尾盘数据（Certainty 市场）
 // 过滤条件：active=true, closed=false, uma_resolution_status=proposed, 按 endDate 升序排序
 func (c *polymarketGammaClient) GetCertaintyMarkets() ([]types.GammaMarket, error) {
@@ -156,17 +230,100 @@ func (c *polymarketGammaClient) GetCertaintyMarkets() ([]types.GammaMarket, erro
 }
 
 // GetMarketsByConditionIDs 根据条件ID列表获取市场
+// 使用 condition_ids 参数批量查询（每次最多 500 个），结果按输入顺序排列，未找到的条件ID会被跳过
+// 需要知道哪些条件ID未找到时使用 LookupMarketsByConditionIDs
 func (c *polymarketGammaClient) GetMarketsByConditionIDs(conditionIDs []string) ([]types.GammaMarket, error) {
+	markets, _, err := c.LookupMarketsByConditionIDs(conditionIDs)
+	return markets, err
+}
+
+// LookupMarketsByConditionIDs 根据条件ID列表获取市场，同时返回未找到的条件ID
+// 结果按输入顺序排列（重复的条件ID只返回一次），条件ID比较不区分大小写
+func (c *polymarketGammaClient) LookupMarketsByConditionIDs(conditionIDs []string) ([]types.GammaMarket, []string, error) {
 	if len(conditionIDs) == 0 {
-		return []types.GammaMarket{}, nil
+		return []types.GammaMarket{}, []string{}, nil
+	}
+
+	const batchSize = 500
+	fetched := make([]types.GammaMarket, 0, len(conditionIDs))
+	for i := 0; i < len(conditionIDs); i += batchSize {
+		end := i + batchSize
+		if end > len(conditionIDs) {
+			end = len(conditionIDs)
+		}
+		markets, err := c.getMarkets(batchSize, WithConditionIDs(conditionIDs[i:end]))
+		if err != nil {
+			return nil, nil, err
+		}
+		fetched = append(fetched, markets...)
 	}
-	return c.getMarkets(500, WithConditionIDs(conditionIDs))
+
+	markets, missing := orderMarketsByConditionIDs(fetched, conditionIDs)
+	return markets, missing, nil
+}
+
+// orderMarketsByConditionIDs 按输入的条件ID顺序排列市场，并返回未找到的条件ID
+func orderMarketsByConditionIDs(markets []types.GammaMarket, conditionIDs []string) ([]types.GammaMarket, []string) {
+	return orderMarketsByKey(markets, conditionIDs, func(market types.GammaMarket) string {
+		return string(market.ConditionID)
+	}, strings.ToLower)
+}
+
+// orderMarketsByIDs 按输入的 Gamma 市场ID顺序排列市场，并返回未找到的市场ID
+func orderMarketsByIDs(markets []types.GammaMarket, ids []string) ([]types.GammaMarket, []string) {
+	return orderMarketsByKey(markets, ids, func(market types.GammaMarket) string {
+		return market.MarketID
+	}, strings.TrimSpace)
+}
+
+// orderMarketsByKey 按 keys 的顺序排列市场（重复的 key 只保留一次），并返回未找到的 key
+// marketKey 取出市场的标识，normalize 用于比较前规范化标识（如忽略大小写）
+func orderMarketsByKey(markets []types.GammaMarket, keys []string, marketKey func(types.GammaMarket) string, normalize func(string) string) ([]types.GammaMarket, []string) {
+	byKey := make(map[string]types.GammaMarket, len(markets))
+	for _, market := range markets {
+		key := normalize(marketKey(market))
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = market
+		}
+	}
+
+	ordered := make([]types.GammaMarket, 0, len(keys))
+	missing := make([]string, 0)
+	seen := make(map[string]bool, len(keys))
+	for _, rawKey := range keys {
+		key := normalize(rawKey)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if market, ok := byKey[key]; ok {
+			ordered = append(ordered, market)
+		} else {
+			missing = append(missing, rawKey)
+		}
+	}
+	return ordered, missing
 }
 
 // GetMarkets 获取市场列表（支持分页和过滤）
 // limit 是每页的数量，options 是过滤选项
+// 组合过滤条件时 API 可能返回重复市场，默认按 MarketID 去重（保留首次出现的记录）
+// 排序规则：指定了 WithOrder 时保持 API 返回的顺序，否则按 MarketID 数值升序排序，保证重复调用结果一致
+// 使用 WithDedup(false) 可获取原始分页数据
 func (c *polymarketGammaClient) GetMarkets(limit int, options ...GetMarketsOption) ([]types.GammaMarket, error) {
-	return c.getMarkets(limit, options...)
+	markets, err := c.getMarkets(limit, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &GetMarketsOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+	if opts.NoDedup {
+		return markets, nil
+	}
+	return normalizeMarkets(markets, opts.Order == nil), nil
 }
 
 // GetAllMarkets 获取所有历史市场数据（自动分页）
@@ -198,13 +355,104 @@ func (c *polymarketGammaClient) GetAllMarkets() ([]types.GammaMarket, error) {
 		page++
 	}
 
+	// 分页过程中数据可能变化导致跨页重复，按 MarketID 去重
+	allMarkets = normalizeMarkets(allMarkets, false)
+
 	return allMarkets, nil
 }
 
+// IterateMarkets 自动分页遍历符合过滤条件的市场，对每个市场调用 fn，不在内存中保存全部市场
+// 适用于全量扫描（市场总数有数万个）；options 与 GetMarkets 相同，WithOffset 指定起始位置。
+// 跨页重复的市场（分页期间数据变化导致）按 MarketID 只回调一次；
+// fn 返回错误时立即停止并返回该错误，ctx 结束时在请求下一页前停止并返回 ctx.Err()
+func (c *polymarketGammaClient) IterateMarkets(ctx context.Context, fn func(market types.GammaMarket) error, options ...GetMarketsOption) error {
+	return c.iterateMarkets(ctx, 500, fn, options...)
+}
+
+// iterateMarkets IterateMarkets 的实现，pageSize 为每页请求的数量
+func (c *polymarketGammaClient) iterateMarkets(ctx context.Context, pageSize int, fn func(market types.GammaMarket) error, options ...GetMarketsOption) error {
+	opts := &GetMarketsOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	seen := make(map[string]bool)
+	for offset := opts.Offset; ; offset += pageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pageOptions := append(append([]GetMarketsOption(nil), options...), WithOffset(offset))
+		markets, fetched, err := c.getMarketsPage(pageSize, pageOptions...)
+		if err != nil {
+			return err
+		}
+
+		for _, market := range markets {
+			if market.MarketID != "" {
+				if seen[market.MarketID] {
+					continue
+				}
+				seen[market.MarketID] = true
+			}
+			if err := fn(market); err != nil {
+				return err
+			}
+		}
+
+		// 按过滤前的数量判断是否为最后一页
+		if fetched < pageSize {
+			return nil
+		}
+	}
+}
+
+// GetRecentlyClosedMarkets 获取 since 之后关闭的市场（自动分页）
+// 按 closedTime 降序分页拉取已关闭市场，某一页中没有任何 since 之后关闭的市场时停止
+// API 不支持按关闭时间过滤，结果在本地按 ClosedTime >= since 过滤，没有 ClosedTime 的市场会被忽略
+// 适用于结算/赎回工具查找可赎回的仓位
+func (c *polymarketGammaClient) GetRecentlyClosedMarkets(since time.Time) ([]types.GammaMarket, error) {
+	const pageSize = 500
+	closedMarkets := make([]types.GammaMarket, 0)
+
+	for offset := 0; ; offset += pageSize {
+		markets, err := c.getMarkets(pageSize,
+			WithOffset(offset),
+			WithOrder("closedTime", false),
+			WithClosed(true),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		recentCount := 0
+		for _, market := range markets {
+			if market.ClosedTime == nil || market.ClosedTime.Before(since) {
+				continue
+			}
+			closedMarkets = append(closedMarkets, market)
+			recentCount++
+		}
+
+		// 最后一页，或本页已全部早于 since（按 closedTime 降序，后续页只会更早）
+		if len(markets) < pageSize || recentCount == 0 {
+			break
+		}
+	}
+
+	return normalizeMarkets(closedMarkets, false), nil
+}
+
 // getMarkets 使用过滤器获取市场列表（内部方法）
 // limit 是必要参数，其他参数通过选项函数传入
 // 内部使用 raw 数据解析，确保所有字段都被正确解析
 func (c *polymarketGammaClient) getMarkets(limit int, options ...GetMarketsOption) ([]types.GammaMarket, error) {
+	markets, _, err := c.getMarketsPage(limit, options...)
+	return markets, err
+}
+
+// getMarketsPage 获取一页市场，同时返回本地过滤（WithEnableOrderBook）前 API 返回的数量，用于判断是否还有下一页
+func (c *polymarketGammaClient) getMarketsPage(limit int, options ...GetMarketsOption) ([]types.GammaMarket, int, error) {
 	// 初始化默认选项
 	opts := &GetMarketsOptions{}
 
@@ -241,6 +489,9 @@ func (c *polymarketGammaClient) getMarkets(limit int, options ...GetMarketsOptio
 	if opts.UmaResolutionStatus != nil {
 		params["uma_resolution_status"] = *opts.UmaResolutionStatus
 	}
+	if opts.EnableOrderBook != nil {
+		params["enableOrderBook"] = strconv.FormatBool(*opts.EnableOrderBook)
+	}
 
 	// 多个值参数（同名参数）
 	multiParams := make(map[string][]string)
@@ -260,19 +511,72 @@ func (c *polymarketGammaClient) getMarkets(limit int, options ...GetMarketsOptio
 	if len(opts.TokenIDs) > 0 {
 		multiParams["clob_token_ids"] = opts.TokenIDs
 	}
+	if len(opts.ids) > 0 {
+		multiParams["id"] = opts.ids
+	}
 
-	rawJSON, err := http.GetRaw(c.baseURL, "GET", "/markets", params, http.WithMultiParams(multiParams))
+	rawJSON, err := http.GetRaw(c.baseURL, "GET", "/markets", params, c.requestOptions(http.WithMultiParams(multiParams))...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// 使用 types.GammaMarket 的自定义 UnmarshalJSON 解析
 	var markets1 []types.GammaMarket
 	if err := json.Unmarshal(rawJSON, &markets1); err != nil {
-		return nil, fmt.Errorf("解析市场JSON失败: %w", err)
+		return nil, 0, fmt.Errorf("解析市场JSON失败: %w", err)
+	}
+	fetched := len(markets1)
+
+	if opts.EnableOrderBook != nil {
+		markets1 = filterMarketsByOrderBook(markets1, *opts.EnableOrderBook)
 	}
 
-	return markets1, nil
+	return markets1, fetched, nil
+}
+
+// filterMarketsByOrderBook 按 EnableOrderBook 字段过滤市场
+// 服务端忽略 enableOrderBook 参数时仍能保证结果符合过滤条件
+func filterMarketsByOrderBook(markets []types.GammaMarket, enabled bool) []types.GammaMarket {
+	filtered := make([]types.GammaMarket, 0, len(markets))
+	for _, market := range markets {
+		if market.EnableOrderBook == enabled {
+			filtered = append(filtered, market)
+		}
+	}
+	return filtered
+}
+
+// normalizeMarkets 按 MarketID 去重（保留首次出现的记录）
+// sortByID 为 true 时按 MarketID 数值升序稳定排序，否则保持原有顺序
+func normalizeMarkets(markets []types.GammaMarket, sortByID bool) []types.GammaMarket {
+	seen := make(map[string]bool, len(markets))
+	result := make([]types.GammaMarket, 0, len(markets))
+	for _, market := range markets {
+		if market.MarketID != "" {
+			if seen[market.MarketID] {
+				continue
+			}
+			seen[market.MarketID] = true
+		}
+		result = append(result, market)
+	}
+
+	if sortByID {
+		sort.SliceStable(result, func(i, j int) bool {
+			return lessMarketID(result[i].MarketID, result[j].MarketID)
+		})
+	}
+	return result
+}
+
+// lessMarketID 比较两个 MarketID，数字 ID 按数值比较，否则按字符串比较
+func lessMarketID(a, b string) bool {
+	aInt, errA := strconv.ParseInt(a, 10, 64)
+	bInt, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil {
+		return aInt < bInt
+	}
+	return a < b
 }
 
 // GetSamplingSimplifiedMarkets 获取采样简化市场
@@ -281,7 +585,7 @@ func (c *polymarketGammaClient) GetSamplingSimplifiedMarkets(limit int) ([]types
 		"limit": strconv.Itoa(limit),
 	}
 
-	result, err := http.Get[[]types.SimplifiedMarket](c.baseURL, internal.GetSamplingSimplifiedMarkets, params)
+	result, err := http.Get[[]types.SimplifiedMarket](c.baseURL, internal.GetSamplingSimplifiedMarkets, params, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sampling simplified markets: %w", err)
 	}
@@ -299,7 +603,7 @@ func (c *polymarketGammaClient) GetSamplingMarkets(limit int) ([]types.GammaMark
 		"limit": strconv.Itoa(limit),
 	}
 
-	result, err := http.Get[[]types.GammaMarket](c.baseURL, internal.GetSamplingMarkets, params)
+	result, err := http.Get[[]types.GammaMarket](c.baseURL, internal.GetSamplingMarkets, params, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sampling markets: %w", err)
 	}
@@ -368,7 +672,7 @@ func (c *polymarketGammaClient) GetSimplifiedMarkets(limit int, offset int, opti
 		multiParams["clob_token_ids"] = opts.TokenIDs
 	}
 
-	result, err := http.Get[[]types.SimplifiedMarket](c.baseURL, internal.GetSimplifiedMarkets, params, http.WithMultiParams(multiParams))
+	result, err := http.Get[[]types.SimplifiedMarket](c.baseURL, internal.GetSimplifiedMarkets, params, c.requestOptions(http.WithMultiParams(multiParams))...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get simplified markets: %w", err)
 	}
@@ -387,7 +691,7 @@ func (c *polymarketGammaClient) GetMarketTradesEvents(marketID string, limit int
 		"offset": strconv.Itoa(offset),
 	}
 
-	result, err := http.Get[[]types.MarketTradesEvent](c.baseURL, fmt.Sprintf("%s%s", internal.GetMarketTradesEvents, marketID), params)
+	result, err := http.Get[[]types.MarketTradesEvent](c.baseURL, fmt.Sprintf("%s%s", internal.GetMarketTradesEvents, marketID), params, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get market trades events: %w", err)
 	}
@@ -10,10 +10,10 @@ import (
 
 // GetProfile 获取用户资料
 func (c *polymarketGammaClient) GetProfile(address types.EthAddress) (*types.Profile, error) {
-	return http.Get[types.Profile](c.baseURL, fmt.Sprintf("%s%s", internal.GetProfile, string(address)), nil)
+	return http.Get[types.Profile](c.baseURL, fmt.Sprintf("%s%s", internal.GetProfile, string(address)), nil, c.requestOptions()...)
 }
 
 // GetProfileByUsername 通过用户名获取资料
 func (c *polymarketGammaClient) GetProfileByUsername(username string) (*types.Profile, error) {
-	return http.Get[types.Profile](c.baseURL, fmt.Sprintf("%s%s", internal.GetProfileByUsername, username), nil)
+	return http.Get[types.Profile](c.baseURL, fmt.Sprintf("%s%s", internal.GetProfileByUsername, username), nil, c.requestOptions()...)
 }
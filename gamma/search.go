@@ -113,5 +113,5 @@ func (c *polymarketGammaClient) Search(query string, options ...SearchOption) (*
 		params["ascending"] = strconv.FormatBool(*opts.Ascending)
 	}
 
-	return http.Get[types.SearchResult](c.baseURL, "/public-search", params)
+	return http.Get[types.SearchResult](c.baseURL, "/public-search", params, c.requestOptions()...)
 }
@@ -54,7 +54,7 @@ func (c *polymarketGammaClient) GetSeries(limit int, offset int, options ...GetS
 		params["closed"] = strconv.FormatBool(*opts.Closed)
 	}
 
-	result, err := http.Get[[]types.Series](c.baseURL, internal.GetSeries, params)
+	result, err := http.Get[[]types.Series](c.baseURL, internal.GetSeries, params, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get series: %w", err)
 	}
@@ -68,5 +68,5 @@ func (c *polymarketGammaClient) GetSeries(limit int, offset int, options ...GetS
 
 // GetSeriesBySlug 通过 slug 获取系列
 func (c *polymarketGammaClient) GetSeriesBySlug(slug string) (*types.Series, error) {
-	return http.Get[types.Series](c.baseURL, fmt.Sprintf("%s%s", internal.GetSeriesBySlug, slug), nil)
+	return http.Get[types.Series](c.baseURL, fmt.Sprintf("%s%s", internal.GetSeriesBySlug, slug), nil, c.requestOptions()...)
 }
@@ -43,7 +43,7 @@ func (c *polymarketGammaClient) GetTags(limit int, offset int, options ...GetTag
 		params["ascending"] = strconv.FormatBool(opts.Ascending)
 	}
 
-	result, err := http.Get[[]types.Tag](c.baseURL, internal.GetTags, params)
+	result, err := http.Get[[]types.Tag](c.baseURL, internal.GetTags, params, c.requestOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tags: %w", err)
 	}
@@ -57,10 +57,10 @@ func (c *polymarketGammaClient) GetTags(limit int, offset int, options ...GetTag
 
 // GetTag 获取单个标签
 func (c *polymarketGammaClient) GetTag(tagID int) (*types.Tag, error) {
-	return http.Get[types.Tag](c.baseURL, fmt.Sprintf("%s%d", internal.GetTag, tagID), nil)
+	return http.Get[types.Tag](c.baseURL, fmt.Sprintf("%s%d", internal.GetTag, tagID), nil, c.requestOptions()...)
 }
 
 // GetTagBySlug 通过 slug 获取标签
 func (c *polymarketGammaClient) GetTagBySlug(slug string) (*types.Tag, error) {
-	return http.Get[types.Tag](c.baseURL, fmt.Sprintf("%s%s", internal.GetTagBySlug, slug), nil)
+	return http.Get[types.Tag](c.baseURL, fmt.Sprintf("%s%s", internal.GetTagBySlug, slug), nil, c.requestOptions()...)
 }
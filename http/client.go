@@ -10,8 +10,10 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
 )
 
 // httpClient HTTP客户端实现（不可导出）
@@ -28,6 +30,13 @@ type HTTPOption func(*httpRequestOptions)
 type httpRequestOptions struct {
 	headers     map[string]string
 	multiParams map[string][]string // 同名参数（如 clob_token_ids=id1&clob_token_ids=id2）
+	proxyURL    string              // 代理地址（为空时使用环境变量中的代理配置）
+	timeout     time.Duration       // 请求超时（为 0 时使用 internal.HTTPClientTimeout）
+	rawResponse *[]byte             // 非 nil 时写入成功响应的原始 body
+	transport   http.RoundTripper   // 非 nil 时替代默认传输层（如 Recorder、Replayer）
+	useNumber   bool                // 为 true 时 interface{} 中的数字解析为 json.Number
+	configErr   error               // 客户端配置无效（如代理地址），非 nil 时请求直接返回该错误
+	limiter     *RateLimiter        // 非 nil 时请求发送前按限流等待
 }
 
 // WithHeaders 设置请求头（函数选项）
@@ -70,15 +79,128 @@ func WithMultiParams(multiParams map[string][]string) HTTPOption {
 	}
 }
 
+// WithProxyURL 为请求指定代理地址（函数选项）
+// 为空时使用环境变量中的代理配置，支持的协议见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) HTTPOption {
+	return func(opts *httpRequestOptions) {
+		opts.proxyURL = proxyURL
+	}
+}
+
+// ConnectionHTTPOptions 将客户端的连接配置（代理、HTTP 超时、传输层）转换为请求选项
+// 代理地址无效时，返回的选项使每个请求都直接返回该错误
+func ConnectionHTTPOptions(conn internal.ConnectionOptions) []HTTPOption {
+	if _, err := conn.Proxy(); err != nil {
+		return []HTTPOption{func(opts *httpRequestOptions) {
+			opts.configErr = err
+		}}
+	}
+
+	var httpOptions []HTTPOption
+	if conn.ProxyURL != "" {
+		httpOptions = append(httpOptions, WithProxyURL(conn.ProxyURL))
+	}
+	if conn.Timeouts.HTTP > 0 {
+		httpOptions = append(httpOptions, WithTimeout(conn.Timeouts.HTTP))
+	}
+	if conn.Transport != nil {
+		httpOptions = append(httpOptions, WithTransport(conn.Transport))
+	}
+	return httpOptions
+}
+
+// WithTimeout 为请求指定超时时间（函数选项）
+// 为 0 时使用默认的 internal.HTTPClientTimeout
+func WithTimeout(timeout time.Duration) HTTPOption {
+	return func(opts *httpRequestOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithRawResponse 将成功响应的原始 body 写入 dst（函数选项）
+// 用于 Get/Post 等泛型请求，在解码后的结构体之外保留原始 JSON，便于读取类型中尚未建模的字段
+func WithRawResponse(dst *[]byte) HTTPOption {
+	return func(opts *httpRequestOptions) {
+		opts.rawResponse = dst
+	}
+}
+
+// WithTransport 为请求指定自定义传输层（函数选项）
+// 可传入 NewRecorder 录制真实会话，或传入 NewReplayer 离线回放录制的响应；设置后代理配置不再生效。
+// 各客户端包的 WithTransport 选项都转为该选项
+func WithTransport(transport RoundTripper) HTTPOption {
+	return func(opts *httpRequestOptions) {
+		opts.transport = transport
+	}
+}
+
+// WithUseNumber 解析响应时将 interface{}（如 map[string]interface{}）中的数字解析为 json.Number 而不是 float64（函数选项）
+// 避免大整数（token ID、链上金额）经过 float64 丢失精度，结构体中声明了具体类型的字段不受影响；
+// 取值可用 types.NumberString、types.NumberInt64、types.NumberBigInt 转换，这些函数同时兼容 float64
+func WithUseNumber() HTTPOption {
+	return func(opts *httpRequestOptions) {
+		opts.useNumber = true
+	}
+}
+
+// SetUserAgent 设置 SDK 所有请求（REST、Relayer、Subgraph、WebSocket）使用的 User-Agent，为空时恢复默认值
+// 默认为 go-polymarket-sdk/<version>（见 types.Version）；单个请求可通过 WithHeader("User-Agent", ...) 覆盖
+func SetUserAgent(ua string) {
+	internal.SetUserAgent(ua)
+}
+
+// NewTransport 创建安全的 HTTP 传输配置
+// proxyURL 为空时从环境变量读取代理配置，否则使用指定的代理（支持 SOCKS5）
+func NewTransport(proxyURL string) (*http.Transport, error) {
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12, // 最低TLS 1.2版本
+			// 不跳过证书验证，使用系统默认的证书验证
+		},
+		Proxy: http.ProxyFromEnvironment, // 支持从环境变量读取代理配置
+	}
+
+	if proxyURL != "" {
+		u, err := internal.ParseProxyURL(proxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+
+	return transport, nil
+}
+
 // getOrCreateClient 获取或创建 HTTP 客户端（使用缓存）
+// 缓存按 baseURL、代理地址和超时时间区分，不同配置的客户端互不影响
+// 指定了自定义传输层时不使用缓存，连接复用由传输层自身负责
 var clientCache = make(map[string]*httpClient)
 var clientCacheMutex sync.RWMutex
 
-func getOrCreateClient(baseURL string) *httpClient {
+func getOrCreateClient(baseURL string, opts *httpRequestOptions) (*httpClient, error) {
+	if opts.configErr != nil {
+		return nil, opts.configErr
+	}
+	proxyURL, timeout := opts.proxyURL, opts.timeout
+	if timeout <= 0 {
+		timeout = internal.HTTPClientTimeout
+	}
+	if opts.transport != nil {
+		return &httpClient{
+			baseURL: baseURL,
+			httpClient: &http.Client{
+				Timeout:   timeout,
+				Transport: opts.transport,
+			},
+			headers: make(map[string]string),
+		}, nil
+	}
+	cacheKey := baseURL + "|" + proxyURL + "|" + timeout.String()
+
 	clientCacheMutex.RLock()
-	if client, ok := clientCache[baseURL]; ok {
+	if client, ok := clientCache[cacheKey]; ok {
 		clientCacheMutex.RUnlock()
-		return client
+		return client, nil
 	}
 	clientCacheMutex.RUnlock()
 
@@ -86,29 +208,26 @@ func getOrCreateClient(baseURL string) *httpClient {
 	defer clientCacheMutex.Unlock()
 
 	// 双重检查
-	if client, ok := clientCache[baseURL]; ok {
-		return client
+	if client, ok := clientCache[cacheKey]; ok {
+		return client, nil
 	}
 
 	// 创建安全的HTTP传输配置
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS12, // 最低TLS 1.2版本
-			// 不跳过证书验证，使用系统默认的证书验证
-		},
-		Proxy: http.ProxyFromEnvironment, // 支持从环境变量读取代理配置
+	transport, err := NewTransport(proxyURL)
+	if err != nil {
+		return nil, err
 	}
 
 	client := &httpClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout:   internal.HTTPClientTimeout,
+			Timeout:   timeout,
 			Transport: transport,
 		},
 		headers: make(map[string]string),
 	}
-	clientCache[baseURL] = client
-	return client
+	clientCache[cacheKey] = client
+	return client, nil
 }
 
 // Get performs a GET request (包级泛型函数)
@@ -124,7 +243,10 @@ func Get[T any](baseURL, path string, params map[string]string, options ...HTTPO
 	}
 
 	// 获取或创建客户端
-	c := getOrCreateClient(baseURL)
+	c, err := getOrCreateClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// 合并普通参数和同名参数
 	var allParams map[string][]string
@@ -146,7 +268,7 @@ func Get[T any](baseURL, path string, params map[string]string, options ...HTTPO
 		}
 	}
 
-	return request[T](c, "GET", path, allParams, nil, opts.headers)
+	return request[T](c, "GET", path, allParams, nil, opts)
 }
 
 // Post performs a POST request (包级泛型函数)
@@ -161,33 +283,40 @@ func Post[T any](baseURL, path string, body interface{}, options ...HTTPOption)
 	}
 
 	// 获取或创建客户端
-	c := getOrCreateClient(baseURL)
+	c, err := getOrCreateClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	return request[T](c, "POST", path, nil, body, opts.headers)
+	return request[T](c, "POST", path, nil, body, opts)
 }
 
 // request performs a generic HTTP request with slice params
 // 这是一个内部辅助函数，使用泛型处理响应
-func request[T any](c *httpClient, method, path string, params map[string][]string, body interface{}, requestHeaders map[string]string) (*T, error) {
-	req, err := buildRequestWithSliceParams(c, method, path, params, body, "application/json", requestHeaders)
+func request[T any](c *httpClient, method, path string, params map[string][]string, body interface{}, opts *httpRequestOptions) (*T, error) {
+	req, err := buildRequestWithSliceParams(c, method, path, params, body, "application/json", opts.headers)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req, opts)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	recordRateLimit(c.baseURL, resp)
 
 	responseBodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		sanitizedBody := sanitizeErrorResponse(responseBodyBytes, 500)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, sanitizedBody)
+	if apiErr := responseError(resp, responseBodyBytes); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if opts.rawResponse != nil {
+		*opts.rawResponse = responseBodyBytes
 	}
 
 	var result T
@@ -195,13 +324,19 @@ func request[T any](c *httpClient, method, path string, params map[string][]stri
 		return &result, nil
 	}
 
-	if err := json.Unmarshal(responseBodyBytes, &result); err != nil {
+	if err := decodeResponse(responseBodyBytes, &result, opts.useNumber); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return &result, nil
 }
 
+// do 经过限速器（如果配置了）后发送请求
+func (c *httpClient) do(req *http.Request, opts *httpRequestOptions) (*http.Response, error) {
+	opts.limiter.Wait(req.Method, req.URL.Path)
+	return c.httpClient.Do(req)
+}
+
 // GetRaw performs a request and returns raw bytes
 // baseURL 为 API 基础 URL，params 为普通参数
 // options 为函数选项，可用于设置请求头和同名参数等
@@ -215,7 +350,10 @@ func GetRaw(baseURL, method, path string, params map[string]string, options ...H
 	}
 
 	// 获取或创建客户端
-	c := getOrCreateClient(baseURL)
+	c, err := getOrCreateClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// 合并普通参数和同名参数
 	var allParams map[string][]string
@@ -242,19 +380,21 @@ func GetRaw(baseURL, method, path string, params map[string]string, options ...H
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req, opts)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	recordRateLimit(c.baseURL, resp)
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		sanitizedBody := sanitizeErrorResponse(bodyBytes, 500)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, sanitizedBody)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
-
-	return io.ReadAll(resp.Body)
+	if apiErr := responseError(resp, bodyBytes); apiErr != nil {
+		return nil, apiErr
+	}
+	return bodyBytes, nil
 }
 
 // PostRaw performs a POST request with raw body bytes and returns raw bytes
@@ -270,7 +410,10 @@ func PostRaw(baseURL, path string, bodyBytes []byte, options ...HTTPOption) ([]b
 	}
 
 	// 获取或创建客户端
-	c := getOrCreateClient(baseURL)
+	c, err := getOrCreateClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// 使用安全的URL构建方法
 	requestURL, err := buildSafeURL(c.baseURL, path)
@@ -286,6 +429,7 @@ func PostRaw(baseURL, path string, bodyBytes []byte, options ...HTTPOption) ([]b
 	// Set headers (preserve exact case)
 	// IMPORTANT: Set Content-Type first, then other headers (matching Python httpx behavior)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", internal.UserAgent())
 
 	// 先设置客户端默认 headers
 	for k, v := range c.headers {
@@ -301,20 +445,21 @@ func PostRaw(baseURL, path string, bodyBytes []byte, options ...HTTPOption) ([]b
 		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req, opts)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	recordRateLimit(c.baseURL, resp)
 
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		sanitizedBody := sanitizeErrorResponse(responseBody, 500)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, sanitizedBody)
+	// 下单等接口被 Cloudflare 拦截时返回 HTML 页面（状态码可能是 2xx），返回 APIError 而不是让调用方解析 JSON 失败
+	if apiErr := responseError(resp, responseBody); apiErr != nil {
+		return nil, apiErr
 	}
 
 	return responseBody, nil
@@ -333,7 +478,10 @@ func DeleteRaw[T any](baseURL, path string, bodyBytes []byte, options ...HTTPOpt
 	}
 
 	// 获取或创建客户端
-	c := getOrCreateClient(baseURL)
+	c, err := getOrCreateClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// 使用安全的URL构建方法
 	requestURL, err := buildSafeURL(c.baseURL, path)
@@ -349,6 +497,7 @@ func DeleteRaw[T any](baseURL, path string, bodyBytes []byte, options ...HTTPOpt
 	// Set headers (preserve exact case)
 	// IMPORTANT: Set Content-Type first, then other headers (matching Python httpx behavior)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", internal.UserAgent())
 
 	// 先设置客户端默认 headers
 	for k, v := range c.headers {
@@ -364,19 +513,20 @@ func DeleteRaw[T any](baseURL, path string, bodyBytes []byte, options ...HTTPOpt
 		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req, opts)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	recordRateLimit(c.baseURL, resp)
 
 	rawBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(rawBytes))
+	if apiErr := responseError(resp, rawBytes); apiErr != nil {
+		return nil, apiErr
 	}
 
 	var result T
@@ -384,13 +534,21 @@ func DeleteRaw[T any](baseURL, path string, bodyBytes []byte, options ...HTTPOpt
 		return &result, nil
 	}
 
-	if err := json.Unmarshal(rawBytes, &result); err != nil {
+	if err := decodeResponse(rawBytes, &result, opts.useNumber); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return &result, nil
 }
 
+// decodeResponse 解析响应 body，useNumber 为 true 时使用 types.DecodeJSON
+func decodeResponse(data []byte, v interface{}, useNumber bool) error {
+	if useNumber {
+		return types.DecodeJSON(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
 // buildSafeURL 安全地构建URL，防止SSRF攻击
 func buildSafeURL(baseURL, path string) (string, error) {
 	base, err := url.Parse(baseURL)
@@ -441,6 +599,8 @@ func buildRequestWithSliceParams(c *httpClient, method, path string, params map[
 	}
 
 	// Set headers (preserve exact case)
+	req.Header.Set("User-Agent", internal.UserAgent())
+
 	// 先设置客户端默认 headers
 	for k, v := range c.headers {
 		req.Header[k] = []string{v}
@@ -535,26 +695,30 @@ func Delete[T any](baseURL, path string, body interface{}, options ...HTTPOption
 	}
 
 	// 获取或创建客户端
-	c := getOrCreateClient(baseURL)
+	c, err := getOrCreateClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := buildRequestWithSliceParams(c, "DELETE", path, nil, body, "application/json", opts.headers)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req, opts)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	recordRateLimit(c.baseURL, resp)
 
 	rawBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(rawBytes))
+	if apiErr := responseError(resp, rawBytes); apiErr != nil {
+		return nil, apiErr
 	}
 
 	var result T
@@ -562,7 +726,7 @@ func Delete[T any](baseURL, path string, body interface{}, options ...HTTPOption
 		return &result, nil
 	}
 
-	if err := json.Unmarshal(rawBytes, &result); err != nil {
+	if err := decodeResponse(rawBytes, &result, opts.useNumber); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -0,0 +1,315 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// 限流相关响应头
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// APIError 表示 API 返回的非 2xx 响应
+// Error() 的格式与之前的 "HTTP <code>: <body>" 保持一致
+type APIError struct {
+	StatusCode int    // HTTP 状态码
+	Body       string // 响应体（已脱敏、截断）；Blocked 时为页面标题或摘要
+	Blocked    bool   // Cloudflare 挑战或拦截页面（403/503 且带 cf-ray 头或挑战页面标记），与 API 参数无关
+
+	// 以下字段来自限流相关响应头，响应中没有对应头时为零值
+	RetryAfter         time.Duration // Retry-After 指定的等待时间
+	RateLimitLimit     int           // X-RateLimit-Limit，-1 表示未知
+	RateLimitRemaining int           // X-RateLimit-Remaining，-1 表示未知
+	RateLimitReset     time.Time     // X-RateLimit-Reset 对应的重置时间
+}
+
+// Error 实现 error 接口
+func (e *APIError) Error() string {
+	if e.Blocked {
+		return fmt.Sprintf("HTTP %d: %v: %s", e.StatusCode, types.ErrCloudflareChallenge, e.Body)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// Is 使 errors.Is(err, types.ErrCloudflareChallenge) 对被拦截（Blocked）的响应返回 true
+func (e *APIError) Is(target error) bool {
+	return e.Blocked && target == types.ErrCloudflareChallenge
+}
+
+// IsBlocked 是否被 Cloudflare 挑战或拦截，这类错误与 API 本身的错误无关，通常需要更换 IP 或稍后重试
+// 普通的 HTML 错误页（如 404、502/504 网关错误）不算拦截
+func (e *APIError) IsBlocked() bool {
+	return e.Blocked
+}
+
+// IsRateLimited 是否为限流错误（HTTP 429）
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// responseError 检查响应状态码和格式：HTML 页面返回以页面标题为 body 的 APIError（Cloudflare 挑战时 Blocked 为 true），
+// 其他非 2xx 响应返回包含脱敏 body 的 APIError，正常响应返回 nil
+func responseError(resp *http.Response, body []byte) *APIError {
+	if internal.IsNonJSONResponse(resp.Header.Get("Content-Type"), body) {
+		apiErr := newAPIError(resp, internal.ResponseSnippet(body, 200))
+		apiErr.Blocked = isCloudflareChallenge(resp, body)
+		return apiErr
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp, sanitizeErrorResponse(body, 500))
+	}
+	return nil
+}
+
+// cloudflareChallengeMarkers Cloudflare 挑战和拦截页面中的标记（小写）
+var cloudflareChallengeMarkers = []string{
+	"cf-chl",
+	"challenge-platform",
+	"cf-browser-verification",
+	"cf-error-details",
+	"attention required! | cloudflare",
+	"just a moment...",
+}
+
+// isCloudflareChallenge 判断 HTML 响应是否为 Cloudflare 挑战或拦截页面
+// 只有 403/503 且带 cf-ray（或 cf-mitigated）响应头或挑战页面标记时才算拦截
+func isCloudflareChallenge(resp *http.Response, body []byte) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	if resp.Header.Get("Cf-Ray") != "" || resp.Header.Get("Cf-Mitigated") != "" {
+		return true
+	}
+	lower := strings.ToLower(string(body))
+	for _, marker := range cloudflareChallengeMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPIError 根据响应创建 APIError，并解析限流相关响应头
+func newAPIError(resp *http.Response, body string) *APIError {
+	status := parseRateLimitHeaders(resp.Header, time.Now())
+	return &APIError{
+		StatusCode:         resp.StatusCode,
+		Body:               body,
+		RetryAfter:         status.RetryAfter,
+		RateLimitLimit:     status.Limit,
+		RateLimitRemaining: status.Remaining,
+		RateLimitReset:     status.Reset,
+	}
+}
+
+// RateLimitStatus 某个 API 最近一次响应中的限流状态
+type RateLimitStatus struct {
+	Limit      int           // 窗口内允许的请求数，-1 表示未知
+	Remaining  int           // 窗口内剩余的请求数，-1 表示未知
+	Reset      time.Time     // 限流窗口重置时间（未知时为零值）
+	RetryAfter time.Duration // 最近一次响应要求的等待时间（没有时为 0）
+	UpdatedAt  time.Time     // 状态更新时间（从未收到响应时为零值）
+}
+
+// rateLimitStatuses 按 baseURL 记录的最新限流状态
+var rateLimitStatuses sync.Map
+
+// GetRateLimitStatus 返回指定 API 最近一次响应中的限流状态
+// 尚未收到任何响应时 Limit 和 Remaining 为 -1，UpdatedAt 为零值
+func GetRateLimitStatus(baseURL string) RateLimitStatus {
+	if v, ok := rateLimitStatuses.Load(baseURL); ok {
+		return v.(RateLimitStatus)
+	}
+	return RateLimitStatus{Limit: -1, Remaining: -1}
+}
+
+// recordRateLimit 记录响应中的限流状态
+// 响应中没有任何限流相关头时不更新
+func recordRateLimit(baseURL string, resp *http.Response) {
+	if resp.Header.Get(HeaderRateLimitLimit) == "" &&
+		resp.Header.Get(HeaderRateLimitRemaining) == "" &&
+		resp.Header.Get(HeaderRateLimitReset) == "" &&
+		resp.Header.Get(HeaderRetryAfter) == "" {
+		return
+	}
+
+	now := time.Now()
+	status := parseRateLimitHeaders(resp.Header, now)
+	status.UpdatedAt = now
+	rateLimitStatuses.Store(baseURL, status)
+}
+
+// parseRateLimitHeaders 解析限流相关响应头
+func parseRateLimitHeaders(header http.Header, now time.Time) RateLimitStatus {
+	status := RateLimitStatus{
+		Limit:      parseIntHeader(header.Get(HeaderRateLimitLimit)),
+		Remaining:  parseIntHeader(header.Get(HeaderRateLimitRemaining)),
+		RetryAfter: internal.ParseRetryAfter(header.Get(HeaderRetryAfter), now),
+	}
+
+	if reset := header.Get(HeaderRateLimitReset); reset != "" {
+		if v, err := strconv.ParseFloat(reset, 64); err == nil && v > 0 {
+			// 大于 1e9 视为 Unix 时间戳，否则视为距现在的秒数
+			if v > 1e9 {
+				status.Reset = time.Unix(int64(v), 0)
+			} else {
+				status.Reset = now.Add(time.Duration(v * float64(time.Second)))
+			}
+		}
+	}
+
+	return status
+}
+
+// parseIntHeader 解析整数响应头，缺失或无效时返回 -1
+func parseIntHeader(value string) int {
+	if value == "" {
+		return -1
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return -1
+	}
+	return v
+}
+
+// marketDataPaths 按接口单独限流的行情接口（types.RateLimits.MarketData）
+var marketDataPaths = map[string]bool{
+	internal.GetOrderBook:        true,
+	internal.GetOrderBooks:       true,
+	internal.MidPoint:            true,
+	internal.MidPoints:           true,
+	internal.Price:               true,
+	internal.GetPrices:           true,
+	internal.GetSpread:           true,
+	internal.GetSpreads:          true,
+	internal.GetLastTradePrice:   true,
+	internal.GetLastTradesPrices: true,
+}
+
+// RateLimiter 客户端限速器，按 types.RateLimits 限制每个统计窗口内的请求数，超过时等待到窗口内有空余再发送
+// 所有请求计入 General，行情接口按接口分别计入 MarketData，POST /order(s) 计入 PostOrder，DELETE /order(s) 计入 CancelOrder；
+// 值为 0 的字段不限制。通过 WithRateLimiter 接入，CLOB 客户端按 clob.WithRateLimits 的配置自动创建
+type RateLimiter struct {
+	limits types.RateLimits
+
+	mu       sync.Mutex
+	requests map[string][]time.Time // 限流类别 -> 窗口内的请求时间（按时间顺序）
+
+	now   func() time.Time    // 为 nil 时使用 time.Now，测试中可替换
+	sleep func(time.Duration) // 为 nil 时使用 time.Sleep，测试中可替换
+}
+
+// NewRateLimiter 创建按 limits 限速的 RateLimiter，Window 不大于 0 时不限速
+func NewRateLimiter(limits types.RateLimits) *RateLimiter {
+	return &RateLimiter{limits: limits, requests: make(map[string][]time.Time)}
+}
+
+// Limits 返回限速器使用的限流配置
+func (l *RateLimiter) Limits() types.RateLimits {
+	return l.limits
+}
+
+// WithRateLimiter 请求发送前经过限速器等待（函数选项），limiter 为 nil 时不限速
+func WithRateLimiter(limiter *RateLimiter) HTTPOption {
+	return func(opts *httpRequestOptions) {
+		opts.limiter = limiter
+	}
+}
+
+// Wait 等待直到 method path 的请求不超过任何相关的限流，并记录这次请求
+func (l *RateLimiter) Wait(method, path string) {
+	if l == nil || l.limits.Window <= 0 {
+		return
+	}
+	buckets := l.buckets(method, path)
+	for {
+		l.mu.Lock()
+		now := l.clock()
+		var wait time.Duration
+		for _, bucket := range buckets {
+			if d := l.waitFor(bucket.key, bucket.limit, now); d > wait {
+				wait = d
+			}
+		}
+		if wait == 0 {
+			for _, bucket := range buckets {
+				l.requests[bucket.key] = append(l.requests[bucket.key], now)
+			}
+			l.mu.Unlock()
+			return
+		}
+		l.mu.Unlock()
+		l.pause(wait)
+	}
+}
+
+// rateLimitBucket 一个限流类别及其窗口内允许的请求数
+type rateLimitBucket struct {
+	key   string
+	limit int
+}
+
+// buckets 返回请求需要计入的限流类别，忽略值为 0 的限流
+func (l *RateLimiter) buckets(method, path string) []rateLimitBucket {
+	buckets := []rateLimitBucket{{"general", l.limits.General}}
+	switch {
+	case marketDataPaths[path]:
+		buckets = append(buckets, rateLimitBucket{"market:" + path, l.limits.MarketData})
+	case method == http.MethodPost && (path == internal.PostOrder || path == internal.PostOrders):
+		buckets = append(buckets, rateLimitBucket{"post_order", l.limits.PostOrder})
+	case method == http.MethodDelete && (path == internal.Cancel || path == internal.CancelOrders):
+		buckets = append(buckets, rateLimitBucket{"cancel_order", l.limits.CancelOrder})
+	}
+	result := buckets[:0]
+	for _, bucket := range buckets {
+		if bucket.limit > 0 {
+			result = append(result, bucket)
+		}
+	}
+	return result
+}
+
+// waitFor 清理窗口外的请求记录，返回该类别还需要等待的时间（调用方持有锁）
+func (l *RateLimiter) waitFor(key string, limit int, now time.Time) time.Duration {
+	requests := l.requests[key]
+	start := now.Add(-l.limits.Window)
+	expired := 0
+	for expired < len(requests) && !requests[expired].After(start) {
+		expired++
+	}
+	requests = requests[expired:]
+	l.requests[key] = requests
+	if len(requests) < limit {
+		return 0
+	}
+	return requests[len(requests)-limit].Add(l.limits.Window).Sub(now)
+}
+
+// clock 返回当前时间
+func (l *RateLimiter) clock() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
+
+// pause 等待 d
+func (l *RateLimiter) pause(d time.Duration) {
+	if l.sleep != nil {
+		l.sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	t.Run("Basic", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(HeaderRateLimitLimit, "100")
+		header.Set(HeaderRateLimitRemaining, "7")
+		header.Set(HeaderRateLimitReset, "1700000060")
+		header.Set(HeaderRetryAfter, "3")
+
+		status := parseRateLimitHeaders(header, now)
+		if status.Limit != 100 || status.Remaining != 7 {
+			t.Errorf("Expected limit 100 / remaining 7, got %d / %d", status.Limit, status.Remaining)
+		}
+		if !status.Reset.Equal(time.Unix(1700000060, 0)) {
+			t.Errorf("Unexpected reset time: %v", status.Reset)
+		}
+		if status.RetryAfter != 3*time.Second {
+			t.Errorf("Expected RetryAfter 3s, got %v", status.RetryAfter)
+		}
+	})
+
+	// 缺失的头应返回 -1 / 零值
+	t.Run("Missing", func(t *testing.T) {
+		status := parseRateLimitHeaders(http.Header{}, now)
+		if status.Limit != -1 || status.Remaining != -1 || !status.Reset.IsZero() || status.RetryAfter != 0 {
+			t.Errorf("Expected unknown status, got %+v", status)
+		}
+	})
+
+	// Retry-After 为 HTTP 日期格式
+	t.Run("RetryAfterDate", func(t *testing.T) {
+		header := http.Header{}
+		header.Set(HeaderRetryAfter, now.Add(10*time.Second).UTC().Format(http.TimeFormat))
+		status := parseRateLimitHeaders(header, now)
+		if status.RetryAfter != 10*time.Second {
+			t.Errorf("Expected RetryAfter 10s, got %v", status.RetryAfter)
+		}
+	})
+}
+
+func TestAPIError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set(HeaderRetryAfter, "2")
+	resp.Header.Set(HeaderRateLimitRemaining, "0")
+
+	err := newAPIError(resp, "rate limited")
+	if err.Error() != "HTTP 429: rate limited" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+	if !err.IsRateLimited() || err.RetryAfter != 2*time.Second || err.RateLimitRemaining != 0 {
+		t.Errorf("Unexpected APIError: %+v", err)
+	}
+}
+
+func TestPostRawBlocked(t *testing.T) {
+	const baseURL = "https://blocked.example.com"
+	challenge := `<!DOCTYPE html><html><head><title>Attention Required! | Cloudflare</title></head><body>Sorry, you have been blocked</body></html>`
+	htmlHeader := http.Header{"Content-Type": []string{"text/html; charset=UTF-8"}}
+
+	cases := []struct {
+		name        string
+		interaction Interaction
+	}{
+		{"Forbidden", Interaction{StatusCode: http.StatusForbidden, Header: htmlHeader, Body: challenge}},
+		{"ServiceUnavailableWithRay", Interaction{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Cf-Ray": []string{"8a1b2c3d4e5f-AMS"}}, Body: `<html><title>Attention Required! | Cloudflare</title></html>`}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			interaction := c.interaction
+			interaction.Method = "POST"
+			interaction.URL = baseURL + "/order"
+			_, err := PostRaw(baseURL, "/order", []byte(`{}`), WithTransport(NewReplayerFromInteractions([]Interaction{interaction})))
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || !apiErr.IsBlocked() {
+				t.Fatalf("Expected blocked APIError, got %v", err)
+			}
+			if apiErr.StatusCode != interaction.StatusCode || apiErr.Body != "Attention Required! | Cloudflare" {
+				t.Errorf("Unexpected APIError: %+v", apiErr)
+			}
+			if !errors.Is(err, types.ErrCloudflareChallenge) {
+				t.Errorf("Expected errors.Is ErrCloudflareChallenge, got %v", err)
+			}
+		})
+	}
+
+	// GET 请求同样识别挑战页面，而不是返回 JSON 解析错误
+	t.Run("Get", func(t *testing.T) {
+		_, err := Get[map[string]interface{}](baseURL, "/book", nil, WithTransport(NewReplayerFromInteractions([]Interaction{{
+			Method: "GET", URL: baseURL + "/book", StatusCode: http.StatusForbidden, Header: htmlHeader, Body: challenge,
+		}})))
+		if !errors.Is(err, types.ErrCloudflareChallenge) {
+			t.Errorf("Expected ErrCloudflareChallenge, got %v", err)
+		}
+	})
+
+	// 普通的 HTML 错误页不是拦截，返回以页面标题为 body 的 APIError
+	notBlocked := []struct {
+		name        string
+		interaction Interaction
+	}{
+		{"NotFoundPage", Interaction{StatusCode: http.StatusNotFound, Header: htmlHeader, Body: `<html><head><title>404 Not Found</title></head></html>`}},
+		{"GatewayTimeout", Interaction{StatusCode: http.StatusGatewayTimeout, Header: http.Header{"Cf-Ray": []string{"8a1b2c3d4e5f-AMS"}}, Body: `<html><head><title>504 Gateway Time-out</title></head></html>`}},
+		{"ForbiddenWithoutMarker", Interaction{StatusCode: http.StatusForbidden, Header: htmlHeader, Body: `<html><head><title>403 Forbidden</title></head></html>`}},
+		{"OKWithHTML", Interaction{StatusCode: http.StatusOK, Body: challenge}},
+	}
+	for _, c := range notBlocked {
+		t.Run(c.name, func(t *testing.T) {
+			interaction := c.interaction
+			interaction.Method = "POST"
+			interaction.URL = baseURL + "/order"
+			_, err := PostRaw(baseURL, "/order", []byte(`{}`), WithTransport(NewReplayerFromInteractions([]Interaction{interaction})))
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.IsBlocked() || apiErr.StatusCode != interaction.StatusCode {
+				t.Fatalf("Expected non-blocked APIError, got %v", err)
+			}
+			if errors.Is(err, types.ErrCloudflareChallenge) {
+				t.Errorf("Expected %d HTML page not to match ErrCloudflareChallenge", interaction.StatusCode)
+			}
+		})
+	}
+
+	// JSON 错误响应不是拦截
+	t.Run("JSONError", func(t *testing.T) {
+		_, err := PostRaw(baseURL, "/order", []byte(`{}`), WithTransport(NewReplayerFromInteractions([]Interaction{{
+			Method: "POST", URL: baseURL + "/order", StatusCode: http.StatusBadRequest, Body: `{"error":"invalid order"}`,
+		}})))
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.IsBlocked() || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected non-blocked APIError, got %v", err)
+		}
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	newLimiter := func(limits types.RateLimits) (*RateLimiter, *time.Time, *[]time.Duration) {
+		now := time.Unix(1700000000, 0)
+		var waits []time.Duration
+		limiter := NewRateLimiter(limits)
+		limiter.now = func() time.Time { return now }
+		limiter.sleep = func(d time.Duration) {
+			waits = append(waits, d)
+			now = now.Add(d)
+		}
+		return limiter, &now, &waits
+	}
+
+	// 同一个行情接口在窗口内超过 MarketData 后等待到最早的请求移出窗口
+	t.Run("MarketData", func(t *testing.T) {
+		limiter, now, waits := newLimiter(types.RateLimits{Window: 10 * time.Second, General: 100, MarketData: 2})
+		limiter.Wait(http.MethodGet, internal.GetOrderBook)
+		*now = now.Add(time.Second)
+		limiter.Wait(http.MethodGet, internal.GetOrderBook)
+		limiter.Wait(http.MethodGet, internal.MidPoint)
+		if len(*waits) != 0 {
+			t.Fatalf("Expected no wait within the limit, got %v", *waits)
+		}
+		limiter.Wait(http.MethodGet, internal.GetOrderBook)
+		if len(*waits) != 1 || (*waits)[0] != 9*time.Second {
+			t.Errorf("Expected a single 9s wait, got %v", *waits)
+		}
+	})
+
+	t.Run("General", func(t *testing.T) {
+		limiter, _, waits := newLimiter(types.RateLimits{Window: time.Second, General: 1})
+		limiter.Wait(http.MethodGet, internal.GetOrderBook)
+		limiter.Wait(http.MethodGet, internal.Time)
+		if len(*waits) != 1 || (*waits)[0] != time.Second {
+			t.Errorf("Expected a single 1s wait, got %v", *waits)
+		}
+	})
+
+	// 下单和撤单只按方法和路径计入对应限流
+	t.Run("Orders", func(t *testing.T) {
+		limiter, _, waits := newLimiter(types.RateLimits{Window: time.Second, PostOrder: 1, CancelOrder: 1})
+		limiter.Wait(http.MethodPost, internal.PostOrder)
+		limiter.Wait(http.MethodDelete, internal.Cancel)
+		limiter.Wait(http.MethodGet, internal.PostOrder)
+		if len(*waits) != 0 {
+			t.Fatalf("Expected no wait, got %v", *waits)
+		}
+		limiter.Wait(http.MethodPost, internal.PostOrders)
+		if len(*waits) != 1 {
+			t.Errorf("Expected POST /orders to share the POST /order limit, got %v", *waits)
+		}
+	})
+
+	// 值为 0 的限流和未配置窗口的限速器都不限制
+	t.Run("Unlimited", func(t *testing.T) {
+		limiter, _, waits := newLimiter(types.RateLimits{Window: time.Second})
+		for i := 0; i < 10; i++ {
+			limiter.Wait(http.MethodGet, internal.GetOrderBook)
+		}
+		limiter, _, _ = newLimiter(types.RateLimits{General: 1})
+		limiter.Wait(http.MethodGet, internal.GetOrderBook)
+		limiter.Wait(http.MethodGet, internal.GetOrderBook)
+		var nilLimiter *RateLimiter
+		nilLimiter.Wait(http.MethodGet, internal.GetOrderBook)
+		if len(*waits) != 0 {
+			t.Errorf("Expected no wait, got %v", *waits)
+		}
+	})
+}
@@ -0,0 +1,294 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// RoundTripper 传输层接口（net/http.RoundTripper 的别名），用于 WithTransport
+type RoundTripper = http.RoundTripper
+
+// ErrNoRecordedResponse 回放时没有找到与请求匹配的录制记录
+var ErrNoRecordedResponse = errors.New("no recorded response")
+
+// Interaction 表示一次录制的请求和响应
+// 不记录请求头，请求 body 中的 API Key、订单签名等字段（见 redactedFields）替换为 Redacted，避免认证信息写入文件
+type Interaction struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header,omitempty"` // 响应头
+	Body        string      `json:"body"`             // 响应 body
+}
+
+// key 回放时用于分组记录的键（方法 + URL）
+func (i Interaction) key() string {
+	return i.Method + " " + i.URL
+}
+
+// matches 判断记录是否与请求匹配，URL 以 "/" 开头时只比较路径和（指定了的）查询参数
+func (i Interaction) matches(req *http.Request) bool {
+	if !strings.EqualFold(i.Method, req.Method) {
+		return false
+	}
+	if !strings.HasPrefix(i.URL, "/") {
+		return i.URL == req.URL.String()
+	}
+	path, query, hasQuery := strings.Cut(i.URL, "?")
+	if path != req.URL.Path {
+		return false
+	}
+	return !hasQuery || query == req.URL.Query().Encode()
+}
+
+// Redacted 录制文件中代替敏感字段值的占位符
+const Redacted = "REDACTED"
+
+// redactedFields 录制时替换的请求 body 字段：owner 为下单和撤单请求中的 API Key，signature 为订单签名
+var redactedFields = map[string]bool{
+	"owner":     true,
+	"signature": true,
+}
+
+// redactRequestBody 将 JSON 请求 body 中（任意层级的）敏感字段替换为 Redacted
+// 没有敏感字段或不是 JSON 时原样返回
+func redactRequestBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || !redactValue(value) {
+		return string(body)
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactValue 递归替换 value 中的敏感字段，返回是否替换了字段
+func redactValue(value any) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if redactedFields[key] {
+				v[key] = Redacted
+				redacted = true
+			} else if redactValue(field) {
+				redacted = true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if redactValue(item) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+// Recorder 录制经过的所有请求和响应，调用 Save 写入文件
+// 通过 WithTransport（或各客户端的 WithTransport 选项）接入，录制的文件可用 NewReplayer 回放
+type Recorder struct {
+	mu           sync.Mutex
+	path         string
+	base         http.RoundTripper
+	interactions []Interaction
+}
+
+// NewRecorder 创建录制器，path 为 Save 写入的文件路径
+// base 为实际发送请求的传输层，为 nil 时使用 NewTransport 创建的默认传输层
+func NewRecorder(path string, base RoundTripper) (*Recorder, error) {
+	if base == nil {
+		transport, err := NewTransport("")
+		if err != nil {
+			return nil, err
+		}
+		base = transport
+	}
+	return &Recorder{path: path, base: base}, nil
+}
+
+// RoundTrip 发送请求并记录请求和响应
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestBody: redactRequestBody(requestBody),
+		StatusCode:  resp.StatusCode,
+		Header:      header,
+		Body:        string(responseBody),
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Interactions 返回目前已录制的请求和响应（副本）
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save 将已录制的请求和响应以 JSON 格式写入文件（覆盖已有内容）
+func (r *Recorder) Save() error {
+	data, err := json.MarshalIndent(r.Interactions(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// Replayer 回放 Recorder 录制的响应，不发送任何网络请求
+// Interaction.URL 为完整 URL 时按方法和完整 URL 匹配请求；以 "/" 开头时只匹配路径，
+// 包含查询参数（如 "/book?token_id=1"）时还要求查询参数一致（按键排序编码），便于在测试中手写响应。
+// 多个记录都能匹配时使用最先出现的；同一请求录制了多次时按录制顺序依次返回，用完后重复返回最后一次的响应。
+// 签名请求的 body 每次都不同（时间戳、salt），因此匹配时不比较请求 body
+type Replayer struct {
+	mu        sync.Mutex
+	keys      []string // 按首次出现顺序排列的匹配键
+	queues    map[string][]Interaction
+	requests  []Interaction
+	unmatched []string
+}
+
+// NewReplayer 从 Recorder 保存的文件创建回放器
+func NewReplayer(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("failed to decode recording: %w", err)
+	}
+	return NewReplayerFromInteractions(interactions), nil
+}
+
+// NewReplayerFromInteractions 使用内存中的录制记录创建回放器，便于在测试中直接构造响应
+func NewReplayerFromInteractions(interactions []Interaction) *Replayer {
+	r := &Replayer{queues: make(map[string][]Interaction)}
+	for _, interaction := range interactions {
+		key := interaction.key()
+		if _, ok := r.queues[key]; !ok {
+			r.keys = append(r.keys, key)
+		}
+		r.queues[key] = append(r.queues[key], interaction)
+	}
+	return r
+}
+
+// RoundTrip 返回与请求匹配的录制响应，没有匹配记录时返回 ErrNoRecordedResponse
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	requestKey := req.Method + " " + req.URL.String()
+
+	r.mu.Lock()
+	interaction, ok := r.next(req)
+	if !ok {
+		r.unmatched = append(r.unmatched, requestKey)
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w for %s", ErrNoRecordedResponse, requestKey)
+	}
+	interaction.Method = req.Method
+	interaction.URL = req.URL.String()
+	interaction.RequestBody = string(requestBody)
+	if interaction.StatusCode == 0 {
+		interaction.StatusCode = http.StatusOK
+	}
+	r.requests = append(r.requests, interaction)
+	r.mu.Unlock()
+
+	header := interaction.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Body))),
+		ContentLength: int64(len(interaction.Body)),
+		Request:       req,
+	}, nil
+}
+
+// next 取出第一个匹配请求的记录，调用方需持有 r.mu
+func (r *Replayer) next(req *http.Request) (Interaction, bool) {
+	for _, key := range r.keys {
+		queue := r.queues[key]
+		if !queue[0].matches(req) {
+			continue
+		}
+		if len(queue) > 1 {
+			r.queues[key] = queue[1:]
+		}
+		return queue[0], true
+	}
+	return Interaction{}, false
+}
+
+// Requests 返回目前回放过的请求（副本），RequestBody 为实际发送的请求 body，StatusCode、Header、Body 为返回的响应
+func (r *Replayer) Requests() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.requests...)
+}
+
+// Unmatched 返回没有匹配记录的请求（"方法 URL"）
+func (r *Replayer) Unmatched() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.unmatched...)
+}
@@ -0,0 +1,328 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// roundTripFunc 用函数实现 RoundTripper，模拟真实服务端
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	const baseURL = "https://clob.example.com"
+
+	// 认证请求头必须发送到服务端，但不能出现在录制文件中
+	authHeaders := map[string]string{
+		"Authorization":   "Bearer live-bearer-token",
+		"POLY_API_KEY":    "live-api-key",
+		"POLY_SIGNATURE":  "live-signature",
+		"POLY_PASSPHRASE": "live-passphrase",
+	}
+
+	calls := 0
+	sentHeaders := map[string]string{}
+	server := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		// 请求头按原样设置（POLY_* 不经过规范化），按原始键查找
+		for name := range authHeaders {
+			if values := req.Header[name]; len(values) > 0 {
+				sentHeaders[name] = values[0]
+			}
+		}
+		body := `{"price":"0.5"}`
+		status := http.StatusOK
+		if req.URL.Path == "/missing" {
+			body, status = `{"error":"not found"}`, http.StatusNotFound
+		}
+		if req.Method == http.MethodPost {
+			requestBody, _ := io.ReadAll(req.Body)
+			body = `{"echo":` + string(requestBody) + `}`
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	recorder, err := NewRecorder(path, server)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+
+	live, err := GetRaw(baseURL, "GET", "/price", map[string]string{"token_id": "1", "side": "BUY"}, WithTransport(recorder))
+	if err != nil {
+		t.Fatalf("recorded GET failed: %v", err)
+	}
+	livePost, err := PostRaw(baseURL, "/order", []byte(`{"a": 1}`), WithTransport(recorder), WithHeaders(authHeaders))
+	if err != nil {
+		t.Fatalf("recorded POST failed: %v", err)
+	}
+	if _, err := GetRaw(baseURL, "GET", "/missing", nil, WithTransport(recorder)); err == nil {
+		t.Fatal("Expected error for 404 response")
+	}
+	if len(recorder.Interactions()) != 3 {
+		t.Fatalf("Expected 3 interactions, got %d", len(recorder.Interactions()))
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	recordedCalls := calls
+
+	t.Run("Replay", func(t *testing.T) {
+		got, err := GetRaw(baseURL, "GET", "/price", map[string]string{"side": "BUY", "token_id": "1"}, WithTransport(replayer))
+		if err != nil {
+			t.Fatalf("replayed GET failed: %v", err)
+		}
+		if string(got) != string(live) {
+			t.Errorf("Expected %s, got %s", live, got)
+		}
+		gotPost, err := PostRaw(baseURL, "/order", []byte(`{"a": 2}`), WithTransport(replayer))
+		if err != nil {
+			t.Fatalf("replayed POST failed: %v", err)
+		}
+		if string(gotPost) != string(livePost) {
+			t.Errorf("Expected %s, got %s", livePost, gotPost)
+		}
+		if calls != recordedCalls {
+			t.Errorf("Replay should not reach the server, got %d extra calls", calls-recordedCalls)
+		}
+	})
+
+	t.Run("RepeatsLastResponse", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if _, err := GetRaw(baseURL, "GET", "/price", map[string]string{"token_id": "1", "side": "BUY"}, WithTransport(replayer)); err != nil {
+				t.Fatalf("repeated replay failed: %v", err)
+			}
+		}
+	})
+
+	t.Run("ErrorStatus", func(t *testing.T) {
+		_, err := GetRaw(baseURL, "GET", "/missing", nil, WithTransport(replayer))
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected replayed 404 APIError, got %v", err)
+		}
+	})
+
+	t.Run("Unrecorded", func(t *testing.T) {
+		_, err := GetRaw(baseURL, "GET", "/book", nil, WithTransport(replayer))
+		if !errors.Is(err, ErrNoRecordedResponse) {
+			t.Errorf("Expected ErrNoRecordedResponse, got %v", err)
+		}
+	})
+
+	t.Run("NoRequestHeaders", func(t *testing.T) {
+		saved, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read recording: %v", err)
+		}
+		interactions, err := json.Marshal(recorder.Interactions())
+		if err != nil {
+			t.Fatalf("failed to marshal interactions: %v", err)
+		}
+		for name, value := range authHeaders {
+			if sentHeaders[name] != value {
+				t.Errorf("Expected %s to reach the server, got %q", name, sentHeaders[name])
+			}
+			if strings.Contains(string(saved), value) || strings.Contains(string(interactions), value) {
+				t.Errorf("Recording should not contain %s header value", name)
+			}
+		}
+	})
+}
+
+// 请求 body 必须原样发送到服务端，但 API Key 和订单签名不能出现在录制文件中
+func TestRecorderRedactsRequestBody(t *testing.T) {
+	const body = `[{"order":{"salt":1,"maker":"0xabc","signature":"0xlive-signature"},"owner":"live-api-key","orderType":"GTC"}]`
+
+	var sent string
+	server := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requestBody, _ := io.ReadAll(req.Body)
+		sent = string(requestBody)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`[]`)),
+			Request:    req,
+		}, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	recorder, err := NewRecorder(path, server)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	if _, err := PostRaw("https://clob.example.com", "/orders", []byte(body), WithTransport(recorder)); err != nil {
+		t.Fatalf("recorded POST failed: %v", err)
+	}
+	if _, err := PostRaw("https://clob.example.com", "/other", []byte("not json"), WithTransport(recorder)); err != nil {
+		t.Fatalf("recorded POST failed: %v", err)
+	}
+	if sent != "not json" {
+		t.Errorf("Expected the request body to reach the server unchanged, got %s", sent)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read recording: %v", err)
+	}
+	for _, secret := range []string{"live-api-key", "0xlive-signature"} {
+		if strings.Contains(string(saved), secret) {
+			t.Errorf("Recording should not contain %s", secret)
+		}
+	}
+
+	interactions := recorder.Interactions()
+	var recorded []map[string]any
+	if err := json.Unmarshal([]byte(interactions[0].RequestBody), &recorded); err != nil {
+		t.Fatalf("failed to decode recorded body: %v", err)
+	}
+	order := recorded[0]["order"].(map[string]any)
+	if recorded[0]["owner"] != Redacted || order["signature"] != Redacted {
+		t.Errorf("Expected owner and signature to be redacted, got %s", interactions[0].RequestBody)
+	}
+	if recorded[0]["orderType"] != "GTC" || order["maker"] != "0xabc" || order["salt"] != float64(1) {
+		t.Errorf("Expected other fields to be kept, got %s", interactions[0].RequestBody)
+	}
+	if interactions[1].RequestBody != "not json" {
+		t.Errorf("Expected non-JSON body to be kept, got %s", interactions[1].RequestBody)
+	}
+}
+
+func TestReplayerPathMatching(t *testing.T) {
+	const baseURL = "https://clob.example.com"
+	replayer := NewReplayerFromInteractions([]Interaction{
+		{Method: "GET", URL: "/book?token_id=1", StatusCode: http.StatusOK, Body: `{"asset_id":"1"}`},
+		{Method: "GET", URL: "/book", StatusCode: http.StatusOK, Body: `{"asset_id":"any"}`},
+		{Method: "POST", URL: "/order", Body: `{"orderID":"0x01"}`},
+	})
+
+	// 指定了查询参数的记录优先，其他查询参数使用只匹配路径的记录
+	for token, want := range map[string]string{"1": `{"asset_id":"1"}`, "2": `{"asset_id":"any"}`} {
+		body, err := GetRaw(baseURL, "GET", "/book", map[string]string{"token_id": token}, WithTransport(replayer))
+		if err != nil || string(body) != want {
+			t.Errorf("token %s: expected %s, got %s (err=%v)", token, want, body, err)
+		}
+	}
+
+	// 状态码为 0 时返回 200，并记录实际发送的请求 body
+	if _, err := PostRaw(baseURL, "/order", []byte(`{"side": "BUY"}`), WithTransport(replayer)); err != nil {
+		t.Fatalf("PostRaw failed: %v", err)
+	}
+	requests := replayer.Requests()
+	if len(requests) != 3 || requests[2].RequestBody != `{"side": "BUY"}` || requests[2].StatusCode != http.StatusOK {
+		t.Errorf("Unexpected recorded requests: %+v", requests)
+	}
+
+	if _, err := GetRaw(baseURL, "GET", "/midpoint", nil, WithTransport(replayer)); !errors.Is(err, ErrNoRecordedResponse) {
+		t.Errorf("Expected ErrNoRecordedResponse, got %v", err)
+	}
+	if unmatched := replayer.Unmatched(); len(unmatched) != 1 || !strings.Contains(unmatched[0], "/midpoint") {
+		t.Errorf("Expected unmatched /midpoint request, got %v", unmatched)
+	}
+}
+
+func TestWithUseNumber(t *testing.T) {
+	const tokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	replayer := NewReplayerFromInteractions([]Interaction{{
+		Method:     "GET",
+		URL:        "https://clob.example.com/numbers",
+		StatusCode: http.StatusOK,
+		Body:       `{"token_id": ` + tokenID + `, "amount": 9007199254740993, "tick": 0.001}`,
+	}})
+
+	// 默认解析为 float64，大整数丢失精度
+	plain, err := Get[map[string]interface{}]("https://clob.example.com", "/numbers", nil, WithTransport(replayer))
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if s, _ := types.NumberString((*plain)["amount"]); s == "9007199254740993" {
+		t.Errorf("Expected float64 decoding to lose precision, got %s", s)
+	}
+
+	resp, err := Get[map[string]interface{}]("https://clob.example.com", "/numbers", nil, WithTransport(replayer), WithUseNumber())
+	if err != nil {
+		t.Fatalf("Get with WithUseNumber failed: %v", err)
+	}
+	result := *resp
+	if _, ok := result["amount"].(json.Number); !ok {
+		t.Fatalf("Expected json.Number, got %T", result["amount"])
+	}
+	if s, ok := types.NumberString(result["token_id"]); !ok || s != tokenID {
+		t.Errorf("Expected token ID %s, got %s", tokenID, s)
+	}
+	if n, ok := types.NumberInt64(result["amount"]); !ok || n != 9007199254740993 {
+		t.Errorf("Expected exact amount, got %d", n)
+	}
+	if n, ok := types.NumberBigInt(result["token_id"]); !ok || n.String() != tokenID {
+		t.Errorf("Expected big.Int token ID, got %v", n)
+	}
+	if s, _ := types.NumberString(result["tick"]); s != "0.001" {
+		t.Errorf("Expected tick 0.001, got %s", s)
+	}
+	// 小数不能转换为整数
+	if _, ok := types.NumberInt64(result["tick"]); ok {
+		t.Error("Expected NumberInt64 to reject a decimal")
+	}
+	// 访问函数同样兼容 float64
+	if f, ok := types.NumberFloat64((*plain)["tick"]); !ok || f != 0.001 {
+		t.Errorf("Expected NumberFloat64 to accept float64, got %v", f)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var got []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = append(got, req.Header.Get("User-Agent"))
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`)), Header: http.Header{}}, nil
+	})
+	const baseURL = "https://clob.example.com"
+
+	if _, err := Get[map[string]interface{}](baseURL, "/ok", nil, WithTransport(transport)); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := PostRaw(baseURL, "/ok", []byte(`{}`), WithTransport(transport)); err != nil {
+		t.Fatalf("PostRaw failed: %v", err)
+	}
+	SetUserAgent("my-bot/1.0")
+	if _, err := GetRaw(baseURL, "GET", "/ok", nil, WithTransport(transport)); err != nil {
+		t.Fatalf("GetRaw failed: %v", err)
+	}
+	// 单个请求的 User-Agent 优先
+	if _, err := GetRaw(baseURL, "GET", "/ok", nil, WithTransport(transport), WithHeader("User-Agent", "override")); err != nil {
+		t.Fatalf("GetRaw failed: %v", err)
+	}
+	SetUserAgent("")
+	if _, err := DeleteRaw[map[string]interface{}](baseURL, "/ok", []byte(`{}`), WithTransport(transport)); err != nil {
+		t.Fatalf("DeleteRaw failed: %v", err)
+	}
+
+	// 未通过 ldflags 注入版本号时为 dev
+	const defaultUA = "go-polymarket-sdk/dev"
+	want := []string{defaultUA, defaultUA, "my-bot/1.0", "override", defaultUA}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected User-Agent %v, got %v", want, got)
+	}
+}
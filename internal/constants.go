@@ -39,13 +39,13 @@ const (
 var (
 	// PolygonRPCMainnetList 主网 RPC 节点列表（按优先级排序，已测试可用性）
 	PolygonRPCMainnetList = []string{
-		"https://rpc-mainnet.matic.quiknode.pro",        // ✅ 已验证可用
-		"https://polygon-rpc.com",                        // ✅ 已验证可用（官方推荐）
-		"https://polygon.drpc.org",                       // ✅ 已验证可用
-		"https://polygon-bor.publicnode.com",            // ✅ 已验证可用
-		"https://polygon.api.onfinality.io/public",      // ✅ 已验证可用
+		"https://rpc-mainnet.matic.quiknode.pro",                 // ✅ 已验证可用
+		"https://polygon-rpc.com",                                // ✅ 已验证可用（官方推荐）
+		"https://polygon.drpc.org",                               // ✅ 已验证可用
+		"https://polygon-bor.publicnode.com",                     // ✅ 已验证可用
+		"https://polygon.api.onfinality.io/public",               // ✅ 已验证可用
 		"https://endpoints.omniatech.io/v1/matic/mainnet/public", // ✅ 已验证可用
-		"https://137.rpc.thirdweb.com",                  // ✅ 已验证可用
+		"https://137.rpc.thirdweb.com",                           // ✅ 已验证可用
 	}
 
 	// PolygonRPCAmoyList 测试网 RPC 节点列表
@@ -84,6 +84,7 @@ const (
 	CancelAll          = "/cancel-all"
 	CancelMarketOrders = "/cancel-market-orders"
 	Orders             = "/data/orders"
+	GetOrder           = "/data/order/"
 )
 
 // Order Books endpoints
@@ -102,6 +103,7 @@ const (
 	GetSpreads          = "/spreads"
 	GetLastTradePrice   = "/last-trade-price"
 	GetLastTradesPrices = "/last-trades-prices"
+	GetPricesHistory    = "/prices-history"
 )
 
 // Trades endpoints
@@ -121,14 +123,14 @@ const (
 
 // Tags endpoints
 const (
-	GetTags    = "/tags"
-	GetTag     = "/tags/"
+	GetTags      = "/tags"
+	GetTag       = "/tags/"
 	GetTagBySlug = "/tags/slug/"
 )
 
 // Series endpoints
 const (
-	GetSeries      = "/series"
+	GetSeries       = "/series"
 	GetSeriesBySlug = "/series/slug/"
 )
 
@@ -140,7 +142,7 @@ const (
 
 // Profiles endpoints
 const (
-	GetProfile         = "/profiles/"
+	GetProfile           = "/profiles/"
 	GetProfileByUsername = "/profiles/username/"
 )
 
@@ -153,8 +155,9 @@ const (
 
 // Rewards endpoints
 const (
-	IsOrderScoring   = "/order-scoring"
-	AreOrdersScoring = "/orders-scoring"
+	IsOrderScoring           = "/order-scoring"
+	AreOrdersScoring         = "/orders-scoring"
+	GetCurrentRewardsMarkets = "/rewards/markets/current"
 )
 
 // Balance endpoints
@@ -192,6 +195,44 @@ const (
 
 	// 交易等待超时
 	TransactionWaitTimeout = 5 * time.Minute
+
+	// 奖励市场列表缓存时间
+	RewardMarketsCacheTTL = 1 * time.Minute
+
+	// 代币元数据缓存时间（accepting_orders 会随市场状态变化）
+	TokenMetaCacheTTL = 1 * time.Minute
+
+	// 代币元数据缓存（tick size、负风险、手续费率、TokenMeta）各自的默认最大条目数，超过时淘汰最久未使用的代币
+	MetadataCacheSize = 10000
+
+	// 等待订单状态时的轮询间隔（指数退避，从初始值逐步翻倍到最大值）
+	OrderStatusPollInitialInterval = 250 * time.Millisecond
+	OrderStatusPollMaxInterval     = 5 * time.Second
+
+	// 请求 RFQ 报价后轮询报价的间隔和最长等待时间
+	RFQQuotePollInterval = 500 * time.Millisecond
+	RFQQuoteTimeout      = 10 * time.Second
+
+	// 查询当前 API 凭证权限范围失败（网络等临时错误）后，在该时间内直接返回上次的错误
+	KeyScopeErrorTTL = 10 * time.Second
+
+	// 服务器时间偏差超过该值时记录警告（偏差过大会导致 L2 认证失败）
+	TimeSyncDriftWarnThreshold = 5 * time.Second
+
+	// 监听条件结算时的轮询间隔（指数退避，从初始值逐步翻倍到最大值）
+	ResolutionPollInitialInterval = 5 * time.Second
+	ResolutionPollMaxInterval     = 1 * time.Minute
+
+	// RPC 节点出现网络错误或限流后，在该时间内优先使用其他健康节点
+	RPCUnhealthyCooldown = 30 * time.Second
+
+	// Relayer 连续失败 RelayerBreakerThreshold 次后熔断，RelayerBreakerCooldown 内的调用直接返回错误
+	RelayerBreakerThreshold = 5
+	RelayerBreakerCooldown  = 60 * time.Second
+
+	// GTD 订单过期时间的安全阈值
+	// API 要求过期时间至少比当前时间晚 1 分钟，否则订单会被拒绝
+	GTDExpirationThreshold = 1 * time.Minute
 )
 
 // ============================================================================
@@ -202,11 +243,32 @@ const (
 	// 查询限制
 	DefaultPositionLimit = 100 // 默认位置查询限制
 
+	// 批量查询负风险状态时的最大并发请求数
+	NegRiskMaxConcurrency = 8
+
+	// 批量查询手续费率时的最大并发请求数
+	FeeRateMaxConcurrency = 8
+
+	// 批量查询价格历史时的最大并发请求数
+	PricesHistoryMaxConcurrency = 4
+
+	// 并发提交订单批次时，每批开始前的最大随机等待时间
+	OrderBatchJitter = 100 * time.Millisecond
+
+	// 单次撤单请求包含的最大订单数，超过时 CancelOrders 自动分批
+	CancelOrdersBatchSize = 1000
+
 	// Gas 估算相关
 	DefaultGasEstimate    = 10_000_000 // 默认 gas 估算值
 	GasEstimateMultiplier = 130        // Gas 估算倍数（1.3x，以百分比表示）
 	GasEstimateExtra      = 100_000    // Gas 估算额外值（100k）
 
+	// EIP-1559 Gas 费用建议相关
+	GasFeeHistoryBlocks     = 10             // eth_feeHistory 统计的区块数
+	GasFeeRewardPercentile  = 50             // 优先费取各区块的第 50 百分位
+	GasFeeBaseFeeMultiplier = 2              // maxFee 中 baseFee 的倍数，为后续区块 baseFee 上涨留出余量
+	MinPriorityFeeWei       = 30_000_000_000 // Polygon 要求的最低优先费（30 gwei）
+
 	// 数量精度
 	QuantityPrecision = 100000.0 // 数量精度（用于四舍五入）
 
@@ -214,6 +276,20 @@ const (
 	APIResponsePreviewLen = 1000 // API 响应预览长度
 )
 
+// ============================================================================
+// 限流相关常量
+// ============================================================================
+
+// CLOB API 默认限流（Polymarket 文档公布的数值，每个统计窗口内允许的请求数）
+// CLOB 客户端默认按这些值限速；API 没有提供查询账户限流等级的接口，等级更高的账户可通过 clob.WithRateLimits 覆盖
+const (
+	RateLimitWindow      = 10 * time.Second
+	RateLimitGeneral     = 5000 // 所有接口合计
+	RateLimitMarketData  = 200  // 单个行情接口（/book、/price、/midpoint 等）
+	RateLimitPostOrder   = 2400 // POST /order（突发）
+	RateLimitCancelOrder = 2400 // DELETE /order（突发）
+)
+
 // ============================================================================
 // 策略相关常量
 // ============================================================================
@@ -224,6 +300,10 @@ const (
 	// Polymarket API 要求最小订单大小，低于此值的订单会被拒绝
 	MinOrderSize float64 = 0.1
 
+	// DefaultMarketMinOrderSize 市场默认最小下单数量（单位：shares）
+	// 大多数 CLOB 市场的 orderMinSize 为 5，OrderArgs.MinSize 未指定时使用此值
+	DefaultMarketMinOrderSize float64 = 5.0
+
 	// DefaultTickSize 默认价格 tick 大小
 	// 用于价格量化，确保价格符合交易所要求
 	DefaultTickSize = 0.001
@@ -1,5 +1,7 @@
 package internal
 
+import "github.com/polymas/go-polymarket-sdk/types"
+
 // 智能合约地址配置（Polygon 主网）
 const (
 	// Relay 相关合约地址
@@ -29,3 +31,61 @@ const (
 	// ProxyFactory 合约地址
 	PolygonProxyFactory = "0xaB45c5A4B0c941a2F231C04C3f49182e1A254052"
 )
+
+// 智能合约地址配置（Amoy 测试网）
+const (
+	// Exchange 合约地址（Regular）
+	AmoyExchange = "0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40"
+	// Exchange 合约地址（Negative Risk）
+	AmoyNegRiskExchange = "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"
+	// Collateral 合约地址（测试网 USDC）
+	AmoyCollateral = "0x9c4e1703476e875070ee25b56a58b008cfb8fa78"
+	// ConditionalTokens 合约地址
+	AmoyConditionalTokens = "0x69308FB512518e39F9b16112fA8d994F4e2Bf8bB"
+)
+
+// ContractAddresses 单条链上的 Polymarket 合约地址
+type ContractAddresses struct {
+	Exchange          string
+	NegRiskExchange   string
+	Collateral        string
+	ConditionalTokens string
+	NegRiskAdapter    string // 为空表示该链未部署（或未配置）NegRiskAdapter
+	ProxyFactory      string // 为空表示该链未部署（或未配置）Proxy 钱包工厂
+}
+
+// chainContracts 按链ID索引的合约地址
+var chainContracts = map[types.ChainID]ContractAddresses{
+	Polygon: {
+		Exchange:          PolygonExchange,
+		NegRiskExchange:   PolygonNegRiskExchange,
+		Collateral:        PolygonCollateral,
+		ConditionalTokens: PolygonConditionalTokens,
+		NegRiskAdapter:    PolygonNegRiskAdapter,
+		ProxyFactory:      PolygonProxyFactory,
+	},
+	Amoy: {
+		Exchange:          AmoyExchange,
+		NegRiskExchange:   AmoyNegRiskExchange,
+		Collateral:        AmoyCollateral,
+		ConditionalTokens: AmoyConditionalTokens,
+	},
+}
+
+// GetContractAddresses 返回指定链的合约地址，不支持的链返回 false
+func GetContractAddresses(chainID types.ChainID) (ContractAddresses, bool) {
+	addresses, ok := chainContracts[chainID]
+	return addresses, ok
+}
+
+// ClobAPIDomainForChain 返回指定链的 CLOB API 地址，不支持的链返回 false
+// Polymarket 只提供主网 CLOB API，测试网（Amoy）的订单无法提交到主网 API
+func ClobAPIDomainForChain(chainID types.ChainID) (string, bool) {
+	if chainID == Polygon {
+		return ClobAPIDomain, true
+	}
+	return "", false
+}
+
+// Multicall3 合约地址（所有 EVM 链上地址相同）
+const Multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
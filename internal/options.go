@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// ConnectionOptions 各客户端共用的连接配置，嵌入各包的 clientOptions，由各包的 WithProxyURL、WithTimeouts、WithTransport 设置
+//
+// ProxyURL 支持 http、https、socks5、socks5h 协议，只对配置它的客户端生效，不修改环境变量；
+// 为空时使用环境变量中的代理配置（HTTPS_PROXY、HTTP_PROXY、NO_PROXY）。
+// 地址无效时客户端失败（见 Proxy），不会回退到环境变量中的代理或直连。
+// HTTP 客户端通过 http.ConnectionHTTPOptions 使用这些配置，WebSocket 客户端通过 WebSocketDialer 使用代理
+type ConnectionOptions struct {
+	ProxyURL  string            // 显式配置的代理地址，为空时使用环境变量中的代理配置
+	Timeouts  types.Timeouts    // 超时配置，未设置的字段使用默认值
+	Transport http.RoundTripper // 自定义传输层（如录制、回放），设置后代理配置不再生效
+}
+
+// ApplyOptions 依次对 opts 应用函数选项，忽略 nil 选项
+func ApplyOptions[T any, O ~func(*T)](opts *T, options []O) *T {
+	for _, opt := range options {
+		if opt != nil {
+			opt(opts)
+		}
+	}
+	return opts
+}
+
+// Proxy 解析并验证显式配置的代理地址，未配置时返回 nil（使用环境变量中的代理配置）
+// 地址无效时返回错误，调用方必须让客户端失败，而不是回退到环境变量中的代理或直连
+func (o ConnectionOptions) Proxy() (*url.URL, error) {
+	if o.ProxyURL == "" {
+		return nil, nil
+	}
+	proxyURL, err := ParseProxyURL(o.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client options: %w", err)
+	}
+	return proxyURL, nil
+}
+
+// WebSocketDialer 创建 WebSocket 拨号器（强制使用 IPv4）
+// proxyURL 为 Proxy 解析的显式代理，为 nil 时使用环境变量中的代理配置
+func WebSocketDialer(proxyURL *url.URL) *websocket.Dialer {
+	netDialer := &net.Dialer{
+		Timeout:   WebSocketDialTimeout,
+		KeepAlive: WebSocketKeepAlive,
+	}
+	proxy := http.ProxyFromEnvironment
+	if proxyURL != nil {
+		proxy = http.ProxyURL(proxyURL)
+	}
+	return &websocket.Dialer{
+		HandshakeTimeout: WebSocketHandshakeTimeout,
+		TLSClientConfig:  &tls.Config{},
+		Proxy:            proxy,
+		NetDial: func(network, addr string) (net.Conn, error) {
+			// 使用 tcp4 避免 IPv6 连接超时
+			if network == "tcp" {
+				network = "tcp4"
+			}
+			return netDialer.Dial(network, addr)
+		},
+	}
+}
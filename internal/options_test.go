@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestWebSocketDialerProxy(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://ws-subscriptions-clob.polymarket.com/ws/market", nil)
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+
+	// 显式配置的代理优先于环境变量
+	t.Setenv("HTTPS_PROXY", "http://127.0.0.1:8080")
+	proxyURL, err := (ConnectionOptions{ProxyURL: "socks5://127.0.0.1:1080"}).Proxy()
+	if err != nil {
+		t.Fatalf("Proxy failed: %v", err)
+	}
+	got, err := WebSocketDialer(proxyURL).Proxy(req)
+	if err != nil || got.String() != "socks5://127.0.0.1:1080" {
+		t.Errorf("Expected explicit proxy, got %v, %v", got, err)
+	}
+
+	// 未配置时使用环境变量中的代理
+	got, err = WebSocketDialer(nil).Proxy(req)
+	want, _ := url.Parse("http://127.0.0.1:8080")
+	if err != nil || got == nil || *got != *want {
+		t.Errorf("Expected environment proxy %v, got %v, %v", want, got, err)
+	}
+}
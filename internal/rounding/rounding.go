@@ -0,0 +1,97 @@
+// Package rounding 实现与 Polymarket 官方 Python 客户端一致的订单金额取整规则
+// 对应 py-clob-client 的 ROUNDING_CONFIG 以及 round_normal / round_down / round_up / decimal_places
+package rounding
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// Config 单个 tick size 对应的取整精度（小数位数）
+type Config struct {
+	Price  int // 价格精度
+	Size   int // 数量精度
+	Amount int // maker/taker 金额精度
+}
+
+// RoundingConfig 按 tick size 索引的取整配置，与 Python 的 ROUNDING_CONFIG 一致
+var RoundingConfig = map[types.TickSize]Config{
+	"0.1":    {Price: 1, Size: 2, Amount: 3},
+	"0.01":   {Price: 2, Size: 2, Amount: 4},
+	"0.001":  {Price: 3, Size: 2, Amount: 5},
+	"0.0001": {Price: 4, Size: 2, Amount: 6},
+}
+
+// ConfigForTickSize 返回 tick size 对应的取整配置
+// 非标准 tick size 按所在区间归类，小于 0.001 的统一使用 0.0001 的配置
+func ConfigForTickSize(tickSize float64) Config {
+	switch {
+	case tickSize >= 0.1:
+		return RoundingConfig["0.1"]
+	case tickSize >= 0.01:
+		return RoundingConfig["0.01"]
+	case tickSize >= 0.001:
+		return RoundingConfig["0.001"]
+	default:
+		return RoundingConfig["0.0001"]
+	}
+}
+
+// RoundNormal 四舍五入（ROUND_HALF_UP）到 decimals 位小数
+func RoundNormal(val float64, decimals int) float64 {
+	multiplier := pow10(decimals)
+	return float64(int64(val*multiplier+0.5)) / multiplier
+}
+
+// RoundDown 向下截断到 decimals 位小数
+func RoundDown(val float64, decimals int) float64 {
+	multiplier := pow10(decimals)
+	return float64(int64(val*multiplier)) / multiplier
+}
+
+// RoundUp 加上半个最小单位后截断到 decimals 位小数（即四舍五入），与签名订单一直使用的取整结果一致
+// 注意不是 math.Ceil：RoundUp(1.2301, 2) 为 1.23
+func RoundUp(val float64, decimals int) float64 {
+	multiplier := pow10(decimals)
+	epsilon := 0.5 / multiplier
+	return float64(int64((val+epsilon)*multiplier)) / multiplier
+}
+
+// DecimalPlaces 返回数值的小数位数（最多统计 10 位）
+func DecimalPlaces(val float64) int {
+	str := fmt.Sprintf("%.10f", val)
+	str = strings.TrimRight(str, "0")
+	str = strings.TrimRight(str, ".")
+	if !strings.Contains(str, ".") {
+		return 0
+	}
+	parts := strings.Split(str, ".")
+	if len(parts) != 2 {
+		return 0
+	}
+	return len(parts[1])
+}
+
+// RoundAmount 按 Python get_order_amounts 的规则对 maker/taker 金额取整：
+// 小数位数超过 decimals 时先用 RoundUp 取整到 decimals+4 位，仍超过则将原始金额向下截断到 decimals 位
+func RoundAmount(amount float64, decimals int) float64 {
+	if DecimalPlaces(amount) <= decimals {
+		return amount
+	}
+	roundedUp := RoundUp(amount, decimals+4)
+	if DecimalPlaces(roundedUp) > decimals {
+		return RoundDown(amount, decimals)
+	}
+	return roundedUp
+}
+
+// pow10 返回 10 的 decimals 次方
+func pow10(decimals int) float64 {
+	multiplier := 1.0
+	for i := 0; i < decimals; i++ {
+		multiplier *= 10
+	}
+	return multiplier
+}
@@ -0,0 +1,88 @@
+package rounding
+
+import (
+	"math"
+	"testing"
+)
+
+// 期望值由 py-clob-client 的 order_builder/helpers.py 计算得到，RoundUp 除外：
+// Python 的 round_up 为 ceil（round_up(1.2301, 2) 为 1.24），SDK 的 RoundUp 保留签名订单一直使用的四舍五入
+
+func TestConfigForTickSize(t *testing.T) {
+	cases := []struct {
+		tickSize float64
+		want     Config
+	}{
+		{0.1, Config{Price: 1, Size: 2, Amount: 3}},
+		{0.01, Config{Price: 2, Size: 2, Amount: 4}},
+		{0.001, Config{Price: 3, Size: 2, Amount: 5}},
+		{0.0001, Config{Price: 4, Size: 2, Amount: 6}},
+	}
+	for _, c := range cases {
+		if got := ConfigForTickSize(c.tickSize); got != c.want {
+			t.Errorf("ConfigForTickSize(%v) = %+v, want %+v", c.tickSize, got, c.want)
+		}
+	}
+}
+
+func TestRoundingHelpers(t *testing.T) {
+	cases := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"RoundNormalHalfUp", RoundNormal(0.555, 2), 0.56},
+		{"RoundNormalTick", RoundNormal(0.5049, 3), 0.505},
+		{"RoundDown", RoundDown(1.239, 2), 1.23},
+		// 与 Python 不同：RoundUp 为加半个最小单位后截断，不是 ceil
+		{"RoundUp", RoundUp(1.2301, 2), 1.23},
+		{"RoundUpHalf", RoundUp(1.235, 2), 1.24},
+		{"RoundUpExact", RoundUp(1.23, 2), 1.23},
+		// 进位后仍超过精度时截断的是原始金额
+		{"RoundAmountTruncate", RoundAmount(1.23456789019, 4), 1.2345},
+		{"RoundAmountRoundUp", RoundAmount(12.203199999, 4), 12.2032},
+	}
+	for _, c := range cases {
+		if math.Abs(c.got-c.want) > 1e-12 {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	if got := DecimalPlaces(0.0001); got != 4 {
+		t.Errorf("DecimalPlaces(0.0001) = %d, want 4", got)
+	}
+	if got := DecimalPlaces(12.5); got != 1 {
+		t.Errorf("DecimalPlaces(12.5) = %d, want 1", got)
+	}
+	if got := DecimalPlaces(3); got != 0 {
+		t.Errorf("DecimalPlaces(3) = %d, want 0", got)
+	}
+}
+
+// TestOrderAmounts 按 get_order_amounts 的流程组合取整函数，与 Python 结果比对
+func TestOrderAmounts(t *testing.T) {
+	cases := []struct {
+		size, price, tickSize float64
+		wantSize, wantAmount  float64
+	}{
+		{100, 0.56, 0.01, 100, 56},
+		{21.04, 0.58, 0.01, 21.04, 12.2032},
+		{33.333, 0.123, 0.001, 33.33, 4.09959},
+		{7.777, 0.0567, 0.0001, 7.77, 0.440559},
+		{15.5, 0.55, 0.1, 15.5, 9.3},
+		{10.129, 0.5049, 0.001, 10.12, 5.1106},
+		{3.3, 0.3333, 0.0001, 3.3, 1.09989},
+	}
+	for _, c := range cases {
+		cfg := ConfigForTickSize(c.tickSize)
+		size := RoundDown(c.size, cfg.Size)
+		amount := RoundAmount(size*RoundNormal(c.price, cfg.Price), cfg.Amount)
+		if math.Abs(size-c.wantSize) > 1e-9 || math.Abs(amount-c.wantAmount) > 1e-9 {
+			t.Errorf("size=%v price=%v tick=%v: got (%v, %v), want (%v, %v)",
+				c.size, c.price, c.tickSize, size, amount, c.wantSize, c.wantAmount)
+		}
+		if DecimalPlaces(amount) > cfg.Amount {
+			t.Errorf("size=%v price=%v tick=%v: amount %v exceeds %d decimals", c.size, c.price, c.tickSize, amount, cfg.Amount)
+		}
+	}
+}
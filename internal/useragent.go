@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"sync/atomic"
+
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// userAgent 通过 SetUserAgent 设置的 User-Agent，nil 时使用默认值
+var userAgent atomic.Pointer[string]
+
+// SetUserAgent 设置所有请求（REST、Relayer、Subgraph、WebSocket）使用的 User-Agent，为空时恢复默认值
+func SetUserAgent(ua string) {
+	if ua == "" {
+		userAgent.Store(nil)
+		return
+	}
+	userAgent.Store(&ua)
+}
+
+// UserAgent 返回请求使用的 User-Agent，默认为 go-polymarket-sdk/<version>
+func UserAgent() string {
+	if ua := userAgent.Load(); ua != nil {
+		return *ua
+	}
+	return "go-polymarket-sdk/" + types.Version
+}
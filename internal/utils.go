@@ -1,10 +1,17 @@
 package internal
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/polymas/go-polymarket-sdk/signing"
@@ -48,73 +55,72 @@ func CreateLevel1Headers(signer *signing.Signer, nonce *int) (map[string]string,
 	return headers, nil
 }
 
-// CreateLevel2Headers creates Level 2 Poly headers for a request
-func CreateLevel2Headers(
-	signer *signing.Signer,
-	creds *types.ApiCreds,
-	requestArgs *types.RequestArgs,
-	builder bool,
-) (map[string]string, error) {
-	timestamp := strconv.FormatInt(time.Now().UTC().Unix(), 10)
-
-	// Convert RequestBody to interface{} for BuildHMACSignature
-	// Python version passes body directly (dict/list), then build_hmac_signature does str(body).replace("'", '"')
-	// Go version: Body is already JSON string (from RequestBody), pass it directly
-	var body interface{}
-	if requestArgs.Body != nil {
-		// Body is already a JSON string (from json.Marshal), pass it as string
-		body = string(*requestArgs.Body)
+// requestTimestamp 返回签名使用的时间戳
+// 调用方按服务器时钟校正后的时间优先，未设置时使用本地时间
+func requestTimestamp(requestArgs *types.RequestArgs) int64 {
+	if requestArgs.Timestamp > 0 {
+		return requestArgs.Timestamp
 	}
+	return time.Now().UTC().Unix()
+}
 
-	hmacSig, err := signing.BuildHMACSignature(
-		creds.Secret,
-		timestamp,
-		requestArgs.Method,
-		requestArgs.RequestPath,
-		body,
-	)
+// MarshalPythonJSON 将 v 序列化为与 Python json.dumps 一致的 JSON（冒号和逗号后带空格）
+// 所有需要 HMAC 签名的请求体都应使用该函数，并将结果同时用于签名和发送，保证两者逐字节一致
+func MarshalPythonJSON(v interface{}) ([]byte, error) {
+	compact, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
+	return []byte(signing.FormatPythonJSON(string(compact))), nil
+}
 
-	if builder {
-		return map[string]string{
-			PolyBuilderSignature:  hmacSig,
-			PolyBuilderTimestamp:  timestamp,
-			PolyBuilderAPIKey:     creds.Key,
-			PolyBuilderPassphrase: creds.Passphrase,
-		}, nil
-	}
+// signatureAssertions 是否在发送请求前校验签名与请求体一致（调试/测试用）
+var signatureAssertions atomic.Bool
 
-	headers := map[string]string{
-		PolyAddress:    signer.Address().String(),
-		PolySignature:  hmacSig,
-		PolyTimestamp:  timestamp,
-		PolyAPIKey:     creds.Key,
-		PolyPassphrase: creds.Passphrase,
+// SetSignatureAssertions 开启或关闭签名断言模式
+// 开启后，每个签名请求在发送前都会对实际发送的字节重新计算 HMAC 并与请求头比较
+func SetSignatureAssertions(enabled bool) {
+	signatureAssertions.Store(enabled)
+}
+
+// SignatureAssertionsEnabled 返回签名断言模式是否开启
+func SignatureAssertionsEnabled() bool {
+	return signatureAssertions.Load()
+}
+
+// VerifySignedBody 对实际发送的 body 字节重新计算 HMAC，并与请求头中的 POLY_SIGNATURE 比较
+// 直接对原始字节签名而不做任何格式转换，因此 body 格式与签名路径不一致时会返回错误
+func VerifySignedBody(secret string, headers map[string]string, method, requestPath string, body []byte) error {
+	secretBytes, err := base64.URLEncoding.DecodeString(secret)
+	if err != nil {
+		return fmt.Errorf("failed to decode secret: %w", err)
 	}
 
-	return headers, nil
+	mac := hmac.New(sha256.New, secretBytes)
+	mac.Write([]byte(headers[PolyTimestamp] + method + requestPath + string(body)))
+	expected := base64.URLEncoding.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(headers[PolySignature])) {
+		return fmt.Errorf("HMAC signature does not match transmitted body for %s %s: body=%s", method, requestPath, body)
+	}
+	return nil
 }
 
-// CreateLevel2HeadersWithBody creates Level 2 Poly headers with body passed directly (for POST /orders)
-// This matches Python behavior where body is passed as list/dict, not JSON string
-func CreateLevel2HeadersWithBody(
+// CreateLevel2Headers creates Level 2 Poly headers for a request
+func CreateLevel2Headers(
 	signer *signing.Signer,
 	creds *types.ApiCreds,
 	requestArgs *types.RequestArgs,
-	body interface{},
 	builder bool,
 ) (map[string]string, error) {
-	timestamp := strconv.FormatInt(time.Now().UTC().Unix(), 10)
+	timestamp := strconv.FormatInt(requestTimestamp(requestArgs), 10)
 
-	// Pass body directly to BuildHMACSignature (matches Python: body is list/dict, not JSON string)
 	hmacSig, err := signing.BuildHMACSignature(
 		creds.Secret,
 		timestamp,
 		requestArgs.Method,
 		requestArgs.RequestPath,
-		body,
+		level2Body(requestArgs),
 	)
 	if err != nil {
 		return nil, err
@@ -140,6 +146,39 @@ func CreateLevel2HeadersWithBody(
 	return headers, nil
 }
 
+// level2Body 返回 L2 签名使用的请求体
+// Python version passes body directly (dict/list), then build_hmac_signature does str(body).replace("'", '"')
+// Go version: Body is already JSON string (from RequestBody), pass it directly
+func level2Body(requestArgs *types.RequestArgs) interface{} {
+	if requestArgs.Body == nil {
+		return nil
+	}
+	return string(*requestArgs.Body)
+}
+
+// DescribeLevel1Auth 返回 L1 请求头签名内容的调试描述：EIP-712 域、ClobAuth 消息各字段、摘要和签名
+// 时间戳和 nonce 取自 headers，与实际发送的请求头一致，便于与 Python SDK 的签名结果逐项对比
+func DescribeLevel1Auth(signer *signing.Signer, headers map[string]string) string {
+	timestamp, _ := strconv.ParseInt(headers[PolyTimestamp], 10, 64)
+	nonce, _ := strconv.Atoi(headers[PolyNonce])
+	digest := signing.ClobAuthDigest(signer.Address(), signer.ChainID(), timestamp, nonce)
+	return fmt.Sprintf("L1 auth: domain={name: %q, version: %q, chainId: %d} ClobAuth={address: %s, timestamp: %q, nonce: %d, message: %q} digest=%s signature=%s",
+		signing.ClobDomainName, signing.ClobVersion, signer.ChainID(),
+		signer.Address(), headers[PolyTimestamp], nonce, signing.MsgToSign,
+		digest.Hex(), headers[PolySignature])
+}
+
+// DescribeLevel2Auth 返回 L2 请求头签名内容的调试描述：实际参与 HMAC 的消息（timestamp + method + path + body）和签名
+// 时间戳取自 headers，与实际发送的请求头一致；secret 和 passphrase 不输出
+func DescribeLevel2Auth(requestArgs *types.RequestArgs, headers map[string]string) string {
+	message, err := signing.HMACMessage(headers[PolyTimestamp], requestArgs.Method, requestArgs.RequestPath, level2Body(requestArgs))
+	if err != nil {
+		message = fmt.Sprintf("<failed to build message: %v>", err)
+	}
+	return fmt.Sprintf("L2 auth: message=%q signature=%s address=%s apiKey=%s passphrase=<redacted> secret=<redacted>",
+		message, headers[PolySignature], headers[PolyAddress], headers[PolyAPIKey])
+}
+
 // ValidateEthAddress 验证以太坊地址格式
 // 返回错误如果地址格式无效
 func ValidateEthAddress(addr string) error {
@@ -240,3 +279,102 @@ func ValidatePriceRange(price, minPrice, maxPrice float64) error {
 
 	return nil
 }
+
+// ParseProxyURL 解析并验证代理地址
+// 支持 http、https、socks5、socks5h 协议，返回解析后的 URL
+func ParseProxyURL(proxyURL string) (*url.URL, error) {
+	if proxyURL == "" {
+		return nil, fmt.Errorf("proxy URL cannot be empty")
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme: %q (expected http, https, socks5 or socks5h)", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("empty host in proxy URL")
+	}
+
+	return u, nil
+}
+
+// ResolveTimeouts 用默认值填充超时配置中未设置（为 0）的字段
+func ResolveTimeouts(timeouts types.Timeouts) types.Timeouts {
+	if timeouts.HTTP <= 0 {
+		timeouts.HTTP = HTTPClientTimeout
+	}
+	if timeouts.HTTPLong <= 0 {
+		timeouts.HTTPLong = HTTPClientLongTimeout
+	}
+	if timeouts.RelayNonce <= 0 {
+		timeouts.RelayNonce = RelayNonceTimeout
+	}
+	if timeouts.TransactionWait <= 0 {
+		timeouts.TransactionWait = TransactionWaitTimeout
+	}
+	if timeouts.TransactionDelay <= 0 {
+		timeouts.TransactionDelay = TransactionDelay
+	}
+	return timeouts
+}
+
+// ParseRetryAfter 解析 Retry-After 响应头
+// 支持秒数（如 "120"）和 HTTP 日期两种格式，缺失或无效时返回 0
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+var (
+	htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// IsNonJSONResponse 判断响应是否为 HTML 页面而不是 API 的 JSON 响应
+// Cloudflare 等代理拦截请求（IP 被标记、挑战页面、维护页面）时返回 HTML，按 Content-Type 或以 "<" 开头的 body 判断
+func IsNonJSONResponse(contentType string, body []byte) bool {
+	if strings.Contains(strings.ToLower(contentType), "text/html") {
+		return true
+	}
+	trimmed := strings.TrimSpace(string(body))
+	return strings.HasPrefix(trimmed, "<")
+}
+
+// ResponseSnippet 返回用于错误信息的响应摘要，最多 maxLen 个字符
+// HTML 页面优先使用 <title>，否则去掉标签并合并空白
+func ResponseSnippet(body []byte, maxLen int) string {
+	text := string(body)
+	if match := htmlTitlePattern.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	} else {
+		text = htmlTagPattern.ReplaceAllString(text, " ")
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > maxLen {
+		return text[:maxLen] + "..."
+	}
+	return text
+}
@@ -29,18 +29,18 @@ func Chain(middlewares ...Middleware) Middleware {
 
 // RetryMiddleware 重试中间件
 type RetryMiddleware struct {
-	MaxRetries          int
-	BackoffBase         time.Duration
-	BackoffMax          time.Duration
+	MaxRetries           int
+	BackoffBase          time.Duration
+	BackoffMax           time.Duration
 	RetryableStatusCodes []int
 }
 
 // NewRetryMiddleware 创建重试中间件
 func NewRetryMiddleware(maxRetries int, backoffBase, backoffMax time.Duration) Middleware {
 	retryMW := &RetryMiddleware{
-		MaxRetries:          maxRetries,
-		BackoffBase:         backoffBase,
-		BackoffMax:          backoffMax,
+		MaxRetries:           maxRetries,
+		BackoffBase:          backoffBase,
+		BackoffMax:           backoffMax,
 		RetryableStatusCodes: []int{500, 502, 503, 504, 408, 429},
 	}
 	return retryMW.Wrap
@@ -51,6 +51,7 @@ func (m *RetryMiddleware) Wrap(next RequestFunc) RequestFunc {
 	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
 		var lastErr error
 		var lastResp *http.Response
+		var retryAfter time.Duration
 
 		for attempt := 0; attempt <= m.MaxRetries; attempt++ {
 			if attempt > 0 {
@@ -59,6 +60,10 @@ func (m *RetryMiddleware) Wrap(next RequestFunc) RequestFunc {
 				if backoff > m.BackoffMax {
 					backoff = m.BackoffMax
 				}
+				// 服务端通过 Retry-After 指定了更长的等待时间时以服务端为准
+				if retryAfter > backoff {
+					backoff = retryAfter
+				}
 
 				internal.LogDebug("重试请求 (尝试 %d/%d)，等待 %v", attempt, m.MaxRetries, backoff)
 
@@ -84,6 +89,7 @@ func (m *RetryMiddleware) Wrap(next RequestFunc) RequestFunc {
 			// 检查状态码是否可重试
 			if m.isRetryableStatusCode(resp.StatusCode) {
 				lastResp = resp
+				retryAfter = internal.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
 				// 读取并关闭响应体
 				io.Copy(io.Discard, resp.Body)
 				resp.Body.Close()
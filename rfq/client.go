@@ -1,45 +1,226 @@
 package rfq
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/polymas/go-polymarket-sdk/http"
 	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/signing"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
 
+// RFQ 接口路径
+const (
+	requestPath = "/rfq/request"
+	quotesPath  = "/rfq/quotes"
+	acceptPath  = "/rfq/accept"
+	cancelPath  = "/rfq/cancel"
+)
+
 // Client 定义 RFQ 客户端的接口
 type Client interface {
 	RequestQuote(request types.RFQRequest) (*types.RFQResponse, error)
+	RequestBestQuote(ctx context.Context, request types.RFQRequest) (*types.RFQQuote, error)
 	GetQuotes(requestID string) ([]types.RFQQuote, error)
 	AcceptQuote(quoteID string) (*types.RFQAcceptResponse, error)
 	CancelRequest(requestID string) error
 }
 
+// AuthHeadersFunc 为请求生成认证请求头，requestArgs 中包含请求方法、路径和实际发送的 body
+type AuthHeadersFunc func(requestArgs *types.RequestArgs) (map[string]string, error)
+
 // rfqClient 处理 RFQ 操作
 type rfqClient struct {
-	baseURL string
+	baseURL     string
+	httpOptions []http.HTTPOption
+	authHeaders AuthHeadersFunc // 为 nil 时请求不带认证头
+}
+
+// ClientOption RFQ 客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions RFQ 客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+	authHeaders AuthHeadersFunc
+}
+
+// WithProxyURL 设置客户端使用的代理地址
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
+}
+
+// WithTimeouts 设置客户端的超时配置，未设置的字段使用默认值
+// 只使用其中的 HTTP 超时
+func WithTimeouts(timeouts types.Timeouts) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Timeouts = timeouts
+	}
+}
+
+// WithTransport 设置询价请求使用的传输层；通过 CLOB 客户端使用 RFQ 时由 clob.WithTransport 统一设置
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Transport = transport
+	}
+}
+
+// WithL2Auth 使用 API 凭证为每个请求添加 L2 认证头，询价和接受报价需要可交易的 API 凭证
+func WithL2Auth(signer *signing.Signer, creds *types.ApiCreds) ClientOption {
+	return WithAuthHeaders(func(requestArgs *types.RequestArgs) (map[string]string, error) {
+		return internal.CreateLevel2Headers(signer, creds, requestArgs, false)
+	})
+}
+
+// WithAuthHeaders 设置生成认证请求头的函数
+// CLOB 客户端通过它复用自身的 L2 认证（服务器时间校准、认证调试日志）
+func WithAuthHeaders(authHeaders AuthHeadersFunc) ClientOption {
+	return func(opts *clientOptions) {
+		opts.authHeaders = authHeaders
+	}
 }
 
 // NewClient 创建新的 RFQ 客户端
-func NewClient() Client {
+// 代理地址无效时后续请求都返回该错误
+func NewClient(options ...ClientOption) Client {
+	opts := internal.ApplyOptions(&clientOptions{}, options)
 	return &rfqClient{
-		baseURL: internal.ClobAPIDomain,
+		baseURL:     internal.ClobAPIDomain,
+		httpOptions: http.ConnectionHTTPOptions(opts.ConnectionOptions),
+		authHeaders: opts.authHeaders,
+	}
+}
+
+// requestOptions 合并客户端级别的 HTTP 选项和请求级别的选项
+func (r *rfqClient) requestOptions(options ...http.HTTPOption) []http.HTTPOption {
+	return append(append([]http.HTTPOption{}, r.httpOptions...), options...)
+}
+
+// headers 返回请求的认证头，未配置认证时返回 nil
+func (r *rfqClient) headers(requestArgs *types.RequestArgs) (map[string]string, error) {
+	if r.authHeaders == nil {
+		return nil, nil
+	}
+	headers, err := r.authHeaders(requestArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create headers: %w", err)
 	}
+	return headers, nil
+}
+
+// post 以 Python json.dumps 格式编码 body 并发送 POST 请求，配置了认证时对实际发送的 body 签名
+func (r *rfqClient) post(path string, body interface{}, result interface{}) error {
+	bodyJSON, err := internal.MarshalPythonJSON(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	requestBody := types.RequestBody(bodyJSON)
+	headers, err := r.headers(&types.RequestArgs{Method: "POST", RequestPath: path, Body: &requestBody})
+	if err != nil {
+		return err
+	}
+
+	responseBody, err := http.PostRaw(r.baseURL, path, bodyJSON, r.requestOptions(http.WithHeaders(headers))...)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(responseBody, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
 }
 
 // RequestQuote 请求报价
 func (r *rfqClient) RequestQuote(request types.RFQRequest) (*types.RFQResponse, error) {
-	return http.Post[types.RFQResponse](r.baseURL, "/rfq/request", request)
+	var response types.RFQResponse
+	if err := r.post(requestPath, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RequestBestQuote 发起询价并返回最优报价（BUY 取价格最低、SELL 取价格最高的报价）
+// 发起请求后每 internal.RFQQuotePollInterval 查询一次报价，直到收到未过期的报价或 ctx 结束；
+// ctx 没有截止时间时最多等待 internal.RFQQuoteTimeout。
+// 没有拿到报价就返回时会取消询价请求（/rfq/cancel），避免做市商继续对已放弃的请求报价；
+// 超时返回 types.ErrNoRFQQuote，ctx 被取消时返回 ctx.Err()
+func (r *rfqClient) RequestBestQuote(ctx context.Context, request types.RFQRequest) (*types.RFQQuote, error) {
+	if request.TokenID == "" {
+		return nil, fmt.Errorf("token ID is required")
+	}
+	if request.Side != types.OrderSideBUY && request.Side != types.OrderSideSELL {
+		return nil, fmt.Errorf("invalid side: %s", request.Side)
+	}
+	if request.Size <= 0 {
+		return nil, fmt.Errorf("size must be positive, got %v", request.Size)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, internal.RFQQuoteTimeout)
+		defer cancel()
+	}
+
+	response, err := r.RequestQuote(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request quote: %w", err)
+	}
+	if response.RequestID == "" {
+		return nil, fmt.Errorf("RFQ response has no request ID")
+	}
+
+	ticker := time.NewTicker(internal.RFQQuotePollInterval)
+	defer ticker.Stop()
+	for {
+		quotes, err := r.GetQuotes(response.RequestID)
+		if err != nil {
+			r.cancelAbandoned(response.RequestID)
+			return nil, err
+		}
+		if best := bestQuote(quotes, request.Side, time.Now()); best != nil {
+			return best, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			r.cancelAbandoned(response.RequestID)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("RFQ request %s: %w", response.RequestID, types.ErrNoRFQQuote)
+			}
+			return nil, fmt.Errorf("RFQ request %s: %w", response.RequestID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// cancelAbandoned 取消放弃等待的询价请求，取消失败只记录日志（请求到期后服务端也会自动关闭）
+func (r *rfqClient) cancelAbandoned(requestID string) {
+	if err := r.CancelRequest(requestID); err != nil {
+		internal.LogDebug("取消 RFQ 请求 %s 失败: %v", requestID, err)
+	}
 }
 
 // GetQuotes 获取报价列表
 func (r *rfqClient) GetQuotes(requestID string) ([]types.RFQQuote, error) {
+	headers, err := r.headers(&types.RequestArgs{Method: "GET", RequestPath: quotesPath})
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"request_id": requestID,
 	}
 
-	result, err := http.Get[[]types.RFQQuote](r.baseURL, "/rfq/quotes", params)
+	result, err := http.Get[[]types.RFQQuote](r.baseURL, quotesPath, params, r.requestOptions(http.WithHeaders(headers))...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get quotes: %w", err)
 	}
@@ -57,7 +238,11 @@ func (r *rfqClient) AcceptQuote(quoteID string) (*types.RFQAcceptResponse, error
 		"quote_id": quoteID,
 	}
 
-	return http.Post[types.RFQAcceptResponse](r.baseURL, "/rfq/accept", requestBody)
+	var response types.RFQAcceptResponse
+	if err := r.post(acceptPath, requestBody, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
 }
 
 // CancelRequest 取消请求
@@ -66,6 +251,31 @@ func (r *rfqClient) CancelRequest(requestID string) error {
 		"request_id": requestID,
 	}
 
-	_, err := http.Post[map[string]interface{}](r.baseURL, "/rfq/cancel", requestBody)
-	return err
+	return r.post(cancelPath, requestBody, nil)
+}
+
+// bestQuote 返回未过期报价中对请求方最有利的报价，没有可用报价时返回 nil
+// 已取消、已过期或已被接受的报价不参与比较；没有过期时间的报价视为有效
+func bestQuote(quotes []types.RFQQuote, side types.OrderSide, now time.Time) *types.RFQQuote {
+	var best *types.RFQQuote
+	for i := range quotes {
+		quote := &quotes[i]
+		switch strings.ToLower(quote.Status) {
+		case "canceled", "cancelled", "expired", "accepted":
+			continue
+		}
+		if !quote.ExpiresAt.IsZero() && !quote.ExpiresAt.After(now) {
+			continue
+		}
+		if best == nil ||
+			(side == types.OrderSideBUY && quote.Price < best.Price) ||
+			(side == types.OrderSideSELL && quote.Price > best.Price) {
+			best = quote
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	result := *best
+	return &result
 }
@@ -1,7 +1,11 @@
 package rfq
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/polymas/go-polymarket-sdk/test"
 	"github.com/polymas/go-polymarket-sdk/types"
@@ -293,3 +297,61 @@ func TestCancelRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestRequestBestQuote(t *testing.T) {
+	// 离线测试：等待超时后取消询价请求
+	t.Run("CancelOnTimeout", func(t *testing.T) {
+		transport := test.NewFixtureTransport(t,
+			test.Fixture{Method: "POST", Path: "/rfq/request", Body: `{"request_id":"req-1","status":"open"}`},
+			test.Fixture{Path: "/rfq/quotes", Body: `[]`},
+			test.Fixture{Method: "POST", Path: "/rfq/cancel", Body: `{}`},
+		)
+		client := NewClient(WithTransport(transport))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := client.RequestBestQuote(ctx, types.RFQRequest{TokenID: test.FixtureYesTokenID, Side: types.OrderSideBUY, Size: 1000})
+		if !errors.Is(err, types.ErrNoRFQQuote) {
+			t.Fatalf("Expected ErrNoRFQQuote, got %v", err)
+		}
+
+		requests := transport.Requests()
+		last := requests[len(requests)-1]
+		if last.Method != "POST" || !strings.HasSuffix(last.URL, "/rfq/cancel") || !strings.Contains(last.RequestBody, `"request_id": "req-1"`) {
+			t.Errorf("Expected the request to be canceled, got %+v", requests)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		transport := test.NewFixtureTransport(t,
+			test.Fixture{Method: "POST", Path: "/rfq/request", Body: `{"request_id":"req-2","status":"open"}`},
+			test.Fixture{Path: "/rfq/quotes", Body: `[]`},
+			test.Fixture{Method: "POST", Path: "/rfq/cancel", Body: `{}`},
+		)
+		client := NewClient(WithTransport(transport))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.RequestBestQuote(ctx, types.RFQRequest{TokenID: test.FixtureYesTokenID, Side: types.OrderSideSELL, Size: 1000})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	// SELL 取价格最高的未过期报价
+	t.Run("BestQuote", func(t *testing.T) {
+		now := time.Now()
+		quotes := []types.RFQQuote{
+			{QuoteID: "q-expired", Price: 0.60, ExpiresAt: now.Add(-time.Minute)},
+			{QuoteID: "q-accepted", Price: 0.58, Status: "accepted"},
+			{QuoteID: "q-low", Price: 0.50},
+			{QuoteID: "q-high", Price: 0.55, ExpiresAt: now.Add(time.Minute)},
+		}
+		if best := bestQuote(quotes, types.OrderSideSELL, now); best == nil || best.QuoteID != "q-high" {
+			t.Errorf("Expected q-high, got %+v", best)
+		}
+		if best := bestQuote(quotes[:2], types.OrderSideSELL, now); best != nil {
+			t.Errorf("Expected no usable quote, got %+v", best)
+		}
+	})
+}
@@ -1,13 +1,12 @@
 package rtds
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net"
 	"net/http"
 	"net/url"
-	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -32,6 +31,7 @@ type Client interface {
 	UnsubscribePrices(tokenIDs []string) error
 	SubscribeComments(marketIDs []string) error
 	UnsubscribeComments(marketIDs []string) error
+	SubscribeActivity(ctx context.Context, conditionIDs []string) (<-chan types.MarketTradesEvent, error)
 }
 
 // RTDSAuth 表示 RTDS 连接的认证信息
@@ -41,31 +41,57 @@ type RTDSAuth struct {
 
 // rtdsClient 处理 RTDS WebSocket 连接
 type rtdsClient struct {
-	conn                *websocket.Conn
-	connMutex           sync.RWMutex
-	onPriceUpdate       func(update *types.EncryptedPriceUpdate)
-	onCommentUpdate     func(comment *types.Comment)
-	reconnectDelay      time.Duration
-	stopChan            chan struct{}
-	stopOnce            sync.Once
-	running             bool
-	runningMutex        sync.RWMutex
-	auth                *RTDSAuth
-	subscribedPrices    map[string]bool
-	subscribedComments  map[string]bool
-	subscriptionsMutex  sync.RWMutex
-	lastConnected       time.Time
-	disconnectedAt      *time.Time
-	disconnectMutex     sync.RWMutex
+	conn               *websocket.Conn
+	connMutex          sync.RWMutex
+	onPriceUpdate      func(update *types.EncryptedPriceUpdate)
+	onCommentUpdate    func(comment *types.Comment)
+	reconnectDelay     time.Duration
+	stopChan           chan struct{}
+	stopOnce           sync.Once
+	running            bool
+	runningMutex       sync.RWMutex
+	auth               *RTDSAuth
+	subscribedPrices   map[string]bool
+	subscribedComments map[string]bool
+	subscriptionsMutex sync.RWMutex
+	activitySubs       map[int]*activitySubscription
+	nextActivitySubID  int
+	activityMutex      sync.RWMutex
+	lastConnected      time.Time
+	disconnectedAt     *time.Time
+	disconnectMutex    sync.RWMutex
+	proxyURL           *url.URL
+	proxyErr           error // 显式配置的代理地址无效，Start 直接返回该错误
+}
+
+// ClientOption RTDS 客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions RTDS 客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+}
+
+// WithProxyURL 设置客户端使用的代理地址
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
 }
 
 // NewClient 创建新的 RTDS 客户端
-func NewClient(reconnectDelay time.Duration) Client {
+// 代理地址无效时 Start 返回该错误
+func NewClient(reconnectDelay time.Duration, options ...ClientOption) Client {
+	proxyURL, proxyErr := internal.ApplyOptions(&clientOptions{}, options).Proxy()
 	return &rtdsClient{
 		reconnectDelay:     reconnectDelay,
 		stopChan:           make(chan struct{}),
 		subscribedPrices:   make(map[string]bool),
 		subscribedComments: make(map[string]bool),
+		activitySubs:       make(map[int]*activitySubscription),
+		proxyURL:           proxyURL,
+		proxyErr:           proxyErr,
 	}
 }
 
@@ -86,6 +112,9 @@ func (r *rtdsClient) SetAuth(auth *RTDSAuth) {
 
 // Start 启动 RTDS WebSocket 连接
 func (r *rtdsClient) Start() error {
+	if r.proxyErr != nil {
+		return r.proxyErr
+	}
 	r.runningMutex.Lock()
 	if r.running {
 		r.runningMutex.Unlock()
@@ -201,44 +230,9 @@ func (r *rtdsClient) run() {
 
 // connectAndListen 连接并监听消息
 func (r *rtdsClient) connectAndListen() error {
-	netDialer := &net.Dialer{
-		Timeout:   internal.WebSocketDialTimeout,
-		DualStack: false,
-		KeepAlive: internal.WebSocketKeepAlive,
-	}
-
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-	}
-
-	var proxyURL *url.URL
-	if proxyEnv := os.Getenv("HTTPS_PROXY"); proxyEnv != "" {
-		if proxyEnv == "" {
-			proxyEnv = os.Getenv("HTTP_PROXY")
-		}
-		if proxyEnv != "" {
-			proxyURL, _ = url.Parse(proxyEnv)
-		}
-	}
+	dialer := internal.WebSocketDialer(r.proxyURL)
 
-	dialer := websocket.Dialer{
-		HandshakeTimeout: internal.WebSocketHandshakeTimeout,
-		TLSClientConfig:  tlsConfig,
-		Proxy: func(req *http.Request) (*url.URL, error) {
-			if proxyURL != nil {
-				return proxyURL, nil
-			}
-			return http.ProxyFromEnvironment(req)
-		},
-		NetDial: func(network, addr string) (net.Conn, error) {
-			if network == "tcp" {
-				network = "tcp4"
-			}
-			return netDialer.Dial(network, addr)
-		},
-	}
-
-	conn, _, err := dialer.Dial(rtdsURL, nil)
+	conn, _, err := dialer.Dial(rtdsURL, http.Header{"User-Agent": {internal.UserAgent()}})
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
@@ -280,6 +274,11 @@ func (r *rtdsClient) connectAndListen() error {
 			return err
 		}
 	}
+	if activityIDs, ok := r.activityConditionIDs(); ok {
+		if err := r.sendSubscription("activity", activityIDs); err != nil {
+			return err
+		}
+	}
 
 	// Start heartbeat
 	heartbeatStop := make(chan struct{})
@@ -344,6 +343,8 @@ func (r *rtdsClient) connectAndListen() error {
 						r.handlePriceUpdate(msg)
 					case "comments":
 						r.handleCommentUpdate(msg)
+					case "activity":
+						r.handleActivityUpdate(msg)
 					}
 				}
 			}
@@ -385,9 +386,9 @@ func (r *rtdsClient) sendSubscription(streamType string, ids []string) error {
 	}
 
 	subMsg := map[string]interface{}{
-		"type":  "subscribe",
+		"type":   "subscribe",
 		"stream": streamType,
-		"ids":   ids,
+		"ids":    ids,
 	}
 
 	return conn.WriteJSON(subMsg)
@@ -404,9 +405,9 @@ func (r *rtdsClient) sendUnsubscription(streamType string, ids []string) error {
 	}
 
 	unsubMsg := map[string]interface{}{
-		"type":  "unsubscribe",
+		"type":   "unsubscribe",
 		"stream": streamType,
-		"ids":   ids,
+		"ids":    ids,
 	}
 
 	return conn.WriteJSON(unsubMsg)
@@ -449,3 +450,249 @@ func (r *rtdsClient) handleCommentUpdate(msg map[string]interface{}) {
 
 	r.onCommentUpdate(&comment)
 }
+
+// activitySubscription 单个 SubscribeActivity 调用对应的订阅
+type activitySubscription struct {
+	conditionIDs map[string]bool // 为空表示接收所有市场的活动
+	ch           chan types.MarketTradesEvent
+}
+
+// SubscribeActivity 订阅市场的公开成交/活动流
+// conditionIDs 为空时接收所有市场的活动
+// 返回的 channel 在 ctx 取消后关闭；连接断开重连后会自动重新订阅
+// 消费过慢导致缓冲区写满时，新事件会被丢弃，不会阻塞其他订阅
+// 活动流为公开数据，不需要认证
+func (r *rtdsClient) SubscribeActivity(ctx context.Context, conditionIDs []string) (<-chan types.MarketTradesEvent, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sub := &activitySubscription{
+		conditionIDs: make(map[string]bool, len(conditionIDs)),
+		ch:           make(chan types.MarketTradesEvent, activityBufferSize),
+	}
+	for _, conditionID := range conditionIDs {
+		if conditionID != "" {
+			sub.conditionIDs[conditionID] = true
+		}
+	}
+
+	r.activityMutex.Lock()
+	id := r.nextActivitySubID
+	r.nextActivitySubID++
+	r.activitySubs[id] = sub
+	r.activityMutex.Unlock()
+
+	// 未连接时订阅会在连接建立后自动发送
+	if r.isConnected() {
+		if err := r.sendSubscription("activity", conditionIDs); err != nil {
+			r.removeActivitySubscription(id)
+			return nil, fmt.Errorf("failed to subscribe activity: %w", err)
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		r.removeActivitySubscription(id)
+	}()
+
+	return sub.ch, nil
+}
+
+// activityBufferSize 每个活动订阅的 channel 缓冲区大小
+const activityBufferSize = 100
+
+// removeActivitySubscription 移除活动订阅并关闭 channel
+// 不再被任何订阅引用的 conditionID 会向服务端取消订阅
+func (r *rtdsClient) removeActivitySubscription(id int) {
+	r.activityMutex.Lock()
+	sub, ok := r.activitySubs[id]
+	if !ok {
+		r.activityMutex.Unlock()
+		return
+	}
+	delete(r.activitySubs, id)
+	close(sub.ch)
+
+	stale := make([]string, 0, len(sub.conditionIDs))
+	for conditionID := range sub.conditionIDs {
+		stillUsed := false
+		for _, other := range r.activitySubs {
+			if other.conditionIDs[conditionID] {
+				stillUsed = true
+				break
+			}
+		}
+		if !stillUsed {
+			stale = append(stale, conditionID)
+		}
+	}
+	r.activityMutex.Unlock()
+
+	if len(stale) > 0 && r.isConnected() {
+		if err := r.sendUnsubscription("activity", stale); err != nil {
+			internal.LogDebug("failed to unsubscribe activity: %v", err)
+		}
+	}
+}
+
+// activityConditionIDs 返回当前所有活动订阅的 conditionID 并集
+// 第二个返回值表示是否存在活动订阅
+func (r *rtdsClient) activityConditionIDs() ([]string, bool) {
+	r.activityMutex.RLock()
+	defer r.activityMutex.RUnlock()
+
+	if len(r.activitySubs) == 0 {
+		return nil, false
+	}
+
+	seen := make(map[string]bool)
+	ids := make([]string, 0)
+	for _, sub := range r.activitySubs {
+		// 任一订阅接收全部市场时，按全部市场订阅
+		if len(sub.conditionIDs) == 0 {
+			return []string{}, true
+		}
+		for conditionID := range sub.conditionIDs {
+			if !seen[conditionID] {
+				seen[conditionID] = true
+				ids = append(ids, conditionID)
+			}
+		}
+	}
+	return ids, true
+}
+
+// isConnected 是否已建立连接
+func (r *rtdsClient) isConnected() bool {
+	r.connMutex.RLock()
+	defer r.connMutex.RUnlock()
+	return r.conn != nil
+}
+
+// activityPayload 活动流消息的原始结构
+// 服务端可能使用 camelCase 或 snake_case 字段名，两种都兼容
+type activityPayload struct {
+	ID              string          `json:"id"`
+	EventID         string          `json:"event_id"`
+	TransactionHash string          `json:"transactionHash"`
+	Type            string          `json:"type"`
+	ConditionID     string          `json:"conditionId"`
+	ConditionIDAlt  string          `json:"condition_id"`
+	MarketID        string          `json:"market_id"`
+	Asset           string          `json:"asset"`
+	TokenID         string          `json:"token_id"`
+	Price           *float64        `json:"price"`
+	Size            *float64        `json:"size"`
+	Side            *string         `json:"side"`
+	Timestamp       json.RawMessage `json:"timestamp"`
+	ProxyWallet     string          `json:"proxyWallet"`
+	UserAddress     string          `json:"user_address"`
+}
+
+// handleActivityUpdate 处理活动流更新，分发给匹配的订阅
+func (r *rtdsClient) handleActivityUpdate(msg map[string]interface{}) {
+	// 事件内容可能包装在 payload 字段中
+	data := msg
+	if payload, ok := msg["payload"].(map[string]interface{}); ok {
+		data = payload
+	}
+
+	event, conditionID, ok := decodeActivityEvent(data)
+	if !ok {
+		return
+	}
+
+	r.activityMutex.RLock()
+	defer r.activityMutex.RUnlock()
+
+	for _, sub := range r.activitySubs {
+		if len(sub.conditionIDs) > 0 && !sub.conditionIDs[conditionID] {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			internal.LogDebug("activity subscription buffer full, dropping event for market %s", conditionID)
+		}
+	}
+}
+
+// decodeActivityEvent 将活动流消息解析为 MarketTradesEvent
+// 返回事件、所属的 conditionID 以及是否解析成功
+func decodeActivityEvent(data map[string]interface{}) (types.MarketTradesEvent, string, bool) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return types.MarketTradesEvent{}, "", false
+	}
+
+	var payload activityPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return types.MarketTradesEvent{}, "", false
+	}
+
+	conditionID := firstNonEmpty(payload.ConditionID, payload.ConditionIDAlt, payload.MarketID)
+	if conditionID == "" {
+		return types.MarketTradesEvent{}, "", false
+	}
+
+	eventType := payload.Type
+	if eventType == "" {
+		eventType = "TRADE"
+	}
+
+	event := types.MarketTradesEvent{
+		EventID:   firstNonEmpty(payload.EventID, payload.ID, payload.TransactionHash),
+		Type:      eventType,
+		MarketID:  conditionID,
+		TokenID:   firstNonEmpty(payload.Asset, payload.TokenID),
+		Price:     payload.Price,
+		Size:      payload.Size,
+		Side:      payload.Side,
+		Timestamp: parseActivityTimestamp(payload.Timestamp),
+	}
+	if user := firstNonEmpty(payload.ProxyWallet, payload.UserAddress); user != "" {
+		address := types.EthAddress(user)
+		event.UserAddress = &address
+	}
+
+	return event, conditionID, true
+}
+
+// parseActivityTimestamp 解析时间戳，支持 Unix 秒/毫秒（数字或字符串）和 RFC3339 格式
+// 无法解析时使用当前时间
+func parseActivityTimestamp(raw json.RawMessage) time.Time {
+	if len(raw) == 0 || string(raw) == "null" {
+		return time.Now()
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err != nil {
+		str = string(raw)
+	}
+
+	if unix, err := strconv.ParseInt(str, 10, 64); err == nil {
+		// 13 位以上视为毫秒
+		if unix > 1e12 {
+			return time.UnixMilli(unix)
+		}
+		return time.Unix(unix, 0)
+	}
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -1,6 +1,7 @@
 package rtds
 
 import (
+	"context"
 	"sync"
 	"testing"
 	"time"
@@ -407,3 +408,106 @@ func TestUnsubscribeComments(t *testing.T) {
 		time.Sleep(1 * time.Second)
 	})
 }
+
+func TestSubscribeActivity(t *testing.T) {
+	// 基本功能测试：未连接时注册订阅，模拟服务端推送活动消息
+	t.Run("Basic", func(t *testing.T) {
+		client := NewClient(test.DefaultReconnectDelay).(*rtdsClient)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := client.SubscribeActivity(ctx, []string{"0xabc"})
+		if err != nil {
+			t.Fatalf("SubscribeActivity failed: %v", err)
+		}
+
+		client.handleActivityUpdate(map[string]interface{}{
+			"stream": "activity",
+			"payload": map[string]interface{}{
+				"conditionId":     "0xabc",
+				"asset":           "123",
+				"price":           0.55,
+				"size":            10.0,
+				"side":            "BUY",
+				"timestamp":       1700000000,
+				"proxyWallet":     "0x0000000000000000000000000000000000000001",
+				"transactionHash": "0xhash",
+			},
+		})
+
+		select {
+		case event := <-events:
+			if event.MarketID != "0xabc" || event.TokenID != "123" || event.EventID != "0xhash" {
+				t.Errorf("Unexpected event: %+v", event)
+			}
+			if event.Price == nil || *event.Price != 0.55 {
+				t.Errorf("Expected price 0.55, got %v", event.Price)
+			}
+			if event.Timestamp.Unix() != 1700000000 {
+				t.Errorf("Expected timestamp 1700000000, got %d", event.Timestamp.Unix())
+			}
+			if event.UserAddress == nil {
+				t.Error("Expected user address to be set")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Did not receive activity event")
+		}
+	})
+
+	// 测试按 conditionID 过滤
+	t.Run("FilterByConditionID", func(t *testing.T) {
+		client := NewClient(test.DefaultReconnectDelay).(*rtdsClient)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := client.SubscribeActivity(ctx, []string{"0xabc"})
+		if err != nil {
+			t.Fatalf("SubscribeActivity failed: %v", err)
+		}
+
+		client.handleActivityUpdate(map[string]interface{}{
+			"stream":      "activity",
+			"conditionId": "0xother",
+		})
+
+		select {
+		case event := <-events:
+			t.Errorf("Expected no event for other market, got %+v", event)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	// 测试 ctx 取消后 channel 关闭
+	t.Run("CancelClosesChannel", func(t *testing.T) {
+		client := NewClient(test.DefaultReconnectDelay).(*rtdsClient)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		events, err := client.SubscribeActivity(ctx, nil)
+		if err != nil {
+			t.Fatalf("SubscribeActivity failed: %v", err)
+		}
+		cancel()
+
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Error("Expected channel to be closed")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Channel was not closed after context cancellation")
+		}
+
+		if _, ok := client.activityConditionIDs(); ok {
+			t.Error("Expected no activity subscriptions after cancellation")
+		}
+	})
+}
+
+func TestInvalidProxyURL(t *testing.T) {
+	// 无效代理地址不能回退到环境变量代理或直连
+	client := NewClient(test.DefaultReconnectDelay, WithProxyURL("ftp://127.0.0.1:1080"))
+	if err := client.Start(); err == nil {
+		client.Stop()
+		t.Fatal("Expected Start to fail for invalid proxy URL")
+	}
+}
@@ -16,24 +16,7 @@ const (
 
 // SignClobAuthMessage 对CLOB认证消息进行签名
 func SignClobAuthMessage(signer *Signer, timestamp int64, nonce int) (string, error) {
-	chainID := signer.ChainID()
-
-	// Create domain separator
-	domainHash := getClobAuthDomainHash(chainID)
-
-	// Create message hash
-	messageHash := getClobAuthMessageHash(
-		signer.Address(),
-		timestamp,
-		nonce,
-	)
-
-	// Combine domain and message
-	combined := append([]byte("\x19\x01"), domainHash.Bytes()...)
-	combined = append(combined, messageHash.Bytes()...)
-
-	// Hash the combined data
-	hash := crypto.Keccak256Hash(combined)
+	hash := ClobAuthDigest(signer.Address(), signer.ChainID(), timestamp, nonce)
 
 	// Sign the hash
 	// Note: For EIP-712, we need the full 65-byte signature (including recovery ID)
@@ -47,6 +30,22 @@ func SignClobAuthMessage(signer *Signer, timestamp int64, nonce int) (string, er
 	return "0x" + common.Bytes2Hex(sigWithRecovery), nil
 }
 
+// ClobAuthDigest 返回 CLOB 认证消息（ClobAuth）的 EIP-712 摘要，即 SignClobAuthMessage 实际签名的 32 字节哈希
+func ClobAuthDigest(address types.EthAddress, chainID types.ChainID, timestamp int64, nonce int) common.Hash {
+	// Create domain separator
+	domainHash := getClobAuthDomainHash(chainID)
+
+	// Create message hash
+	messageHash := getClobAuthMessageHash(address, timestamp, nonce)
+
+	// Combine domain and message
+	combined := append([]byte("\x19\x01"), domainHash.Bytes()...)
+	combined = append(combined, messageHash.Bytes()...)
+
+	// Hash the combined data
+	return crypto.Keccak256Hash(combined)
+}
+
 // getClobAuthDomainHash creates the domain separator hash
 func getClobAuthDomainHash(chainID types.ChainID) common.Hash {
 	// EIP-712 domain separator
@@ -9,6 +9,23 @@ import (
 	"regexp"
 )
 
+var (
+	// pythonColonPattern 冒号后缺少空格："key":"value" -> "key": "value"
+	pythonColonPattern = regexp.MustCompile(`":(\S)`)
+	// pythonCommaPattern 逗号后紧跟引号："a","b" -> "a", "b"
+	pythonCommaPattern = regexp.MustCompile(`,(")`)
+	// pythonNestedCommaPattern 逗号后紧跟嵌套结构：},{ -> }, {
+	pythonNestedCommaPattern = regexp.MustCompile(`,(\{|\[)`)
+)
+
+// FormatPythonJSON 将 Go 的紧凑 JSON 转换为 Python json.dumps 的默认格式（冒号和逗号后加空格）
+// 对已格式化的字符串重复调用结果不变，HMAC 签名和请求体都应使用该格式
+func FormatPythonJSON(compact string) string {
+	formatted := pythonColonPattern.ReplaceAllString(compact, `": $1`)
+	formatted = pythonCommaPattern.ReplaceAllString(formatted, `, $1`)
+	return pythonNestedCommaPattern.ReplaceAllString(formatted, `, $1`)
+}
+
 // BuildHMACSignature 使用密钥对载荷进行签名创建HMAC签名
 // 与Python实现保持一致：build_hmac_signature
 //
@@ -34,6 +51,24 @@ func BuildHMACSignature(secret, timestamp, method, requestPath string, body inte
 		return "", fmt.Errorf("failed to decode secret: %w", err)
 	}
 
+	message, err := HMACMessage(timestamp, method, requestPath, body)
+	if err != nil {
+		return "", err
+	}
+
+	// Create HMAC (Python: hmac.new(base64_secret, bytes(message, "utf-8"), hashlib.sha256))
+	h := hmac.New(sha256.New, secretBytes)
+	h.Write([]byte(message))
+	digest := h.Sum(nil)
+
+	// Base64 URL-safe encode (Python: base64.urlsafe_b64encode(h.digest()).decode("utf-8"))
+	signature := base64.URLEncoding.EncodeToString(digest)
+	return signature, nil
+}
+
+// HMACMessage 返回 BuildHMACSignature 实际签名的消息：timestamp + method + requestPath + body（Python json.dumps 格式）
+// 参数含义与 BuildHMACSignature 相同，可用于与 Python SDK 逐字节对比签名内容
+func HMACMessage(timestamp, method, requestPath string, body interface{}) (string, error) {
 	// Build message: timestamp + method + requestPath
 	// Python: message = str(timestamp) + str(method) + str(request_path)
 	message := timestamp + method + requestPath
@@ -81,27 +116,8 @@ func BuildHMACSignature(secret, timestamp, method, requestPath string, body inte
 
 		// Go's json.Marshal produces compact JSON: {"key":"value","key2":"value2"}
 		// Python's str(dict).replace("'", '"') produces: {"key": "value", "key2": "value2"} (with spaces)
-		// We need to add spaces to match Python's format exactly
-		// Add space after colon: "key":"value" -> "key": "value"
-		// Use regex to replace ": with ":  (one space) only if not already followed by space
-		bodyJSONStr = regexp.MustCompile(`":(\S)`).ReplaceAllString(bodyJSONStr, `": $1`)
-
-		// Add space after comma: "a","b" -> "a", "b"
-		// Match comma followed by quote (no space) and add space
-		bodyJSONStr = regexp.MustCompile(`,(")`).ReplaceAllString(bodyJSONStr, `, $1`)
-
-		// Also handle comma followed by { or [ (for nested structures)
-		bodyJSONStr = regexp.MustCompile(`,(\{|\[)`).ReplaceAllString(bodyJSONStr, `, $1`)
-
-		message += bodyJSONStr
+		// FormatPythonJSON is idempotent, so bodies already formatted by the caller are signed unchanged
+		message += FormatPythonJSON(bodyJSONStr)
 	}
-
-	// Create HMAC (Python: hmac.new(base64_secret, bytes(message, "utf-8"), hashlib.sha256))
-	h := hmac.New(sha256.New, secretBytes)
-	h.Write([]byte(message))
-	digest := h.Sum(nil)
-
-	// Base64 URL-safe encode (Python: base64.urlsafe_b64encode(h.digest()).decode("utf-8"))
-	signature := base64.URLEncoding.EncodeToString(digest)
-	return signature, nil
+	return message, nil
 }
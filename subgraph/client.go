@@ -28,15 +28,43 @@ type Client interface {
 type subgraphClient struct {
 	url        string
 	httpClient *http.Client
+	proxyErr   error // 显式配置的代理地址无效，所有查询直接返回该错误
+}
+
+// ClientOption Subgraph 客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions Subgraph 客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+}
+
+// WithProxyURL 设置客户端使用的代理地址
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
 }
 
 // NewClient 创建新的 Subgraph 客户端
-func NewClient() Client {
+// 代理地址无效时所有查询返回该错误
+func NewClient(options ...ClientOption) Client {
+	httpClient := &http.Client{
+		Timeout: internal.HTTPClientTimeout,
+	}
+
+	proxyURL, proxyErr := internal.ApplyOptions(&clientOptions{}, options).Proxy()
+	if proxyURL != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(proxyURL)
+		httpClient.Transport = transport
+	}
+
 	return &subgraphClient{
-		url: subgraphURL,
-		httpClient: &http.Client{
-			Timeout: internal.HTTPClientTimeout,
-		},
+		url:        subgraphURL,
+		httpClient: httpClient,
+		proxyErr:   proxyErr,
 	}
 }
 
@@ -48,6 +76,9 @@ type GraphQLRequest struct {
 
 // Query 执行 GraphQL 查询
 func (s *subgraphClient) Query(query string, variables map[string]interface{}) (*types.GraphQLResponse, error) {
+	if s.proxyErr != nil {
+		return nil, s.proxyErr
+	}
 	req := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -64,6 +95,7 @@ func (s *subgraphClient) Query(query string, variables map[string]interface{}) (
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", internal.UserAgent())
 
 	resp, err := s.httpClient.Do(httpReq)
 	if err != nil {
@@ -28,8 +28,8 @@ func TestQuery(t *testing.T) {
 		response, err := client.Query(query, variables)
 		if err != nil {
 			// 检查是否是端点已移除的错误
-			if strings.Contains(err.Error(), "endpoint has been removed") || 
-			   strings.Contains(err.Error(), "removed") {
+			if strings.Contains(err.Error(), "endpoint has been removed") ||
+				strings.Contains(err.Error(), "removed") {
 				t.Skip("Skipping test: Subgraph endpoint has been removed by The Graph")
 				return
 			}
@@ -49,8 +49,8 @@ func TestQuery(t *testing.T) {
 		_, err := client.Query(invalidQuery, variables)
 		if err != nil {
 			// 检查是否是端点已移除的错误
-			if strings.Contains(err.Error(), "endpoint has been removed") || 
-			   strings.Contains(err.Error(), "removed") {
+			if strings.Contains(err.Error(), "endpoint has been removed") ||
+				strings.Contains(err.Error(), "removed") {
 				t.Skip("Skipping test: Subgraph endpoint has been removed by The Graph")
 				return
 			}
@@ -194,7 +194,7 @@ func TestGetUserPositions(t *testing.T) {
 
 	// 使用测试用户地址
 	userAddr := test.GetTestUserAddress(config)
-	
+
 	// 注意：Subgraph API端点已被移除，测试会失败
 
 	// 基本功能测试
@@ -202,8 +202,8 @@ func TestGetUserPositions(t *testing.T) {
 		positions, err := client.GetUserPositions(userAddr)
 		if err != nil {
 			// 检查是否是端点已移除的错误
-			if strings.Contains(err.Error(), "endpoint has been removed") || 
-			   strings.Contains(err.Error(), "removed") {
+			if strings.Contains(err.Error(), "endpoint has been removed") ||
+				strings.Contains(err.Error(), "removed") {
 				t.Skip("Skipping test: Subgraph endpoint has been removed by The Graph")
 				return
 			}
@@ -277,8 +277,8 @@ func TestGetUserPNL(t *testing.T) {
 		pnl, err := client.GetUserPNL(userAddr)
 		if err != nil {
 			// 检查是否是端点已移除的错误
-			if strings.Contains(err.Error(), "endpoint has been removed") || 
-			   strings.Contains(err.Error(), "removed") {
+			if strings.Contains(err.Error(), "endpoint has been removed") ||
+				strings.Contains(err.Error(), "removed") {
 				t.Skip("Skipping test: Subgraph endpoint has been removed by The Graph")
 				return
 			}
@@ -290,3 +290,11 @@ func TestGetUserPNL(t *testing.T) {
 		t.Logf("GetUserPNL returned: %+v", pnl)
 	})
 }
+
+func TestInvalidProxyURL(t *testing.T) {
+	// 无效代理地址不能回退到环境变量代理或直连
+	client := NewClient(WithProxyURL("socks5://"))
+	if _, err := client.Query(`query { markets(first: 1) { id } }`, nil); err == nil || !strings.Contains(err.Error(), "invalid client options") {
+		t.Errorf("Expected invalid proxy error, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	_ "embed"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkhttp "github.com/polymas/go-polymarket-sdk/http"
+)
+
+// 内置 fixture 使用的代币ID（同一市场的 Yes / No）
+const (
+	FixtureYesTokenID    = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	FixtureNoTokenID     = "48331043336612883890938759509493159234755048973500640148014422747788308965732"
+	FixtureConditionID   = "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1"
+	FixtureGammaMarketID = "253591"
+)
+
+//go:embed testdata/book.json
+var orderBookFixture string
+
+//go:embed testdata/midpoints.json
+var midpointsFixture string
+
+//go:embed testdata/markets.json
+var marketsFixture string
+
+// Fixture 表示一个固定的 HTTP 响应
+// Path 不含查询参数时匹配该路径的所有请求；包含查询参数（如 "/book?token_id=1"）时要求查询参数完全一致（按键排序编码）
+type Fixture struct {
+	Method     string // 请求方法，默认 GET
+	Path       string // 请求路径，可包含查询参数
+	StatusCode int    // 响应状态码，默认 200
+	Body       string // 响应 body
+}
+
+// OrderBookFixture 返回 FixtureYesTokenID 的订单簿响应（GET /book）
+func OrderBookFixture() Fixture {
+	return Fixture{Path: "/book", Body: orderBookFixture}
+}
+
+// MidpointsFixture 返回 FixtureYesTokenID 和 FixtureNoTokenID 的批量中间价响应（POST /midpoints）
+func MidpointsFixture() Fixture {
+	return Fixture{Method: http.MethodPost, Path: "/midpoints", Body: midpointsFixture}
+}
+
+// MarketsFixture 返回包含一个已部署市场的 Gamma 市场列表响应（GET /markets）
+func MarketsFixture() Fixture {
+	return Fixture{Path: "/markets", Body: marketsFixture}
+}
+
+// Interaction 转换为 http.Replayer 使用的回放记录，响应的 Content-Type 为 application/json
+func (f Fixture) Interaction() sdkhttp.Interaction {
+	method := f.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	statusCode := f.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return sdkhttp.Interaction{
+		Method:     strings.ToUpper(method),
+		URL:        f.Path,
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       f.Body,
+	}
+}
+
+// NewFixtureTransport 创建返回 fixtures 的 http.Replayer，通过各客户端的 WithTransport 选项接入，
+// 不需要网络和认证信息，用于测试响应解析和请求体格式。每个请求使用第一个匹配的 fixture，
+// 回放器的 Requests 记录了收到的所有请求；测试结束时仍有请求没有匹配的 fixture 则测试失败
+func NewFixtureTransport(t testing.TB, fixtures ...Fixture) *sdkhttp.Replayer {
+	interactions := make([]sdkhttp.Interaction, len(fixtures))
+	for i, fixture := range fixtures {
+		interactions[i] = fixture.Interaction()
+	}
+	replayer := sdkhttp.NewReplayerFromInteractions(interactions)
+	t.Cleanup(func() {
+		for _, request := range replayer.Unmatched() {
+			t.Errorf("no fixture for %s", request)
+		}
+	})
+	return replayer
+}
+
+// NewFixtureClient 创建使用 fixtures 响应的客户端，newClient 用传入的传输层创建客户端（即各客户端的 WithTransport 选项），例如：
+//
+//	client, transport := test.NewFixtureClient(t, func(rt http.RoundTripper) clob.ReadonlyClient {
+//		return clob.NewReadonlyClient(clob.WithTransport(rt))
+//	}, test.OrderBookFixture(), test.MidpointsFixture())
+//
+// 返回的回放器记录了客户端发出的所有请求，可用于检查请求参数和请求体格式
+func NewFixtureClient[C any](t testing.TB, newClient func(transport sdkhttp.RoundTripper) C, fixtures ...Fixture) (C, *sdkhttp.Replayer) {
+	t.Helper()
+	transport := NewFixtureTransport(t, fixtures...)
+	return newClient(transport), transport
+}
@@ -0,0 +1,80 @@
+package test_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/polymas/go-polymarket-sdk/clob"
+	"github.com/polymas/go-polymarket-sdk/gamma"
+	"github.com/polymas/go-polymarket-sdk/http"
+	"github.com/polymas/go-polymarket-sdk/test"
+)
+
+// newFixtureClobClient 创建使用 fixtures 响应的只读 CLOB 客户端
+func newFixtureClobClient(t *testing.T, fixtures ...test.Fixture) (clob.ReadonlyClient, *http.Replayer) {
+	return test.NewFixtureClient(t, func(transport http.RoundTripper) clob.ReadonlyClient {
+		return clob.NewReadonlyClient(clob.WithTransport(transport))
+	}, fixtures...)
+}
+
+func TestNewFixtureClient(t *testing.T) {
+	client, transport := newFixtureClobClient(t, test.OrderBookFixture(), test.MidpointsFixture())
+
+	t.Run("OrderBook", func(t *testing.T) {
+		book, err := client.GetOrderBook(test.FixtureYesTokenID)
+		if err != nil {
+			t.Fatalf("GetOrderBook failed: %v", err)
+		}
+		if len(book.Bids) != 2 || len(book.Asks) != 2 {
+			t.Fatalf("Unexpected book: %+v", book)
+		}
+		if mid, ok := book.Midpoint(); !ok || mid != 0.51 {
+			t.Errorf("Expected midpoint 0.51 from best bid 0.5 and best ask 0.52, got %v", mid)
+		}
+	})
+
+	t.Run("Midpoints", func(t *testing.T) {
+		midpoints, err := client.GetMidpoints([]string{test.FixtureYesTokenID, test.FixtureNoTokenID})
+		if err != nil {
+			t.Fatalf("GetMidpoints failed: %v", err)
+		}
+		if len(midpoints) != 2 || float64(midpoints[0].Value) != 0.51 || float64(midpoints[1].Value) != 0.49 {
+			t.Errorf("Unexpected midpoints: %+v", midpoints)
+		}
+
+		// 请求体格式
+		requests := transport.Requests()
+		last := requests[len(requests)-1]
+		var body []map[string]string
+		if err := json.Unmarshal([]byte(last.RequestBody), &body); err != nil {
+			t.Fatalf("Invalid request body %q: %v", last.RequestBody, err)
+		}
+		if len(body) != 2 || body[0]["token_id"] != test.FixtureYesTokenID {
+			t.Errorf("Unexpected request body: %s", last.RequestBody)
+		}
+	})
+
+	t.Run("RequestRecorded", func(t *testing.T) {
+		requests := transport.Requests()
+		if len(requests) == 0 || !strings.Contains(requests[0].URL, "token_id="+test.FixtureYesTokenID) {
+			t.Errorf("Expected recorded /book request, got %+v", requests)
+		}
+	})
+}
+
+func TestMarketsFixture(t *testing.T) {
+	transport := test.NewFixtureTransport(t, test.MarketsFixture())
+	client := gamma.NewClient(gamma.WithTransport(transport))
+
+	markets, err := client.GetMarkets(1)
+	if err != nil {
+		t.Fatalf("GetMarkets failed: %v", err)
+	}
+	if len(markets) != 1 || markets[0].MarketID != test.FixtureGammaMarketID {
+		t.Fatalf("Unexpected markets: %+v", markets)
+	}
+	if len(markets[0].TokenIDs) != 2 || markets[0].TokenIDs[0] != test.FixtureYesTokenID || markets[0].TokenIDs[1] != test.FixtureNoTokenID {
+		t.Errorf("Unexpected token IDs: %v", markets[0].TokenIDs)
+	}
+}
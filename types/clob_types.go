@@ -3,6 +3,9 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +46,7 @@ type RequestArgs struct {
 	Method      string       `json:"method"` // GET, POST, DELETE
 	RequestPath string       `json:"request_path"`
 	Body        *RequestBody `json:"body,omitempty"` // nil means no body
+	Timestamp   int64        `json:"-"`              // 签名使用的 Unix 时间戳（秒），为 0 时使用本地时间
 }
 
 // TokenValue 表示带值的代币
@@ -63,10 +67,25 @@ type BookParams struct {
 	Side    string `json:"side"` // BUY or SELL
 }
 
+// Normalize 校验并规范化 Side（大小写不敏感，统一转为 BUY/SELL），Side 为空时保持为空
+func (p BookParams) Normalize() (BookParams, error) {
+	if p.Side == "" {
+		return p, nil
+	}
+	side, err := ParseOrderSide(p.Side)
+	if err != nil {
+		return p, fmt.Errorf("token %s: %w", p.TokenID, err)
+	}
+	p.Side = string(side)
+	return p, nil
+}
+
 // Price 表示代币和方向的价格
+// BookParams.Side 与 ResolvedSide 均为价格实际对应的方向；请求未指定方向时由响应决定
 type Price struct {
 	BookParams
-	Price float64 `json:"price"`
+	Price        float64   `json:"price"`
+	ResolvedSide OrderSide `json:"resolved_side,omitempty"` // 价格对应的方向（BUY/SELL），响应未给出方向时为空
 }
 
 // UnmarshalJSON 实现Price的自定义JSON反序列化，处理price可能是字符串或数字的情况
@@ -82,9 +101,14 @@ func (p *Price) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	// 复制字段
+	// 复制字段，方向统一为大写
 	p.TokenID = temp.TokenID
 	p.Side = temp.Side
+	p.ResolvedSide = ""
+	if side, err := ParseOrderSide(temp.Side); err == nil {
+		p.Side = string(side)
+		p.ResolvedSide = side
+	}
 
 	// 处理price（可能是数字或字符串）
 	switch v := temp.Price.(type) {
@@ -134,6 +158,18 @@ type PriceHistory struct {
 	History []TimeseriesPoint `json:"history"`
 }
 
+// PriceHistoryInterval 价格历史的时间范围（截至当前时间）
+type PriceHistoryInterval string
+
+const (
+	PriceHistoryInterval1h  PriceHistoryInterval = "1h"  // 最近 1 小时
+	PriceHistoryInterval6h  PriceHistoryInterval = "6h"  // 最近 6 小时
+	PriceHistoryInterval1d  PriceHistoryInterval = "1d"  // 最近 1 天
+	PriceHistoryInterval1w  PriceHistoryInterval = "1w"  // 最近 1 周
+	PriceHistoryInterval1m  PriceHistoryInterval = "1m"  // 最近 1 个月
+	PriceHistoryIntervalMax PriceHistoryInterval = "max" // 全部历史
+)
+
 // PaginatedResponse 表示分页API响应
 type PaginatedResponse[T any] struct {
 	Data       []T    `json:"data"`
@@ -149,6 +185,7 @@ const (
 	OrderTypeGTC OrderType = "GTC" // Good Till Cancel
 	OrderTypeIOC OrderType = "IOC" // Immediate Or Cancel
 	OrderTypeFOK OrderType = "FOK" // Fill Or Kill
+	OrderTypeGTD OrderType = "GTD" // Good Till Date
 )
 
 // OrderSide 表示订单方向
@@ -159,6 +196,27 @@ const (
 	OrderSideSELL OrderSide = "SELL"
 )
 
+// ParseOrderSide 解析订单方向，大小写不敏感（"buy"、"Buy" 均解析为 OrderSideBUY）
+func ParseOrderSide(side string) (OrderSide, error) {
+	switch OrderSide(strings.ToUpper(strings.TrimSpace(side))) {
+	case OrderSideBUY:
+		return OrderSideBUY, nil
+	case OrderSideSELL:
+		return OrderSideSELL, nil
+	default:
+		return "", fmt.Errorf("invalid order side %q: must be BUY or SELL", side)
+	}
+}
+
+// 订单状态（OpenOrder.Status 的取值）
+const (
+	OrderStatusLive      = "LIVE"      // 挂单中
+	OrderStatusMatched   = "MATCHED"   // 已撮合，等待链上结算
+	OrderStatusDelayed   = "DELAYED"   // 撮合被延迟（如体育市场的延迟撮合）
+	OrderStatusUnmatched = "UNMATCHED" // 延迟后未撮合，转为挂单
+	OrderStatusCanceled  = "CANCELED"  // 已取消
+)
+
 // OrderArgs 表示创建订单的参数
 type OrderArgs struct {
 	TokenID    string    `json:"token_id"`
@@ -166,8 +224,142 @@ type OrderArgs struct {
 	Size       float64   `json:"size"`
 	Side       OrderSide `json:"side"`
 	FeeRateBps *int      `json:"fee_rate_bps,omitempty"`
+	Expiration int64     `json:"expiration,omitempty"` // GTD 订单的过期时间（Unix 秒），其他订单类型忽略
+	MinSize    *float64  `json:"min_size,omitempty"`   // 市场最小下单数量（如 GammaMarket.OrderMinSize），为 nil 时使用已缓存的 TokenMeta.MinOrderSize 或默认值
+	Nonce      *int64    `json:"nonce,omitempty"`      // 订单 nonce，为 nil 时使用 maker 在交易所合约上的当前 nonce（见 web3.Client.OrderNonce、GaslessClient.CancelAllOnchain），查询失败时签名返回错误
+}
+
+// Validate 按 tickSize 检查订单参数，CreateAndPostOrders 和签名时使用相同的规则：
+//   - 价格在 [tickSize, 1-tickSize] 内（边界值有效）
+//   - 数量为正数
+//   - 方向为 OrderSideBUY 或 OrderSideSELL（区分大小写，其他写法可先用 ParseOrderSide 转换）
+//   - Expiration 不为负数，设置时（GTD 订单）必须晚于当前时间
+//   - Nonce 设置时不为负数
+func (o OrderArgs) Validate(tickSize TickSize) error {
+	tick, err := strconv.ParseFloat(string(tickSize), 64)
+	if err != nil || tick <= 0 || tick >= 0.5 {
+		return fmt.Errorf("invalid tick size: %q", tickSize)
+	}
+	// 允许浮点误差，避免 1-tickSize 等边界价格被误判
+	const epsilon = 1e-9
+	if math.IsNaN(o.Price) || o.Price < tick-epsilon || o.Price > 1-tick+epsilon {
+		return fmt.Errorf("price (%g) must be in range [%g, %g] for tick size %s", o.Price, tick, 1-tick, tickSize)
+	}
+	if math.IsNaN(o.Size) || math.IsInf(o.Size, 0) || o.Size <= 0 {
+		return fmt.Errorf("size (%g) must be positive", o.Size)
+	}
+	if o.Side != OrderSideBUY && o.Side != OrderSideSELL {
+		return fmt.Errorf("invalid order side %q: must be BUY or SELL", o.Side)
+	}
+	if o.Nonce != nil && *o.Nonce < 0 {
+		return fmt.Errorf("nonce (%d) must not be negative", *o.Nonce)
+	}
+	if o.Expiration < 0 {
+		return fmt.Errorf("expiration (%d) must not be negative", o.Expiration)
+	}
+	if o.Expiration > 0 && o.Expiration <= time.Now().Unix() {
+		return fmt.Errorf("expiration (%d) is in the past", o.Expiration)
+	}
+	return nil
+}
+
+// OrderArgsRaw 直接指定签名数量的订单参数，用于在外部自行计算数量的场景（见 PostRawOrder）
+// MakerAmount 和 TakerAmount 为链上 6 位小数整数，原样签名，不经过价格/数量换算和舍入：
+// BUY 订单 maker 为支付的 USDC、taker 为买入的份额；SELL 订单 maker 为卖出的份额、taker 为收到的 USDC
+type OrderArgsRaw struct {
+	TokenID     string    `json:"token_id"`
+	Side        OrderSide `json:"side"`
+	MakerAmount *big.Int  `json:"maker_amount"`
+	TakerAmount *big.Int  `json:"taker_amount"`
+	FeeRateBps  *int      `json:"fee_rate_bps,omitempty"`
+	Expiration  int64     `json:"expiration,omitempty"` // GTD 订单的过期时间（Unix 秒），其他订单类型忽略
+	Nonce       *int64    `json:"nonce,omitempty"`      // 订单 nonce，为 nil 时使用 maker 当前的交易所 nonce，查询失败时签名返回错误
 }
 
+// Shares 返回订单的份额数量（BUY 为 TakerAmount，SELL 为 MakerAmount），单位为份
+func (o OrderArgsRaw) Shares() float64 {
+	amount := o.MakerAmount
+	if o.Side == OrderSideBUY {
+		amount = o.TakerAmount
+	}
+	if amount == nil {
+		return 0
+	}
+	shares, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(1e6)).Float64()
+	return shares
+}
+
+// ImpliedPrice 返回 maker/taker 数量隐含的价格（USDC 数量 / 份额数量），数量缺失或份额为 0 时返回 0
+func (o OrderArgsRaw) ImpliedPrice() float64 {
+	if o.MakerAmount == nil || o.TakerAmount == nil {
+		return 0
+	}
+	usdc, shares := o.MakerAmount, o.TakerAmount
+	if o.Side != OrderSideBUY {
+		usdc, shares = o.TakerAmount, o.MakerAmount
+	}
+	if shares.Sign() == 0 {
+		return 0
+	}
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(usdc), new(big.Float).SetInt(shares)).Float64()
+	return price
+}
+
+// Validate 按 tickSize 检查订单参数：
+//   - MakerAmount 和 TakerAmount 为正数
+//   - 方向为 OrderSideBUY 或 OrderSideSELL
+//   - 隐含价格在 [tickSize, 1-tickSize] 内（与 OrderArgs.Validate 的价格范围相同）
+//   - Expiration 和 Nonce 的规则与 OrderArgs.Validate 相同
+func (o OrderArgsRaw) Validate(tickSize TickSize) error {
+	tick, err := strconv.ParseFloat(string(tickSize), 64)
+	if err != nil || tick <= 0 || tick >= 0.5 {
+		return fmt.Errorf("invalid tick size: %q", tickSize)
+	}
+	if o.MakerAmount == nil || o.MakerAmount.Sign() <= 0 {
+		return fmt.Errorf("maker amount (%v) must be positive", o.MakerAmount)
+	}
+	if o.TakerAmount == nil || o.TakerAmount.Sign() <= 0 {
+		return fmt.Errorf("taker amount (%v) must be positive", o.TakerAmount)
+	}
+	if o.Side != OrderSideBUY && o.Side != OrderSideSELL {
+		return fmt.Errorf("invalid order side %q: must be BUY or SELL", o.Side)
+	}
+	// 允许浮点误差，避免 1-tickSize 等边界价格被误判
+	const epsilon = 1e-9
+	if price := o.ImpliedPrice(); price < tick-epsilon || price > 1-tick+epsilon {
+		return fmt.Errorf("implied price (%g) must be in range [%g, %g] for tick size %s", price, tick, 1-tick, tickSize)
+	}
+	if o.Nonce != nil && *o.Nonce < 0 {
+		return fmt.Errorf("nonce (%d) must not be negative", *o.Nonce)
+	}
+	if o.Expiration < 0 {
+		return fmt.Errorf("expiration (%d) must not be negative", o.Expiration)
+	}
+	if o.Expiration > 0 && o.Expiration <= time.Now().Unix() {
+		return fmt.Errorf("expiration (%d) is in the past", o.Expiration)
+	}
+	return nil
+}
+
+// RateLimits CLOB API 的限流配置，各字段为每个 Window 内允许的请求数
+type RateLimits struct {
+	Window      time.Duration
+	General     int // 所有接口合计
+	MarketData  int // 单个行情接口（/book、/price、/midpoint 等）
+	PostOrder   int // POST /order
+	CancelOrder int // DELETE /order
+}
+
+// MinOrderSizePolicy 订单数量低于市场最小下单数量时的处理方式
+type MinOrderSizePolicy string
+
+const (
+	// MinOrderSizeClamp 将数量提高到最小下单数量后提交（默认）
+	MinOrderSizeClamp MinOrderSizePolicy = "clamp"
+	// MinOrderSizeReject 不提交该订单，在对应结果中返回 ErrOrderBelowMinSize
+	MinOrderSizeReject MinOrderSizePolicy = "reject"
+)
+
 // MarketOrderArgs 表示创建市价单的参数
 type MarketOrderArgs struct {
 	OrderArgs
@@ -192,6 +384,7 @@ func (fs *FloatString) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
+	s = strings.TrimSpace(s)
 
 	// Handle empty string or "0"
 	if s == "" || s == "0" {
@@ -241,16 +434,21 @@ func (nt *NullableTime) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	*nt = ParseNullableTime(s)
+	return nil
+}
+
+// ParseNullableTime 按 NullableTime 的规则解析时间字符串：RFC3339、Unix 时间戳（秒）、日期（2006-01-02）
+// 或不带时区的日期时间，"0"、空字符串或无法解析时 Time 为 nil
+func ParseNullableTime(s string) NullableTime {
 	// Handle "0" or empty string - set to nil
 	if s == "" || s == "0" {
-		nt.Time = nil
-		return nil
+		return NullableTime{}
 	}
 
 	// Try parsing as RFC3339 first (full datetime)
 	if t, err := time.Parse(time.RFC3339, s); err == nil {
-		nt.Time = &t
-		return nil
+		return NullableTime{&t}
 	}
 
 	// Try parsing as Unix timestamp (string format)
@@ -259,28 +457,24 @@ func (nt *NullableTime) UnmarshalJSON(data []byte) error {
 		var ts int64
 		if _, err := fmt.Sscanf(s, "%d", &ts); err == nil {
 			t := time.Unix(ts, 0)
-			nt.Time = &t
-			return nil
+			return NullableTime{&t}
 		}
 	}
 
 	// Try parsing as date-only format (2006-01-02)
 	if t, err := time.Parse("2006-01-02", s); err == nil {
-		nt.Time = &t
-		return nil
+		return NullableTime{&t}
 	}
 
 	// Try parsing as date with time but no timezone (2006-01-02T15:04:05)
 	if strings.Contains(s, "T") && !strings.Contains(s, "Z") && !strings.Contains(s, "+") && !strings.Contains(s, "-") {
 		if t, err := time.Parse("2006-01-02T15:04:05", s); err == nil {
-			nt.Time = &t
-			return nil
+			return NullableTime{&t}
 		}
 	}
 
 	// If all parsing fails, set to nil
-	nt.Time = nil
-	return nil
+	return NullableTime{}
 }
 
 // MarshalJSON 实现NullableTime的自定义JSON序列化
@@ -310,12 +504,75 @@ type OpenOrder struct {
 	CreatedAt       NullableTime `json:"created_at"`
 }
 
+// RemainingSize 返回订单尚未成交的数量（original_size - size_matched），不会小于 0
+func (o *OpenOrder) RemainingSize() float64 {
+	remaining := float64(o.OriginalSize) - float64(o.SizeMatched)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// FilledRatio 返回订单已成交的比例（0~1），原始数量为 0 时返回 0
+func (o *OpenOrder) FilledRatio() float64 {
+	if o.OriginalSize <= 0 {
+		return 0
+	}
+	ratio := float64(o.SizeMatched) / float64(o.OriginalSize)
+	if ratio > 1 {
+		return 1
+	}
+	return ratio
+}
+
 // OrderPostResponse 表示提交订单的响应
 // API返回camelCase格式：errorMsg, orderID
 type OrderPostResponse struct {
-	OrderID  Keccak256 `json:"orderID"`
-	Status   string    `json:"status"`
-	ErrorMsg string    `json:"errorMsg"`
+	OrderID            Keccak256   `json:"orderID"`
+	Status             string      `json:"status"`
+	ErrorMsg           string      `json:"errorMsg"`
+	Success            bool        `json:"success"`
+	MakingAmount       FloatString `json:"makingAmount"`                 // 成交时 maker 付出的数量（BUY 为 USDC，SELL 为 shares）
+	TakingAmount       FloatString `json:"takingAmount"`                 // 成交时 maker 获得的数量（BUY 为 shares，SELL 为 USDC）
+	TransactionsHashes []string    `json:"transactionsHashes,omitempty"` // 撮合产生的链上交易哈希
+	Fill               *FillResult `json:"-"`                            // 成交结果（由 PostOrder 根据响应计算）
+	Source             string      `json:"-"`                            // 订单来源标签（客户端通过 WithOrderSource 设置，不发送给服务端）
+}
+
+// Err 将 ErrorMsg 映射为错误，订单提交成功时返回 nil
+// 已知的拒绝原因可用 errors.Is 判断，例如 errors.Is(resp.Err(), ErrInsufficientBalance)，见 ParseClobError
+func (r *OrderPostResponse) Err() error {
+	return ParseClobError(r.ErrorMsg)
+}
+
+// FillResult 表示订单提交后的成交情况
+// 用于判断 IOC/FOK 订单是否（部分）成交
+type FillResult struct {
+	MatchedSize   float64 // 已成交数量（shares）
+	RemainingSize float64 // 未成交数量（shares）
+	AveragePrice  float64 // 成交均价（未成交时为 0）
+}
+
+// ToFillResult 根据下单方向和下单数量，从响应的 making/taking 数量计算成交结果
+func (r *OrderPostResponse) ToFillResult(side OrderSide, requestedSize float64) FillResult {
+	var shares, usdc float64
+	if side == OrderSideBUY {
+		usdc, shares = float64(r.MakingAmount), float64(r.TakingAmount)
+	} else {
+		shares, usdc = float64(r.MakingAmount), float64(r.TakingAmount)
+	}
+
+	result := FillResult{
+		MatchedSize:   shares,
+		RemainingSize: requestedSize - shares,
+	}
+	if result.RemainingSize < 0 {
+		result.RemainingSize = 0
+	}
+	if shares > 0 {
+		result.AveragePrice = usdc / shares
+	}
+	return result
 }
 
 // OrderCancelResponse 表示取消订单的响应
@@ -324,11 +581,259 @@ type OrderCancelResponse struct {
 	NotCanceled map[Keccak256]string `json:"not_canceled,omitempty"`
 }
 
+// CancelFailureReason 表示撤单失败原因的分类
+type CancelFailureReason string
+
+const (
+	CancelReasonNotFound      CancelFailureReason = "not_found"      // 订单不存在或已撤销，无需处理
+	CancelReasonNotOwned      CancelFailureReason = "not_owned"      // 订单不属于当前账户（凭证与订单 maker 不一致）
+	CancelReasonAlreadyFilled CancelFailureReason = "already_filled" // 订单已完全成交
+	CancelReasonOther         CancelFailureReason = "other"          // 其他原因（如批次请求失败），可以重试
+)
+
+// ClassifyCancelReason 根据 CLOB 返回的原因文本（不区分大小写）判断撤单失败的分类
+// 如 "Order not found or already canceled" 为 CancelReasonNotFound，"order already matched" 为 CancelReasonAlreadyFilled；
+// 无法识别的原因为 CancelReasonOther
+func ClassifyCancelReason(reason string) CancelFailureReason {
+	lower := strings.ToLower(reason)
+	containsAny := func(keywords ...string) bool {
+		for _, keyword := range keywords {
+			if strings.Contains(lower, keyword) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	// "can't be found - already canceled or matched" 同时包含 matched，先判断不存在
+	case containsAny("not found", "can't be found", "cannot be found", "does not exist", "already canceled", "already cancelled"):
+		return CancelReasonNotFound
+	case containsAny("not owned", "not the owner", "not belong", "does not own", "maker mismatch"):
+		return CancelReasonNotOwned
+	case containsAny("matched", "filled"):
+		return CancelReasonAlreadyFilled
+	default:
+		return CancelReasonOther
+	}
+}
+
+// NotFound 返回因订单不存在或已撤销而未撤销的订单ID（按ID排序）
+func (r *OrderCancelResponse) NotFound() []Keccak256 {
+	return r.notCanceledBy(CancelReasonNotFound)
+}
+
+// NotOwned 返回因订单不属于当前账户而未撤销的订单ID（按ID排序）
+func (r *OrderCancelResponse) NotOwned() []Keccak256 {
+	return r.notCanceledBy(CancelReasonNotOwned)
+}
+
+// AlreadyFilled 返回因订单已成交而未撤销的订单ID（按ID排序）
+func (r *OrderCancelResponse) AlreadyFilled() []Keccak256 {
+	return r.notCanceledBy(CancelReasonAlreadyFilled)
+}
+
+// notCanceledBy 返回失败原因属于指定分类的订单ID（按ID排序）
+func (r *OrderCancelResponse) notCanceledBy(category CancelFailureReason) []Keccak256 {
+	var orderIDs []Keccak256
+	for orderID, reason := range r.NotCanceled {
+		if ClassifyCancelReason(reason) == category {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	sort.Slice(orderIDs, func(i, j int) bool { return orderIDs[i] < orderIDs[j] })
+	return orderIDs
+}
+
+// MarketsCancelResponse 表示一次撤销多个市场订单（CancelMarketsOrders）的结果
+// 嵌入的 OrderCancelResponse 为所有市场合并后的结果；请求失败的市场无法得知其中的订单，记入 FailedMarkets
+type MarketsCancelResponse struct {
+	OrderCancelResponse
+	Markets       map[Keccak256]*OrderCancelResponse `json:"markets"`                  // 每个请求成功的市场各自的撤单结果
+	FailedMarkets map[Keccak256]string               `json:"failed_markets,omitempty"` // 请求失败的市场及原因
+}
+
+// ReplaceResult 表示撤单并重新下单（ReplaceOrders）的结果
+type ReplaceResult struct {
+	Canceled    []Keccak256          `json:"canceled"`     // 成功撤销的订单
+	NotCanceled map[Keccak256]string `json:"not_canceled"` // 撤销失败的订单及原因
+	Posted      []OrderPostResponse  `json:"posted"`       // 新订单的提交结果，与传入顺序一一对应；撤单失败时为空
+}
+
+// AllSucceeded 所有旧订单都已撤销且所有新订单都提交成功时返回 true
+func (r *ReplaceResult) AllSucceeded() bool {
+	if len(r.NotCanceled) > 0 {
+		return false
+	}
+	for _, resp := range r.Posted {
+		if !resp.Success || resp.ErrorMsg != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// BookSource 表示订单簿数据的来源
+type BookSource string
+
+const (
+	BookSourceREST BookSource = "rest" // 通过 REST API 查询
+	BookSourceWSS  BookSource = "wss"  // 来自 WSS 订阅在本地维护的订单簿
+)
+
 // OrderBookSummary 表示订单簿摘要
 type OrderBookSummary struct {
-	TokenID string       `json:"token_id"`
-	Bids    []OrderLevel `json:"bids,omitempty"`
-	Asks    []OrderLevel `json:"asks,omitempty"`
+	TokenID        string       `json:"token_id"`
+	Bids           []OrderLevel `json:"bids,omitempty"`
+	Asks           []OrderLevel `json:"asks,omitempty"`
+	LastTradePrice FloatString  `json:"last_trade_price,omitempty"` // 最后成交价，响应中没有时为 0
+	Source         BookSource   `json:"-"`                          // 数据来源，由客户端设置，直接解析的响应中为空
+}
+
+// TopLevels 返回只保留最优 levels 档的订单簿副本
+// 返回的 Bids 按价格从高到低、Asks 按价格从低到高排列（最优价在前）；levels <= 0 时保留全部档位
+func (s *OrderBookSummary) TopLevels(levels int) *OrderBookSummary {
+	bids := append([]OrderLevel(nil), s.Bids...)
+	asks := append([]OrderLevel(nil), s.Asks...)
+	sort.SliceStable(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.SliceStable(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	if levels > 0 {
+		if len(bids) > levels {
+			bids = bids[:levels]
+		}
+		if len(asks) > levels {
+			asks = asks[:levels]
+		}
+	}
+	return &OrderBookSummary{TokenID: s.TokenID, Bids: bids, Asks: asks, LastTradePrice: s.LastTradePrice, Source: s.Source}
+}
+
+// Midpoint 返回最优买价与最优卖价的中间价，任一侧为空时返回 false
+func (s *OrderBookSummary) Midpoint() (float64, bool) {
+	top := s.TopLevels(1)
+	if len(top.Bids) == 0 || len(top.Asks) == 0 {
+		return 0, false
+	}
+	return (float64(top.Bids[0].Price) + float64(top.Asks[0].Price)) / 2, true
+}
+
+// Quote 表示代币的完整报价，由一次订单簿查询得到
+// 某一侧没有挂单时对应的 Bid 或 Ask 为 0，此时 Mid 和 Spread 也为 0，可以改用 LastTrade
+type Quote struct {
+	TokenID   string  `json:"token_id"`
+	Bid       float64 `json:"bid"`        // 最优买价
+	Ask       float64 `json:"ask"`        // 最优卖价
+	Mid       float64 `json:"mid"`        // (Bid + Ask) / 2
+	Spread    float64 `json:"spread"`     // Ask - Bid
+	LastTrade float64 `json:"last_trade"` // 最后成交价
+
+	Source BookSource `json:"source,omitempty"` // 计算报价所用订单簿的来源
+}
+
+// Quote 根据订单簿计算最优买卖价、中间价、价差和最后成交价
+func (s *OrderBookSummary) Quote() Quote {
+	top := s.TopLevels(1)
+	quote := Quote{TokenID: s.TokenID, LastTrade: float64(s.LastTradePrice), Source: s.Source}
+	if len(top.Bids) > 0 {
+		quote.Bid = float64(top.Bids[0].Price)
+	}
+	if len(top.Asks) > 0 {
+		quote.Ask = float64(top.Asks[0].Price)
+	}
+	if len(top.Bids) > 0 && len(top.Asks) > 0 {
+		quote.Mid = (quote.Bid + quote.Ask) / 2
+		quote.Spread = quote.Ask - quote.Bid
+	}
+	return quote
+}
+
+// Microprice 返回按最优档数量加权的微观价格，任一侧为空或最优档数量均为 0 时返回 false
+// 公式：microprice = (bidPrice × askSize + askPrice × bidSize) / (bidSize + askSize)
+// 买一数量越大，价格越靠近卖一价（反之亦然），比中间价更能反映短期价格压力
+func (s *OrderBookSummary) Microprice() (float64, bool) {
+	top := s.TopLevels(1)
+	if len(top.Bids) == 0 || len(top.Asks) == 0 {
+		return 0, false
+	}
+	bid, ask := top.Bids[0], top.Asks[0]
+	total := float64(bid.Size) + float64(ask.Size)
+	if total <= 0 {
+		return 0, false
+	}
+	return (float64(bid.Price)*float64(ask.Size) + float64(ask.Price)*float64(bid.Size)) / total, true
+}
+
+// Imbalance 返回最优 levels 档的买卖数量失衡度，取值范围 [-1, 1]；levels <= 0 时使用全部档位
+// 公式：imbalance = (bidVolume - askVolume) / (bidVolume + askVolume)，正值表示买盘更强
+// 两侧总数量为 0 时返回 false
+func (s *OrderBookSummary) Imbalance(levels int) (float64, bool) {
+	top := s.TopLevels(levels)
+	var bidVolume, askVolume float64
+	for _, level := range top.Bids {
+		bidVolume += float64(level.Size)
+	}
+	for _, level := range top.Asks {
+		askVolume += float64(level.Size)
+	}
+	total := bidVolume + askVolume
+	if total <= 0 {
+		return 0, false
+	}
+	return (bidVolume - askVolume) / total, true
+}
+
+// BookDiff 表示两次订单簿快照之间发生变化的价格档位
+// Size 为 0 表示该价格档位已被移除；首个快照的 BookDiff 包含全部档位
+type BookDiff struct {
+	TokenID   string       `json:"token_id"`
+	Bids      []OrderLevel `json:"bids,omitempty"`
+	Asks      []OrderLevel `json:"asks,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// IsEmpty 两侧均没有变化时返回 true
+func (d *BookDiff) IsEmpty() bool {
+	return len(d.Bids) == 0 && len(d.Asks) == 0
+}
+
+// DiffOrderBooks 比较两次订单簿快照，返回新增、数量变化和被移除（Size 为 0）的档位
+// prev 为 nil 时返回 next 的全部档位；结果中 Bids 按价格从高到低、Asks 按价格从低到高排列
+func DiffOrderBooks(prev, next *OrderBookSummary) BookDiff {
+	if next == nil {
+		next = &OrderBookSummary{}
+	}
+	if prev == nil {
+		prev = &OrderBookSummary{}
+	}
+
+	diff := BookDiff{TokenID: next.TokenID, Timestamp: time.Now()}
+	diff.Bids = diffLevels(prev.Bids, next.Bids)
+	diff.Asks = diffLevels(prev.Asks, next.Asks)
+	sort.SliceStable(diff.Bids, func(i, j int) bool { return diff.Bids[i].Price > diff.Bids[j].Price })
+	sort.SliceStable(diff.Asks, func(i, j int) bool { return diff.Asks[i].Price < diff.Asks[j].Price })
+	return diff
+}
+
+// diffLevels 按价格比较同一侧的档位
+func diffLevels(prev, next []OrderLevel) []OrderLevel {
+	prevSizes := make(map[FloatString]FloatString, len(prev))
+	for _, level := range prev {
+		prevSizes[level.Price] = level.Size
+	}
+	nextSizes := make(map[FloatString]bool, len(next))
+
+	var changed []OrderLevel
+	for _, level := range next {
+		nextSizes[level.Price] = true
+		if size, ok := prevSizes[level.Price]; !ok || size != level.Size {
+			changed = append(changed, level)
+		}
+	}
+	for _, level := range prev {
+		if !nextSizes[level.Price] {
+			changed = append(changed, OrderLevel{Price: level.Price, Size: 0})
+		}
+	}
+	return changed
 }
 
 // OrderLevel 表示订单簿中的价格层级
@@ -341,14 +846,62 @@ type OrderLevel struct {
 // OrderBookSummaryResponse 表示批量获取订单簿API的响应
 // 根据 POST /books API 文档：https://docs.polymarket.com/api-reference/orderbook/get-multiple-order-books-summaries-by-request
 type OrderBookSummaryResponse struct {
-	AssetID      string       `json:"asset_id"`       // 资产ID
-	Market       string       `json:"market"`         // 市场ID（condition_id）
-	Timestamp    string       `json:"timestamp"`      // 时间戳
-	Hash         string       `json:"hash"`           // 订单簿哈希
-	MinOrderSize string       `json:"min_order_size"` // 最小订单大小
-	TickSize     string       `json:"tick_size"`      // tick大小
-	Bids         []OrderLevel `json:"bids,omitempty"` // 买盘
-	Asks         []OrderLevel `json:"asks,omitempty"` // 卖盘
+	AssetID        string       `json:"asset_id"`                   // 资产ID
+	Market         string       `json:"market"`                     // 市场ID（condition_id）
+	Timestamp      string       `json:"timestamp"`                  // 时间戳
+	Hash           string       `json:"hash"`                       // 订单簿哈希
+	MinOrderSize   string       `json:"min_order_size"`             // 最小订单大小
+	TickSize       string       `json:"tick_size"`                  // tick大小
+	Bids           []OrderLevel `json:"bids,omitempty"`             // 买盘
+	Asks           []OrderLevel `json:"asks,omitempty"`             // 卖盘
+	LastTradePrice FloatString  `json:"last_trade_price,omitempty"` // 最后成交价
+}
+
+// Summary 转换为 OrderBookSummary，便于使用 Quote、Midpoint 等计算方法
+func (r *OrderBookSummaryResponse) Summary() *OrderBookSummary {
+	return &OrderBookSummary{TokenID: r.AssetID, Bids: r.Bids, Asks: r.Asks, LastTradePrice: r.LastTradePrice}
+}
+
+// UnmarshalJSON 兼容 min_order_size、tick_size、timestamp 为数字或字符串的响应，统一保存为字符串
+func (r *OrderBookSummaryResponse) UnmarshalJSON(data []byte) error {
+	type alias OrderBookSummaryResponse
+	aux := struct {
+		*alias
+		Timestamp    json.RawMessage `json:"timestamp"`
+		MinOrderSize json.RawMessage `json:"min_order_size"`
+		TickSize     json.RawMessage `json:"tick_size"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if r.Timestamp, err = numberOrString(aux.Timestamp); err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+	if r.MinOrderSize, err = numberOrString(aux.MinOrderSize); err != nil {
+		return fmt.Errorf("invalid min_order_size: %w", err)
+	}
+	if r.TickSize, err = numberOrString(aux.TickSize); err != nil {
+		return fmt.Errorf("invalid tick_size: %w", err)
+	}
+	return nil
+}
+
+// numberOrString 将 JSON 数字或字符串转换为字符串，缺失或 null 时返回空字符串
+func numberOrString(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s), nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
 }
 
 // ClobMarket 表示CLOB市场
@@ -372,6 +925,21 @@ type ClobMarket struct {
 	Archived                bool       `json:"archived"`
 	NegRisk                 bool       `json:"neg_risk"`
 	NegRiskMarketID         Keccak256  `json:"neg_risk_market_id"`
+	MakerBaseFee            int        `json:"maker_base_fee"` // maker 手续费率（bps）
+	TakerBaseFee            int        `json:"taker_base_fee"` // taker 手续费率（bps）
+}
+
+// TokenMeta 表示下单所需的代币元数据，由 GetTokenMeta 获取
+type TokenMeta struct {
+	TokenID         string    `json:"token_id"`
+	ConditionID     Keccak256 `json:"condition_id"`
+	Outcome         string    `json:"outcome"`
+	TickSize        TickSize  `json:"tick_size"`
+	NegRisk         bool      `json:"neg_risk"`
+	FeeRateBps      int       `json:"fee_rate_bps"`
+	MinOrderSize    float64   `json:"min_order_size"`
+	AcceptingOrders bool      `json:"accepting_orders"`
+	EnableOrderBook bool      `json:"enable_order_book"`
 }
 
 // TickSize 表示tick大小值
@@ -450,6 +1018,32 @@ type MarketRewards struct {
 	MarketCompetitiveness float64        `json:"market_competitiveness"`
 }
 
+// RewardsConfig 表示流动性奖励计划的全局参数
+// CLOB 没有单独的全局配置接口，由当前所有奖励市场（/rewards/markets/current）的奖励配置汇总得到
+type RewardsConfig struct {
+	AssetAddress EthAddress   `json:"asset_address"` // 奖励资产，多种资产时为第一个奖励配置的资产
+	DailyPool    float64      `json:"daily_pool"`    // 当前生效的奖励配置每日奖励之和
+	TotalPool    float64      `json:"total_pool"`    // 所有奖励配置的奖励总额之和
+	MarketsCount int          `json:"markets_count"` // 有奖励配置的市场数量
+	StartDate    NullableTime `json:"start_date"`    // 最早的奖励配置开始日期
+	EndDate      NullableTime `json:"end_date"`      // 最晚的奖励配置结束日期
+	CurrentEpoch RewardsEpoch `json:"current_epoch"` // 当前 UTC 日的奖励周期
+}
+
+// RewardsEpoch 表示一个奖励周期；流动性奖励按 UTC 日计算和发放，每个周期为一天
+type RewardsEpoch struct {
+	Epoch        string    `json:"epoch"`         // 周期日期（YYYY-MM-DD）
+	StartDate    time.Time `json:"start_date"`    // 周期开始时间（含）
+	EndDate      time.Time `json:"end_date"`      // 周期结束时间（不含）
+	TotalRewards float64   `json:"total_rewards"` // 该日生效的奖励配置每日奖励之和
+	MarketsCount int       `json:"markets_count"` // 该日有生效奖励配置的市场数量
+}
+
+// Contains 判断时间 t 是否在周期内（含开始时间，不含结束时间）
+func (e RewardsEpoch) Contains(t time.Time) bool {
+	return !t.Before(e.StartDate) && t.Before(e.EndDate)
+}
+
 // PolygonTrade 表示Polygon上的交易
 type PolygonTrade struct {
 	TradeID      string     `json:"id"`
@@ -463,13 +1057,90 @@ type PolygonTrade struct {
 	TakerAddress EthAddress `json:"taker_address"`
 }
 
+// ClobTrade 表示 CLOB 撮合产生的结算交易（/data/trades）
+// 与 Data API 的 Trade 不同，包含手续费、撮合时间和链上结算状态
+type ClobTrade struct {
+	TradeID         string           `json:"id"`
+	TakerOrderID    Keccak256        `json:"taker_order_id"`
+	ConditionID     Keccak256        `json:"market"`
+	TokenID         string           `json:"asset_id"`
+	Side            OrderSide        `json:"side"`
+	Size            FloatString      `json:"size"`
+	FeeRateBps      FloatString      `json:"fee_rate_bps"`
+	Price           FloatString      `json:"price"`
+	Status          string           `json:"status"` // MATCHED / MINED / CONFIRMED / RETRYING / FAILED
+	MatchTime       NullableTime     `json:"match_time"`
+	LastUpdate      NullableTime     `json:"last_update"`
+	Outcome         string           `json:"outcome"`
+	BucketIndex     int              `json:"bucket_index"`
+	Owner           string           `json:"owner"`
+	MakerAddress    EthAddress       `json:"maker_address"`
+	MakerOrders     []MakerOrderFill `json:"maker_orders"`
+	TransactionHash string           `json:"transaction_hash"`
+	TraderSide      string           `json:"trader_side"` // TAKER / MAKER
+}
+
+// MakerOrderFill 表示结算交易中被撮合的 maker 订单
+type MakerOrderFill struct {
+	OrderID       Keccak256   `json:"order_id"`
+	Owner         string      `json:"owner"`
+	MakerAddress  EthAddress  `json:"maker_address"`
+	MatchedAmount FloatString `json:"matched_amount"`
+	Price         FloatString `json:"price"`
+	FeeRateBps    FloatString `json:"fee_rate_bps"`
+	TokenID       string      `json:"asset_id"`
+	Outcome       string      `json:"outcome"`
+}
+
 // LastTradePrice 表示最后成交价
-type LastTradePrice TokenValue
+// 除价格外还包含成交方向；接口返回时也会带上数量和时间
+type LastTradePrice struct {
+	TokenID   string       `json:"token_id"`
+	Value     float64      `json:"value"`     // 成交价格（接口字段为 price）
+	Side      OrderSide    `json:"side"`      // 最后一笔成交的方向，接口未返回时为空
+	Size      float64      `json:"size"`      // 成交数量，接口未返回时为 0
+	Timestamp NullableTime `json:"timestamp"` // 成交时间，接口未返回时为 nil
+}
+
+// UnmarshalJSON 实现LastTradePrice的自定义JSON反序列化
+// 价格优先读取 price 字段，兼容旧格式的 value 字段；数值可能是数字或字符串
+func (l *LastTradePrice) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		TokenID   string       `json:"token_id"`
+		AssetID   string       `json:"asset_id"`
+		Price     *FloatString `json:"price"`
+		Value     *FloatString `json:"value"`
+		Side      OrderSide    `json:"side"`
+		Size      FloatString  `json:"size"`
+		Timestamp NullableTime `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	l.TokenID = temp.TokenID
+	if l.TokenID == "" {
+		l.TokenID = temp.AssetID
+	}
+	switch {
+	case temp.Price != nil:
+		l.Value = float64(*temp.Price)
+	case temp.Value != nil:
+		l.Value = float64(*temp.Value)
+	default:
+		l.Value = 0
+	}
+	l.Side = OrderSide(strings.ToUpper(string(temp.Side)))
+	l.Size = float64(temp.Size)
+	l.Timestamp = temp.Timestamp
+	return nil
+}
 
 // BalanceAllowance 表示余额授权信息
 type BalanceAllowance struct {
-	Allowance float64 `json:"allowance"`
-	Balance   float64 `json:"balance"`
+	Allowance        float64 `json:"allowance"`
+	Balance          float64 `json:"balance"`
+	NegRiskAllowance float64 `json:"neg_risk_allowance,omitempty"` // 负风险市场可用的授权额度，仅链上查询（GetBalanceAllowanceOf）时填充
 }
 
 // APIKey 表示 API 密钥信息
@@ -478,6 +1149,25 @@ type APIKey struct {
 	Key       string    `json:"key"`
 	CreatedAt time.Time `json:"created_at"`
 	Readonly  bool      `json:"readonly,omitempty"`
+	Scope     string    `json:"scope,omitempty"` // 权限范围（read / trade），服务端未返回时为空
+}
+
+// API 密钥权限范围
+const (
+	APIKeyScopeRead  = "read"  // 只读，不能下单/撤单
+	APIKeyScopeTrade = "trade" // 可交易
+)
+
+// EffectiveScope 返回密钥的权限范围
+// 优先使用服务端返回的 Scope，未返回时根据 Readonly 推断
+func (k APIKey) EffectiveScope() string {
+	if k.Scope != "" {
+		return strings.ToLower(k.Scope)
+	}
+	if k.Readonly {
+		return APIKeyScopeRead
+	}
+	return APIKeyScopeTrade
 }
 
 // Notification 表示通知信息
@@ -518,9 +1208,59 @@ type RFQQuote struct {
 }
 
 // RFQAcceptResponse 表示接受报价的响应
+// 响应可能是 RFQ 格式（order_id/status），也可能是下单格式（orderID/success/errorMsg），UnmarshalJSON 统一处理
 type RFQAcceptResponse struct {
-	QuoteID  string    `json:"quote_id"`
-	OrderID  Keccak256 `json:"order_id,omitempty"`
-	Status   string    `json:"status"`
-	AcceptedAt time.Time `json:"accepted_at"`
+	QuoteID      string      `json:"quote_id"`
+	OrderID      Keccak256   `json:"order_id,omitempty"`
+	Status       string      `json:"status"`
+	AcceptedAt   time.Time   `json:"accepted_at"`
+	Success      bool        `json:"success,omitempty"`
+	ErrorMsg     string      `json:"errorMsg,omitempty"`
+	MakingAmount FloatString `json:"makingAmount,omitempty"`
+	TakingAmount FloatString `json:"takingAmount,omitempty"`
+}
+
+// UnmarshalJSON 兼容下单格式的 orderID 字段
+func (r *RFQAcceptResponse) UnmarshalJSON(data []byte) error {
+	type alias RFQAcceptResponse
+	aux := struct {
+		*alias
+		OrderPostID Keccak256 `json:"orderID"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if r.OrderID == "" {
+		r.OrderID = aux.OrderPostID
+	}
+	return nil
+}
+
+// OrderPostResponse 转换为下单响应，返回了订单ID时视为成交成功
+func (r *RFQAcceptResponse) OrderPostResponse() *OrderPostResponse {
+	return &OrderPostResponse{
+		OrderID:      r.OrderID,
+		Status:       r.Status,
+		ErrorMsg:     r.ErrorMsg,
+		Success:      r.Success || (r.OrderID != "" && r.ErrorMsg == ""),
+		MakingAmount: r.MakingAmount,
+		TakingAmount: r.TakingAmount,
+	}
+}
+
+// CacheStats 表示一个缓存的使用统计
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`      // 命中次数
+	Misses    uint64 `json:"misses"`    // 未命中次数（需要请求 API）
+	Evictions uint64 `json:"evictions"` // 因超过容量被淘汰的条目数
+	Size      int    `json:"size"`      // 当前条目数
+	Capacity  int    `json:"capacity"`  // 最大条目数，0 表示不限制
+}
+
+// MetadataCacheStats 表示 CLOB 客户端各个代币元数据缓存的使用统计
+type MetadataCacheStats struct {
+	TickSize  CacheStats `json:"tick_size"`
+	NegRisk   CacheStats `json:"neg_risk"`
+	FeeRate   CacheStats `json:"fee_rate"`
+	TokenMeta CacheStats `json:"token_meta"`
 }
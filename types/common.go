@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -73,6 +75,33 @@ type TimeseriesPoint struct {
 	Timestamp time.Time `json:"t"`
 }
 
+// UnmarshalJSON 实现TimeseriesPoint的自定义JSON反序列化
+// API 返回的 t 是 Unix 秒时间戳，同时兼容 RFC3339 字符串（TimeseriesPoint 序列化后的格式）
+func (p *TimeseriesPoint) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Value     float64         `json:"p"`
+		Timestamp json.RawMessage `json:"t"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	p.Value = raw.Value
+	p.Timestamp = time.Time{}
+
+	if len(raw.Timestamp) == 0 || string(raw.Timestamp) == "null" {
+		return nil
+	}
+	var seconds int64
+	if err := json.Unmarshal(raw.Timestamp, &seconds); err == nil {
+		p.Timestamp = time.Unix(seconds, 0).UTC()
+		return nil
+	}
+	if err := json.Unmarshal(raw.Timestamp, &p.Timestamp); err != nil {
+		return fmt.Errorf("invalid timeseries timestamp %s: %w", raw.Timestamp, err)
+	}
+	return nil
+}
+
 // ChainID 表示区块链链ID
 type ChainID int
 
@@ -83,6 +112,27 @@ const (
 	Amoy ChainID = 80002
 )
 
+// Environment 表示客户端所连接的网络环境
+type Environment string
+
+const (
+	EnvironmentMainnet Environment = "mainnet"
+	EnvironmentTestnet Environment = "testnet"
+	EnvironmentUnknown Environment = "unknown"
+)
+
+// Environment 返回链ID对应的网络环境，未知链返回 EnvironmentUnknown
+func (c ChainID) Environment() Environment {
+	switch c {
+	case Polygon:
+		return EnvironmentMainnet
+	case Amoy:
+		return EnvironmentTestnet
+	default:
+		return EnvironmentUnknown
+	}
+}
+
 // SignatureType 表示钱包签名类型
 type SignatureType int
 
@@ -95,6 +145,40 @@ const (
 	SafeSignatureType SignatureType = 2
 )
 
+// String 返回签名类型的可读名称
+func (s SignatureType) String() string {
+	switch s {
+	case EOASignatureType:
+		return "EOA"
+	case ProxySignatureType:
+		return "POLY_PROXY"
+	case SafeSignatureType:
+		return "POLY_GNOSIS_SAFE"
+	default:
+		return fmt.Sprintf("SignatureType(%d)", int(s))
+	}
+}
+
+// IsValid 检查签名类型是否为支持的取值（0、1、2）
+func (s SignatureType) IsValid() bool {
+	switch s {
+	case EOASignatureType, ProxySignatureType, SafeSignatureType:
+		return true
+	default:
+		return false
+	}
+}
+
+// Timeouts 客户端超时配置
+// 字段为 0 时使用 SDK 默认值（对应 internal 包中的同名常量）
+type Timeouts struct {
+	HTTP             time.Duration // 普通 HTTP 请求超时（默认 30s）
+	HTTPLong         time.Duration // Relayer 等耗时较长的 HTTP 请求超时（默认 60s）
+	RelayNonce       time.Duration // 获取 relay nonce 的单次请求超时（默认 30s）
+	TransactionWait  time.Duration // 等待链上交易确认的总超时（默认 5m）
+	TransactionDelay time.Duration // 轮询交易收据的间隔（默认 2s）
+}
+
 // BookSnapshot 表示订单簿快照
 type BookSnapshot struct {
 	BestBid *BookSide
@@ -165,17 +165,17 @@ type Trade struct {
 func (t *Trade) UnmarshalJSON(data []byte) error {
 	// 使用临时结构体来解析JSON
 	var temp struct {
-		TradeID     string     `json:"id"`
-		ConditionID Keccak256  `json:"market"`
-		TokenID     string     `json:"asset_id"`
-		Side        OrderSide  `json:"side"`
-		Price       float64    `json:"price"`
-		Size        float64    `json:"size"`
-		CashAmount  float64    `json:"cash_amount"`
-		TokenAmount float64    `json:"token_amount"`
+		TradeID     string      `json:"id"`
+		ConditionID Keccak256   `json:"market"`
+		TokenID     string      `json:"asset_id"`
+		Side        OrderSide   `json:"side"`
+		Price       float64     `json:"price"`
+		Size        float64     `json:"size"`
+		CashAmount  float64     `json:"cash_amount"`
+		TokenAmount float64     `json:"token_amount"`
 		Timestamp   interface{} `json:"timestamp"` // 可能是数字或字符串
-		User        EthAddress `json:"user"`
-		TakerOnly   bool       `json:"taker_only"`
+		User        EthAddress  `json:"user"`
+		TakerOnly   bool        `json:"taker_only"`
 	}
 
 	if err := json.Unmarshal(data, &temp); err != nil {
@@ -240,15 +240,15 @@ type Activity struct {
 func (a *Activity) UnmarshalJSON(data []byte) error {
 	// 使用临时结构体来解析JSON
 	var temp struct {
-		ActivityID  string     `json:"id"`
-		Type        string     `json:"type"`
-		ConditionID Keccak256  `json:"market"`
-		TokenID     string     `json:"asset_id"`
-		Side        *OrderSide `json:"side,omitempty"`
-		Tokens      float64    `json:"tokens"`
-		Cash        float64    `json:"cash"`
+		ActivityID  string      `json:"id"`
+		Type        string      `json:"type"`
+		ConditionID Keccak256   `json:"market"`
+		TokenID     string      `json:"asset_id"`
+		Side        *OrderSide  `json:"side,omitempty"`
+		Tokens      float64     `json:"tokens"`
+		Cash        float64     `json:"cash"`
 		Timestamp   interface{} `json:"timestamp"` // 可能是数字或字符串
-		User        EthAddress `json:"user"`
+		User        EthAddress  `json:"user"`
 	}
 
 	if err := json.Unmarshal(data, &temp); err != nil {
@@ -350,8 +350,8 @@ type GQLPosition struct {
 
 // GraphQLResponse 表示 GraphQL 响应
 type GraphQLResponse struct {
-	Data   interface{}            `json:"data"`
-	Errors []GraphQLError         `json:"errors,omitempty"`
+	Data   interface{}    `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
 }
 
 // GraphQLError 表示 GraphQL 错误
@@ -369,25 +369,47 @@ type GraphQLErrorLocation struct {
 
 // MarketVolume 表示市场交易量
 type MarketVolume struct {
-	MarketID  string  `json:"marketId"`
-	Volume    float64 `json:"volume"`
-	TradeCount int    `json:"tradeCount"`
-	StartTime int64   `json:"startTime"`
-	EndTime   int64   `json:"endTime"`
+	MarketID   string  `json:"marketId"`
+	Volume     float64 `json:"volume"`
+	TradeCount int     `json:"tradeCount"`
+	StartTime  int64   `json:"startTime"`
+	EndTime    int64   `json:"endTime"`
 }
 
 // MarketOpenInterest 表示市场未平仓量
 type MarketOpenInterest struct {
-	MarketID        string    `json:"marketId"`
-	TotalOpenInterest float64 `json:"totalOpenInterest"`
-	Timestamp       time.Time `json:"timestamp"`
+	MarketID          string    `json:"marketId"`
+	TotalOpenInterest float64   `json:"totalOpenInterest"`
+	Timestamp         time.Time `json:"timestamp"`
 }
 
 // UserPNL 表示用户盈亏
 type UserPNL struct {
 	User          EthAddress `json:"user"`
-	TotalPNL      float64   `json:"totalPNL"`
-	RealizedPNL   float64   `json:"realizedPNL"`
-	UnrealizedPNL float64   `json:"unrealizedPNL"`
-	Timestamp     time.Time `json:"timestamp"`
+	TotalPNL      float64    `json:"totalPNL"`
+	RealizedPNL   float64    `json:"realizedPNL"`
+	UnrealizedPNL float64    `json:"unrealizedPNL"`
+	Timestamp     time.Time  `json:"timestamp"`
+}
+
+// Portfolio 表示地址的资产汇总（GetPortfolioSummary）
+type Portfolio struct {
+	User             EthAddress          `json:"user"`
+	USDCBalance      float64             `json:"usdc_balance"`       // 链上 USDC 余额（包含挂单占用的部分）
+	ReservedInOrders float64             `json:"reserved_in_orders"` // BUY 挂单未成交部分占用的 USDC
+	AvailableUSDC    float64             `json:"available_usdc"`     // 可用于新订单的 USDC（USDCBalance - ReservedInOrders，不小于 0）
+	PositionsValue   float64             `json:"positions_value"`    // 持仓按标记价格计算的市值
+	NetWorth         float64             `json:"net_worth"`          // 资产净值（USDCBalance + PositionsValue）
+	Positions        []PortfolioPosition `json:"positions"`
+}
+
+// PortfolioPosition 表示单个持仓的标记价格和市值
+type PortfolioPosition struct {
+	TokenID     string    `json:"token_id"`
+	ConditionID Keccak256 `json:"condition_id"`
+	Title       string    `json:"title"`
+	Outcome     string    `json:"outcome"`
+	Size        float64   `json:"size"`
+	MarkPrice   float64   `json:"mark_price"` // 中间价，没有订单簿（如已结算）时为 Data API 返回的当前价格
+	Value       float64   `json:"value"`      // Size * MarkPrice
 }
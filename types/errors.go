@@ -1,9 +1,138 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	ErrInvalidEthAddress  = errors.New("invalid Ethereum address format")
+	ErrInvalidKeccak256   = errors.New("invalid Keccak256 hash format")
+	ErrInvalidHexString   = errors.New("invalid hex string format")
+	ErrAPIKeyCannotTrade  = errors.New("this API key is read-only and cannot trade")
+	ErrTradeNotFound      = errors.New("trade not found")
+	ErrOrderBelowMinSize  = errors.New("order size below market minimum")
+	ErrRelayerUnavailable = errors.New("relayer unavailable (circuit breaker open)")
+	ErrRelayTxNotFound    = errors.New("relay transaction not found")
+	ErrRelayerBlocked     = errors.New("relayer request blocked (non-JSON response)")
+	ErrMarketNotResolved  = errors.New("market not resolved")
+	ErrSplitResolution    = errors.New("market resolved with split payouts (no single winner)")
+	ErrNoRFQQuote         = errors.New("no RFQ quote received")
+)
+
+// ErrCloudflareChallenge 请求被 Cloudflare 挑战或拦截（403/503 的 Cloudflare HTML 页面），与 API 参数无关，通常需要更换 IP 或稍后重试
+// CLOB、Gamma 等 REST 请求返回 IsBlocked() 为 true 的 *http.APIError，可用 errors.Is(err, types.ErrCloudflareChallenge) 判断
+var ErrCloudflareChallenge = errors.New("request blocked by Cloudflare challenge (non-JSON response)")
 
+// CLOB 下单被拒绝的原因，由 ParseClobError 根据服务端的 errorMsg 映射
 var (
-	ErrInvalidEthAddress = errors.New("invalid Ethereum address format")
-	ErrInvalidKeccak256  = errors.New("invalid Keccak256 hash format")
-	ErrInvalidHexString  = errors.New("invalid hex string format")
+	ErrInsufficientBalance = errors.New("not enough balance or allowance")
+	ErrInvalidTickSize     = errors.New("order price breaks minimum tick size")
+	ErrDuplicateOrder      = errors.New("duplicate order")
+	ErrInvalidExpiration   = errors.New("invalid order expiration")
+	ErrFOKNotFilled        = errors.New("FOK order could not be fully filled")
+	ErrMarketNotReady      = errors.New("market not ready")
+	ErrInvalidSignature    = errors.New("invalid order signature")
+	ErrOrderBookNotFound   = errors.New("order book does not exist")
+	ErrTokenNotApproved    = errors.New("exchange is not an approved ERC1155 operator")
 )
+
+// clobErrorPatterns CLOB 下单错误（错误码或对应的错误信息，不区分大小写）与对应的错误
+// 按错误码和完整的错误信息匹配，避免撤单等其他接口的相似信息（如 "order does not exist"）被误判
+var clobErrorPatterns = []struct {
+	pattern *regexp.Regexp
+	err     error
+}{
+	{regexp.MustCompile(`(?i)invalid_order_not_enough_balance|not enough balance ?/ ?allowance`), ErrInsufficientBalance},
+	{regexp.MustCompile(`(?i)invalid_order_min_tick_size|breaks minimum tick size rule`), ErrInvalidTickSize},
+	{regexp.MustCompile(`(?i)invalid_order_min_size|size \S* ?lower than the minimum`), ErrOrderBelowMinSize},
+	{regexp.MustCompile(`(?i)invalid_order_duplicated|order is invalid\. duplicated`), ErrDuplicateOrder},
+	{regexp.MustCompile(`(?i)invalid_order_expiration|invalid expiration`), ErrInvalidExpiration},
+	{regexp.MustCompile(`(?i)fok_order_not_filled_error|order couldn't be fully filled`), ErrFOKNotFilled},
+	{regexp.MustCompile(`(?i)market_not_ready|market is not yet ready`), ErrMarketNotReady},
+	{regexp.MustCompile(`(?i)invalid order signature|^invalid signature$`), ErrInvalidSignature},
+	{regexp.MustCompile(`(?i)the orderbook \S+ does not exist|no orderbook exists`), ErrOrderBookNotFound},
+}
+
+// ParseClobError 将 CLOB 返回的错误信息（如 OrderPostResponse.ErrorMsg）映射为可用 errors.Is 判断的错误
+// 已知错误返回包装了对应 ErrXxx 的错误（保留原始信息），未知错误原样返回，msg 为空时返回 nil
+func ParseClobError(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	for _, known := range clobErrorPatterns {
+		if known.pattern.MatchString(msg) {
+			return fmt.Errorf("%w: %s", known.err, msg)
+		}
+	}
+	return errors.New(msg)
+}
+
+// InsufficientBalanceError 表示下单前检查发现余额不足，errors.Is(err, ErrInsufficientBalance) 为 true
+type InsufficientBalanceError struct {
+	Asset     string  // 不足的资产，USDC 或代币ID
+	Required  float64 // 提交订单需要的数量
+	Available float64 // 当前可用数量
+}
+
+// Shortfall 返回还差的数量
+func (e *InsufficientBalanceError) Shortfall() float64 {
+	return e.Required - e.Available
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("%v: %s required %.6f, available %.6f, shortfall %.6f",
+		ErrInsufficientBalance, e.Asset, e.Required, e.Available, e.Shortfall())
+}
+
+// Is 使 errors.Is(err, ErrInsufficientBalance) 成立
+func (e *InsufficientBalanceError) Is(target error) bool {
+	return target == ErrInsufficientBalance
+}
+
+// TokenNotApprovedError 表示下单前检查发现交易所不是代币持有地址的 ERC1155 授权操作员，SELL 订单无法成交
+// 服务端对这种情况同样返回余额/授权不足，因此 errors.Is(err, ErrInsufficientBalance) 与
+// errors.Is(err, ErrTokenNotApproved) 都为 true
+type TokenNotApprovedError struct {
+	Owner   EthAddress // 持有代币的地址（Proxy/Safe 模式为代理地址）
+	TokenID string     // 需要卖出的代币ID
+	NegRisk bool       // true 表示需要授权 NegRiskCTFExchange，否则为 CTFExchange
+}
+
+func (e *TokenNotApprovedError) Error() string {
+	exchange := "CTFExchange"
+	if e.NegRisk {
+		exchange = "NegRiskCTFExchange"
+	}
+	return fmt.Sprintf("%v: %s has not approved %s to transfer token %s", ErrTokenNotApproved, e.Owner, exchange, e.TokenID)
+}
+
+// Is 使 errors.Is(err, ErrTokenNotApproved) 和 errors.Is(err, ErrInsufficientBalance) 成立
+func (e *TokenNotApprovedError) Is(target error) bool {
+	return target == ErrTokenNotApproved || target == ErrInsufficientBalance
+}
+
+// InsufficientAllowanceError 表示下单前检查发现交易所的 USDC 授权额度不足，BUY 订单无法成交
+// 负风险市场需要同时授权 NegRiskCTFExchange 和 NegRiskAdapter，Available 为两者中较小的值；
+// 服务端对这种情况返回余额/授权不足，因此 errors.Is(err, ErrInsufficientBalance) 为 true
+type InsufficientAllowanceError struct {
+	Owner     EthAddress // 支付 USDC 的地址（Proxy/Safe 模式为代理地址）
+	NegRisk   bool       // true 表示负风险市场的授权，否则为 CTFExchange
+	Required  float64    // 提交订单需要的授权额度
+	Available float64    // 当前授权额度
+}
+
+func (e *InsufficientAllowanceError) Error() string {
+	spender := "CTFExchange"
+	if e.NegRisk {
+		spender = "NegRiskCTFExchange/NegRiskAdapter"
+	}
+	return fmt.Sprintf("%v: %s USDC allowance for %s required %.6f, available %.6f",
+		ErrInsufficientBalance, e.Owner, spender, e.Required, e.Available)
+}
+
+// Is 使 errors.Is(err, ErrInsufficientBalance) 成立
+func (e *InsufficientAllowanceError) Is(target error) bool {
+	return target == ErrInsufficientBalance
+}
@@ -1,8 +1,10 @@
 package types
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -21,13 +23,21 @@ func parseTokenIDs(value interface{}) []string {
 		result := make([]string, 0, len(v))
 		for _, item := range v {
 			// Format token ID preserving precision for large numbers
-			result = append(result, formatTokenIDString(item))
+			// 跳过 null 和空字符串（尚未部署的市场）
+			if tokenID := formatTokenIDString(item); tokenID != "" {
+				result = append(result, tokenID)
+			}
 		}
 		return result
 	case string:
+		// 尚未部署到 CLOB 的市场返回空字符串
+		if strings.TrimSpace(v) == "" {
+			return []string{}
+		}
 		// Try to parse as JSON first (API returns JSON string arrays as strings)
+		// 数字形式的 token ID 超过 2^53，必须用 json.Number 保留原始数字串，不能经过 float64
 		var parsed interface{}
-		if err := json.Unmarshal([]byte(v), &parsed); err == nil {
+		if err := DecodeJSON([]byte(v), &parsed); err == nil {
 			return parseTokenIDs(parsed)
 		}
 		// If not JSON, return as single element
@@ -48,6 +58,9 @@ func formatTokenIDString(value interface{}) string {
 	case string:
 		// Already a string, return as-is
 		return v
+	case json.Number:
+		// 使用 DecodeJSON 解析得到的数字，原样保留全部位数
+		return v.String()
 	case float64:
 		// JSON numbers are parsed as float64, convert to string without scientific notation
 		// Use %.0f to preserve full precision for large integers
@@ -104,50 +117,112 @@ func parseOutcomes(value interface{}) []string {
 // parseOutcomePrices parses outcome prices from various formats (JSON string, array, etc.)
 // API returns JSON string arrays as strings like "[0.95, 0.05]"
 // Returns []float64, converting from any format
+// 无法解析的元素（如空字符串）按 0 保留在原位置，保证价格与 TokenIDs 一一对应；需要发现这类输入时使用 ParseOutcomePrices
 func parseOutcomePrices(value interface{}) []float64 {
+	prices, _ := parseOutcomePriceList(value, false)
+	return prices
+}
+
+// ParseOutcomePrices 严格解析结果价格（JSON 数组、JSON 字符串或逗号分隔字符串），支持科学计数法（如 "1e-3"）
+// 与 GammaMarket 解析时的宽松处理不同，任一元素为空或无法解析时返回错误，而不是得到与 TokenIDs 长度不一致的结果
+func ParseOutcomePrices(value interface{}) ([]float64, error) {
+	return parseOutcomePriceList(value, true)
+}
+
+// parseOutcomePriceList 解析结果价格列表，strict 为 false 时无法解析的元素按 0 处理
+func parseOutcomePriceList(value interface{}, strict bool) ([]float64, error) {
 	if value == nil {
-		return nil
+		return nil, nil
 	}
 
 	switch v := value.(type) {
 	case []float64:
-		return v
+		return v, nil
 	case []interface{}:
 		result := make([]float64, 0, len(v))
-		for _, item := range v {
-			switch val := item.(type) {
-			case float64:
-				result = append(result, val)
-			case float32:
-				result = append(result, float64(val))
-			case int:
-				result = append(result, float64(val))
-			case string:
-				if f, err := strconv.ParseFloat(val, 64); err == nil {
-					result = append(result, f)
+		for i, item := range v {
+			price, err := parseOutcomePrice(item)
+			if err != nil {
+				if strict {
+					return nil, fmt.Errorf("invalid outcome price at index %d: %w", i, err)
 				}
+				price = 0
 			}
+			result = append(result, price)
 		}
-		return result
+		return result, nil
 	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
 		// Try to parse as JSON first (API returns JSON string arrays as strings)
 		var parsed interface{}
-		if err := json.Unmarshal([]byte(v), &parsed); err == nil {
-			return parseOutcomePrices(parsed)
+		if err := DecodeJSON([]byte(v), &parsed); err == nil {
+			if _, ok := parsed.([]interface{}); ok || !strict {
+				return parseOutcomePriceList(parsed, strict)
+			}
+			return nil, fmt.Errorf("outcome prices must be an array, got %s", v)
 		}
 		// If not JSON, try comma-separated
 		parts := strings.Split(v, ",")
 		result := make([]float64, 0, len(parts))
-		for _, part := range parts {
-			cleaned := strings.TrimSpace(strings.Trim(part, "[]\"'"))
-			if f, err := strconv.ParseFloat(cleaned, 64); err == nil {
-				result = append(result, f)
+		for i, part := range parts {
+			cleaned := strings.TrimSpace(strings.Trim(strings.TrimSpace(part), "[]\"'"))
+			price, err := parseOutcomePrice(cleaned)
+			if err != nil {
+				if strict {
+					return nil, fmt.Errorf("invalid outcome price at index %d: %w", i, err)
+				}
+				price = 0
 			}
+			result = append(result, price)
 		}
-		return result
+		return result, nil
 	default:
-		return nil
+		if strict {
+			return nil, fmt.Errorf("unsupported outcome prices type: %T", value)
+		}
+		return nil, nil
+	}
+}
+
+// parseOutcomePrice 解析单个结果价格，空值和非有限数视为无效
+func parseOutcomePrice(item interface{}) (float64, error) {
+	var price float64
+	switch val := item.(type) {
+	case float64:
+		price = val
+	case float32:
+		price = float64(val)
+	case int:
+		price = float64(val)
+	case int64:
+		price = float64(val)
+	case json.Number:
+		f, err := val.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("invalid price %q", val.String())
+		}
+		price = f
+	case string:
+		cleaned := strings.TrimSpace(val)
+		if cleaned == "" {
+			return 0, fmt.Errorf("empty price")
+		}
+		f, err := strconv.ParseFloat(cleaned, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid price %q", val)
+		}
+		price = f
+	case nil:
+		return 0, fmt.Errorf("empty price")
+	default:
+		return 0, fmt.Errorf("unexpected price type: %T", item)
+	}
+	if math.IsNaN(price) || math.IsInf(price, 0) {
+		return 0, fmt.Errorf("invalid price %v", price)
 	}
+	return price, nil
 }
 
 // Event 表示Polymarket事件
@@ -360,10 +435,12 @@ type GammaMarket struct {
 // UnmarshalJSON 实现GammaMarket的自定义JSON反序列化
 // 处理 outcomes、outcomePrices、clobTokenIds 和 umaResolutionStatuses 的字符串解析（API返回JSON字符串数组）
 // 时间字段使用标准库自动解析 RFC3339 格式
+// 尚未部署到 CLOB 的市场 clobTokenIds 为 null、空字符串或空数组，解析后 TokenIDs 为空，不会包含空字符串
 func (m *GammaMarket) UnmarshalJSON(data []byte) error {
 	// 先解析到 map 以便预处理字符串数组字段
+	// 数字保留为 json.Number，避免数字形式的 clobTokenIds 经过 float64 丢失精度
 	var rawData map[string]interface{}
-	if err := json.Unmarshal(data, &rawData); err != nil {
+	if err := DecodeJSON(data, &rawData); err != nil {
 		return err
 	}
 
@@ -463,6 +540,47 @@ func (m *GammaMarket) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(processedData, aux)
 }
 
+// IsTradeable 市场是否可以下单：已部署到 CLOB（有 token ID）、正在接受订单且未关闭
+func (m *GammaMarket) IsTradeable() bool {
+	if m == nil || len(m.TokenIDs) == 0 {
+		return false
+	}
+	return m.AcceptingOrders && !m.Closed
+}
+
+// AcceptingOrdersAt 返回市场开始接受订单的时间，API 未提供时返回 nil
+// 定时开盘的市场（如 15 分钟 BTC 涨跌市场）在该时间之前无法下单
+func (m *GammaMarket) AcceptingOrdersAt() *time.Time {
+	if m == nil {
+		return nil
+	}
+	return m.AcceptingOrdersTimestamp
+}
+
+// WaitUntilAcceptingOrders 阻塞直到市场开始接受订单的时间
+// 没有开盘时间或开盘时间已过时立即返回 nil；ctx 结束时返回 ctx.Err()
+// 仅根据开盘时间等待，不会重新查询市场状态
+func (m *GammaMarket) WaitUntilAcceptingOrders(ctx context.Context) error {
+	openAt := m.AcceptingOrdersAt()
+	if openAt == nil {
+		return ctx.Err()
+	}
+
+	wait := time.Until(*openAt)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // GetOutcomePrices 获取结果价格映射
 func GetOutcomePrices(m *GammaMarket) map[string]float64 {
 	if m == nil {
@@ -470,6 +588,10 @@ func GetOutcomePrices(m *GammaMarket) map[string]float64 {
 	}
 	outcomePrices := make(map[string]float64)
 	for i, tokenId := range m.TokenIDs {
+		// 价格数量少于 TokenIDs 时跳过缺少价格的代币，避免越界
+		if i >= len(m.OutcomePrices) {
+			break
+		}
 		outcomePrices[tokenId] = m.OutcomePrices[i]
 	}
 	return outcomePrices
@@ -482,6 +604,9 @@ func GetOutcomeNames(m *GammaMarket) map[string]string {
 	}
 	outcomeNames := make(map[string]string)
 	for i, tokenId := range m.TokenIDs {
+		if i >= len(m.Outcomes) {
+			break
+		}
 		outcomeNames[tokenId] = m.Outcomes[i]
 	}
 	return outcomeNames
@@ -589,13 +714,13 @@ func (s *Series) UnmarshalJSON(data []byte) error {
 	// 使用临时结构体来解析JSON
 	var temp struct {
 		ID          interface{} `json:"id"` // 可能是字符串或数字
-		Slug        string       `json:"slug"`
-		Title       string       `json:"title"`
-		Description string       `json:"description"`
-		Recurrence  string       `json:"recurrence"`
-		Closed      bool         `json:"closed"`
-		CreatedAt   time.Time    `json:"created_at"`
-		UpdatedAt   time.Time    `json:"updated_at"`
+		Slug        string      `json:"slug"`
+		Title       string      `json:"title"`
+		Description string      `json:"description"`
+		Recurrence  string      `json:"recurrence"`
+		Closed      bool        `json:"closed"`
+		CreatedAt   time.Time   `json:"created_at"`
+		UpdatedAt   time.Time   `json:"updated_at"`
 	}
 
 	if err := json.Unmarshal(data, &temp); err != nil {
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecodeJSON 解析 JSON，interface{} 中的数字解析为 json.Number 而不是 float64
+// token ID 是 77~78 位的大整数、链上金额可能超过 2^53，经过 float64 会丢失精度；
+// 解析结果中的数字可用 NumberString、NumberInt64、NumberBigInt 等转换为所需类型
+func DecodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	// 与 json.Unmarshal 一致：不允许有多余的内容
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("invalid character after top-level value")
+	}
+	return nil
+}
+
+// NumberString 将解析得到的数字（json.Number、float64、整数）或字符串转换为十进制字符串
+// 同时兼容 DecodeJSON 和 json.Unmarshal 的解析结果；json.Number 原样保留全部位数，
+// float64 不使用科学计数法。其他类型返回 false
+func NumberString(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case json.Number:
+		return val.String(), true
+	case string:
+		return strings.TrimSpace(val), true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32), true
+	case int:
+		return strconv.Itoa(val), true
+	case int64:
+		return strconv.FormatInt(val, 10), true
+	default:
+		return "", false
+	}
+}
+
+// NumberInt64 将解析得到的数字或数字字符串转换为 int64
+// 不是整数、超出 int64 范围或类型不支持时返回 false
+func NumberInt64(v interface{}) (int64, bool) {
+	switch val := v.(type) {
+	case float64:
+		if val != math.Trunc(val) || val < math.MinInt64 || val >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(val), true
+	case int:
+		return int64(val), true
+	case int64:
+		return val, true
+	}
+	s, ok := NumberString(v)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// NumberFloat64 将解析得到的数字或数字字符串转换为 float64，无法解析时返回 false
+func NumberFloat64(v interface{}) (float64, bool) {
+	if f, ok := v.(float64); ok {
+		return f, true
+	}
+	s, ok := NumberString(v)
+	if !ok || s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// NumberBigInt 将解析得到的整数或整数字符串转换为 *big.Int，用于 token ID 和链上金额
+// 来自 json.Unmarshal 的 float64 超过 2^53 时已经丢失精度，应改用 DecodeJSON 解析
+func NumberBigInt(v interface{}) (*big.Int, bool) {
+	s, ok := NumberString(v)
+	if !ok {
+		return nil, false
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	return n, ok
+}
@@ -0,0 +1,7 @@
+package types
+
+// Version SDK 版本号，出现在所有请求默认的 User-Agent 中（go-polymarket-sdk/<version>）
+// 默认为 "dev"，发布构建时通过 ldflags 注入：
+//
+//	go build -ldflags "-X github.com/polymas/go-polymarket-sdk/types.Version=$(git describe --tags)"
+var Version = "dev"
@@ -1,5 +1,42 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// USDCDecimals USDC 的小数位数，链上余额是以 10^-6 USDC 为单位的整数
+const USDCDecimals = 6
+
+// usdcUnit 1 USDC 对应的链上整数（10^6）
+var usdcUnit = big.NewInt(1_000_000)
+
+// FormatUSDC 将链上 6 位小数的 USDC 整数精确转换为十进制字符串，如 1234567 -> "1.234567"
+// 不经过 float64，适合对账等需要精确金额的场景；raw 为 nil 时返回 "0.000000"
+func FormatUSDC(raw *big.Int) string {
+	if raw == nil {
+		raw = new(big.Int)
+	}
+	sign := ""
+	if raw.Sign() < 0 {
+		sign = "-"
+	}
+	whole, frac := new(big.Int).QuoRem(new(big.Int).Abs(raw), usdcUnit, new(big.Int))
+	return fmt.Sprintf("%s%s.%0*d", sign, whole.String(), USDCDecimals, frac.Int64())
+}
+
+// USDCToFloat 将链上 6 位小数的 USDC 整数转换为 float64（大额时可能损失精度），raw 为 nil 时返回 0
+func USDCToFloat(raw *big.Int) float64 {
+	if raw == nil {
+		return 0
+	}
+	result, _ := new(big.Float).Quo(new(big.Float).SetInt(raw), new(big.Float).SetInt(usdcUnit)).Float64()
+	return result
+}
+
 // TransactionReceipt 表示区块链交易收据
 type TransactionReceipt struct {
 	TxHash            Keccak256   `json:"transaction_hash"`
@@ -19,3 +56,264 @@ type Log struct {
 	Topics  []Keccak256 `json:"topics"`
 	Data    string      `json:"data"`
 }
+
+// ConditionResolution 表示条件（市场）在链上的结算状态
+// 数据来自 ConditionalTokens 合约的 payoutDenominator / payoutNumerators
+type ConditionResolution struct {
+	ConditionID       Keccak256 `json:"condition_id"`
+	Resolved          bool      `json:"resolved"`           // payoutDenominator > 0 表示已结算
+	OutcomeSlotCount  int       `json:"outcome_slot_count"` // 结果数量（二元市场为 2）
+	PayoutNumerators  []uint64  `json:"payout_numerators"`  // 各结果的赔付分子
+	PayoutDenominator uint64    `json:"payout_denominator"` // 赔付分母，未结算时为 0
+}
+
+// Payout 返回第 index 个结果每份代币可赎回的 USDC 比例（0~1），未结算或越界时返回 0
+func (r *ConditionResolution) Payout(index int) float64 {
+	if !r.Resolved || r.PayoutDenominator == 0 || index < 0 || index >= len(r.PayoutNumerators) {
+		return 0
+	}
+	return float64(r.PayoutNumerators[index]) / float64(r.PayoutDenominator)
+}
+
+// WinningIndex 返回全额赔付（每份代币可赎回 1 USDC）的结果序号
+// 未结算或按比例拆分赔付（没有单一赢家，如 50/50）时返回 false
+func (r *ConditionResolution) WinningIndex() (int, bool) {
+	if !r.Resolved || r.PayoutDenominator == 0 {
+		return 0, false
+	}
+	for i, numerator := range r.PayoutNumerators {
+		if numerator == r.PayoutDenominator {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Call 表示 Multicall 中的一次只读合约调用
+type Call struct {
+	Target       EthAddress `json:"target"`        // 被调用的合约地址
+	Data         []byte     `json:"data"`          // ABI 编码后的调用数据
+	AllowFailure bool       `json:"allow_failure"` // 为 true 时该调用失败不影响整批，结果为 nil
+}
+
+// TokenHoldings 表示地址持有的条件代币（ERC1155）余额及交易所的操作员授权状态
+type TokenHoldings struct {
+	Balances                map[string]*big.Int `json:"balances"`                   // token ID -> 余额原始整数（6 位小数）
+	ExchangeApproved        bool                `json:"exchange_approved"`          // CTFExchange 是否为授权操作员
+	NegRiskExchangeApproved bool                `json:"neg_risk_exchange_approved"` // NegRiskCTFExchange 是否为授权操作员
+}
+
+// Approved 返回对应交易所是否可以转移该地址的条件代币（SELL 订单成交需要）
+func (h *TokenHoldings) Approved(negRisk bool) bool {
+	if negRisk {
+		return h.NegRiskExchangeApproved
+	}
+	return h.ExchangeApproved
+}
+
+// CollateralHoldings 表示地址的 USDC 余额及对各交易合约的 USDC 授权额度（原始整数，6 位小数）
+type CollateralHoldings struct {
+	Balance                  *big.Int `json:"balance"`
+	ExchangeAllowance        *big.Int `json:"exchange_allowance"`          // 对 CTFExchange 的授权
+	NegRiskExchangeAllowance *big.Int `json:"neg_risk_exchange_allowance"` // 对 NegRiskCTFExchange 的授权
+	NegRiskAdapterAllowance  *big.Int `json:"neg_risk_adapter_allowance"`  // 对 NegRiskAdapter 的授权，链上未部署时为 nil
+}
+
+// Allowance 返回对应交易所的 BUY 订单可用的 USDC 授权额度
+// 负风险市场需要同时授权 NegRiskCTFExchange 和 NegRiskAdapter，取两者中较小的值
+func (h *CollateralHoldings) Allowance(negRisk bool) *big.Int {
+	if !negRisk {
+		return h.ExchangeAllowance
+	}
+	if h.NegRiskAdapterAllowance != nil && h.NegRiskAdapterAllowance.Cmp(h.NegRiskExchangeAllowance) < 0 {
+		return h.NegRiskAdapterAllowance
+	}
+	return h.NegRiskExchangeAllowance
+}
+
+// WalletInfo 描述当前钱包的地址和部署状态，用于排查资金所在地址
+type WalletInfo struct {
+	BaseAddress   EthAddress    `json:"base_address"`   // 私钥对应的 EOA 地址
+	ProxyAddress  EthAddress    `json:"proxy_address"`  // 资金所在地址（Proxy/Safe 钱包地址，EOA 模式下与 BaseAddress 相同）
+	SignatureType SignatureType `json:"signature_type"` // 签名类型
+	ProxyDeployed bool          `json:"proxy_deployed"` // 代理合约是否已部署在链上（EOA 模式下为 false）
+}
+
+// NeedsDeployment 代理钱包模式下代理合约尚未部署时返回 true
+func (w *WalletInfo) NeedsDeployment() bool {
+	return w.SignatureType != EOASignatureType && !w.ProxyDeployed
+}
+
+// CircuitState 熔断器状态
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // 正常，请求直接发送
+	CircuitOpen     CircuitState = "open"      // 熔断中，请求直接返回错误
+	CircuitHalfOpen CircuitState = "half_open" // 冷却结束，放行一次探测请求检查是否恢复
+)
+
+// RelaySubmitResponse 表示 Relayer /submit 接口的响应
+// Relayer 不同版本返回的字段名不一致，UnmarshalJSON 统一处理以下别名：
+//   - 交易哈希：transactionHash、txHash、hash
+//   - 错误信息：error（字符串或 {"message", "code"} 对象）、message、errorMessage、reason
+type RelaySubmitResponse struct {
+	TransactionID   string                 `json:"transactionID"`
+	TransactionHash string                 `json:"transactionHash"`
+	State           string                 `json:"state"`
+	ErrorMessage    string                 `json:"error,omitempty"`
+	ErrorCode       string                 `json:"code,omitempty"`
+	Details         map[string]interface{} `json:"details,omitempty"`
+}
+
+// UnmarshalJSON 实现RelaySubmitResponse的自定义JSON反序列化，兼容各种字段名
+func (r *RelaySubmitResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		TransactionID   string                 `json:"transactionID"`
+		TransactionHash string                 `json:"transactionHash"`
+		TxHash          string                 `json:"txHash"`
+		Hash            string                 `json:"hash"`
+		State           string                 `json:"state"`
+		Error           json.RawMessage        `json:"error"`
+		Message         string                 `json:"message"`
+		ErrorMessage    string                 `json:"errorMessage"`
+		Reason          string                 `json:"reason"`
+		Code            json.RawMessage        `json:"code"`
+		Details         map[string]interface{} `json:"details"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	// code 可能是字符串，也可能是数字；无法识别的 code 忽略，不影响交易哈希和错误信息的解析
+	code, _ := numberOrString(raw.Code)
+
+	*r = RelaySubmitResponse{
+		TransactionID:   raw.TransactionID,
+		TransactionHash: firstNonEmpty(raw.TransactionHash, raw.TxHash, raw.Hash),
+		State:           raw.State,
+		ErrorCode:       code,
+		Details:         raw.Details,
+	}
+
+	// error 可能是字符串，也可能是嵌套对象
+	var errorString string
+	if len(raw.Error) > 0 && json.Unmarshal(raw.Error, &errorString) != nil {
+		var errorObject struct {
+			Message string          `json:"message"`
+			Code    json.RawMessage `json:"code"`
+		}
+		if err := json.Unmarshal(raw.Error, &errorObject); err == nil {
+			errorString = errorObject.Message
+			if code, err := numberOrString(errorObject.Code); err == nil && code != "" {
+				r.ErrorCode = code
+			}
+		}
+	}
+	r.ErrorMessage = firstNonEmpty(errorString, raw.Message, raw.ErrorMessage, raw.Reason)
+	return nil
+}
+
+// Failed 判断 Relayer 是否报告交易失败（state 为 STATE_FAILED 或 FAILED，不区分大小写）
+func (r *RelaySubmitResponse) Failed() bool {
+	return strings.EqualFold(r.State, "STATE_FAILED") || strings.EqualFold(r.State, "FAILED")
+}
+
+// FailureMessage 返回失败原因，包含错误码和 details（按键排序），没有错误信息时返回 "交易提交失败"
+func (r *RelaySubmitResponse) FailureMessage() string {
+	message := r.ErrorMessage
+	if message == "" {
+		message = "交易提交失败"
+	}
+
+	var details []string
+	if r.ErrorCode != "" {
+		details = append(details, fmt.Sprintf("code: %s", r.ErrorCode))
+	}
+	keys := make([]string, 0, len(r.Details))
+	for k := range r.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		details = append(details, fmt.Sprintf("%s: %v", k, r.Details[k]))
+	}
+
+	if len(details) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, strings.Join(details, ", "))
+}
+
+// Relayer 交易状态
+const (
+	RelayStateNew       = "STATE_NEW"       // 已接收，尚未上链
+	RelayStateExecuted  = "STATE_EXECUTED"  // 已发送到链上
+	RelayStateMined     = "STATE_MINED"     // 已打包
+	RelayStateConfirmed = "STATE_CONFIRMED" // 已确认
+	RelayStateFailed    = "STATE_FAILED"    // 执行失败
+	RelayStateInvalid   = "STATE_INVALID"   // 交易无效，不会上链
+)
+
+// RelayStatus 表示 Relayer 中一笔交易的当前状态（GET /transaction）
+// 字段别名与 RelaySubmitResponse 相同；TransactionHash 在交易发送到链上之前可能为空
+type RelayStatus struct {
+	TransactionID   string `json:"transactionID"`
+	TransactionHash string `json:"transactionHash"`
+	State           string `json:"state"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ErrorMessage    string `json:"error,omitempty"`
+	CreatedAt       string `json:"createdAt"`
+	UpdatedAt       string `json:"updatedAt"`
+}
+
+// UnmarshalJSON 实现RelayStatus的自定义JSON反序列化，兼容 RelaySubmitResponse 的字段别名
+func (r *RelayStatus) UnmarshalJSON(data []byte) error {
+	var resp RelaySubmitResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	var raw struct {
+		From      string `json:"from"`
+		To        string `json:"to"`
+		CreatedAt string `json:"createdAt"`
+		UpdatedAt string `json:"updatedAt"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = RelayStatus{
+		TransactionID:   resp.TransactionID,
+		TransactionHash: resp.TransactionHash,
+		State:           resp.State,
+		From:            raw.From,
+		To:              raw.To,
+		ErrorMessage:    resp.ErrorMessage,
+		CreatedAt:       raw.CreatedAt,
+		UpdatedAt:       raw.UpdatedAt,
+	}
+	return nil
+}
+
+// Failed 判断交易是否已失败或无效，失败的交易不会再上链，可以重新提交
+func (r *RelayStatus) Failed() bool {
+	return strings.EqualFold(r.State, RelayStateFailed) || strings.EqualFold(r.State, "FAILED") ||
+		strings.EqualFold(r.State, RelayStateInvalid)
+}
+
+// Pending 判断交易是否仍在处理中（未确认且未失败），此时不应重新提交
+func (r *RelayStatus) Pending() bool {
+	return !r.Failed() && !strings.EqualFold(r.State, RelayStateConfirmed)
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatUSDC(t *testing.T) {
+	large, _ := new(big.Int).SetString("123456789012345678901", 10)
+	cases := []struct {
+		raw  *big.Int
+		want string
+	}{
+		{nil, "0.000000"},
+		{big.NewInt(0), "0.000000"},
+		{big.NewInt(1), "0.000001"},
+		{big.NewInt(1_234_567), "1.234567"},
+		{big.NewInt(-2_500_000), "-2.500000"},
+		{large, "123456789012345.678901"},
+	}
+	for _, tc := range cases {
+		if got := FormatUSDC(tc.raw); got != tc.want {
+			t.Errorf("FormatUSDC(%v) = %q, want %q", tc.raw, got, tc.want)
+		}
+	}
+
+	if got := USDCToFloat(big.NewInt(1_234_567)); got != 1.234567 {
+		t.Errorf("USDCToFloat = %v, want 1.234567", got)
+	}
+	if got := USDCToFloat(nil); got != 0 {
+		t.Errorf("USDCToFloat(nil) = %v, want 0", got)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"math"
 	"math/big"
+	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -14,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/signing"
 	"github.com/polymas/go-polymarket-sdk/types"
@@ -25,11 +29,24 @@ type Client interface {
 	GetPrivateKey() *ecdsa.PrivateKey
 	GetBaseAddress() types.EthAddress
 	GetPolyProxyAddress() (types.EthAddress, error)
+	WalletInfo() (*types.WalletInfo, error)
 	GetChainID() types.ChainID
 	GetSignatureType() types.SignatureType
 	GetPOLBalance() (float64, error)
 	GetUSDCBalance(address types.EthAddress) (float64, error)
+	GetUSDCBalanceRaw(address types.EthAddress) (*big.Int, error)
+	GetUSDCBalanceAllowance(address types.EthAddress) (*types.BalanceAllowance, error)
 	GetTokenBalance(tokenID string, address types.EthAddress) (float64, error)
+	GetTokenHoldings(address types.EthAddress, tokenIDs []string) (*types.TokenHoldings, error)
+	GetCollateralHoldings(address types.EthAddress) (*types.CollateralHoldings, error)
+	WaitForReceipt(ctx context.Context, txHash types.Keccak256) (*types.TransactionReceipt, error)
+	SuggestGasFees(ctx context.Context) (maxFee, maxPriority *big.Int, err error)
+	GetConditionResolution(conditionID types.Keccak256) (*types.ConditionResolution, error)
+	GetConditionResolutions(conditionIDs []types.Keccak256) (map[types.Keccak256]*types.ConditionResolution, error)
+	WatchResolution(ctx context.Context, conditionID types.Keccak256) (<-chan types.ConditionResolution, error)
+	Multicall(calls []types.Call) ([][]byte, error)
+	GetExchangeNonce(negRisk bool) (int64, error)
+	OrderNonce(negRisk bool) (int64, error)
 	Close()
 }
 
@@ -37,8 +54,9 @@ type Client interface {
 // 不允许直接导出，只能通过 NewClient 创建
 type baseClient struct {
 	clients         []*ethclient.Client // 多个 RPC 客户端，支持轮询和故障转移
-	currentIndex    int64              // 当前使用的客户端索引（使用 atomic 操作）
+	currentIndex    int64               // 当前使用的客户端索引（使用 atomic 操作）
 	clientMu        sync.RWMutex        // 保护 clients 切片的并发访问
+	rpcHealth       *rpcHealth          // 各节点健康状态，失败的节点暂时排在最后
 	privateKey      *ecdsa.PrivateKey
 	signer          *signing.Signer
 	signatureType   types.SignatureType
@@ -46,7 +64,148 @@ type baseClient struct {
 	baseAddress     types.EthAddress
 	proxyAddress    types.EthAddress
 	exchangeAddress common.Address
+	contracts       internal.ContractAddresses // 当前链的合约地址
 	exchangeABI     *abi.ABI
+	proxyURL        *url.URL                        // 客户端显式配置的代理（nil 表示使用环境变量）
+	timeouts        types.Timeouts                  // 超时配置（已填充默认值）
+	debugRedaction  bool                            // 调试日志是否屏蔽签名和地址
+	gasMultiplier   uint64                          // Relayer 交易 gas 估算倍数（百分比，130 表示 1.3x）
+	gasExtra        uint64                          // Relayer 交易 gas 估算在倍数之外额外增加的值
+	onRelaySubmit   func(types.RelaySubmitResponse) // Relayer 接受提交后、等待回执前调用，可为 nil
+	orderNonces     orderNonceCache                 // OrderNonce 缓存的交易所 nonce
+}
+
+// ClientOption Web3 客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions Web3 客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+	debugRedaction bool
+	startupCheck   bool
+	rpcURLs        []string
+	gasMultiplier  float64
+	gasExtra       *uint64
+	onRelaySubmit  func(types.RelaySubmitResponse)
+}
+
+// WithProxyURL 设置客户端使用的代理地址（RPC 和 Relayer 请求均生效）
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
+}
+
+// WithTimeouts 设置客户端的超时配置，未设置的字段使用默认值
+// Relayer 请求、nonce 获取和交易确认等待均使用该配置
+func WithTimeouts(timeouts types.Timeouts) ClientOption {
+	return func(opts *clientOptions) {
+		opts.Timeouts = timeouts
+	}
+}
+
+// WithDebugRedaction 设置调试日志是否屏蔽签名和钱包地址（默认开启）
+// 请求体和完整响应只在 LOG_LEVEL=DEBUG 时输出
+func WithDebugRedaction(enabled bool) ClientOption {
+	return func(opts *clientOptions) {
+		opts.debugRedaction = enabled
+	}
+}
+
+// WithStartupCheck 创建客户端时检查 RPC 节点是否可用（eth_chainId）
+// 所有节点都不可达或节点所在链与 chainID 不一致时 NewClient 直接返回错误，
+// 而不是在首次调用（如 RedeemPositions）时才失败。检查使用 Timeouts.HTTP 作为超时
+func WithStartupCheck() ClientOption {
+	return func(opts *clientOptions) {
+		opts.startupCheck = true
+	}
+}
+
+// WithRPCURL 使用自定义 RPC 节点（如 Alchemy、Infura 或自建节点）替代内置的公共节点
+// 可多次调用配置多个节点，按轮询和故障转移使用。支持 http、https、ws、wss 协议；
+// 使用自定义节点时创建客户端会自动检查节点可达且链ID与 chainID 一致
+func WithRPCURL(rpcURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.rpcURLs = append(opts.rpcURLs, rpcURL)
+	}
+}
+
+// WithGasEstimateMultiplier 设置通过 Relayer 提交的交易在 gas 估算值上乘的倍数（默认 1.3）
+// 大批量的负风险赎回等交易估算偏低、执行时 out of gas 时可以调高；小于 1 的值被忽略
+func WithGasEstimateMultiplier(multiplier float64) ClientOption {
+	return func(opts *clientOptions) {
+		if multiplier >= 1 {
+			opts.gasMultiplier = multiplier
+		}
+	}
+}
+
+// WithGasEstimateExtra 设置通过 Relayer 提交的交易在乘以倍数之后额外增加的 gas（默认 100k）
+func WithGasEstimateExtra(extra uint64) ClientOption {
+	return func(opts *clientOptions) {
+		opts.gasExtra = &extra
+	}
+}
+
+// WithRelaySubmittedHook 设置 Relayer 接受免 gas 交易后的回调，在等待回执之前调用
+// 回调收到 Relayer 的提交响应（含 transactionID，可能还没有交易哈希），调用方可以持久化 transactionID，
+// 进程在等待回执期间退出时，重启后用 GetRelayTransaction 查询结果，避免重复提交
+// 回调在提交交易的 goroutine 中同步执行，不应阻塞
+func WithRelaySubmittedHook(hook func(types.RelaySubmitResponse)) ClientOption {
+	return func(opts *clientOptions) {
+		opts.onRelaySubmit = hook
+	}
+}
+
+// applyGasEstimate 设置 gas 估算倍数和额外值，未配置时使用默认值
+func (opts *clientOptions) applyGasEstimate(c *baseClient) {
+	c.gasMultiplier = internal.GasEstimateMultiplier
+	c.gasExtra = internal.GasEstimateExtra
+	if opts.gasMultiplier > 0 {
+		c.gasMultiplier = uint64(math.Round(opts.gasMultiplier * 100))
+	}
+	if opts.gasExtra != nil {
+		c.gasExtra = *opts.gasExtra
+	}
+}
+
+// validateRPCURL 检查 RPC 地址格式
+func validateRPCURL(rpcURL string) error {
+	parsed, err := url.Parse(rpcURL)
+	if err != nil {
+		return err
+	}
+	switch parsed.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return fmt.Errorf("unsupported scheme %q (must be http, https, ws or wss)", parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
+
+// newProxyTransport 创建使用指定代理的 HTTP 传输配置
+func newProxyTransport(proxyURL *url.URL) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(proxyURL)
+	return transport
+}
+
+// dialRPC 连接 RPC 节点，配置了代理时通过代理连接
+func dialRPC(rpcURL string, proxyURL *url.URL) (*ethclient.Client, error) {
+	if proxyURL == nil {
+		return ethclient.Dial(rpcURL)
+	}
+
+	rpcClient, err := rpc.DialOptions(context.Background(), rpcURL,
+		rpc.WithHTTPClient(&http.Client{Transport: newProxyTransport(proxyURL)}))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(rpcClient), nil
 }
 
 // NewClient 创建新的基础Web3客户端
@@ -55,10 +214,35 @@ func NewClient(
 	privateKey string,
 	signatureType types.SignatureType,
 	chainID types.ChainID,
+	options ...ClientOption,
 ) (Client, error) {
-	// 根据 chainID 选择对应的 RPC 节点列表
+	if !signatureType.IsValid() {
+		return nil, fmt.Errorf("invalid signature type: %s", signatureType)
+	}
+
+	opts := internal.ApplyOptions(&clientOptions{debugRedaction: true}, options)
+
+	proxyURL, err := opts.Proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, ok := internal.GetContractAddresses(chainID)
+	if !ok {
+		return nil, fmt.Errorf("unsupported chain ID: %d", chainID)
+	}
+
+	// 根据 chainID 选择对应的 RPC 节点列表，配置了自定义节点时优先使用
 	var rpcURLs []string
-	if chainID == internal.Amoy {
+	if len(opts.rpcURLs) > 0 {
+		for _, rpcURL := range opts.rpcURLs {
+			if err := validateRPCURL(rpcURL); err != nil {
+				return nil, fmt.Errorf("invalid RPC URL %q: %w", rpcURL, err)
+			}
+		}
+		rpcURLs = opts.rpcURLs
+		opts.startupCheck = true
+	} else if chainID == internal.Amoy {
 		rpcURLs = internal.PolygonRPCAmoyList
 	} else {
 		rpcURLs = internal.PolygonRPCMainnetList
@@ -73,7 +257,7 @@ func NewClient(
 	var failedURLs []string
 
 	for _, rpcURL := range rpcURLs {
-		client, err := ethclient.Dial(rpcURL)
+		client, err := dialRPC(rpcURL, proxyURL)
 		if err != nil {
 			failedURLs = append(failedURLs, fmt.Sprintf("%s: %v", rpcURL, err))
 			continue
@@ -117,14 +301,21 @@ func NewClient(
 	web3Client := &baseClient{
 		clients:         clients,
 		currentIndex:    0,
+		rpcHealth:       newRPCHealth(len(clients)),
 		privateKey:      signer.PrivateKey(),
 		signer:          signer,
 		signatureType:   signatureType,
 		chainID:         chainID,
 		baseAddress:     baseAddress,
-		exchangeAddress: common.HexToAddress(internal.PolygonExchange),
+		exchangeAddress: common.HexToAddress(contracts.Exchange),
+		contracts:       contracts,
 		exchangeABI:     exchangeABI,
+		proxyURL:        proxyURL,
+		timeouts:        internal.ResolveTimeouts(opts.Timeouts),
+		debugRedaction:  opts.debugRedaction,
+		onRelaySubmit:   opts.onRelaySubmit,
 	}
+	opts.applyGasEstimate(web3Client)
 
 	// Initialize proxy address (will be lazy-loaded on first call)
 	// For non-proxy types, proxy address equals base address
@@ -132,9 +323,49 @@ func NewClient(
 		web3Client.proxyAddress = baseAddress
 	}
 
+	if opts.startupCheck {
+		ctx, cancel := context.WithTimeout(context.Background(), web3Client.timeouts.HTTP)
+		defer cancel()
+		if err := web3Client.checkRPC(ctx); err != nil {
+			web3Client.Close()
+			return nil, fmt.Errorf("RPC startup check failed: %w", err)
+		}
+	}
+
 	return web3Client, nil
 }
 
+// checkRPC 依次向各节点请求 eth_chainId，任一节点可用且链ID正确即通过
+// 链ID不一致说明 RPC 配置错误，直接返回错误而不尝试其他节点
+func (c *baseClient) checkRPC(ctx context.Context) error {
+	c.clientMu.RLock()
+	clients := c.clients
+	c.clientMu.RUnlock()
+
+	if len(clients) == 0 {
+		return fmt.Errorf("no RPC clients available")
+	}
+
+	var lastErr error
+	for _, client := range clients {
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return validateRPCChainID(c.chainID, chainID)
+	}
+	return fmt.Errorf("all RPC nodes unreachable, last error: %w", lastErr)
+}
+
+// validateRPCChainID 检查 RPC 节点返回的链ID与客户端配置是否一致
+func validateRPCChainID(expected types.ChainID, actual *big.Int) error {
+	if actual == nil || !actual.IsInt64() || actual.Int64() != int64(expected) {
+		return fmt.Errorf("RPC node is on chain ID %v, expected %d", actual, expected)
+	}
+	return nil
+}
+
 // isRateLimitError 检查错误是否为 429 rate limit 错误
 func isRateLimitError(err error) bool {
 	if err == nil {
@@ -157,7 +388,12 @@ func isRetryableError(err error) bool {
 		strings.Contains(errStr, "timeout") ||
 		strings.Contains(errStr, "connection") ||
 		strings.Contains(errStr, "network") ||
-		strings.Contains(errStr, "dial")
+		strings.Contains(errStr, "dial") ||
+		strings.Contains(errStr, "deadline exceeded") ||
+		strings.Contains(errStr, "eof") ||
+		strings.Contains(errStr, "502") ||
+		strings.Contains(errStr, "503") ||
+		strings.Contains(errStr, "504")
 }
 
 // getNextClientIndex 获取下一个客户端索引（轮询）
@@ -185,16 +421,15 @@ func (c *baseClient) callContractWithRetry(
 		return nil, fmt.Errorf("no RPC clients available")
 	}
 
-	// 从当前索引开始，尝试所有节点
-	startIndex := c.getNextClientIndex()
+	// 健康节点按轮询顺序优先，最近失败的节点放在最后
 	var lastErr error
 
-	for i := 0; i < len(clients); i++ {
-		index := (startIndex + i) % len(clients)
+	for _, index := range c.rpcOrder(len(clients)) {
 		client := clients[index]
 
 		result, err := client.CallContract(ctx, msg, blockNumber)
 		if err == nil {
+			c.rpcHealth.recordSuccess(index)
 			return result, nil
 		}
 
@@ -202,6 +437,7 @@ func (c *baseClient) callContractWithRetry(
 
 		// 如果是 429 错误或可重试错误，继续尝试下一个节点
 		if isRetryableError(err) {
+			c.rpcHealth.recordFailure(index)
 			continue
 		}
 
@@ -227,16 +463,15 @@ func (c *baseClient) balanceAtWithRetry(
 		return nil, fmt.Errorf("no RPC clients available")
 	}
 
-	// 从当前索引开始，尝试所有节点
-	startIndex := c.getNextClientIndex()
+	// 健康节点按轮询顺序优先，最近失败的节点放在最后
 	var lastErr error
 
-	for i := 0; i < len(clients); i++ {
-		index := (startIndex + i) % len(clients)
+	for _, index := range c.rpcOrder(len(clients)) {
 		client := clients[index]
 
 		balance, err := client.BalanceAt(ctx, account, blockNumber)
 		if err == nil {
+			c.rpcHealth.recordSuccess(index)
 			return balance, nil
 		}
 
@@ -244,6 +479,49 @@ func (c *baseClient) balanceAtWithRetry(
 
 		// 如果是 429 错误或可重试错误，继续尝试下一个节点
 		if isRetryableError(err) {
+			c.rpcHealth.recordFailure(index)
+			continue
+		}
+
+		// 对于其他错误，直接返回
+		return nil, err
+	}
+
+	// 所有节点都失败了，返回最后一个错误
+	return nil, fmt.Errorf("all RPC nodes failed, last error: %w", lastErr)
+}
+
+// codeAtWithRetry 带重试的合约代码查询，支持多节点轮询和故障转移
+func (c *baseClient) codeAtWithRetry(
+	ctx context.Context,
+	account common.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	c.clientMu.RLock()
+	clients := c.clients
+	c.clientMu.RUnlock()
+
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("no RPC clients available")
+	}
+
+	// 健康节点按轮询顺序优先，最近失败的节点放在最后
+	var lastErr error
+
+	for _, index := range c.rpcOrder(len(clients)) {
+		client := clients[index]
+
+		code, err := client.CodeAt(ctx, account, blockNumber)
+		if err == nil {
+			c.rpcHealth.recordSuccess(index)
+			return code, nil
+		}
+
+		lastErr = err
+
+		// 如果是 429 错误或可重试错误，继续尝试下一个节点
+		if isRetryableError(err) {
+			c.rpcHealth.recordFailure(index)
 			continue
 		}
 
@@ -268,16 +546,15 @@ func (c *baseClient) estimateGasWithRetry(
 		return 0, fmt.Errorf("no RPC clients available")
 	}
 
-	// 从当前索引开始，尝试所有节点
-	startIndex := c.getNextClientIndex()
+	// 健康节点按轮询顺序优先，最近失败的节点放在最后
 	var lastErr error
 
-	for i := 0; i < len(clients); i++ {
-		index := (startIndex + i) % len(clients)
+	for _, index := range c.rpcOrder(len(clients)) {
 		client := clients[index]
 
 		gas, err := client.EstimateGas(ctx, msg)
 		if err == nil {
+			c.rpcHealth.recordSuccess(index)
 			return gas, nil
 		}
 
@@ -285,6 +562,7 @@ func (c *baseClient) estimateGasWithRetry(
 
 		// 如果是 429 错误或可重试错误，继续尝试下一个节点
 		if isRetryableError(err) {
+			c.rpcHealth.recordFailure(index)
 			continue
 		}
 
@@ -296,6 +574,48 @@ func (c *baseClient) estimateGasWithRetry(
 	return 0, fmt.Errorf("all RPC nodes failed, last error: %w", lastErr)
 }
 
+// feeHistoryWithRetry 带重试的 eth_feeHistory 查询，支持多节点轮询和故障转移
+func (c *baseClient) feeHistoryWithRetry(
+	ctx context.Context,
+	blockCount uint64,
+	rewardPercentiles []float64,
+) (*ethereum.FeeHistory, error) {
+	c.clientMu.RLock()
+	clients := c.clients
+	c.clientMu.RUnlock()
+
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("no RPC clients available")
+	}
+
+	// 健康节点按轮询顺序优先，最近失败的节点放在最后
+	var lastErr error
+
+	for _, index := range c.rpcOrder(len(clients)) {
+		client := clients[index]
+
+		history, err := client.FeeHistory(ctx, blockCount, nil, rewardPercentiles)
+		if err == nil {
+			c.rpcHealth.recordSuccess(index)
+			return history, nil
+		}
+
+		lastErr = err
+
+		// 如果是 429 错误或可重试错误，继续尝试下一个节点
+		if isRetryableError(err) {
+			c.rpcHealth.recordFailure(index)
+			continue
+		}
+
+		// 对于其他错误，直接返回
+		return nil, err
+	}
+
+	// 所有节点都失败了，返回最后一个错误
+	return nil, fmt.Errorf("all RPC nodes failed, last error: %w", lastErr)
+}
+
 // transactionReceiptWithRetry 带重试的交易回执查询，支持多节点轮询和故障转移
 func (c *baseClient) transactionReceiptWithRetry(
 	ctx context.Context,
@@ -309,16 +629,15 @@ func (c *baseClient) transactionReceiptWithRetry(
 		return nil, fmt.Errorf("no RPC clients available")
 	}
 
-	// 从当前索引开始，尝试所有节点
-	startIndex := c.getNextClientIndex()
+	// 健康节点按轮询顺序优先，最近失败的节点放在最后
 	var lastErr error
 
-	for i := 0; i < len(clients); i++ {
-		index := (startIndex + i) % len(clients)
+	for _, index := range c.rpcOrder(len(clients)) {
 		client := clients[index]
 
 		receipt, err := client.TransactionReceipt(ctx, txHash)
 		if err == nil {
+			c.rpcHealth.recordSuccess(index)
 			return receipt, nil
 		}
 
@@ -326,6 +645,7 @@ func (c *baseClient) transactionReceiptWithRetry(
 
 		// 如果是 429 错误或可重试错误，继续尝试下一个节点
 		if isRetryableError(err) {
+			c.rpcHealth.recordFailure(index)
 			continue
 		}
 
@@ -350,16 +670,15 @@ func (c *baseClient) transactionByHashWithRetry(
 		return nil, false, fmt.Errorf("no RPC clients available")
 	}
 
-	// 从当前索引开始，尝试所有节点
-	startIndex := c.getNextClientIndex()
+	// 健康节点按轮询顺序优先，最近失败的节点放在最后
 	var lastErr error
 
-	for i := 0; i < len(clients); i++ {
-		index := (startIndex + i) % len(clients)
+	for _, index := range c.rpcOrder(len(clients)) {
 		client := clients[index]
 
 		tx, pending, err := client.TransactionByHash(ctx, txHash)
 		if err == nil {
+			c.rpcHealth.recordSuccess(index)
 			return tx, pending, nil
 		}
 
@@ -367,6 +686,7 @@ func (c *baseClient) transactionByHashWithRetry(
 
 		// 如果是 429 错误或可重试错误，继续尝试下一个节点
 		if isRetryableError(err) {
+			c.rpcHealth.recordFailure(index)
 			continue
 		}
 
@@ -396,6 +716,14 @@ func (c *baseClient) GetChainID() types.ChainID {
 	return c.chainID
 }
 
+// contractAddress 返回当前链上指定合约的地址，该链未配置此合约时返回错误，避免把交易发到其他链的地址
+func (c *baseClient) contractAddress(name, address string) (common.Address, error) {
+	if address == "" {
+		return common.Address{}, fmt.Errorf("%s contract is not configured for chain %d", name, c.chainID)
+	}
+	return common.HexToAddress(address), nil
+}
+
 // GetSignatureType 返回签名类型
 func (c *baseClient) GetSignatureType() types.SignatureType {
 	return c.signatureType
@@ -418,9 +746,11 @@ func (c *baseClient) GetPolyProxyAddress() (types.EthAddress, error) {
 		proxyAddr, err = c.getPolyProxyWalletAddress(c.baseAddress)
 	case types.SafeSignatureType:
 		proxyAddr, err = c.getSafeProxyAddress(c.baseAddress)
-	default:
-		// For EOA or unknown types, return the base address
+	case types.EOASignatureType:
+		// EOA 钱包没有代理地址，直接使用 base address
 		proxyAddr = c.baseAddress
+	default:
+		return "", fmt.Errorf("invalid signature type: %s", c.signatureType)
 	}
 
 	if err != nil {
@@ -432,6 +762,32 @@ func (c *baseClient) GetPolyProxyAddress() (types.EthAddress, error) {
 	return proxyAddr, nil
 }
 
+// WalletInfo 返回基础地址、代理地址、签名类型以及代理合约是否已部署
+// 用于排查“资金在哪个地址、钱包是否已初始化”：EOA 模式下资金在基础地址，
+// Proxy/Safe 模式下资金在代理地址，代理合约在首次交易前可能尚未部署
+func (c *baseClient) WalletInfo() (*types.WalletInfo, error) {
+	proxyAddr, err := c.GetPolyProxyAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proxy address: %w", err)
+	}
+
+	info := &types.WalletInfo{
+		BaseAddress:   c.baseAddress,
+		ProxyAddress:  proxyAddr,
+		SignatureType: c.signatureType,
+	}
+	if c.signatureType == types.EOASignatureType {
+		return info, nil
+	}
+
+	code, err := c.codeAtWithRetry(context.Background(), common.HexToAddress(string(proxyAddr)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proxy code: %w", err)
+	}
+	info.ProxyDeployed = len(code) > 0
+	return info, nil
+}
+
 // getPolyProxyWalletAddress 获取给定地址的Polymarket代理钱包地址
 func (c *baseClient) getPolyProxyWalletAddress(address types.EthAddress) (types.EthAddress, error) {
 	// Call getPolyProxyWalletAddress on the exchange contract
@@ -514,23 +870,34 @@ func (c *baseClient) GetPOLBalance() (float64, error) {
 	return result, nil
 }
 
-// GetUSDCBalance 获取地址的USDC余额
+// GetUSDCBalance 获取地址的USDC余额（单位为 USDC，大额时 float64 可能损失精度）
+// 需要精确金额时使用 GetUSDCBalanceRaw
 func (c *baseClient) GetUSDCBalance(address types.EthAddress) (float64, error) {
-	// 获取 USDC 合约地址（PolygonCollateral）
-	usdcAddr := common.HexToAddress(internal.PolygonCollateral)
+	balance, err := c.GetUSDCBalanceRaw(address)
+	if err != nil {
+		return 0, err
+	}
+	return types.USDCToFloat(balance), nil
+}
+
+// GetUSDCBalanceRaw 获取地址的链上 USDC 余额原始整数
+// USDC 有 types.USDCDecimals（6）位小数，返回值为余额乘以 10^6，可用 types.FormatUSDC 精确格式化
+func (c *baseClient) GetUSDCBalanceRaw(address types.EthAddress) (*big.Int, error) {
+	// 获取当前链的 USDC 合约地址
+	usdcAddr := common.HexToAddress(c.contracts.Collateral)
 
 	// 创建 ERC20 标准的 balanceOf(address) ABI
 	balanceOfABI := `[{"constant":true,"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"}]`
 	parsedABI, err := abi.JSON(strings.NewReader(balanceOfABI))
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse ABI: %w", err)
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
 	}
 
 	// 打包函数调用
 	addr := common.HexToAddress(string(address))
 	packed, err := parsedABI.Pack("balanceOf", addr)
 	if err != nil {
-		return 0, fmt.Errorf("failed to pack function call: %w", err)
+		return nil, fmt.Errorf("failed to pack function call: %w", err)
 	}
 
 	// 调用合约
@@ -541,20 +908,34 @@ func (c *baseClient) GetUSDCBalance(address types.EthAddress) (float64, error) {
 
 	result, err := c.callContractWithRetry(context.Background(), callMsg, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to call contract: %w", err)
+		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
 	// 解包结果
 	var balance *big.Int
 	err = parsedABI.UnpackIntoInterface(&balance, "balanceOf", result)
 	if err != nil {
-		return 0, fmt.Errorf("failed to unpack result: %w", err)
+		return nil, fmt.Errorf("failed to unpack result: %w", err)
+	}
+	if balance == nil {
+		balance = new(big.Int)
 	}
+	return balance, nil
+}
 
-	// USDC 有 6 位小数，所以除以 1e6
-	balanceFloat := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(1e6))
-	resultFloat, _ := balanceFloat.Float64()
-	return resultFloat, nil
+// GetUSDCBalanceAllowance 获取任意地址的 USDC 余额及授权额度，结果以 USDC 为单位（已除以 1e6）
+// Allowance 为对 CTFExchange 的授权，NegRiskAllowance 为负风险市场可用的授权（见 types.CollateralHoldings.Allowance）；
+// 需要原始整数时使用 GetCollateralHoldings
+func (c *baseClient) GetUSDCBalanceAllowance(address types.EthAddress) (*types.BalanceAllowance, error) {
+	holdings, err := c.GetCollateralHoldings(address)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BalanceAllowance{
+		Balance:          types.USDCToFloat(holdings.Balance),
+		Allowance:        types.USDCToFloat(holdings.Allowance(false)),
+		NegRiskAllowance: types.USDCToFloat(holdings.Allowance(true)),
+	}, nil
 }
 
 // GetTokenBalance 获取地址的代币余额
@@ -566,7 +947,7 @@ func (c *baseClient) GetTokenBalance(tokenID string, address types.EthAddress) (
 	}
 
 	// Get ConditionalTokens contract address
-	conditionalTokensAddr := common.HexToAddress(internal.PolygonConditionalTokens)
+	conditionalTokensAddr := common.HexToAddress(c.contracts.ConditionalTokens)
 
 	// Create ABI for balanceOf(address account, uint256 id)
 	balanceOfABI := `[{"constant":true,"inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"}]`
@@ -1,8 +1,22 @@
 package web3
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/test"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
@@ -82,6 +96,28 @@ func TestGetUSDCBalance(t *testing.T) {
 	})
 }
 
+func TestGetUSDCBalanceAllowance(t *testing.T) {
+	client := newTestWeb3Client(t)
+	config := test.LoadTestConfig()
+
+	t.Run("Basic", func(t *testing.T) {
+		result, err := client.GetUSDCBalanceAllowance(test.GetTestUserAddress(config))
+		if err != nil {
+			t.Fatalf("GetUSDCBalanceAllowance failed: %v", err)
+		}
+		if result.Balance < 0 || result.Allowance < 0 {
+			t.Errorf("Expected non-negative balance and allowance, got %+v", result)
+		}
+		t.Logf("GetUSDCBalanceAllowance returned: %+v", result)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		if _, err := client.GetUSDCBalanceAllowance(types.EthAddress("invalid-address")); err == nil {
+			t.Error("Expected error for invalid address")
+		}
+	})
+}
+
 func TestGetTokenBalance(t *testing.T) {
 	client := newTestWeb3Client(t)
 	config := test.LoadTestConfig()
@@ -148,6 +184,48 @@ func TestGetPolyProxyAddress(t *testing.T) {
 	})
 }
 
+func TestWalletInfo(t *testing.T) {
+	// EOA 钱包无需链上查询：资金在基础地址，不存在代理合约
+	t.Run("EOA", func(t *testing.T) {
+		client, err := NewClient("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", types.EOASignatureType, types.Polygon)
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		defer client.Close()
+
+		info, err := client.WalletInfo()
+		if err != nil {
+			t.Fatalf("WalletInfo failed: %v", err)
+		}
+		if info.BaseAddress != client.GetBaseAddress() || info.ProxyAddress != info.BaseAddress {
+			t.Errorf("Expected proxy address to equal base address, got %+v", info)
+		}
+		if info.SignatureType != types.EOASignatureType || info.ProxyDeployed || info.NeedsDeployment() {
+			t.Errorf("Unexpected EOA wallet info: %+v", info)
+		}
+	})
+
+	t.Run("NeedsDeployment", func(t *testing.T) {
+		info := &types.WalletInfo{SignatureType: types.ProxySignatureType}
+		if !info.NeedsDeployment() {
+			t.Error("Expected undeployed proxy wallet to need deployment")
+		}
+		info.ProxyDeployed = true
+		if info.NeedsDeployment() {
+			t.Error("Expected deployed proxy wallet not to need deployment")
+		}
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		client := newTestWeb3Client(t)
+		info, err := client.WalletInfo()
+		if err != nil {
+			t.Fatalf("WalletInfo failed: %v", err)
+		}
+		t.Logf("WalletInfo returned: %+v", info)
+	})
+}
+
 func TestGetChainID(t *testing.T) {
 	client := newTestWeb3Client(t)
 
@@ -203,3 +281,801 @@ func TestClose(t *testing.T) {
 		}
 	})
 }
+
+func TestWithProxyURL(t *testing.T) {
+	// 无效代理地址应在构造时返回错误（无需私钥和网络）
+	t.Run("InvalidScheme", func(t *testing.T) {
+		_, err := NewClient("", types.EOASignatureType, types.Polygon, WithProxyURL("ftp://127.0.0.1:1080"))
+		if err == nil {
+			t.Error("Expected error for unsupported proxy scheme")
+		}
+	})
+
+	t.Run("EmptyHost", func(t *testing.T) {
+		_, err := NewClient("", types.EOASignatureType, types.Polygon, WithProxyURL("socks5://"))
+		if err == nil {
+			t.Error("Expected error for proxy URL without host")
+		}
+	})
+}
+
+func TestInvalidSignatureType(t *testing.T) {
+	// 超出范围的签名类型应在构造时返回错误，而不是静默回退为 EOA
+	t.Run("NewClient", func(t *testing.T) {
+		_, err := NewClient("", types.SignatureType(3), types.Polygon)
+		if err == nil {
+			t.Fatal("Expected error for invalid signature type")
+		}
+		if !strings.Contains(err.Error(), "SignatureType(3)") {
+			t.Errorf("Expected error to mention the invalid value, got: %v", err)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		cases := map[types.SignatureType]string{
+			types.EOASignatureType:   "EOA",
+			types.ProxySignatureType: "POLY_PROXY",
+			types.SafeSignatureType:  "POLY_GNOSIS_SAFE",
+			types.SignatureType(-1):  "SignatureType(-1)",
+		}
+		for sigType, expected := range cases {
+			if sigType.String() != expected {
+				t.Errorf("Expected %q, got %q", expected, sigType.String())
+			}
+			if sigType.IsValid() != (sigType >= 0 && sigType <= 2) {
+				t.Errorf("Unexpected IsValid() for %s", sigType)
+			}
+		}
+	})
+}
+
+func TestWithTimeouts(t *testing.T) {
+	// 公开的测试私钥（hardhat 默认账户），不会发起网络请求
+	const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+	t.Run("Defaults", func(t *testing.T) {
+		client, err := NewClient(testPrivateKey, types.EOASignatureType, types.Polygon)
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		timeouts := client.(*baseClient).timeouts
+		if timeouts.HTTPLong != internal.HTTPClientLongTimeout || timeouts.TransactionWait != internal.TransactionWaitTimeout {
+			t.Errorf("Expected default timeouts, got %+v", timeouts)
+		}
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		client, err := NewClient(testPrivateKey, types.EOASignatureType, types.Polygon,
+			WithTimeouts(types.Timeouts{TransactionWait: time.Minute, TransactionDelay: 500 * time.Millisecond}))
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		timeouts := client.(*baseClient).timeouts
+		if timeouts.TransactionWait != time.Minute || timeouts.TransactionDelay != 500*time.Millisecond {
+			t.Errorf("Expected custom timeouts to be applied, got %+v", timeouts)
+		}
+		// 未设置的字段使用默认值
+		if timeouts.RelayNonce != internal.RelayNonceTimeout {
+			t.Errorf("Expected default RelayNonce timeout, got %v", timeouts.RelayNonce)
+		}
+	})
+}
+
+func TestWithStartupCheck(t *testing.T) {
+	t.Run("Option", func(t *testing.T) {
+		opts := &clientOptions{}
+		WithStartupCheck()(opts)
+		if !opts.startupCheck {
+			t.Error("Expected startup check to be enabled")
+		}
+	})
+
+	t.Run("ValidateChainID", func(t *testing.T) {
+		if err := validateRPCChainID(types.Polygon, big.NewInt(137)); err != nil {
+			t.Errorf("Expected matching chain ID to pass, got %v", err)
+		}
+		err := validateRPCChainID(types.Polygon, big.NewInt(80002))
+		if err == nil || !strings.Contains(err.Error(), "80002") || !strings.Contains(err.Error(), "137") {
+			t.Errorf("Expected clear chain mismatch error, got %v", err)
+		}
+		if err := validateRPCChainID(types.Polygon, nil); err == nil {
+			t.Error("Expected error for missing chain ID")
+		}
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		config := test.LoadTestConfig()
+		if config.PrivateKey == "" {
+			t.Skip("Skipping test: POLY_PRIVATE_KEY not set")
+		}
+		client, err := NewClient(config.PrivateKey, config.SignatureType, config.ChainID, WithStartupCheck())
+		if err != nil {
+			t.Fatalf("NewClient with startup check failed: %v", err)
+		}
+		client.Close()
+	})
+}
+
+func TestWithRPCURL(t *testing.T) {
+	const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+	t.Run("Option", func(t *testing.T) {
+		opts := &clientOptions{}
+		WithRPCURL("https://rpc-a.example.com")(opts)
+		WithRPCURL("wss://rpc-b.example.com")(opts)
+		if len(opts.rpcURLs) != 2 || opts.rpcURLs[1] != "wss://rpc-b.example.com" {
+			t.Errorf("Expected 2 RPC URLs, got %v", opts.rpcURLs)
+		}
+	})
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		for _, rpcURL := range []string{"ftp://rpc.example.com", "polygon-rpc.com", "https://", "://bad"} {
+			_, err := NewClient(testPrivateKey, types.EOASignatureType, types.Polygon, WithRPCURL(rpcURL))
+			if err == nil || !strings.Contains(err.Error(), "invalid RPC URL") {
+				t.Errorf("Expected invalid RPC URL error for %q, got %v", rpcURL, err)
+			}
+		}
+	})
+
+	t.Run("ValidURL", func(t *testing.T) {
+		for _, rpcURL := range []string{"https://polygon-rpc.com", "http://localhost:8545", "wss://polygon.example.com/ws"} {
+			if err := validateRPCURL(rpcURL); err != nil {
+				t.Errorf("Expected %q to be valid, got %v", rpcURL, err)
+			}
+		}
+	})
+}
+
+func TestRPCHealth(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	health := newRPCHealth(3)
+	health.now = func() time.Time { return now }
+
+	equal := func(a, b []int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if order := health.order(1, 3); !equal(order, []int{1, 2, 0}) {
+		t.Errorf("Expected round-robin order [1 2 0], got %v", order)
+	}
+
+	// 节点 1 失败后排到最后
+	health.recordFailure(1)
+	if order := health.order(1, 3); !equal(order, []int{2, 0, 1}) {
+		t.Errorf("Expected unhealthy node last [2 0 1], got %v", order)
+	}
+
+	// 冷却时间过后恢复参与轮询
+	now = now.Add(internal.RPCUnhealthyCooldown + time.Second)
+	if order := health.order(1, 3); !equal(order, []int{1, 2, 0}) {
+		t.Errorf("Expected node to recover after cooldown, got %v", order)
+	}
+
+	// 调用成功立即恢复
+	health.recordFailure(0)
+	health.recordSuccess(0)
+	if order := health.order(0, 3); !equal(order, []int{0, 1, 2}) {
+		t.Errorf("Expected node to recover after success, got %v", order)
+	}
+
+	// 所有节点都不健康时仍然全部尝试
+	health.recordFailure(0)
+	health.recordFailure(1)
+	health.recordFailure(2)
+	if order := health.order(2, 3); !equal(order, []int{2, 0, 1}) {
+		t.Errorf("Expected all nodes to be tried, got %v", order)
+	}
+
+	// 未初始化健康状态时退化为普通轮询
+	var nilHealth *rpcHealth
+	nilHealth.recordFailure(0)
+	if order := nilHealth.order(2, 3); !equal(order, []int{2, 0, 1}) {
+		t.Errorf("Expected plain round-robin without health tracking, got %v", order)
+	}
+}
+
+func TestWaitForReceipt(t *testing.T) {
+	// 公开的测试私钥（hardhat 默认账户）
+	const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+	client, err := NewClient(testPrivateKey, types.EOASignatureType, types.Polygon)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	// 无效交易哈希应在请求前返回错误
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := client.WaitForReceipt(context.Background(), types.Keccak256("0x1234"))
+		if err == nil {
+			t.Error("Expected error for invalid transaction hash")
+		}
+	})
+
+	// 已取消的 ctx 应立即返回错误
+	t.Run("CanceledContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		txHash := types.Keccak256("0x" + strings.Repeat("0", 64))
+		receipt, err := client.WaitForReceipt(ctx, txHash)
+		if err == nil {
+			t.Fatalf("Expected error for canceled context, got receipt %+v", receipt)
+		}
+		t.Logf("WaitForReceipt with canceled context returned error (expected): %v", err)
+	})
+}
+
+func TestSuggestFeesFromHistory(t *testing.T) {
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000)) }
+
+	// 优先费取中位数，maxFee = baseFee * 2 + maxPriority
+	t.Run("Median", func(t *testing.T) {
+		history := &ethereum.FeeHistory{
+			BaseFee: []*big.Int{gwei(90), gwei(100)},
+			Reward:  [][]*big.Int{{gwei(40)}, {gwei(60)}, {gwei(50)}},
+		}
+		maxFee, maxPriority, err := suggestFeesFromHistory(history)
+		if err != nil {
+			t.Fatalf("suggestFeesFromHistory failed: %v", err)
+		}
+		if maxPriority.Cmp(gwei(50)) != 0 {
+			t.Errorf("Expected maxPriority 50 gwei, got %s", maxPriority)
+		}
+		if maxFee.Cmp(gwei(250)) != 0 {
+			t.Errorf("Expected maxFee 250 gwei, got %s", maxFee)
+		}
+	})
+
+	// 优先费不低于 Polygon 最低要求
+	t.Run("MinPriorityFee", func(t *testing.T) {
+		history := &ethereum.FeeHistory{
+			BaseFee: []*big.Int{gwei(1)},
+			Reward:  [][]*big.Int{{gwei(1)}},
+		}
+		_, maxPriority, err := suggestFeesFromHistory(history)
+		if err != nil {
+			t.Fatalf("suggestFeesFromHistory failed: %v", err)
+		}
+		if maxPriority.Cmp(big.NewInt(internal.MinPriorityFeeWei)) != 0 {
+			t.Errorf("Expected minimum priority fee, got %s", maxPriority)
+		}
+	})
+
+	t.Run("EmptyHistory", func(t *testing.T) {
+		if _, _, err := suggestFeesFromHistory(&ethereum.FeeHistory{}); err == nil {
+			t.Error("Expected error for empty fee history")
+		}
+	})
+}
+
+func TestWatchResolution(t *testing.T) {
+	// 公开的测试私钥（hardhat 默认账户）
+	const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+	client, err := NewClient(testPrivateKey, types.EOASignatureType, types.Polygon)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("InvalidConditionID", func(t *testing.T) {
+		if _, err := client.WatchResolution(context.Background(), types.Keccak256("0x1234")); err == nil {
+			t.Error("Expected error for invalid condition ID")
+		}
+	})
+
+	// ctx 结束后通道关闭且不发送结果
+	t.Run("CanceledContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		ch, err := client.WatchResolution(ctx, types.Keccak256("0x"+strings.Repeat("0", 64)))
+		if err != nil {
+			t.Fatalf("WatchResolution failed: %v", err)
+		}
+		select {
+		case resolution, ok := <-ch:
+			if ok {
+				t.Errorf("Expected channel to close without a value, got %+v", resolution)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected channel to close after context cancellation")
+		}
+	})
+
+	t.Run("BatchInvalidConditionID", func(t *testing.T) {
+		if _, err := client.GetConditionResolutions([]types.Keccak256{types.Keccak256("0x" + strings.Repeat("0", 64)), "0x1234"}); err == nil {
+			t.Error("Expected error for invalid condition ID in batch")
+		}
+	})
+
+	// 没有条件时不发送 RPC 请求
+	t.Run("BatchEmpty", func(t *testing.T) {
+		resolutions, err := client.GetConditionResolutions(nil)
+		if err != nil || len(resolutions) != 0 {
+			t.Errorf("Expected empty result, got %v (err=%v)", resolutions, err)
+		}
+	})
+
+	t.Run("Payout", func(t *testing.T) {
+		resolution := types.ConditionResolution{Resolved: true, PayoutNumerators: []uint64{0, 1}, PayoutDenominator: 1}
+		if resolution.Payout(0) != 0 || resolution.Payout(1) != 1 || resolution.Payout(2) != 0 {
+			t.Errorf("Unexpected payouts: %v, %v, %v", resolution.Payout(0), resolution.Payout(1), resolution.Payout(2))
+		}
+	})
+}
+
+func TestChainContracts(t *testing.T) {
+	// 公开的测试私钥（hardhat 默认账户）
+	const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+	chains := []struct {
+		name       string
+		chainID    types.ChainID
+		collateral string
+		ctf        string
+	}{
+		{"Polygon", types.Polygon, internal.PolygonCollateral, internal.PolygonConditionalTokens},
+		{"Amoy", types.Amoy, internal.AmoyCollateral, internal.AmoyConditionalTokens},
+	}
+
+	for _, chain := range chains {
+		t.Run(chain.name, func(t *testing.T) {
+			client, err := NewGaslessClient(testPrivateKey, types.ProxySignatureType, chain.chainID, nil)
+			if err != nil {
+				t.Fatalf("NewGaslessClient failed: %v", err)
+			}
+			defer client.Close()
+
+			if !strings.EqualFold(client.contracts.Collateral, chain.collateral) {
+				t.Errorf("Expected collateral %s, got %s", chain.collateral, client.contracts.Collateral)
+			}
+			if !strings.EqualFold(client.contracts.ConditionalTokens, chain.ctf) {
+				t.Errorf("Expected conditional tokens %s, got %s", chain.ctf, client.contracts.ConditionalTokens)
+			}
+
+			// redeemPositions 的第一个参数即抵押品地址
+			data, err := client.encodeRedeem(types.Keccak256("0x" + strings.Repeat("1", 64)))
+			if err != nil {
+				t.Fatalf("encodeRedeem failed: %v", err)
+			}
+			if !bytes.Contains(data, common.HexToAddress(chain.collateral).Bytes()) {
+				t.Errorf("Expected redeem calldata to reference collateral %s", chain.collateral)
+			}
+		})
+	}
+
+	t.Run("MissingNegRiskAdapter", func(t *testing.T) {
+		// Amoy 未配置 NegRiskAdapter，neg risk 操作应直接失败，而不是把交易发到主网地址
+		client, err := NewGaslessClient(testPrivateKey, types.ProxySignatureType, types.Amoy, nil)
+		if err != nil {
+			t.Fatalf("NewGaslessClient failed: %v", err)
+		}
+		defer client.Close()
+
+		_, err = client.MergeTokens(types.Keccak256("0x"+strings.Repeat("1", 64)), 1, true)
+		if err == nil || !strings.Contains(err.Error(), "NegRiskAdapter") {
+			t.Errorf("Expected NegRiskAdapter error, got %v", err)
+		}
+	})
+
+	t.Run("UnsupportedChain", func(t *testing.T) {
+		if _, err := NewClient(testPrivateKey, types.EOASignatureType, types.ChainID(1)); err == nil {
+			t.Error("Expected error for unsupported chain ID")
+		}
+	})
+}
+
+func TestMulticallEncoding(t *testing.T) {
+	parsedABI, err := getMulticall3ABI()
+	if err != nil {
+		t.Fatalf("getMulticall3ABI failed: %v", err)
+	}
+
+	t.Run("Pack", func(t *testing.T) {
+		calls := []types.Call{
+			{Target: types.EthAddress(internal.PolygonCollateral), Data: []byte{0x70, 0xa0, 0x82, 0x31}},
+			{Target: types.EthAddress(internal.PolygonConditionalTokens), Data: []byte{0x00, 0xfd, 0xd5, 0x8e}, AllowFailure: true},
+		}
+		packed, err := packMulticall(parsedABI, calls)
+		if err != nil {
+			t.Fatalf("packMulticall failed: %v", err)
+		}
+		if !bytes.Equal(packed[:4], parsedABI.Methods["aggregate3"].ID) {
+			t.Errorf("Expected aggregate3 selector, got %x", packed[:4])
+		}
+		if !bytes.Contains(packed, common.HexToAddress(internal.PolygonCollateral).Bytes()) {
+			t.Error("Expected packed data to contain call target")
+		}
+	})
+
+	t.Run("InvalidTarget", func(t *testing.T) {
+		if _, err := packMulticall(parsedABI, []types.Call{{Target: "0x1234"}}); err == nil {
+			t.Error("Expected error for invalid target")
+		}
+	})
+
+	// 失败的调用结果为 nil，成功的调用保持顺序
+	t.Run("Unpack", func(t *testing.T) {
+		encoded, err := parsedABI.Methods["aggregate3"].Outputs.Pack([]multicall3Result{
+			{Success: true, ReturnData: []byte{0x01}},
+			{Success: false, ReturnData: []byte{0xff}},
+			{Success: true, ReturnData: []byte{0x02, 0x03}},
+		})
+		if err != nil {
+			t.Fatalf("Failed to encode results: %v", err)
+		}
+		results, err := unpackMulticall(parsedABI, encoded, 3)
+		if err != nil {
+			t.Fatalf("unpackMulticall failed: %v", err)
+		}
+		if !bytes.Equal(results[0], []byte{0x01}) || results[1] != nil || !bytes.Equal(results[2], []byte{0x02, 0x03}) {
+			t.Errorf("Unexpected results: %x", results)
+		}
+		if _, err := unpackMulticall(parsedABI, encoded, 2); err == nil {
+			t.Error("Expected error for result count mismatch")
+		}
+	})
+}
+
+// fakeMulticallRPC 进程内的 eth_call 服务，按 Multicall3 aggregate3 解码调用并返回打包的结果
+type fakeMulticallRPC struct {
+	multicallABI *abi.ABI
+	ctfABI       *abi.ABI
+	slots        map[common.Hash]int64
+	denominators map[common.Hash]int64
+	numerators   map[common.Hash][]int64
+	batches      [][]string // 每次 eth_call 中各子调用的方法名
+}
+
+func (f *fakeMulticallRPC) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	input, _ := args["input"].(string)
+	if input == "" {
+		input, _ = args["data"].(string)
+	}
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := f.multicallABI.Methods["aggregate3"]
+	values, err := aggregate.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	var calls []multicall3Call
+	if err := aggregate.Inputs.Copy(&calls, values); err != nil {
+		return nil, err
+	}
+
+	methods := make([]string, len(calls))
+	results := make([]multicall3Result, len(calls))
+	for i, call := range calls {
+		method, err := f.ctfABI.MethodById(call.CallData[:4])
+		if err != nil {
+			return nil, err
+		}
+		callArgs, err := method.Inputs.Unpack(call.CallData[4:])
+		if err != nil {
+			return nil, err
+		}
+		condition := common.Hash(callArgs[0].([32]byte))
+		var value int64
+		switch method.Name {
+		case "getOutcomeSlotCount":
+			value = f.slots[condition]
+		case "payoutDenominator":
+			value = f.denominators[condition]
+		case "payoutNumerators":
+			value = f.numerators[condition][callArgs[1].(*big.Int).Int64()]
+		}
+		returnData, err := method.Outputs.Pack(big.NewInt(value))
+		if err != nil {
+			return nil, err
+		}
+		methods[i] = method.Name
+		results[i] = multicall3Result{Success: true, ReturnData: returnData}
+	}
+	f.batches = append(f.batches, methods)
+	return aggregate.Outputs.Pack(results)
+}
+
+func TestGetConditionResolutionsMulticall(t *testing.T) {
+	multicallABI, err := getMulticall3ABI()
+	if err != nil {
+		t.Fatalf("getMulticall3ABI failed: %v", err)
+	}
+	ctfABI, err := getConditionResolutionABI()
+	if err != nil {
+		t.Fatalf("getConditionResolutionABI failed: %v", err)
+	}
+
+	// 已结算（二元）、未结算、已结算（三个结果、平分）交替排列，检查第二次 Multicall 结果的对应关系
+	binary := types.Keccak256("0x" + strings.Repeat("11", 32))
+	open := types.Keccak256("0x" + strings.Repeat("22", 32))
+	split := types.Keccak256("0x" + strings.Repeat("33", 32))
+	hash := func(id types.Keccak256) common.Hash { return common.HexToHash(id.String()) }
+	fake := &fakeMulticallRPC{
+		multicallABI: multicallABI,
+		ctfABI:       ctfABI,
+		slots:        map[common.Hash]int64{hash(binary): 2, hash(open): 2, hash(split): 3},
+		denominators: map[common.Hash]int64{hash(binary): 1, hash(split): 2},
+		numerators:   map[common.Hash][]int64{hash(binary): {0, 1}, hash(split): {1, 1, 0}},
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", fake); err != nil {
+		t.Fatalf("RegisterName failed: %v", err)
+	}
+	defer server.Stop()
+	contracts, _ := internal.GetContractAddresses(types.Polygon)
+	client := &baseClient{
+		clients:   []*ethclient.Client{ethclient.NewClient(rpc.DialInProc(server))},
+		contracts: contracts,
+	}
+
+	resolutions, err := client.GetConditionResolutions([]types.Keccak256{binary, open, split, binary})
+	if err != nil {
+		t.Fatalf("GetConditionResolutions failed: %v", err)
+	}
+	if len(resolutions) != 3 {
+		t.Fatalf("Expected 3 unique conditions, got %d", len(resolutions))
+	}
+
+	want := map[types.Keccak256]types.ConditionResolution{
+		binary: {ConditionID: binary, Resolved: true, OutcomeSlotCount: 2, PayoutDenominator: 1, PayoutNumerators: []uint64{0, 1}},
+		open:   {ConditionID: open, OutcomeSlotCount: 2},
+		split:  {ConditionID: split, Resolved: true, OutcomeSlotCount: 3, PayoutDenominator: 2, PayoutNumerators: []uint64{1, 1, 0}},
+	}
+	for id, expected := range want {
+		got := resolutions[id]
+		if got == nil || got.ConditionID != expected.ConditionID || got.Resolved != expected.Resolved ||
+			got.OutcomeSlotCount != expected.OutcomeSlotCount || got.PayoutDenominator != expected.PayoutDenominator ||
+			fmt.Sprint(got.PayoutNumerators) != fmt.Sprint(expected.PayoutNumerators) {
+			t.Errorf("Condition %s: expected %+v, got %+v", id, expected, got)
+		}
+	}
+
+	// 第一次读取 3 个条件的结果数和分母，第二次只读取已结算条件的 2 + 3 个分子
+	if len(fake.batches) != 2 || len(fake.batches[0]) != 6 || len(fake.batches[1]) != 5 {
+		t.Fatalf("Expected batches of 6 and 5 calls, got %v", fake.batches)
+	}
+	for _, method := range fake.batches[1] {
+		if method != "payoutNumerators" {
+			t.Errorf("Expected only payoutNumerators in second batch, got %v", fake.batches[1])
+		}
+	}
+}
+
+// fakeAllowanceRPC 进程内的 eth_call 服务，按 Multicall3 aggregate3 解码 USDC 的 balanceOf/allowance 调用
+type fakeAllowanceRPC struct {
+	multicallABI *abi.ABI
+	erc20ABI     *abi.ABI
+	balance      int64
+	allowances   map[common.Address]int64 // spender -> 授权额度
+	spenders     []common.Address         // 按调用顺序记录查询的 spender
+}
+
+func (f *fakeAllowanceRPC) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	input, _ := args["input"].(string)
+	if input == "" {
+		input, _ = args["data"].(string)
+	}
+	data, err := hexutil.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := f.multicallABI.Methods["aggregate3"]
+	values, err := aggregate.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	var calls []multicall3Call
+	if err := aggregate.Inputs.Copy(&calls, values); err != nil {
+		return nil, err
+	}
+
+	results := make([]multicall3Result, len(calls))
+	for i, call := range calls {
+		method, err := f.erc20ABI.MethodById(call.CallData[:4])
+		if err != nil {
+			return nil, err
+		}
+		callArgs, err := method.Inputs.Unpack(call.CallData[4:])
+		if err != nil {
+			return nil, err
+		}
+		value := f.balance
+		if method.Name == "allowance" {
+			spender := callArgs[1].(common.Address)
+			f.spenders = append(f.spenders, spender)
+			value = f.allowances[spender]
+		}
+		returnData, err := method.Outputs.Pack(big.NewInt(value))
+		if err != nil {
+			return nil, err
+		}
+		results[i] = multicall3Result{Success: true, ReturnData: returnData}
+	}
+	return aggregate.Outputs.Pack(results)
+}
+
+func TestGetCollateralHoldings(t *testing.T) {
+	multicallABI, err := getMulticall3ABI()
+	if err != nil {
+		t.Fatalf("getMulticall3ABI failed: %v", err)
+	}
+	erc20ABI, err := getERC20ABI()
+	if err != nil {
+		t.Fatalf("getERC20ABI failed: %v", err)
+	}
+	contracts, _ := internal.GetContractAddresses(types.Polygon)
+	exchange := common.HexToAddress(contracts.Exchange)
+	negRiskExchange := common.HexToAddress(contracts.NegRiskExchange)
+	adapter := common.HexToAddress(contracts.NegRiskAdapter)
+
+	// 已授权 CTFExchange 和 NegRiskCTFExchange，但 NegRiskAdapter 的授权不足
+	fake := &fakeAllowanceRPC{
+		multicallABI: multicallABI,
+		erc20ABI:     erc20ABI,
+		balance:      50_000_000,
+		allowances:   map[common.Address]int64{exchange: 100_000_000, negRiskExchange: 100_000_000, adapter: 1_000_000},
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", fake); err != nil {
+		t.Fatalf("RegisterName failed: %v", err)
+	}
+	defer server.Stop()
+	client := &baseClient{
+		clients:   []*ethclient.Client{ethclient.NewClient(rpc.DialInProc(server))},
+		contracts: contracts,
+	}
+
+	address := types.EthAddress("0x0000000000000000000000000000000000000001")
+	holdings, err := client.GetCollateralHoldings(address)
+	if err != nil {
+		t.Fatalf("GetCollateralHoldings failed: %v", err)
+	}
+	if fmt.Sprint(fake.spenders) != fmt.Sprint([]common.Address{exchange, negRiskExchange, adapter}) {
+		t.Errorf("Expected allowances for both exchanges and the adapter, got %v", fake.spenders)
+	}
+	if holdings.Balance.Int64() != 50_000_000 || holdings.Allowance(false).Int64() != 100_000_000 || holdings.Allowance(true).Int64() != 1_000_000 {
+		t.Errorf("Unexpected holdings: %+v", holdings)
+	}
+
+	result, err := client.GetUSDCBalanceAllowance(address)
+	if err != nil {
+		t.Fatalf("GetUSDCBalanceAllowance failed: %v", err)
+	}
+	if result.Balance != 50 || result.Allowance != 100 || result.NegRiskAllowance != 1 {
+		t.Errorf("Expected balance 50, allowance 100 and neg risk allowance 1, got %+v", result)
+	}
+}
+
+// fakeNonceRPC 进程内的 eth_call 服务，按交易所合约返回 nonces(maker) 的当前值
+type fakeNonceRPC struct {
+	nonceABI *abi.ABI
+	nonces   map[common.Address]int64
+	fail     bool
+	calls    int
+}
+
+func (f *fakeNonceRPC) Call(args map[string]interface{}, block string) (hexutil.Bytes, error) {
+	f.calls++
+	if f.fail {
+		return nil, errors.New("execution reverted")
+	}
+	to, _ := args["to"].(string)
+	return f.nonceABI.Methods["nonces"].Outputs.Pack(big.NewInt(f.nonces[common.HexToAddress(to)]))
+}
+
+func TestOrderNonce(t *testing.T) {
+	nonceABI, err := getExchangeNonceABI()
+	if err != nil {
+		t.Fatalf("getExchangeNonceABI failed: %v", err)
+	}
+	contracts, _ := internal.GetContractAddresses(types.Polygon)
+	fake := &fakeNonceRPC{
+		nonceABI: nonceABI,
+		nonces: map[common.Address]int64{
+			common.HexToAddress(contracts.Exchange):        2,
+			common.HexToAddress(contracts.NegRiskExchange): 5,
+		},
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", fake); err != nil {
+		t.Fatalf("RegisterName failed: %v", err)
+	}
+	defer server.Stop()
+	client := &baseClient{
+		clients:       []*ethclient.Client{ethclient.NewClient(rpc.DialInProc(server))},
+		contracts:     contracts,
+		signatureType: types.EOASignatureType,
+		baseAddress:   types.EthAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"),
+	}
+
+	// 首次查询链上，之后使用缓存
+	for i := 0; i < 2; i++ {
+		if nonce, err := client.OrderNonce(false); err != nil || nonce != 2 {
+			t.Fatalf("Expected nonce 2, got %d (err=%v)", nonce, err)
+		}
+	}
+	if nonce, err := client.OrderNonce(true); err != nil || nonce != 5 {
+		t.Fatalf("Expected neg-risk nonce 5, got %d (err=%v)", nonce, err)
+	}
+	if fake.calls != 2 {
+		t.Errorf("Expected one eth_call per exchange, got %d", fake.calls)
+	}
+
+	// CancelAllOnchain 成功后刷新两个交易所的 nonce
+	fake.nonces[common.HexToAddress(contracts.Exchange)] = 3
+	fake.nonces[common.HexToAddress(contracts.NegRiskExchange)] = 6
+	client.refreshOrderNonces()
+	if nonce, _ := client.OrderNonce(false); nonce != 3 {
+		t.Errorf("Expected refreshed nonce 3, got %d", nonce)
+	}
+	if nonce, _ := client.OrderNonce(true); nonce != 6 {
+		t.Errorf("Expected refreshed neg-risk nonce 6, got %d", nonce)
+	}
+
+	// 刷新失败时清除缓存，而不是继续使用旧值
+	fake.fail = true
+	client.refreshOrderNonces()
+	if _, err := client.OrderNonce(false); err == nil {
+		t.Error("Expected error after failed refresh instead of the stale nonce")
+	}
+}
+
+func TestRedactSensitive(t *testing.T) {
+	address := "0x9d84ce0306f8551e02efef1680475fc0f1dc1344"
+	txHash := "0x" + strings.Repeat("ab", 32)
+	signature := "0x" + strings.Repeat("cd", 65)
+
+	input := `{"from": "` + address + `", "signature": "abc123", "sig": "` + signature + `", "transactionHash": "` + txHash + `"}`
+	output := redactSensitive(input)
+
+	if strings.Contains(output, address) {
+		t.Errorf("Expected address to be masked: %s", output)
+	}
+	if !strings.Contains(output, "0x9d84...1344") {
+		t.Errorf("Expected masked address to keep prefix and suffix: %s", output)
+	}
+	if strings.Contains(output, "abc123") || strings.Contains(output, signature) {
+		t.Errorf("Expected signatures to be redacted: %s", output)
+	}
+	// 交易哈希不属于敏感信息，保持原样
+	if !strings.Contains(output, txHash) {
+		t.Errorf("Expected transaction hash to be preserved: %s", output)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+		client, err := NewClient(testPrivateKey, types.EOASignatureType, types.Polygon, WithDebugRedaction(false))
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		defer client.Close()
+		if got := client.(*baseClient).debugString(input); got != input {
+			t.Errorf("Expected debug output unchanged when redaction is disabled, got %s", got)
+		}
+	})
+
+	// 截断位置落在签名中间时，签名的前半段也不能原样输出
+	t.Run("Preview", func(t *testing.T) {
+		client := &baseClient{debugRedaction: true}
+		body := `{"data": "` + strings.Repeat("0", 450) + `", "sig": "` + signature + `", "metadata": "` + strings.Repeat("1", 200) + `"}`
+		preview := client.debugPreview(body, 500)
+		if strings.Contains(preview, strings.Repeat("cd", 10)) {
+			t.Errorf("Expected truncated signature to be redacted: %s", preview[450:])
+		}
+		if !strings.HasSuffix(preview, "...") || len(preview) != 503 {
+			t.Errorf("Expected preview truncated to 500 bytes, got %d bytes", len(preview))
+		}
+	})
+}
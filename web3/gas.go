@@ -0,0 +1,54 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/polymas/go-polymarket-sdk/internal"
+)
+
+// SuggestGasFees 根据节点的 eth_feeHistory 建议 EIP-1559 交易费用（单位 wei）
+// maxPriority 取最近区块优先费的中位数，且不低于 Polygon 要求的最低优先费；
+// maxFee = 下一区块 baseFee * 2 + maxPriority，避免拥堵时直接提交的交易因费用过低而卡住
+func (c *baseClient) SuggestGasFees(ctx context.Context) (maxFee, maxPriority *big.Int, err error) {
+	history, err := c.feeHistoryWithRetry(ctx, internal.GasFeeHistoryBlocks, []float64{internal.GasFeeRewardPercentile})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+	return suggestFeesFromHistory(history)
+}
+
+// suggestFeesFromHistory 根据 fee history 计算 maxFee 和 maxPriority
+func suggestFeesFromHistory(history *ethereum.FeeHistory) (maxFee, maxPriority *big.Int, err error) {
+	if history == nil || len(history.BaseFee) == 0 {
+		return nil, nil, fmt.Errorf("fee history contains no base fee")
+	}
+
+	// BaseFee 的最后一个元素是下一个区块的 baseFee
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	if baseFee == nil {
+		return nil, nil, fmt.Errorf("fee history contains no base fee")
+	}
+
+	// 各区块指定百分位的优先费取中位数
+	rewards := make([]*big.Int, 0, len(history.Reward))
+	for _, blockRewards := range history.Reward {
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			rewards = append(rewards, blockRewards[0])
+		}
+	}
+	maxPriority = big.NewInt(internal.MinPriorityFeeWei)
+	if len(rewards) > 0 {
+		sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+		if median := rewards[len(rewards)/2]; median.Cmp(maxPriority) > 0 {
+			maxPriority = new(big.Int).Set(median)
+		}
+	}
+
+	maxFee = new(big.Int).Mul(baseFee, big.NewInt(internal.GasFeeBaseFeeMultiplier))
+	maxFee.Add(maxFee, maxPriority)
+	return maxFee, maxPriority, nil
+}
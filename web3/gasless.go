@@ -23,8 +23,11 @@ type GaslessClient struct {
 	conditionalABI  *abi.ABI
 	negRiskABI      *abi.ABI
 	proxyFactoryABI *abi.ABI
+	relayerBreaker  *circuitBreaker // Relayer 熔断器，避免 Relayer 故障时每次调用都重试到超时
 	// relayer 调用统计
 	relayerCallCount int64 // 使用 atomic 操作，记录总调用次数
+	// 最近一次估算成功时平均每笔交易的 gas（使用 atomic 操作），估算失败时按它和交易数回退
+	gasPerTx uint64
 }
 
 // NewGaslessClient creates a new gasless Web3 client
@@ -33,13 +36,14 @@ func NewGaslessClient(
 	signatureType types.SignatureType,
 	chainID types.ChainID,
 	builderCreds *types.ApiCreds,
+	options ...ClientOption,
 ) (*GaslessClient, error) {
 	// Only support proxy (1) and safe (2) wallets
 	if signatureType != types.ProxySignatureType && signatureType != types.SafeSignatureType {
 		return nil, fmt.Errorf("gaslessClient only supports signature_type=1 (proxy) and signature_type=2 (safe)")
 	}
 
-	baseClientInterface, err := NewClient(privateKey, signatureType, chainID)
+	baseClientInterface, err := NewClient(privateKey, signatureType, chainID, options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create base client: %w", err)
 	}
@@ -68,13 +72,19 @@ func NewGaslessClient(
 	}
 	localSigner := NewLocalSigner(baseClientImpl.GetSigner(), builderCreds)
 
+	httpClient := &http.Client{
+		Timeout: baseClientImpl.timeouts.HTTPLong,
+		// Use default transport (automatically handles proxy, TLS, etc.)
+	}
+	if baseClientImpl.proxyURL != nil {
+		// 显式配置的代理优先于环境变量
+		httpClient.Transport = newProxyTransport(baseClientImpl.proxyURL)
+	}
+
 	client := &GaslessClient{
-		baseClient: baseClientImpl,
-		web3Client: baseClientInterface, // 保存接口引用
-		httpClient: &http.Client{
-			Timeout: internal.HTTPClientLongTimeout,
-			// Use default transport (automatically handles proxy, TLS, etc.)
-		},
+		baseClient:      baseClientImpl,
+		web3Client:      baseClientInterface, // 保存接口引用
+		httpClient:      httpClient,
 		relayURL:        internal.RelayerDomain,
 		relayHub:        internal.RelayHub,
 		relayAddress:    internal.RelayAddress,
@@ -83,6 +93,7 @@ func NewGaslessClient(
 		conditionalABI:  conditionalABI,
 		negRiskABI:      negRiskABI,
 		proxyFactoryABI: proxyFactoryABI,
+		relayerBreaker:  newCircuitBreaker(internal.RelayerBreakerThreshold, internal.RelayerBreakerCooldown),
 	}
 
 	return client, nil
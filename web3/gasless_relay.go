@@ -35,8 +35,16 @@ func (c *GaslessClient) executeGaslessBatch(
 		return nil, fmt.Errorf("no transactions to execute")
 	}
 
+	// Relayer 熔断中时直接返回，不再获取 nonce 和提交
+	probe, err := c.relayerBreaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	if probe {
+		defer c.relayerBreaker.release()
+	}
+
 	var body interface{}
-	var err error
 
 	switch c.signatureType {
 	case types.ProxySignatureType:
@@ -54,7 +62,7 @@ func (c *GaslessClient) executeGaslessBatch(
 		}
 		body, err = c.buildSafeRelayTransactionBatch(safeTxns, metadata)
 	default:
-		return nil, fmt.Errorf("unsupported signature type: %d", c.signatureType)
+		return nil, fmt.Errorf("unsupported signature type: %s", c.signatureType)
 	}
 
 	if err != nil {
@@ -70,13 +78,9 @@ func (c *GaslessClient) executeGaslessBatch(
 	// 记录 relayer 调用次数（在格式化 body 之后，用于日志）
 	callCount := atomic.AddInt64(&c.relayerCallCount, 1)
 
-	// Debug: log request body (truncated for security)
-	if len(bodyJSON) > 500 {
-		log.Printf("[DEBUG] [Relayer调用 #%d] 请求体 (前500字符): %s...", callCount, string(bodyJSON[:500]))
-	} else {
-		log.Printf("[DEBUG] [Relayer调用 #%d] 请求体: %s", callCount, string(bodyJSON))
-	}
-	
+	// Debug: log request body (truncated, signatures and addresses redacted by default)
+	internal.LogDebug("[Relayer调用 #%d] 请求体: %s", callCount, c.debugPreview(string(bodyJSON), 500))
+
 	// Debug: log encoded proxy data length and first bytes
 	var bodyMap map[string]interface{}
 	if err := json.Unmarshal(bodyJSON, &bodyMap); err == nil {
@@ -85,7 +89,7 @@ func (c *GaslessClient) executeGaslessBatch(
 			if len(encodedTxnHex) < previewLen {
 				previewLen = len(encodedTxnHex)
 			}
-			log.Printf("[DEBUG] [Relayer调用 #%d] Proxy data length: %d bytes, first %d chars: %s", 
+			internal.LogDebug("[Relayer调用 #%d] Proxy data length: %d bytes, first %d chars: %s",
 				callCount, len(encodedTxnHex), previewLen, encodedTxnHex[:previewLen])
 		}
 	}
@@ -104,6 +108,7 @@ func (c *GaslessClient) executeGaslessBatch(
 	}
 
 	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("User-Agent", internal.UserAgent())
 	for k, v := range requestHeaders {
 		req.Header.Set(k, v)
 	}
@@ -112,14 +117,30 @@ func (c *GaslessClient) executeGaslessBatch(
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		c.relayerBreaker.recordFailure()
 		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
 	defer resp.Body.Close()
 
+	// 5xx 视为 Relayer 故障，其他响应说明 Relayer 可用
+	if resp.StatusCode >= http.StatusInternalServerError {
+		c.relayerBreaker.recordFailure()
+	} else {
+		c.relayerBreaker.recordSuccess()
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := relayerBlockedError(resp, responseBody); err != nil {
+		log.Printf("[ERROR] [Relayer调用 #%d] 批量提交被拦截: HTTP %d", callCount, resp.StatusCode)
+		return nil, err
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
 		// 限制错误日志长度，避免泄露敏感信息
-		errorMsg := string(bodyBytes)
+		errorMsg := string(responseBody)
 		if len(errorMsg) > 200 {
 			errorMsg = errorMsg[:200] + "..."
 		}
@@ -128,126 +149,144 @@ func (c *GaslessClient) executeGaslessBatch(
 	}
 
 	// Parse response
-	var gaslessResp map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&gaslessResp); err != nil {
+	var gaslessResp types.RelaySubmitResponse
+	if err := json.Unmarshal(responseBody, &gaslessResp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	// 检查交易状态
-	if state, ok := gaslessResp["state"].(string); ok {
-		if state == "STATE_FAILED" || state == "FAILED" || state == "failed" {
-			// 尝试提取错误信息 - 检查多个可能的字段
-			errorMsg := "交易提交失败"
-			errorDetails := []string{}
-			
-			// 检查各种可能的错误字段
-			if errMsg, ok := gaslessResp["error"].(string); ok && errMsg != "" {
-				errorMsg = errMsg
-			} else if errMsg, ok := gaslessResp["message"].(string); ok && errMsg != "" {
-				errorMsg = errMsg
-			} else if errMsg, ok := gaslessResp["errorMessage"].(string); ok && errMsg != "" {
-				errorMsg = errMsg
-			} else if errMsg, ok := gaslessResp["reason"].(string); ok && errMsg != "" {
-				errorMsg = errMsg
-			} else if errData, ok := gaslessResp["error"].(map[string]interface{}); ok {
-				// 错误可能是嵌套对象
-				if msg, ok := errData["message"].(string); ok {
-					errorMsg = msg
-				}
-				if code, ok := errData["code"].(string); ok {
-					errorDetails = append(errorDetails, fmt.Sprintf("code: %s", code))
-				}
-			}
-			
-			// 检查是否有嵌套的错误信息
-			if details, ok := gaslessResp["details"].(map[string]interface{}); ok {
-				for k, v := range details {
-					errorDetails = append(errorDetails, fmt.Sprintf("%s: %v", k, v))
-				}
-			}
+	txHashStr, err := c.submittedTxHash(&gaslessResp)
+	if err != nil {
+		log.Printf("[ERROR] [Relayer调用 #%d] %v", callCount, err)
+		internal.LogDebug("[Relayer调用 #%d] 完整响应 (JSON): %s", callCount, c.debugString(string(responseBody)))
+		return nil, err
+	}
 
-			transactionID := ""
-			if txID, ok := gaslessResp["transactionID"].(string); ok {
-				transactionID = txID
-			}
+	// 同时记录 transactionID，进程在等待回执期间退出时可以用 GetRelayTransaction 查询结果，避免重复提交
+	log.Printf("[OK] [Relayer调用 #%d] 批量提交成功，交易哈希: %s, transactionID: %s", callCount, txHashStr, gaslessResp.TransactionID)
 
-			fullErrorMsg := errorMsg
-			if len(errorDetails) > 0 {
-				fullErrorMsg = fmt.Sprintf("%s (%s)", errorMsg, strings.Join(errorDetails, ", "))
-			}
+	// Wait for transaction receipt
+	txHash := common.HexToHash(txHashStr)
+	receipt, err := c.waitForTransactionReceipt(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for receipt: %w", err)
+	}
 
-			log.Printf("[ERROR] [Relayer调用 #%d] 交易提交失败 (state: %s, transactionID: %s): %s",
-				callCount, state, transactionID, fullErrorMsg)
-			log.Printf("[ERROR] [Relayer调用 #%d] 完整响应 (JSON): %s", callCount, formatMapAsJSON(gaslessResp))
-			return nil, fmt.Errorf("交易提交失败 (state: %s, transactionID: %s): %s", state, transactionID, fullErrorMsg)
-		}
+	log.Printf("[OK] [Relayer调用 #%d] 交易已确认，区块号: %d", callCount, receipt.BlockNumber)
+	return receipt, nil
+}
+
+// submittedTxHash 在 Relayer 接受提交（未报告失败且返回了 transactionID）时先调用 WithRelaySubmittedHook 设置的回调，
+// 再返回交易哈希；交易还在处理中、没有哈希时回调同样会收到 transactionID
+func (c *GaslessClient) submittedTxHash(resp *types.RelaySubmitResponse) (string, error) {
+	if c.onRelaySubmit != nil && resp.TransactionID != "" && !resp.Failed() {
+		c.onRelaySubmit(*resp)
 	}
+	return relaySubmitTxHash(resp)
+}
 
-	txHashStr, ok := gaslessResp["transactionHash"].(string)
-	if !ok {
-		// 尝试其他可能的字段名
-		if txHash, ok2 := gaslessResp["txHash"].(string); ok2 {
-			txHashStr = txHash
-		} else if txHash, ok2 := gaslessResp["hash"].(string); ok2 {
-			txHashStr = txHash
-		} else {
-			// 如果状态不是失败但没有交易哈希，可能是还在处理中
-			if state, ok := gaslessResp["state"].(string); ok && state != "STATE_FAILED" {
-				log.Printf("[WARN] [Relayer调用 #%d] 响应中没有找到交易哈希，但状态为: %s，响应内容: %+v", callCount, state, gaslessResp)
-				return nil, fmt.Errorf("交易可能还在处理中，未返回交易哈希 (state: %s): %v", state, gaslessResp)
-			}
-			log.Printf("[ERROR] [Relayer调用 #%d] 响应中没有找到交易哈希，响应内容: %+v", callCount, gaslessResp)
-			return nil, fmt.Errorf("no transaction hash in response: %v", gaslessResp)
+// relaySubmitTxHash 根据 Relayer 的提交响应返回交易哈希
+// Relayer 报告失败时返回失败原因；未失败但没有交易哈希时说明交易可能还在处理中，同样返回错误
+func relaySubmitTxHash(resp *types.RelaySubmitResponse) (string, error) {
+	if resp.Failed() {
+		return "", fmt.Errorf("交易提交失败 (state: %s, transactionID: %s): %s", resp.State, resp.TransactionID, resp.FailureMessage())
+	}
+	if resp.TransactionHash == "" {
+		if resp.State != "" {
+			return "", fmt.Errorf("交易可能还在处理中，未返回交易哈希 (state: %s, transactionID: %s)", resp.State, resp.TransactionID)
 		}
+		return "", fmt.Errorf("no transaction hash in relay response")
 	}
+	return resp.TransactionHash, nil
+}
 
-	if txHashStr == "" {
-		// 检查状态，如果失败则返回错误
-		if state, ok := gaslessResp["state"].(string); ok && (state == "STATE_FAILED" || state == "FAILED" || state == "failed") {
-			errorMsg := "交易提交失败"
-			if errMsg, ok := gaslessResp["error"].(string); ok && errMsg != "" {
-				errorMsg = errMsg
-			}
-			log.Printf("[ERROR] [Relayer调用 #%d] 交易哈希为空且状态为失败，响应内容: %+v", callCount, gaslessResp)
-			return nil, fmt.Errorf("交易提交失败 (state: %s): %s", state, errorMsg)
-		}
-		log.Printf("[ERROR] [Relayer调用 #%d] 交易哈希为空，响应内容: %+v", callCount, gaslessResp)
-		return nil, fmt.Errorf("transaction hash is empty in response: %v", gaslessResp)
+// relayerBlockedError Relayer 返回 HTML 页面（如 Cloudflare 拦截的 403/503）时返回 types.ErrRelayerBlocked，
+// 包含状态码和页面摘要，与 Relayer 自身返回的 JSON 错误区分；响应为 JSON 时返回 nil
+func relayerBlockedError(resp *http.Response, body []byte) error {
+	if !internal.IsNonJSONResponse(resp.Header.Get("Content-Type"), body) {
+		return nil
 	}
+	return fmt.Errorf("%w: HTTP %d: %s", types.ErrRelayerBlocked, resp.StatusCode, internal.ResponseSnippet(body, 200))
+}
 
-	log.Printf("[OK] [Relayer调用 #%d] 批量提交成功，交易哈希: %s", callCount, txHashStr)
+// GetRelayTransaction 按 transactionID（来自提交响应和提交日志）查询 Relayer 中交易的当前状态
+// 用于进程在提交后、收到回执前退出的情况：重启后先确认之前提交的交易是否仍在处理或已上链，避免重复赎回
+// 交易发送到链上后返回的 TransactionHash 不为空，可以用 WaitForReceipt 等待回执；Relayer 中没有该交易时返回 types.ErrRelayTxNotFound
+func (c *GaslessClient) GetRelayTransaction(id string) (*types.RelayStatus, error) {
+	if id == "" {
+		return nil, fmt.Errorf("relay transaction id is required")
+	}
 
-	// Wait for transaction receipt
-	txHash := common.HexToHash(txHashStr)
-	receipt, err := c.waitForTransactionReceipt(txHash)
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/transaction", c.relayURL), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wait for receipt: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	q := req.URL.Query()
+	q.Set("id", id)
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("User-Agent", internal.UserAgent())
 
-	log.Printf("[OK] [Relayer调用 #%d] 交易已确认，区块号: %d", callCount, receipt.BlockNumber)
-	return receipt, nil
-}
+	callCount := atomic.AddInt64(&c.relayerCallCount, 1)
+	internal.LogDebug("[Relayer调用 #%d] 查询交易状态 (transactionID: %s)", callCount, id)
 
-// formatMapAsJSON formats a map as JSON string for logging
-func formatMapAsJSON(m map[string]interface{}) string {
-	jsonBytes, err := json.MarshalIndent(m, "", "  ")
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Sprintf("%+v", m)
+		return nil, fmt.Errorf("failed to get relay transaction: %w", err)
 	}
-	return string(jsonBytes)
-}
+	defer resp.Body.Close()
 
-// formatJSONWithSpaces formats JSON with spaces to match Python's json.dumps format
-func formatJSONWithSpaces(body interface{}) ([]byte, error) {
-	bodyJSONCompact, err := json.Marshal(body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := relayerBlockedError(resp, body); err != nil {
 		return nil, err
 	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", types.ErrRelayTxNotFound, id)
+	}
+	if resp.StatusCode != http.StatusOK {
+		errorMsg := string(body)
+		if len(errorMsg) > 200 {
+			errorMsg = errorMsg[:200] + "..."
+		}
+		return nil, fmt.Errorf("relay returned error: HTTP %d: %s", resp.StatusCode, errorMsg)
+	}
+	return parseRelayTransaction(body, id)
+}
 
-	bodyJSONStr := string(bodyJSONCompact)
-	bodyJSONStr = regexp.MustCompile(`":(\S)`).ReplaceAllString(bodyJSONStr, `": $1`)
-	bodyJSONStr = regexp.MustCompile(`,(")`).ReplaceAllString(bodyJSONStr, `, $1`)
-	return []byte(bodyJSONStr), nil
+// parseRelayTransaction 解析 Relayer /transaction 的响应
+// 响应通常是交易数组，也兼容单个交易对象；数组中有多笔交易时返回 transactionID 与 id 相同的一笔
+func parseRelayTransaction(body []byte, id string) (*types.RelayStatus, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, fmt.Errorf("%w: %s", types.ErrRelayTxNotFound, id)
+	}
+
+	var statuses []types.RelayStatus
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &statuses); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	} else {
+		var status types.RelayStatus
+		if err := json.Unmarshal(trimmed, &status); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	for i := range statuses {
+		if statuses[i].TransactionID == id || (len(statuses) == 1 && statuses[i].TransactionID == "") {
+			return &statuses[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", types.ErrRelayTxNotFound, id)
+}
+
+// formatJSONWithSpaces formats JSON with spaces to match Python's json.dumps format
+// 与 CLOB 签名请求共用 internal.MarshalPythonJSON，保证格式规则一致
+func formatJSONWithSpaces(body interface{}) ([]byte, error) {
+	return internal.MarshalPythonJSON(body)
 }
 
 // buildProxyRelayTransactionBatch builds a proxy relay transaction body for batch transactions
@@ -276,7 +315,10 @@ func (c *GaslessClient) buildProxyRelayTransactionBatch(
 	}
 
 	// Estimate gas
-	proxyFactoryAddr := common.HexToAddress(internal.PolygonProxyFactory)
+	proxyFactoryAddr, err := c.contractAddress("ProxyFactory", c.contracts.ProxyFactory)
+	if err != nil {
+		return nil, err
+	}
 	callMsg := ethereum.CallMsg{
 		From: common.HexToAddress(string(c.baseAddress)),
 		To:   &proxyFactoryAddr,
@@ -284,12 +326,7 @@ func (c *GaslessClient) buildProxyRelayTransactionBatch(
 	}
 
 	estimatedGas, err := c.estimateGasWithRetry(context.Background(), callMsg)
-	if err != nil {
-		// Use default if estimation fails (increase for batch)
-		estimatedGas = internal.DefaultGasEstimate * uint64(len(proxyTxns))
-	}
-
-	gasLimit := strconv.FormatUint(estimatedGas*internal.GasEstimateMultiplier/100+internal.GasEstimateExtra, 10) // 1.3x + 100k
+	gasLimit := strconv.FormatUint(c.relayGasLimit(estimatedGas, err, len(proxyTxns)), 10)
 
 	// Create proxy struct for signing
 	encodedTxnHex := "0x" + hex.EncodeToString(encodedTxn)
@@ -361,6 +398,23 @@ func (c *GaslessClient) buildProxyRelayTransactionBatch(
 	}, nil
 }
 
+// relayGasLimit 根据 gas 估算结果计算 Relayer 交易的 gas limit（估算值 × 倍数 + 额外值，默认 1.3x + 100k）
+// 估算成功时记录平均每笔交易的 gas；估算失败时按最近一次成功估算的每笔 gas 乘以交易数回退，
+// 还没有成功估算过时使用 internal.DefaultGasEstimate 乘以交易数
+func (c *GaslessClient) relayGasLimit(estimatedGas uint64, estimateErr error, txCount int) uint64 {
+	if txCount < 1 {
+		txCount = 1
+	}
+	if estimateErr == nil && estimatedGas > 0 {
+		atomic.StoreUint64(&c.gasPerTx, estimatedGas/uint64(txCount))
+	} else if perTx := atomic.LoadUint64(&c.gasPerTx); perTx > 0 {
+		estimatedGas = perTx * uint64(txCount)
+	} else {
+		estimatedGas = internal.DefaultGasEstimate * uint64(txCount)
+	}
+	return estimatedGas*c.gasMultiplier/100 + c.gasExtra
+}
+
 // createSafeMultiSendTransaction creates a Safe multiSend transaction that batches multiple transactions
 // This matches Python's create_safe_multisend_transaction function
 func (c *GaslessClient) createSafeMultiSendTransaction(
@@ -549,10 +603,10 @@ func (c *GaslessClient) encodeProxy(proxyTxns []map[string]interface{}) ([]byte,
 		tupleOffsetBytes := make([]byte, 32)
 		tupleOffset.FillBytes(tupleOffsetBytes)
 		data = append(data, tupleOffsetBytes...)
-		
-		log.Printf("[DEBUG] encodeProxy: Transaction %d, tuple offset: 0x%x (calculated from: 0x%x * %d + %d)", 
+
+		internal.LogDebug("encodeProxy: Transaction %d, tuple offset: 0x%x (calculated from: 0x%x * %d + %d)",
 			i, tupleOffsetValue, 0x20, len(proxyTxns)+1, len(data)-32) // -32 because we just added the offset
-		
+
 		typeCode := uint8(proxyTxn["typeCode"].(int))
 		to := common.HexToAddress(proxyTxn["to"].(string))
 		value := big.NewInt(int64(proxyTxn["value"].(int)))
@@ -561,10 +615,10 @@ func (c *GaslessClient) encodeProxy(proxyTxns []map[string]interface{}) ([]byte,
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode data: %w", err)
 		}
-		
+
 		// Debug: log transaction details
-		log.Printf("[DEBUG] encodeProxy: Transaction %d details - typeCode: %d, to: %s, value: %d, dataLen: %d", 
-			i, typeCode, to.Hex(), value.Int64(), len(txnData))
+		internal.LogDebug("encodeProxy: Transaction %d details - typeCode: %d, to: %s, value: %d, dataLen: %d",
+			i, typeCode, c.debugString(to.Hex()), value.Int64(), len(txnData))
 
 		// Encode typeCode (uint8, padded to 32 bytes)
 		typeCodeBytes := make([]byte, 32)
@@ -670,7 +724,18 @@ func pad32Bytes(b []byte) []byte {
 }
 
 // getRelayNonce gets nonce from relay with retry mechanism
+// 所有重试都失败时记为一次 Relayer 失败（用于熔断）；成功与否以随后的提交结果为准
 func (c *GaslessClient) getRelayNonce(walletType string) (int, error) {
+	nonce, err := c.fetchRelayNonce(walletType)
+	if err != nil {
+		c.relayerBreaker.recordFailure()
+		return 0, err
+	}
+	return nonce, nil
+}
+
+// fetchRelayNonce 从 Relayer 获取 nonce，超时和非 200 响应按指数退避重试
+func (c *GaslessClient) fetchRelayNonce(walletType string) (int, error) {
 	url := fmt.Sprintf("%s/nonce", c.relayURL)
 
 	// Retry up to 3 times with exponential backoff
@@ -694,9 +759,10 @@ func (c *GaslessClient) getRelayNonce(walletType string) (int, error) {
 		q.Set("address", string(c.baseAddress))
 		q.Set("type", walletType)
 		req.URL.RawQuery = q.Encode()
+		req.Header.Set("User-Agent", internal.UserAgent())
 
 		// Create context with timeout for this specific request
-		ctx, cancel := context.WithTimeout(context.Background(), internal.RelayNonceTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeouts.RelayNonce)
 		req = req.WithContext(ctx)
 
 		// 记录 relayer 调用次数（nonce 请求）
@@ -717,25 +783,35 @@ func (c *GaslessClient) getRelayNonce(walletType string) (int, error) {
 		}
 		defer resp.Body.Close()
 
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read response: %w", err)
+			continue
+		}
+		// 被代理拦截时重试通常无效，直接返回
+		if err := relayerBlockedError(resp, body); err != nil {
+			return 0, err
+		}
+
 		if resp.StatusCode != http.StatusOK {
 			lastErr = fmt.Errorf("relay returned error: HTTP %d", resp.StatusCode)
 			continue // Retry on non-200 status
 		}
 
 		var result map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if err := types.DecodeJSON(body, &result); err != nil {
 			lastErr = fmt.Errorf("failed to decode response: %w", err)
 			continue // Retry on decode error
 		}
 
 		nonceStr, ok := result["nonce"].(string)
 		if !ok {
-			nonceFloat, ok := result["nonce"].(float64)
+			nonceInt, ok := types.NumberInt64(result["nonce"])
 			if !ok {
 				lastErr = fmt.Errorf("invalid nonce in response: %v", result)
 				continue // Retry on invalid response
 			}
-			nonce := int(nonceFloat)
+			nonce := int(nonceInt)
 			log.Printf("[OK] [Relayer调用 #%d] 成功获取 nonce: %d (类型: %s)", callCount, nonce, walletType)
 			return nonce, nil
 		}
@@ -756,10 +832,26 @@ func (c *GaslessClient) getRelayNonce(walletType string) (int, error) {
 }
 
 // waitForTransactionReceipt waits for a transaction receipt
+// 使用客户端配置的 TransactionWait 作为超时时间
 func (c *GaslessClient) waitForTransactionReceipt(txHash common.Hash) (*types.TransactionReceipt, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), internal.TransactionWaitTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeouts.TransactionWait)
 	defer cancel()
 
+	return c.waitForReceipt(ctx, txHash)
+}
+
+// WaitForReceipt 等待交易上链并返回交易收据
+// 超时和取消由调用方通过 ctx 控制（ctx 没有截止时间时会一直等待），轮询间隔使用 TransactionDelay 配置
+// 交易执行失败（status 为 0）时返回包含 revert 原因的错误
+func (c *baseClient) WaitForReceipt(ctx context.Context, txHash types.Keccak256) (*types.TransactionReceipt, error) {
+	if err := txHash.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction hash %q: %w", txHash, err)
+	}
+	return c.waitForReceipt(ctx, common.HexToHash(txHash.String()))
+}
+
+// waitForReceipt 轮询交易收据直到交易确认、失败或 ctx 结束
+func (c *baseClient) waitForReceipt(ctx context.Context, txHash common.Hash) (*types.TransactionReceipt, error) {
 	startTime := time.Now()
 	attemptCount := 0
 
@@ -797,7 +889,7 @@ func (c *GaslessClient) waitForTransactionReceipt(txHash common.Hash) (*types.Tr
 			case <-ctx.Done():
 				log.Printf("[ERROR] 等待交易确认超时 (已等待: %v, 交易哈希: %s)", elapsed, txHash.Hex())
 				return nil, ctx.Err()
-			case <-time.After(internal.TransactionDelay):
+			case <-time.After(c.timeouts.TransactionDelay):
 				continue
 			}
 		}
@@ -808,7 +900,7 @@ func (c *GaslessClient) waitForTransactionReceipt(txHash common.Hash) (*types.Tr
 }
 
 // extractTransactionError 尝试从失败的交易中提取错误信息
-func (c *GaslessClient) extractTransactionError(ctx context.Context, txHash common.Hash, receipt *ethtypes.Receipt) string {
+func (c *baseClient) extractTransactionError(ctx context.Context, txHash common.Hash, receipt *ethtypes.Receipt) string {
 	// 获取交易详情
 	tx, _, err := c.transactionByHashWithRetry(ctx, txHash)
 	if err != nil {
@@ -1,8 +1,16 @@
 package web3
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	sdkhttp "github.com/polymas/go-polymarket-sdk/http"
+	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/test"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
@@ -14,8 +22,8 @@ func newTestGaslessClient(t *testing.T) *GaslessClient {
 	test.SkipIfNoBuilderCreds(t, config)
 
 	builderCreds := &types.ApiCreds{
-		Key:       config.BuilderAPIKey,
-		Secret:    config.BuilderSecret,
+		Key:        config.BuilderAPIKey,
+		Secret:     config.BuilderSecret,
 		Passphrase: config.BuilderPassphrase,
 	}
 
@@ -183,3 +191,315 @@ func TestMergeTokens(t *testing.T) {
 		}
 	})
 }
+
+func TestRelayerCircuitBreaker(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	breaker := newCircuitBreaker(3, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	t.Run("OpensAfterThreshold", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if _, err := breaker.allow(); err != nil {
+				t.Fatalf("call %d should be allowed: %v", i, err)
+			}
+			breaker.recordFailure()
+		}
+		if state := breaker.currentState(); state != types.CircuitOpen {
+			t.Fatalf("Expected open after 3 failures, got %s", state)
+		}
+		if _, err := breaker.allow(); !errors.Is(err, types.ErrRelayerUnavailable) {
+			t.Errorf("Expected ErrRelayerUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("HalfOpenProbe", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		if state := breaker.currentState(); state != types.CircuitHalfOpen {
+			t.Fatalf("Expected half_open after cooldown, got %s", state)
+		}
+		if probe, err := breaker.allow(); err != nil || !probe {
+			t.Fatalf("probe should be allowed: probe=%v err=%v", probe, err)
+		}
+		// 探测进行中时其他调用仍被拒绝
+		if _, err := breaker.allow(); !errors.Is(err, types.ErrRelayerUnavailable) {
+			t.Errorf("Expected concurrent call to be rejected during probe, got %v", err)
+		}
+		breaker.recordFailure()
+		if state := breaker.currentState(); state != types.CircuitOpen {
+			t.Fatalf("Expected failed probe to reopen, got %s", state)
+		}
+	})
+
+	t.Run("Recovers", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		if probe, err := breaker.allow(); err != nil || !probe {
+			t.Fatalf("probe should be allowed: probe=%v err=%v", probe, err)
+		}
+		breaker.recordSuccess()
+		if state := breaker.currentState(); state != types.CircuitClosed {
+			t.Fatalf("Expected closed after successful probe, got %s", state)
+		}
+		if probe, err := breaker.allow(); err != nil || probe {
+			t.Errorf("Expected non-probe calls allowed after recovery, got probe=%v err=%v", probe, err)
+		}
+	})
+
+	t.Run("ReleaseWithoutResult", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure()
+		}
+		now = now.Add(time.Minute)
+		if probe, err := breaker.allow(); err != nil || !probe {
+			t.Fatalf("probe should be allowed: probe=%v err=%v", probe, err)
+		}
+		// 探测在本地失败（未到达 Relayer），释放后下一次调用可以继续探测
+		breaker.release()
+		if probe, err := breaker.allow(); err != nil || !probe {
+			t.Errorf("Expected a new probe after release, got probe=%v err=%v", probe, err)
+		}
+		breaker.recordSuccess()
+	})
+
+	t.Run("NonProbeCallKeepsProbe", func(t *testing.T) {
+		// 熔断前已放行的调用在 half_open 期间结束，不能释放探测名额
+		inflight, err := breaker.allow()
+		if err != nil || inflight {
+			t.Fatalf("closed breaker should allow a non-probe call: probe=%v err=%v", inflight, err)
+		}
+		for i := 0; i < 3; i++ {
+			breaker.recordFailure()
+		}
+		now = now.Add(time.Minute)
+		if probe, err := breaker.allow(); err != nil || !probe {
+			t.Fatalf("probe should be allowed: probe=%v err=%v", probe, err)
+		}
+
+		// 与 executeGaslessBatch 一致：只有获得探测名额的调用才释放
+		if inflight {
+			breaker.release()
+		}
+
+		if _, err := breaker.allow(); !errors.Is(err, types.ErrRelayerUnavailable) {
+			t.Errorf("Expected second probe to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("FastFail", func(t *testing.T) {
+		open := newCircuitBreaker(1, time.Hour)
+		open.recordFailure()
+		client := &GaslessClient{relayerBreaker: open}
+		_, err := client.executeGaslessBatch([]map[string]interface{}{{"to": "0x0"}}, "redeem", "")
+		if !errors.Is(err, types.ErrRelayerUnavailable) {
+			t.Errorf("Expected ErrRelayerUnavailable, got %v", err)
+		}
+		if client.RelayerCircuitState() != types.CircuitOpen {
+			t.Errorf("Expected open state, got %s", client.RelayerCircuitState())
+		}
+	})
+}
+
+func TestRelaySubmitResponse(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		wantHash string
+		wantErr  string
+	}{
+		{"TransactionHash", `{"transactionID":"tx-1","transactionHash":"0xabc","state":"STATE_NEW"}`, "0xabc", ""},
+		{"TxHash", `{"txHash":"0xdef"}`, "0xdef", ""},
+		{"Hash", `{"hash":"0x123","state":"STATE_MINED"}`, "0x123", ""},
+		{"FailedStringError", `{"transactionID":"tx-2","state":"STATE_FAILED","error":"nonce too low"}`, "", "nonce too low"},
+		{"FailedNestedError", `{"state":"failed","error":{"message":"execution reverted","code":"E42"},"details":{"b":2,"a":1}}`, "", "execution reverted (code: E42, a: 1, b: 2)"},
+		{"FailedNumericCode", `{"state":"STATE_FAILED","error":{"message":"execution reverted","code":-32000}}`, "", "execution reverted (code: -32000)"},
+		{"FailedTopLevelNumericCode", `{"state":"FAILED","message":"rate limited","code":429}`, "", "rate limited (code: 429)"},
+		{"FailedReason", `{"state":"FAILED","reason":"insufficient funds"}`, "", "insufficient funds"},
+		{"FailedNoMessage", `{"state":"STATE_FAILED","transactionHash":"0xabc"}`, "", "交易提交失败"},
+		{"Pending", `{"transactionID":"tx-3","state":"STATE_NEW"}`, "", "还在处理中"},
+		{"Empty", `{}`, "", "no transaction hash"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var resp types.RelaySubmitResponse
+			if err := json.Unmarshal([]byte(c.body), &resp); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			hash, err := relaySubmitTxHash(&resp)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil || hash != c.wantHash {
+				t.Errorf("Expected hash %s, got %s (err=%v)", c.wantHash, hash, err)
+			}
+		})
+	}
+}
+
+func TestRelaySubmittedHook(t *testing.T) {
+	var submitted []types.RelaySubmitResponse
+	client := &GaslessClient{baseClient: &baseClient{
+		onRelaySubmit: func(resp types.RelaySubmitResponse) { submitted = append(submitted, resp) },
+	}}
+
+	cases := []struct {
+		name   string
+		resp   types.RelaySubmitResponse
+		wantID string
+	}{
+		{"Submitted", types.RelaySubmitResponse{TransactionID: "tx-1", TransactionHash: "0xabc", State: "STATE_NEW"}, "tx-1"},
+		// 还没有交易哈希时回调同样收到 transactionID，调用方可以稍后查询
+		{"Pending", types.RelaySubmitResponse{TransactionID: "tx-2", State: "STATE_NEW"}, "tx-2"},
+		{"Failed", types.RelaySubmitResponse{TransactionID: "tx-3", State: "STATE_FAILED", ErrorMessage: "nonce too low"}, ""},
+		{"NoTransactionID", types.RelaySubmitResponse{TransactionHash: "0xdef"}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			submitted = nil
+			_, _ = client.submittedTxHash(&c.resp)
+			if c.wantID == "" {
+				if len(submitted) != 0 {
+					t.Errorf("Expected no callback, got %+v", submitted)
+				}
+				return
+			}
+			if len(submitted) != 1 || submitted[0].TransactionID != c.wantID {
+				t.Errorf("Expected callback with transactionID %s, got %+v", c.wantID, submitted)
+			}
+		})
+	}
+}
+
+func TestGetRelayTransaction(t *testing.T) {
+	const id = "0190f2a1-7c1e-7d4e-b6a0-3e1f5e6a7b8c"
+	newClient := func(fixtures ...test.Fixture) (*GaslessClient, *sdkhttp.Replayer) {
+		transport := test.NewFixtureTransport(t, fixtures...)
+		return &GaslessClient{httpClient: &http.Client{Transport: transport}, relayURL: internal.RelayerDomain}, transport
+	}
+
+	t.Run("Mined", func(t *testing.T) {
+		client, transport := newClient(test.Fixture{
+			Path: "/transaction",
+			Body: `[{"transactionID":"` + id + `","transactionHash":"0xabc","state":"STATE_MINED","from":"0x01","to":"0x02","createdAt":"2026-10-15T08:00:00Z"}]`,
+		})
+		status, err := client.GetRelayTransaction(id)
+		if err != nil {
+			t.Fatalf("GetRelayTransaction failed: %v", err)
+		}
+		if status.TransactionHash != "0xabc" || status.State != types.RelayStateMined || !status.Pending() || status.Failed() {
+			t.Errorf("Unexpected status: %+v", status)
+		}
+		if requests := transport.Requests(); len(requests) != 1 || !strings.Contains(requests[0].URL, "id="+id) {
+			t.Errorf("Expected id query parameter, got %+v", requests)
+		}
+	})
+
+	// 交易尚未上链时没有交易哈希
+	t.Run("New", func(t *testing.T) {
+		client, _ := newClient(test.Fixture{Path: "/transaction", Body: `{"transactionID":"` + id + `","state":"STATE_NEW"}`})
+		status, err := client.GetRelayTransaction(id)
+		if err != nil || status.TransactionHash != "" || !status.Pending() {
+			t.Errorf("Expected pending status without hash, got %+v (err=%v)", status, err)
+		}
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		client, _ := newClient(test.Fixture{Path: "/transaction", Body: `[{"transactionID":"` + id + `","state":"STATE_FAILED","error":"execution reverted"}]`})
+		status, err := client.GetRelayTransaction(id)
+		if err != nil || !status.Failed() || status.Pending() || status.ErrorMessage != "execution reverted" {
+			t.Errorf("Expected failed status, got %+v (err=%v)", status, err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		for _, fixture := range []test.Fixture{
+			{Path: "/transaction", Body: `[]`},
+			{Path: "/transaction", Body: `[{"transactionID":"other","state":"STATE_MINED"}]`},
+			{Path: "/transaction", StatusCode: http.StatusNotFound, Body: `{"error":"not found"}`},
+		} {
+			client, _ := newClient(fixture)
+			if _, err := client.GetRelayTransaction(id); !errors.Is(err, types.ErrRelayTxNotFound) {
+				t.Errorf("Expected ErrRelayTxNotFound for %s, got %v", fixture.Body, err)
+			}
+		}
+	})
+
+	// Cloudflare 拦截时返回 HTML 页面
+	t.Run("Blocked", func(t *testing.T) {
+		client, _ := newClient(test.Fixture{
+			Path:       "/transaction",
+			StatusCode: http.StatusForbidden,
+			Body:       "<!DOCTYPE html>\n<html><head><title>Just a moment...</title></head><body></body></html>",
+		})
+		_, err := client.GetRelayTransaction(id)
+		if !errors.Is(err, types.ErrRelayerBlocked) || !strings.Contains(err.Error(), "HTTP 403: Just a moment...") {
+			t.Errorf("Expected ErrRelayerBlocked with status and title, got %v", err)
+		}
+	})
+
+	t.Run("EmptyID", func(t *testing.T) {
+		client, _ := newClient()
+		if _, err := client.GetRelayTransaction(""); err == nil {
+			t.Error("Expected error for empty id")
+		}
+	})
+}
+
+func TestEncodeIncrementNonce(t *testing.T) {
+	data, err := encodeIncrementNonce()
+	if err != nil {
+		t.Fatalf("encodeIncrementNonce failed: %v", err)
+	}
+	// incrementNonce() 的函数选择器，没有参数
+	if got := fmt.Sprintf("%x", data); got != "627cdcb9" {
+		t.Errorf("Expected selector 627cdcb9, got %s", got)
+	}
+}
+
+func TestRelayGasLimit(t *testing.T) {
+	newClient := func(options ...ClientOption) *GaslessClient {
+		opts := &clientOptions{}
+		for _, opt := range options {
+			opt(opts)
+		}
+		base := &baseClient{}
+		opts.applyGasEstimate(base)
+		return &GaslessClient{baseClient: base}
+	}
+	estimateErr := errors.New("execution reverted")
+
+	t.Run("Default", func(t *testing.T) {
+		client := newClient()
+		if got := client.relayGasLimit(1_000_000, nil, 2); got != 1_400_000 {
+			t.Errorf("Expected 1.3x + 100k = 1400000, got %d", got)
+		}
+	})
+
+	// 估算失败时 gas limit 随交易数线性增长，优先使用最近一次成功估算的每笔 gas
+	t.Run("FallbackScalesWithBatch", func(t *testing.T) {
+		client := newClient()
+		if got := client.relayGasLimit(0, estimateErr, 3); got != internal.DefaultGasEstimate*3*13/10+internal.GasEstimateExtra {
+			t.Errorf("Expected default estimate for 3 transactions, got %d", got)
+		}
+
+		client.relayGasLimit(400_000, nil, 2) // 每笔 200k
+		small := client.relayGasLimit(0, estimateErr, 2)
+		large := client.relayGasLimit(0, estimateErr, 20)
+		if small != 200_000*2*13/10+100_000 || large != 200_000*20*13/10+100_000 {
+			t.Errorf("Expected fallback from last estimate, got %d for 2 and %d for 20 transactions", small, large)
+		}
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		client := newClient(WithGasEstimateMultiplier(2), WithGasEstimateExtra(0))
+		if got := client.relayGasLimit(1_000_000, nil, 10); got != 2_000_000 {
+			t.Errorf("Expected 2x with no extra, got %d", got)
+		}
+		// 小于 1 的倍数被忽略
+		client = newClient(WithGasEstimateMultiplier(0.5))
+		if got := client.relayGasLimit(1_000_000, nil, 1); got != 1_400_000 {
+			t.Errorf("Expected default multiplier, got %d", got)
+		}
+	})
+}
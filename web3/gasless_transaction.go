@@ -74,11 +74,14 @@ func (c *GaslessClient) RedeemPositions(
 
 		if pos.NegRisk {
 			// Use neg risk adapter
-			to = common.HexToAddress(internal.PolygonNegRiskAdapter)
+			to, err = c.contractAddress("NegRiskAdapter", c.contracts.NegRiskAdapter)
+			if err != nil {
+				return nil, err
+			}
 			data, err = c.encodeRedeemNegRisk(pos.ConditionID, intAmounts)
 		} else {
 			// Use conditional tokens
-			to = common.HexToAddress(internal.PolygonConditionalTokens)
+			to = common.HexToAddress(c.contracts.ConditionalTokens)
 			data, err = c.encodeRedeem(pos.ConditionID)
 		}
 
@@ -102,7 +105,7 @@ func (c *GaslessClient) RedeemPositions(
 
 // encodeRedeem encodes redeem positions transaction for regular markets
 func (c *GaslessClient) encodeRedeem(conditionID types.Keccak256) ([]byte, error) {
-	usdcAddr := common.HexToAddress(internal.PolygonCollateral)
+	usdcAddr := common.HexToAddress(c.contracts.Collateral)
 	hashZero := common.HexToHash(internal.HashZero)
 	indexSets := []*big.Int{big.NewInt(1), big.NewInt(2)}
 
@@ -171,9 +174,13 @@ func (c *GaslessClient) SplitUSDC(amount float64, conditionID types.Keccak256, n
 	var ctfContract common.Address
 
 	if negRisk {
-		ctfContract = common.HexToAddress(internal.PolygonNegRiskAdapter)
+		var err error
+		ctfContract, err = c.contractAddress("NegRiskAdapter", c.contracts.NegRiskAdapter)
+		if err != nil {
+			return nil, err
+		}
 	} else {
-		ctfContract = common.HexToAddress(internal.PolygonConditionalTokens)
+		ctfContract = common.HexToAddress(c.contracts.ConditionalTokens)
 	}
 
 	// Execute split
@@ -222,11 +229,14 @@ func (c *GaslessClient) MergeTokens(conditionID types.Keccak256, amount float64,
 
 	if negRisk {
 		// Use neg risk adapter for merge
-		to = common.HexToAddress(internal.PolygonNegRiskAdapter)
+		to, err = c.contractAddress("NegRiskAdapter", c.contracts.NegRiskAdapter)
+		if err != nil {
+			return nil, err
+		}
 		data, err = c.encodeMergeNegRisk(conditionID, intAmount)
 	} else {
 		// Use conditional tokens
-		to = common.HexToAddress(internal.PolygonConditionalTokens)
+		to = common.HexToAddress(c.contracts.ConditionalTokens)
 		data, err = c.encodeMerge(conditionID, intAmount)
 	}
 
@@ -258,7 +268,7 @@ func (c *GaslessClient) MergeTokens(conditionID types.Keccak256, amount float64,
 // 8. partition[0] (uint256)
 // 9. partition[1] (uint256)
 func (c *GaslessClient) encodeSplit(conditionID types.Keccak256, amount *big.Int) ([]byte, error) {
-	usdcAddr := common.HexToAddress(internal.PolygonCollateral)
+	usdcAddr := common.HexToAddress(c.contracts.Collateral)
 	hashZero := common.HexToHash(internal.HashZero)
 	partition := []*big.Int{big.NewInt(1), big.NewInt(2)} // Partition [1, 2] for binary markets (YES|NO)
 
@@ -347,7 +357,7 @@ func (c *GaslessClient) encodeSplitNegRisk(conditionID types.Keccak256, amount *
 // 8. partition[0] (uint256)
 // 9. partition[1] (uint256)
 func (c *GaslessClient) encodeMerge(conditionID types.Keccak256, amount *big.Int) ([]byte, error) {
-	usdcAddr := common.HexToAddress(internal.PolygonCollateral)
+	usdcAddr := common.HexToAddress(c.contracts.Collateral)
 	hashZero := common.HexToHash(internal.HashZero)
 	partition := []*big.Int{big.NewInt(1), big.NewInt(2)} // Partition [1, 2] for binary markets (YES|NO)
 
@@ -0,0 +1,123 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// multicall3Call 对应 Multicall3.aggregate3 的 Call3 参数
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// multicall3Result 对应 Multicall3.aggregate3 的 Result 返回值
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Multicall 通过 Multicall3 在一次 eth_call 中执行多个只读调用
+// 返回结果与 calls 顺序一致；AllowFailure 的调用失败时对应结果为 nil，
+// 其他调用失败时整批返回错误
+func (c *baseClient) Multicall(calls []types.Call) ([][]byte, error) {
+	if len(calls) == 0 {
+		return [][]byte{}, nil
+	}
+
+	parsedABI, err := getMulticall3ABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse multicall ABI: %w", err)
+	}
+	packed, err := packMulticall(parsedABI, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	multicallAddr := common.HexToAddress(internal.Multicall3Address)
+	result, err := c.callContractWithRetry(context.Background(), ethereum.CallMsg{To: &multicallAddr, Data: packed}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call multicall: %w", err)
+	}
+	return unpackMulticall(parsedABI, result, len(calls))
+}
+
+// packMulticall 将调用列表编码为 aggregate3 调用数据
+func packMulticall(parsedABI *abi.ABI, calls []types.Call) ([]byte, error) {
+	args := make([]multicall3Call, len(calls))
+	for i, call := range calls {
+		if err := call.Target.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid target for call %d: %w", i, err)
+		}
+		args[i] = multicall3Call{
+			Target:       common.HexToAddress(call.Target.String()),
+			AllowFailure: call.AllowFailure,
+			CallData:     call.Data,
+		}
+	}
+	packed, err := parsedABI.Pack("aggregate3", args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack aggregate3: %w", err)
+	}
+	return packed, nil
+}
+
+// unpackMulticall 解码 aggregate3 返回值，失败的调用结果为 nil
+func unpackMulticall(parsedABI *abi.ABI, data []byte, expected int) ([][]byte, error) {
+	var results []multicall3Result
+	if err := parsedABI.UnpackIntoInterface(&results, "aggregate3", data); err != nil {
+		return nil, fmt.Errorf("failed to unpack aggregate3: %w", err)
+	}
+	if len(results) != expected {
+		return nil, fmt.Errorf("multicall returned %d results, expected %d", len(results), expected)
+	}
+
+	out := make([][]byte, len(results))
+	for i, result := range results {
+		if result.Success {
+			out[i] = result.ReturnData
+		}
+	}
+	return out, nil
+}
+
+// getMulticall3ABI Multicall3 合约的 aggregate3 方法
+func getMulticall3ABI() (*abi.ABI, error) {
+	abiJSON := `[{
+		"inputs": [{
+			"components": [
+				{"internalType": "address", "name": "target", "type": "address"},
+				{"internalType": "bool", "name": "allowFailure", "type": "bool"},
+				{"internalType": "bytes", "name": "callData", "type": "bytes"}
+			],
+			"internalType": "struct Multicall3.Call3[]",
+			"name": "calls",
+			"type": "tuple[]"
+		}],
+		"name": "aggregate3",
+		"outputs": [{
+			"components": [
+				{"internalType": "bool", "name": "success", "type": "bool"},
+				{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+			],
+			"internalType": "struct Multicall3.Result[]",
+			"name": "returnData",
+			"type": "tuple[]"
+		}],
+		"stateMutability": "payable",
+		"type": "function"
+	}]`
+	parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return &parsedABI, nil
+}
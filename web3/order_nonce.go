@@ -0,0 +1,159 @@
+package web3
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// GetExchangeNonce 查询订单 maker（Proxy/Safe 模式为代理地址，EOA 模式为基础地址）在交易所合约上的当前 nonce
+// negRisk 为 true 时查询 NegRiskCTFExchange，否则查询 CTFExchange；两个合约的 nonce 相互独立。
+// 交易所只接受 nonce 与当前值相等的订单；签名订单默认使用的是 OrderNonce 缓存的值
+func (c *baseClient) GetExchangeNonce(negRisk bool) (int64, error) {
+	maker, err := c.GetPolyProxyAddress()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get maker address: %w", err)
+	}
+
+	parsedABI, err := getExchangeNonceABI()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	packed, err := parsedABI.Pack("nonces", common.HexToAddress(string(maker)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to pack nonces: %w", err)
+	}
+
+	exchange := c.exchangeContract(negRisk)
+	result, err := c.callContractWithRetry(context.Background(), ethereum.CallMsg{To: &exchange, Data: packed}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call nonces: %w", err)
+	}
+	var nonce *big.Int
+	if err := parsedABI.UnpackIntoInterface(&nonce, "nonces", result); err != nil {
+		return 0, fmt.Errorf("failed to unpack nonces: %w", err)
+	}
+	if !nonce.IsInt64() {
+		return 0, fmt.Errorf("exchange nonce out of range: %s", nonce)
+	}
+	return nonce.Int64(), nil
+}
+
+// orderNonceCache 缓存两个交易所合约上的 maker nonce，nonce 只在 incrementNonce 之后变化
+type orderNonceCache struct {
+	mu     sync.Mutex
+	nonces map[bool]int64 // 以 negRisk 为键
+}
+
+// OrderNonce 返回签名订单默认使用的 nonce，即缓存的 GetExchangeNonce(negRisk)，首次调用时查询链上
+// 同一客户端的 CancelAllOnchain 成功后刷新缓存；通过其他客户端或直接调用合约递增 nonce 时，需要自行设置 OrderArgs.Nonce
+func (c *baseClient) OrderNonce(negRisk bool) (int64, error) {
+	c.orderNonces.mu.Lock()
+	nonce, ok := c.orderNonces.nonces[negRisk]
+	c.orderNonces.mu.Unlock()
+	if ok {
+		return nonce, nil
+	}
+
+	nonce, err := c.GetExchangeNonce(negRisk)
+	if err != nil {
+		return 0, err
+	}
+	c.orderNonces.store(negRisk, nonce)
+	return nonce, nil
+}
+
+// store 缓存 nonce
+func (cache *orderNonceCache) store(negRisk bool, nonce int64) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.nonces == nil {
+		cache.nonces = make(map[bool]int64, 2)
+	}
+	cache.nonces[negRisk] = nonce
+}
+
+// refreshOrderNonces 重新查询两个交易所的 nonce，查询失败的交易所清除缓存，下次 OrderNonce 时再查询
+func (c *baseClient) refreshOrderNonces() {
+	for _, negRisk := range []bool{false, true} {
+		nonce, err := c.GetExchangeNonce(negRisk)
+		if err != nil {
+			internal.LogWarn("刷新交易所 nonce 失败（negRisk=%v）: %v", negRisk, err)
+			c.orderNonces.mu.Lock()
+			delete(c.orderNonces.nonces, negRisk)
+			c.orderNonces.mu.Unlock()
+			continue
+		}
+		c.orderNonces.store(negRisk, nonce)
+	}
+}
+
+// CancelAllOnchain 通过 Relayer 在 CTFExchange 和 NegRiskCTFExchange 上各调用一次 incrementNonce，
+// 使代理钱包此前签名的所有订单（nonce 等于旧值）在链上失效，不需要逐个撤单，也不依赖 CLOB API 是否可用。
+//
+// 注意：
+//   - 交易所只接受 nonce 与 maker 当前 nonce 相等的订单；成功后刷新 OrderNonce 的缓存，
+//     使用同一客户端的 CLOB 客户端之后签名的订单默认使用新 nonce
+//   - 失效的订单在撮合上链时被合约拒绝，CLOB 中可能仍显示为挂单，可再调用 CLOB 的 CancelAll 清理
+func (c *GaslessClient) CancelAllOnchain() (*types.TransactionReceipt, error) {
+	data, err := encodeIncrementNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode incrementNonce: %w", err)
+	}
+
+	proxyTxns := make([]map[string]interface{}, 0, 2)
+	for _, negRisk := range []bool{false, true} {
+		proxyTxns = append(proxyTxns, map[string]interface{}{
+			"typeCode": 1,
+			"to":       c.exchangeContract(negRisk).Hex(),
+			"value":    0,
+			"data":     "0x" + hex.EncodeToString(data),
+		})
+	}
+
+	receipt, err := c.executeGaslessBatch(proxyTxns, "Cancel All Onchain", "cancel all onchain")
+	if err != nil {
+		return nil, err
+	}
+	c.refreshOrderNonces()
+	return receipt, nil
+}
+
+// exchangeContract 返回当前链上的交易所合约地址
+func (c *baseClient) exchangeContract(negRisk bool) common.Address {
+	if negRisk {
+		return common.HexToAddress(c.contracts.NegRiskExchange)
+	}
+	return common.HexToAddress(c.contracts.Exchange)
+}
+
+// encodeIncrementNonce 编码 incrementNonce() 调用
+func encodeIncrementNonce() ([]byte, error) {
+	parsedABI, err := getExchangeNonceABI()
+	if err != nil {
+		return nil, err
+	}
+	return parsedABI.Pack("incrementNonce")
+}
+
+// getExchangeNonceABI 交易所合约中与订单 nonce 相关的方法
+func getExchangeNonceABI() (*abi.ABI, error) {
+	abiJSON := `[
+		{"inputs":[{"name":"","type":"address"}],"name":"nonces","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+		{"inputs":[],"name":"incrementNonce","outputs":[],"stateMutability":"nonpayable","type":"function"}
+	]`
+	parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return &parsedABI, nil
+}
@@ -0,0 +1,42 @@
+package web3
+
+import (
+	"regexp"
+)
+
+var (
+	// signatureFieldPattern 匹配 JSON 中的签名字段值
+	signatureFieldPattern = regexp.MustCompile(`("signature"\s*:\s*)"[^"]*"`)
+	// rawSignaturePattern 匹配 65 字节的十六进制签名
+	rawSignaturePattern = regexp.MustCompile(`0x[0-9a-fA-F]{130}\b`)
+	// addressPattern 匹配独立出现的以太坊地址（不匹配更长的哈希）
+	addressPattern = regexp.MustCompile(`\b0x[0-9a-fA-F]{40}\b`)
+)
+
+// redactSensitive 屏蔽调试输出中的签名和钱包地址
+// 签名整体替换为 <redacted>，地址只保留前 6 位和后 4 位
+func redactSensitive(s string) string {
+	s = signatureFieldPattern.ReplaceAllString(s, `$1"<redacted>"`)
+	s = rawSignaturePattern.ReplaceAllString(s, "<redacted>")
+	return addressPattern.ReplaceAllStringFunc(s, func(addr string) string {
+		return addr[:6] + "..." + addr[len(addr)-4:]
+	})
+}
+
+// debugString 按客户端的脱敏配置处理调试输出
+func (c *baseClient) debugString(s string) string {
+	if !c.debugRedaction {
+		return s
+	}
+	return redactSensitive(s)
+}
+
+// debugPreview 先对完整内容脱敏再截取前 n 个字节，避免签名被截断后不再匹配脱敏规则而原样输出
+// 超过 n 个字节时末尾追加 "..."
+func (c *baseClient) debugPreview(s string, n int) string {
+	s = c.debugString(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
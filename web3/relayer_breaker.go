@@ -0,0 +1,133 @@
+package web3
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// circuitBreaker Relayer 调用的熔断器
+// 连续失败 threshold 次后进入 open 状态，cooldown 内的调用直接返回 types.ErrRelayerUnavailable；
+// 冷却结束后进入 half_open 状态并只放行一次探测调用：成功则恢复 closed，失败则重新 open
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     types.CircuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool // half_open 状态下是否已有探测调用在进行
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+}
+
+// newCircuitBreaker 创建熔断器，threshold 或 cooldown 不大于 0 时使用 internal 中的默认值
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = internal.RelayerBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = internal.RelayerBreakerCooldown
+	}
+	return &circuitBreaker{
+		state:     types.CircuitClosed,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow 检查是否允许发起调用，熔断中返回 types.ErrRelayerUnavailable
+// probe 为 true 表示本次调用是 half_open 状态下的探测调用
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case types.CircuitOpen:
+		remaining := b.cooldown - b.now().Sub(b.openedAt)
+		if remaining > 0 {
+			return false, fmt.Errorf("%w: retry in %s", types.ErrRelayerUnavailable, remaining.Round(time.Second))
+		}
+		b.state = types.CircuitHalfOpen
+		b.probing = true
+		internal.LogInfo("Relayer 熔断冷却结束，发送探测请求")
+		return true, nil
+	case types.CircuitHalfOpen:
+		if b.probing {
+			return false, fmt.Errorf("%w: probing for recovery", types.ErrRelayerUnavailable)
+		}
+		b.probing = true
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// release 探测调用在记录成功或失败之前返回（如本地签名失败）时释放探测名额
+// 只能由获得探测名额（allow 返回 probe 为 true）的调用执行，否则会放行第二个并发探测
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// recordSuccess 调用成功，恢复为 closed 状态
+func (b *circuitBreaker) recordSuccess() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != types.CircuitClosed {
+		internal.LogInfo("Relayer 已恢复，熔断器关闭")
+	}
+	b.state = types.CircuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// recordFailure 调用失败（网络错误、超时或 5xx），连续失败达到阈值或探测失败时进入 open 状态
+func (b *circuitBreaker) recordFailure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.state == types.CircuitHalfOpen || b.failures >= b.threshold {
+		if b.state != types.CircuitOpen {
+			internal.LogWarn("Relayer 连续失败 %d 次，熔断 %s", b.failures, b.cooldown)
+		}
+		b.state = types.CircuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+// currentState 返回熔断器当前状态，open 状态冷却结束后报告为 half_open
+func (b *circuitBreaker) currentState() types.CircuitState {
+	if b == nil {
+		return types.CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == types.CircuitOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return types.CircuitHalfOpen
+	}
+	return b.state
+}
+
+// RelayerCircuitState 返回 Relayer 熔断器的当前状态
+// 为 open 时 RedeemPositions 等 Relayer 调用会立即返回 types.ErrRelayerUnavailable
+func (c *GaslessClient) RelayerCircuitState() types.CircuitState {
+	return c.relayerBreaker.currentState()
+}
@@ -0,0 +1,254 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/polymas/go-polymarket-sdk/internal"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// GetConditionResolution 查询条件在 ConditionalTokens 合约上的结算状态
+func (c *baseClient) GetConditionResolution(conditionID types.Keccak256) (*types.ConditionResolution, error) {
+	return c.getConditionResolution(context.Background(), conditionID)
+}
+
+// conditionResolutionBatchSize 单次 Multicall 查询的最大条件数，避免 eth_call 超出公共 RPC 的 gas 上限
+const conditionResolutionBatchSize = 200
+
+// GetConditionResolutions 通过 Multicall 批量查询多个条件的结算状态，返回以条件ID为键的结果（重复的条件ID只查询一次）
+// 每批条件先在一次 RPC 中读取 getOutcomeSlotCount 和 payoutDenominator，再在一次 RPC 中读取已结算条件的 payoutNumerators，
+// 未结算的条件不需要第二次读取。每批最多 conditionResolutionBatchSize 个条件，任一批次失败时返回错误
+func (c *baseClient) GetConditionResolutions(conditionIDs []types.Keccak256) (map[types.Keccak256]*types.ConditionResolution, error) {
+	unique := make([]types.Keccak256, 0, len(conditionIDs))
+	seen := make(map[types.Keccak256]bool, len(conditionIDs))
+	for _, conditionID := range conditionIDs {
+		if err := conditionID.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid condition ID %q: %w", conditionID, err)
+		}
+		if !seen[conditionID] {
+			seen[conditionID] = true
+			unique = append(unique, conditionID)
+		}
+	}
+
+	parsedABI, err := getConditionResolutionABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	result := make(map[types.Keccak256]*types.ConditionResolution, len(unique))
+	for start := 0; start < len(unique); start += conditionResolutionBatchSize {
+		end := min(start+conditionResolutionBatchSize, len(unique))
+		if err := c.getConditionResolutionBatch(parsedABI, unique[start:end], result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// getConditionResolutionBatch 用两次 Multicall 查询一批条件的结算状态，结果写入 result
+func (c *baseClient) getConditionResolutionBatch(parsedABI *abi.ABI, conditionIDs []types.Keccak256, result map[types.Keccak256]*types.ConditionResolution) error {
+	ctf := types.EthAddress(c.contracts.ConditionalTokens)
+	pack := func(method string, args ...interface{}) (types.Call, error) {
+		data, err := parsedABI.Pack(method, args...)
+		if err != nil {
+			return types.Call{}, fmt.Errorf("failed to pack %s: %w", method, err)
+		}
+		return types.Call{Target: ctf, Data: data}, nil
+	}
+	unpack := func(method string, data []byte) (*big.Int, error) {
+		var value *big.Int
+		if err := parsedABI.UnpackIntoInterface(&value, method, data); err != nil {
+			return nil, fmt.Errorf("failed to unpack %s: %w", method, err)
+		}
+		return value, nil
+	}
+
+	calls := make([]types.Call, 0, 2*len(conditionIDs))
+	for _, conditionID := range conditionIDs {
+		condition := common.HexToHash(conditionID.String())
+		for _, method := range []string{"getOutcomeSlotCount", "payoutDenominator"} {
+			call, err := pack(method, condition)
+			if err != nil {
+				return err
+			}
+			calls = append(calls, call)
+		}
+	}
+	results, err := c.Multicall(calls)
+	if err != nil {
+		return err
+	}
+
+	resolved := make([]*types.ConditionResolution, 0, len(conditionIDs))
+	var numeratorCalls []types.Call
+	for i, conditionID := range conditionIDs {
+		slotCount, err := unpack("getOutcomeSlotCount", results[2*i])
+		if err != nil {
+			return err
+		}
+		denominator, err := unpack("payoutDenominator", results[2*i+1])
+		if err != nil {
+			return err
+		}
+		resolution := &types.ConditionResolution{
+			ConditionID:       conditionID,
+			OutcomeSlotCount:  int(slotCount.Int64()),
+			PayoutDenominator: denominator.Uint64(),
+			Resolved:          denominator.Sign() > 0,
+		}
+		result[conditionID] = resolution
+		if !resolution.Resolved {
+			continue
+		}
+
+		resolved = append(resolved, resolution)
+		condition := common.HexToHash(conditionID.String())
+		for slot := 0; slot < resolution.OutcomeSlotCount; slot++ {
+			call, err := pack("payoutNumerators", condition, big.NewInt(int64(slot)))
+			if err != nil {
+				return err
+			}
+			numeratorCalls = append(numeratorCalls, call)
+		}
+	}
+	if len(numeratorCalls) == 0 {
+		return nil
+	}
+
+	results, err = c.Multicall(numeratorCalls)
+	if err != nil {
+		return err
+	}
+	index := 0
+	for _, resolution := range resolved {
+		resolution.PayoutNumerators = make([]uint64, resolution.OutcomeSlotCount)
+		for slot := range resolution.PayoutNumerators {
+			numerator, err := unpack("payoutNumerators", results[index])
+			if err != nil {
+				return err
+			}
+			resolution.PayoutNumerators[slot] = numerator.Uint64()
+			index++
+		}
+	}
+	return nil
+}
+
+// WatchResolution 监听条件结算，结算后发送一次结果并关闭通道
+// 按 internal.ResolutionPollInitialInterval 开始轮询，逐步退避到 internal.ResolutionPollMaxInterval；
+// 查询失败只记录日志并继续轮询。ctx 结束时关闭通道且不发送结果
+func (c *baseClient) WatchResolution(ctx context.Context, conditionID types.Keccak256) (<-chan types.ConditionResolution, error) {
+	if err := conditionID.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid condition ID %q: %w", conditionID, err)
+	}
+
+	ch := make(chan types.ConditionResolution, 1)
+	go func() {
+		defer close(ch)
+
+		interval := internal.ResolutionPollInitialInterval
+		for {
+			resolution, err := c.getConditionResolution(ctx, conditionID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				internal.LogWarn("查询条件 %s 结算状态失败: %v", conditionID, err)
+			} else if resolution.Resolved {
+				ch <- *resolution
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			interval *= 2
+			if interval > internal.ResolutionPollMaxInterval {
+				interval = internal.ResolutionPollMaxInterval
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// getConditionResolution 读取 getOutcomeSlotCount、payoutDenominator 和 payoutNumerators
+func (c *baseClient) getConditionResolution(ctx context.Context, conditionID types.Keccak256) (*types.ConditionResolution, error) {
+	if err := conditionID.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid condition ID %q: %w", conditionID, err)
+	}
+
+	parsedABI, err := getConditionResolutionABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+	ctfAddr := common.HexToAddress(c.contracts.ConditionalTokens)
+	condition := common.HexToHash(conditionID.String())
+
+	call := func(method string, out interface{}, args ...interface{}) error {
+		packed, err := parsedABI.Pack(method, args...)
+		if err != nil {
+			return fmt.Errorf("failed to pack %s: %w", method, err)
+		}
+		result, err := c.callContractWithRetry(ctx, ethereum.CallMsg{To: &ctfAddr, Data: packed}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to call %s: %w", method, err)
+		}
+		if err := parsedABI.UnpackIntoInterface(out, method, result); err != nil {
+			return fmt.Errorf("failed to unpack %s: %w", method, err)
+		}
+		return nil
+	}
+
+	var slotCount *big.Int
+	if err := call("getOutcomeSlotCount", &slotCount, condition); err != nil {
+		return nil, err
+	}
+	var denominator *big.Int
+	if err := call("payoutDenominator", &denominator, condition); err != nil {
+		return nil, err
+	}
+
+	resolution := &types.ConditionResolution{
+		ConditionID:       conditionID,
+		OutcomeSlotCount:  int(slotCount.Int64()),
+		PayoutDenominator: denominator.Uint64(),
+		Resolved:          denominator.Sign() > 0,
+	}
+	if !resolution.Resolved {
+		return resolution, nil
+	}
+
+	resolution.PayoutNumerators = make([]uint64, resolution.OutcomeSlotCount)
+	for i := 0; i < resolution.OutcomeSlotCount; i++ {
+		var numerator *big.Int
+		if err := call("payoutNumerators", &numerator, condition, big.NewInt(int64(i))); err != nil {
+			return nil, err
+		}
+		resolution.PayoutNumerators[i] = numerator.Uint64()
+	}
+	return resolution, nil
+}
+
+// getConditionResolutionABI ConditionalTokens 合约中查询结算状态的只读方法
+func getConditionResolutionABI() (*abi.ABI, error) {
+	abiJSON := `[
+		{"constant":true,"inputs":[{"name":"conditionId","type":"bytes32"}],"name":"getOutcomeSlotCount","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+		{"constant":true,"inputs":[{"name":"","type":"bytes32"}],"name":"payoutDenominator","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+		{"constant":true,"inputs":[{"name":"","type":"bytes32"},{"name":"","type":"uint256"}],"name":"payoutNumerators","outputs":[{"name":"","type":"uint256"}],"type":"function"}
+	]`
+	parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return &parsedABI, nil
+}
@@ -0,0 +1,84 @@
+package web3
+
+import (
+	"sync"
+	"time"
+
+	"github.com/polymas/go-polymarket-sdk/internal"
+)
+
+// rpcHealth 记录各 RPC 节点的健康状态
+// 节点出现可重试错误（网络错误、超时、限流）后在 internal.RPCUnhealthyCooldown 内视为不健康，
+// 调用时优先轮询健康节点，不健康的节点只在健康节点都失败后才尝试
+type rpcHealth struct {
+	mu             sync.Mutex
+	failures       []int       // 连续失败次数
+	unhealthyUntil []time.Time // 在该时间之前视为不健康
+	now            func() time.Time
+}
+
+// newRPCHealth 创建 n 个节点的健康状态记录
+func newRPCHealth(n int) *rpcHealth {
+	return &rpcHealth{
+		failures:       make([]int, n),
+		unhealthyUntil: make([]time.Time, n),
+		now:            time.Now,
+	}
+}
+
+// recordSuccess 节点调用成功，恢复为健康状态
+func (h *rpcHealth) recordSuccess(index int) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if index < 0 || index >= len(h.failures) {
+		return
+	}
+	h.failures[index] = 0
+	h.unhealthyUntil[index] = time.Time{}
+}
+
+// recordFailure 节点出现可重试错误，在冷却时间内标记为不健康
+func (h *rpcHealth) recordFailure(index int) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if index < 0 || index >= len(h.failures) {
+		return
+	}
+	h.failures[index]++
+	h.unhealthyUntil[index] = h.now().Add(internal.RPCUnhealthyCooldown)
+	internal.LogDebug("RPC 节点 #%d 连续失败 %d 次，%s 内优先使用其他节点", index, h.failures[index], internal.RPCUnhealthyCooldown)
+}
+
+// order 返回本次调用尝试节点的顺序：从 start 开始轮询，健康节点在前，不健康节点在后
+func (h *rpcHealth) order(start, n int) []int {
+	healthy := make([]int, 0, n)
+	var unhealthy []int
+
+	var now time.Time
+	if h != nil {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		now = h.now()
+	}
+
+	for i := 0; i < n; i++ {
+		index := (start + i) % n
+		if h != nil && index < len(h.unhealthyUntil) && now.Before(h.unhealthyUntil[index]) {
+			unhealthy = append(unhealthy, index)
+			continue
+		}
+		healthy = append(healthy, index)
+	}
+	return append(healthy, unhealthy...)
+}
+
+// rpcOrder 返回本次 RPC 调用尝试节点的顺序（轮询起点每次递增）
+func (c *baseClient) rpcOrder(n int) []int {
+	return c.rpcHealth.order(c.getNextClientIndex(), n)
+}
@@ -0,0 +1,145 @@
+package web3
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// GetTokenHoldings 查询地址持有的多个条件代币（ERC1155）余额，以及 CTFExchange、NegRiskCTFExchange 是否为其授权操作员
+// 所有读取通过 Multicall 在一次 RPC 中完成，用于提交 SELL 订单前检查代币是否足够、交易所能否转移代币
+func (c *baseClient) GetTokenHoldings(address types.EthAddress, tokenIDs []string) (*types.TokenHoldings, error) {
+	if err := address.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", address, err)
+	}
+
+	parsedABI, err := getERC1155ABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	owner := common.HexToAddress(string(address))
+	ctf := types.EthAddress(c.contracts.ConditionalTokens)
+	calls := make([]types.Call, 0, len(tokenIDs)+2)
+	for _, tokenID := range tokenIDs {
+		id, ok := new(big.Int).SetString(tokenID, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid token ID: %s", tokenID)
+		}
+		data, err := parsedABI.Pack("balanceOf", owner, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack balanceOf: %w", err)
+		}
+		calls = append(calls, types.Call{Target: ctf, Data: data})
+	}
+	for _, negRisk := range []bool{false, true} {
+		data, err := parsedABI.Pack("isApprovedForAll", owner, c.exchangeContract(negRisk))
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack isApprovedForAll: %w", err)
+		}
+		calls = append(calls, types.Call{Target: ctf, Data: data})
+	}
+
+	results, err := c.Multicall(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := &types.TokenHoldings{Balances: make(map[string]*big.Int, len(tokenIDs))}
+	for i, tokenID := range tokenIDs {
+		var balance *big.Int
+		if err := parsedABI.UnpackIntoInterface(&balance, "balanceOf", results[i]); err != nil {
+			return nil, fmt.Errorf("failed to unpack balanceOf: %w", err)
+		}
+		holdings.Balances[tokenID] = balance
+	}
+	approvals := results[len(tokenIDs):]
+	if err := parsedABI.UnpackIntoInterface(&holdings.ExchangeApproved, "isApprovedForAll", approvals[0]); err != nil {
+		return nil, fmt.Errorf("failed to unpack isApprovedForAll: %w", err)
+	}
+	if err := parsedABI.UnpackIntoInterface(&holdings.NegRiskExchangeApproved, "isApprovedForAll", approvals[1]); err != nil {
+		return nil, fmt.Errorf("failed to unpack isApprovedForAll: %w", err)
+	}
+	return holdings, nil
+}
+
+// GetCollateralHoldings 查询地址的 USDC 余额及对 CTFExchange、NegRiskCTFExchange、NegRiskAdapter 的授权额度
+// 所有读取通过 Multicall 在一次 RPC 中完成，用于提交 BUY 订单前检查对应交易所能否转移 USDC；
+// 当前链未配置 NegRiskAdapter 时不查询其授权
+func (c *baseClient) GetCollateralHoldings(address types.EthAddress) (*types.CollateralHoldings, error) {
+	if err := address.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", address, err)
+	}
+
+	parsedABI, err := getERC20ABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	owner := common.HexToAddress(string(address))
+	spenders := []common.Address{c.exchangeContract(false), c.exchangeContract(true)}
+	if c.contracts.NegRiskAdapter != "" {
+		spenders = append(spenders, common.HexToAddress(c.contracts.NegRiskAdapter))
+	}
+
+	collateral := types.EthAddress(c.contracts.Collateral)
+	balanceData, err := parsedABI.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack balanceOf: %w", err)
+	}
+	calls := []types.Call{{Target: collateral, Data: balanceData}}
+	for _, spender := range spenders {
+		data, err := parsedABI.Pack("allowance", owner, spender)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack allowance: %w", err)
+		}
+		calls = append(calls, types.Call{Target: collateral, Data: data})
+	}
+
+	results, err := c.Multicall(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := &types.CollateralHoldings{}
+	if err := parsedABI.UnpackIntoInterface(&holdings.Balance, "balanceOf", results[0]); err != nil {
+		return nil, fmt.Errorf("failed to unpack balanceOf: %w", err)
+	}
+	allowances := []**big.Int{&holdings.ExchangeAllowance, &holdings.NegRiskExchangeAllowance, &holdings.NegRiskAdapterAllowance}
+	for i := range spenders {
+		if err := parsedABI.UnpackIntoInterface(allowances[i], "allowance", results[i+1]); err != nil {
+			return nil, fmt.Errorf("failed to unpack allowance: %w", err)
+		}
+	}
+	return holdings, nil
+}
+
+// getERC20ABI USDC 合约中与余额和授权额度相关的方法
+func getERC20ABI() (*abi.ABI, error) {
+	abiJSON := `[
+		{"constant":true,"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+		{"constant":true,"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"type":"function"}
+	]`
+	parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return &parsedABI, nil
+}
+
+// getERC1155ABI ConditionalTokens 合约中与余额和操作员授权相关的方法
+func getERC1155ABI() (*abi.ABI, error) {
+	abiJSON := `[
+		{"inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"name":"account","type":"address"},{"name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"}
+	]`
+	parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return &parsedABI, nil
+}
@@ -1,10 +1,12 @@
 package websocket
 
 import (
+	"net/url"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/polymas/go-polymarket-sdk/internal"
 	"github.com/polymas/go-polymarket-sdk/types"
 )
 
@@ -34,6 +36,10 @@ type Client interface {
 	UpdateSubscription(assetIDs []string) error
 	SubscribeAssets(assetIDs []string) error
 	UnsubscribeAssets(assetIDs []string) error
+	// OrderBook 返回根据订阅消息在本地维护的订单簿，未订阅、未收到快照或连接已断开时返回 false
+	OrderBook(assetID string) (*types.OrderBookSummary, bool)
+	// LocalBook 返回本地维护的订单簿，可注册最优价变化回调
+	LocalBook(assetID string) *LocalOrderBook
 	// USER 频道方法
 	StartUserChannel() error
 	StopUserChannel()
@@ -42,23 +48,23 @@ type Client interface {
 // webSocketClient 处理订单簿订阅的WebSocket连接
 // 不允许直接导出，只能通过 NewWebSocketClient 创建
 type webSocketClient struct {
-	url              string
-	conn             *websocket.Conn
-	connMutex        sync.RWMutex
-	subscribedIDs    []string
-	subscribedMutex  sync.RWMutex // 保护subscribedIDs的访问
-	onBookUpdate     func(assetID string, snapshot *types.BookSnapshot)
-	onOrderUpdate    func(order *types.OpenOrder)
-	onTradeUpdate    func(trade *types.PolygonTrade)
-	reconnectDelay   time.Duration
-	stopChan         chan struct{}
-	stopOnce         sync.Once // Ensure stopChan is only closed once
-	running          bool
-	runningMutex     sync.RWMutex
-	auth             *WebSocketAuth // Optional authentication (for authenticated channels)
-	lastConnected    time.Time      // 最后连接成功的时间
-	disconnectedAt   *time.Time     // 断连时间（nil表示已连接）
-	disconnectMutex  sync.RWMutex   // 保护断连时间
+	url             string
+	conn            *websocket.Conn
+	connMutex       sync.RWMutex
+	subscribedIDs   []string
+	subscribedMutex sync.RWMutex // 保护subscribedIDs的访问
+	onBookUpdate    func(assetID string, snapshot *types.BookSnapshot)
+	onOrderUpdate   func(order *types.OpenOrder)
+	onTradeUpdate   func(trade *types.PolygonTrade)
+	reconnectDelay  time.Duration
+	stopChan        chan struct{}
+	stopOnce        sync.Once // Ensure stopChan is only closed once
+	running         bool
+	runningMutex    sync.RWMutex
+	auth            *WebSocketAuth // Optional authentication (for authenticated channels)
+	lastConnected   time.Time      // 最后连接成功的时间
+	disconnectedAt  *time.Time     // 断连时间（nil表示已连接）
+	disconnectMutex sync.RWMutex   // 保护断连时间
 	// USER 频道相关
 	userConn         *websocket.Conn
 	userConnMutex    sync.RWMutex
@@ -66,15 +72,47 @@ type webSocketClient struct {
 	userRunningMutex sync.RWMutex
 	userStopChan     chan struct{}
 	userStopOnce     sync.Once
+	proxyURL         *url.URL // 客户端显式配置的代理（nil 表示使用环境变量）
+	proxyErr         error    // 显式配置的代理地址无效，Start 和 StartUserChannel 直接返回该错误
+
+	// 根据 MARKET 频道消息在本地维护的订单簿，连接断开时清空
+	books      map[string]*LocalOrderBook
+	booksMutex sync.RWMutex
+}
+
+// ClientOption WebSocket 客户端配置选项
+type ClientOption func(*clientOptions)
+
+// clientOptions WebSocket 客户端配置
+type clientOptions struct {
+	internal.ConnectionOptions
+}
+
+// WithProxyURL 设置客户端使用的代理地址
+// 支持的协议和无效地址的处理见 internal.ConnectionOptions
+func WithProxyURL(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.ProxyURL = proxyURL
+	}
+}
+
+// parseProxyOption 解析客户端配置中的代理地址，未配置时返回 nil（使用环境变量中的代理配置）
+// 代理地址无效时返回错误，由 Start 返回给调用方
+func parseProxyOption(options []ClientOption) (*url.URL, error) {
+	return internal.ApplyOptions(&clientOptions{}, options).Proxy()
 }
 
 // NewClient 创建新的WebSocket客户端
+// 代理地址无效时 Start 和 StartUserChannel 返回该错误
 // 返回 Client 接口，不允许直接访问实现类型
-func NewClient(reconnectDelay time.Duration) Client {
+func NewClient(reconnectDelay time.Duration, options ...ClientOption) Client {
+	proxyURL, proxyErr := parseProxyOption(options)
 	return &webSocketClient{
 		url:            wsMarketURL,
 		reconnectDelay: reconnectDelay,
 		stopChan:       make(chan struct{}),
+		proxyURL:       proxyURL,
+		proxyErr:       proxyErr,
 	}
 }
 
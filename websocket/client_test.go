@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -444,3 +445,177 @@ func TestStopUserChannel(t *testing.T) {
 		t.Logf("StopUserChannel succeeded")
 	})
 }
+
+func TestLocalOrderBook(t *testing.T) {
+	w := NewClient(test.DefaultReconnectDelay).(*webSocketClient)
+	w.running = true
+	assetID := test.FixtureYesTokenID
+
+	// 收到快照之前没有本地数据
+	if _, ok := w.OrderBook(assetID); ok {
+		t.Fatal("Expected no local book before snapshot")
+	}
+	w.handlePriceChange(map[string]interface{}{
+		"price_changes": []interface{}{map[string]interface{}{"asset_id": assetID, "price": "0.5", "size": "10", "side": "BUY"}},
+	})
+	if _, ok := w.OrderBook(assetID); ok {
+		t.Fatal("Expected price_change without snapshot to be ignored")
+	}
+
+	w.handleBookUpdate(map[string]interface{}{
+		"asset_id": assetID,
+		"bids":     []interface{}{map[string]interface{}{"price": "0.48", "size": "10"}, map[string]interface{}{"price": "0.5", "size": "5"}},
+		"asks":     []interface{}{map[string]interface{}{"price": "0.53", "size": "10"}, map[string]interface{}{"price": "0.52", "size": "5"}},
+	})
+	// 新格式：price_changes 中每项带 asset_id
+	w.handlePriceChange(map[string]interface{}{
+		"price_changes": []interface{}{
+			map[string]interface{}{"asset_id": assetID, "price": "0.5", "size": "0", "side": "BUY"},
+			map[string]interface{}{"asset_id": assetID, "price": "0.49", "size": "7", "side": "BUY"},
+		},
+	})
+	// 旧格式：顶层 asset_id 加 changes
+	w.handlePriceChange(map[string]interface{}{
+		"asset_id": assetID,
+		"changes":  []interface{}{map[string]interface{}{"price": "0.51", "size": "3", "side": "SELL"}},
+	})
+	w.handleLastTradePrice(map[string]interface{}{"asset_id": assetID, "price": "0.505"})
+
+	book, ok := w.OrderBook(assetID)
+	if !ok {
+		t.Fatal("Expected local book after snapshot")
+	}
+	if book.Source != types.BookSourceWSS || book.LastTradePrice != 0.505 {
+		t.Errorf("Unexpected book metadata: %+v", book)
+	}
+	if len(book.Bids) != 2 || book.Bids[0].Price != 0.49 || book.Bids[1].Price != 0.48 {
+		t.Errorf("Unexpected bids: %+v", book.Bids)
+	}
+	if len(book.Asks) != 3 || book.Asks[0].Price != 0.51 || book.Asks[0].Size != 3 || book.Asks[2].Price != 0.53 {
+		t.Errorf("Unexpected asks: %+v", book.Asks)
+	}
+
+	// 取消订阅后不再返回本地数据
+	w.removeBooks([]string{assetID})
+	if _, ok := w.OrderBook(assetID); ok {
+		t.Error("Expected no local book after unsubscribe")
+	}
+}
+
+func TestTopOfBookChange(t *testing.T) {
+	levels := func(prices ...float64) []types.OrderLevel {
+		result := make([]types.OrderLevel, len(prices))
+		for i, price := range prices {
+			result[i] = types.OrderLevel{Price: types.FloatString(price), Size: 10}
+		}
+		return result
+	}
+
+	t.Run("TopOnly", func(t *testing.T) {
+		book := NewLocalOrderBook("token")
+		var tops [][2]float64
+		book.OnTopOfBookChange(func(bid, ask float64) {
+			tops = append(tops, [2]float64{bid, ask})
+		})
+
+		book.ApplySnapshot(levels(0.48, 0.5), levels(0.52, 0.55))
+		book.ApplyChange("BUY", 0.47, 10)  // 更深的档位，不通知
+		book.ApplyChange("SELL", 0.55, 0)  // 更深的档位，不通知
+		book.ApplyChange("BUY", 0.51, 5)   // 最优买价变化
+		book.ApplyChange("SELL", 0.52, 20) // 最优价不变，只有数量变化
+		book.ApplyChange("SELL", 0.52, 0)  // 卖盘清空
+
+		want := [][2]float64{{0.5, 0.52}, {0.51, 0.52}, {0.51, 0}}
+		if !reflect.DeepEqual(tops, want) {
+			t.Errorf("Expected %v, got %v", want, tops)
+		}
+	})
+
+	t.Run("Debounce", func(t *testing.T) {
+		book := NewLocalOrderBook("token")
+		book.SetTopOfBookMinInterval(50 * time.Millisecond)
+
+		// 手动推进时间并触发延后的检查，不依赖真实计时
+		now := time.Unix(1700000000, 0)
+		var pending []func()
+		book.now = func() time.Time { return now }
+		book.afterFunc = func(d time.Duration, f func()) func() bool {
+			index := len(pending)
+			pending = append(pending, f)
+			return func() bool {
+				stopped := pending[index] != nil
+				pending[index] = nil
+				return stopped
+			}
+		}
+		fire := func() {
+			for i, f := range pending {
+				if f != nil {
+					pending[i] = nil
+					f()
+				}
+			}
+		}
+		var tops [][2]float64
+		book.OnTopOfBookChange(func(bid, ask float64) {
+			tops = append(tops, [2]float64{bid, ask})
+		})
+
+		book.ApplySnapshot(levels(0.5), levels(0.52))
+		// 间隔内跳动后又恢复：取消待发送的通知
+		now = now.Add(10 * time.Millisecond)
+		book.ApplyChange("BUY", 0.51, 5)
+		book.ApplyChange("BUY", 0.51, 0)
+		now = now.Add(50 * time.Millisecond)
+		fire()
+		if len(tops) != 1 {
+			t.Fatalf("Expected flip to be filtered, got %v", tops)
+		}
+
+		// 超过间隔后的变化立即通知，之后间隔内的多次变化合并为一次，使用最新的最优价
+		book.ApplyChange("SELL", 0.515, 5)
+		now = now.Add(10 * time.Millisecond)
+		book.ApplyChange("BUY", 0.505, 5)
+		book.ApplyChange("BUY", 0.51, 5)
+		if len(tops) != 2 {
+			t.Fatalf("Expected changes within the interval to wait, got %v", tops)
+		}
+		now = now.Add(40 * time.Millisecond)
+		fire()
+		want := [][2]float64{{0.5, 0.52}, {0.5, 0.515}, {0.51, 0.515}}
+		if !reflect.DeepEqual(tops, want) {
+			t.Errorf("Expected %v, got %v", want, tops)
+		}
+	})
+
+	// 断开重连后保留回调
+	t.Run("KeptAcrossReset", func(t *testing.T) {
+		w := NewClient(test.DefaultReconnectDelay).(*webSocketClient)
+		calls := 0
+		w.LocalBook("0xtoken").OnTopOfBookChange(func(bid, ask float64) { calls++ })
+		w.handleBookUpdate(map[string]interface{}{"asset_id": "token", "bids": []interface{}{map[string]interface{}{"price": "0.5", "size": "1"}}})
+		w.resetBooks()
+		w.handleBookUpdate(map[string]interface{}{"asset_id": "token", "bids": []interface{}{map[string]interface{}{"price": "0.49", "size": "1"}}})
+		if calls != 2 {
+			t.Errorf("Expected callback to survive reset, got %d calls", calls)
+		}
+	})
+}
+
+func TestInvalidProxyURL(t *testing.T) {
+	// 无效代理地址不能回退到环境变量代理或直连
+	client := NewClient(test.DefaultReconnectDelay, WithProxyURL("socks5://"))
+	if err := client.Start([]string{"token"}); err == nil {
+		client.Stop()
+		t.Fatal("Expected Start to fail for invalid proxy URL")
+	}
+	if client.IsRunning() {
+		t.Error("Expected client not to be running")
+	}
+
+	sports := NewSportsClient(test.DefaultReconnectDelay, WithProxyURL("socks5://"))
+	if err := sports.Start(); err == nil {
+		sports.Stop()
+		t.Error("Expected sports Start to fail for invalid proxy URL")
+	}
+}
@@ -1,14 +1,10 @@
 package websocket
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"log"
-	"net"
 	"net/http"
-	"net/url"
-	"os"
 	"sync"
 	"time"
 
@@ -18,6 +14,9 @@ import (
 
 // Start 启动WebSocket连接和订阅循环
 func (w *webSocketClient) Start(assetIDs []string) error {
+	if w.proxyErr != nil {
+		return w.proxyErr
+	}
 	w.runningMutex.Lock()
 	if w.running {
 		w.runningMutex.Unlock()
@@ -132,51 +131,10 @@ func (w *webSocketClient) logSubscriptionStats() {
 
 // connectAndListen connects to WebSocket and listens for messages
 func (w *webSocketClient) connectAndListen() error {
-	// Configure dialer to prefer IPv4 and increase timeout
-	netDialer := &net.Dialer{
-		Timeout:   internal.WebSocketDialTimeout,
-		DualStack: false, // Disable IPv6 to avoid timeout issues
-		KeepAlive: internal.WebSocketKeepAlive,
-	}
-
-	// Configure TLS
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false, // 验证SSL证书
-	}
-
-	// Check for proxy from environment variables
-	var proxyURL *url.URL
-	if proxyEnv := os.Getenv("HTTPS_PROXY"); proxyEnv != "" {
-		if proxyEnv == "" {
-			proxyEnv = os.Getenv("HTTP_PROXY")
-		}
-		if proxyEnv != "" {
-			proxyURL, _ = url.Parse(proxyEnv)
-		}
-	}
-
-	dialer := websocket.Dialer{
-		HandshakeTimeout: internal.WebSocketHandshakeTimeout,
-		TLSClientConfig:  tlsConfig,
-		Proxy: func(req *http.Request) (*url.URL, error) {
-			if proxyURL != nil {
-				return proxyURL, nil
-			}
-			return http.ProxyFromEnvironment(req)
-		},
-		NetDial: func(network, addr string) (net.Conn, error) {
-			// Force IPv4 by using "tcp4" instead of "tcp"
-			if network == "tcp" {
-				network = "tcp4"
-			}
-			// TCP连接日志已移除
-			conn, err := netDialer.Dial(network, addr)
-			return conn, err
-		},
-	}
+	dialer := internal.WebSocketDialer(w.proxyURL)
 
 	// WebSocket连接日志已移除
-	conn, _, err := dialer.Dial(w.url, nil)
+	conn, _, err := dialer.Dial(w.url, http.Header{"User-Agent": {internal.UserAgent()}})
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
@@ -185,6 +143,9 @@ func (w *webSocketClient) connectAndListen() error {
 	w.conn = conn
 	w.connMutex.Unlock()
 
+	// 断开期间可能漏掉增量消息，本地订单簿只在本次连接内有效，重连后等待新的 book 快照
+	defer w.resetBooks()
+
 	// Send subscription message according to Polymarket WSS documentation
 	// https://docs.polymarket.com/developers/CLOB/websocket/wss-overview
 	w.subscribedMutex.RLock()
@@ -272,9 +233,14 @@ func (w *webSocketClient) connectAndListen() error {
 					continue
 				}
 
-				// Handle book updates
-				if eventType, ok := msg["event_type"].(string); ok && eventType == "book" {
+				// Handle market channel events
+				switch eventType, _ := msg["event_type"].(string); eventType {
+				case "book":
 					w.handleBookUpdate(msg)
+				case "price_change":
+					w.handlePriceChange(msg)
+				case "last_trade_price":
+					w.handleLastTradePrice(msg)
 				}
 			}
 		}
@@ -283,44 +249,9 @@ func (w *webSocketClient) connectAndListen() error {
 
 // connectAndListenUserChannel 连接并监听 USER 频道
 func (w *webSocketClient) connectAndListenUserChannel() error {
-	netDialer := &net.Dialer{
-		Timeout:   internal.WebSocketDialTimeout,
-		DualStack: false,
-		KeepAlive: internal.WebSocketKeepAlive,
-	}
-
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-	}
-
-	var proxyURL *url.URL
-	if proxyEnv := os.Getenv("HTTPS_PROXY"); proxyEnv != "" {
-		if proxyEnv == "" {
-			proxyEnv = os.Getenv("HTTP_PROXY")
-		}
-		if proxyEnv != "" {
-			proxyURL, _ = url.Parse(proxyEnv)
-		}
-	}
-
-	dialer := websocket.Dialer{
-		HandshakeTimeout: internal.WebSocketHandshakeTimeout,
-		TLSClientConfig:  tlsConfig,
-		Proxy: func(req *http.Request) (*url.URL, error) {
-			if proxyURL != nil {
-				return proxyURL, nil
-			}
-			return http.ProxyFromEnvironment(req)
-		},
-		NetDial: func(network, addr string) (net.Conn, error) {
-			if network == "tcp" {
-				network = "tcp4"
-			}
-			return netDialer.Dial(network, addr)
-		},
-	}
+	dialer := internal.WebSocketDialer(w.proxyURL)
 
-	conn, _, err := dialer.Dial(wsUserURL, nil)
+	conn, _, err := dialer.Dial(wsUserURL, http.Header{"User-Agent": {internal.UserAgent()}})
 	if err != nil {
 		return fmt.Errorf("failed to connect to USER channel: %w", err)
 	}
@@ -0,0 +1,240 @@
+package websocket
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/polymas/go-polymarket-sdk/types"
+)
+
+// LocalOrderBook 根据 WSS 市场频道消息在本地维护的完整订单簿
+// book 消息替换整个订单簿，price_change 消息更新单个档位（数量为 0 表示删除该档位），
+// last_trade_price 消息更新最后成交价。并发安全
+type LocalOrderBook struct {
+	mu             sync.RWMutex
+	tokenID        string
+	bids           map[float64]float64 // 价格 -> 数量
+	asks           map[float64]float64
+	lastTradePrice float64
+	hasSnapshot    bool
+	updatedAt      time.Time
+
+	// 最优价变化通知
+	onTopChange  func(bid, ask float64)
+	topInterval  time.Duration
+	topNotified  bool
+	notifiedBid  float64
+	notifiedAsk  float64
+	lastTopFired time.Time
+	stopTopTimer func() bool // 取消待发送的通知，没有待发送的通知时为 nil
+
+	now       func() time.Time                            // 当前时间（测试中替换），nil 时使用 time.Now
+	afterFunc func(d time.Duration, f func()) func() bool // 延后执行 f 并返回取消函数（测试中替换），nil 时使用 time.AfterFunc
+}
+
+// NewLocalOrderBook 创建空的本地订单簿，收到第一个快照之前 Summary 返回 false
+func NewLocalOrderBook(tokenID string) *LocalOrderBook {
+	return &LocalOrderBook{
+		tokenID: tokenID,
+		bids:    make(map[float64]float64),
+		asks:    make(map[float64]float64),
+	}
+}
+
+// ApplySnapshot 用完整快照替换订单簿
+func (b *LocalOrderBook) ApplySnapshot(bids, asks []types.OrderLevel) {
+	b.mu.Lock()
+	b.bids = levelMap(bids)
+	b.asks = levelMap(asks)
+	b.hasSnapshot = true
+	b.updatedAt = time.Now()
+	b.mu.Unlock()
+	b.checkTopOfBook()
+}
+
+// ApplyChange 更新单个档位，size 为 0 时删除该档位
+// side 为 BUY 时更新买盘，SELL 时更新卖盘，其他值忽略
+func (b *LocalOrderBook) ApplyChange(side string, price, size float64) {
+	b.mu.Lock()
+	var levels map[float64]float64
+	switch strings.ToUpper(side) {
+	case string(types.OrderSideBUY):
+		levels = b.bids
+	case string(types.OrderSideSELL):
+		levels = b.asks
+	default:
+		b.mu.Unlock()
+		return
+	}
+	if size <= 0 {
+		delete(levels, price)
+	} else {
+		levels[price] = size
+	}
+	b.updatedAt = time.Now()
+	b.mu.Unlock()
+	b.checkTopOfBook()
+}
+
+// Reset 清空订单簿，等待下一个快照（连接断开时使用），已注册的回调保留
+func (b *LocalOrderBook) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids = make(map[float64]float64)
+	b.asks = make(map[float64]float64)
+	b.hasSnapshot = false
+	if b.stopTopTimer != nil {
+		b.stopTopTimer()
+		b.stopTopTimer = nil
+	}
+}
+
+// OnTopOfBookChange 设置最优买价或最优卖价变化时的回调，只改变更深档位的更新不会触发
+// 某一侧没有挂单时对应价格为 0。回调在处理消息的 goroutine 或防抖定时器的 goroutine 中执行，
+// 不应长时间阻塞；callback 为 nil 时取消通知
+func (b *LocalOrderBook) OnTopOfBookChange(callback func(bid, ask float64)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onTopChange = callback
+	b.topNotified = false
+	if b.stopTopTimer != nil {
+		b.stopTopTimer()
+		b.stopTopTimer = nil
+	}
+}
+
+// SetTopOfBookMinInterval 设置两次最优价通知之间的最小间隔，用于过滤快速来回跳动
+// 间隔内的变化会合并为一次通知：间隔结束时最优价与上次通知相同则不再通知（如价格变化后又恢复），
+// 否则以最新的最优价通知一次。默认为 0（每次变化都立即通知）
+func (b *LocalOrderBook) SetTopOfBookMinInterval(interval time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.topInterval = interval
+}
+
+// checkTopOfBook 最优价与上次通知不同时调用回调，距上次通知不足最小间隔时延后到间隔结束再检查
+func (b *LocalOrderBook) checkTopOfBook() {
+	b.mu.Lock()
+	if b.onTopChange == nil || !b.hasSnapshot {
+		b.mu.Unlock()
+		return
+	}
+	bid, ask := b.bestPrices()
+	if b.topNotified && bid == b.notifiedBid && ask == b.notifiedAsk {
+		// 间隔内价格已恢复，取消待发送的通知
+		if b.stopTopTimer != nil {
+			b.stopTopTimer()
+			b.stopTopTimer = nil
+		}
+		b.mu.Unlock()
+		return
+	}
+	if wait := b.topInterval - b.clock().Sub(b.lastTopFired); b.topNotified && wait > 0 {
+		if b.stopTopTimer == nil {
+			b.stopTopTimer = b.schedule(wait, func() {
+				b.mu.Lock()
+				b.stopTopTimer = nil
+				b.mu.Unlock()
+				b.checkTopOfBook()
+			})
+		}
+		b.mu.Unlock()
+		return
+	}
+	b.topNotified = true
+	b.notifiedBid, b.notifiedAsk = bid, ask
+	b.lastTopFired = b.clock()
+	callback := b.onTopChange
+	b.mu.Unlock()
+
+	callback(bid, ask)
+}
+
+// clock 返回当前时间
+func (b *LocalOrderBook) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+// schedule 在 d 之后执行 f，返回取消函数
+func (b *LocalOrderBook) schedule(d time.Duration, f func()) func() bool {
+	if b.afterFunc != nil {
+		return b.afterFunc(d, f)
+	}
+	return time.AfterFunc(d, f).Stop
+}
+
+// bestPrices 返回当前最优买价和最优卖价，没有挂单的一侧为 0（调用方持有锁）
+func (b *LocalOrderBook) bestPrices() (bid, ask float64) {
+	for price := range b.bids {
+		if price > bid {
+			bid = price
+		}
+	}
+	for price := range b.asks {
+		if ask == 0 || price < ask {
+			ask = price
+		}
+	}
+	return bid, ask
+}
+
+// SetLastTradePrice 更新最后成交价
+func (b *LocalOrderBook) SetLastTradePrice(price float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastTradePrice = price
+	b.updatedAt = time.Now()
+}
+
+// UpdatedAt 返回最后一次更新的时间
+func (b *LocalOrderBook) UpdatedAt() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.updatedAt
+}
+
+// Summary 返回订单簿副本，Bids 按价格从高到低、Asks 按价格从低到高排列（最优价在前）
+// 还没有收到快照时返回 false
+func (b *LocalOrderBook) Summary() (*types.OrderBookSummary, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.hasSnapshot {
+		return nil, false
+	}
+	bids := levelList(b.bids)
+	asks := levelList(b.asks)
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	return &types.OrderBookSummary{
+		TokenID:        b.tokenID,
+		Bids:           bids,
+		Asks:           asks,
+		LastTradePrice: types.FloatString(b.lastTradePrice),
+		Source:         types.BookSourceWSS,
+	}, true
+}
+
+// levelMap 将档位列表转换为价格 -> 数量的映射，忽略数量为 0 的档位
+func levelMap(levels []types.OrderLevel) map[float64]float64 {
+	m := make(map[float64]float64, len(levels))
+	for _, level := range levels {
+		if level.Size > 0 {
+			m[float64(level.Price)] = float64(level.Size)
+		}
+	}
+	return m
+}
+
+// levelList 将价格 -> 数量的映射转换为档位列表（未排序）
+func levelList(m map[float64]float64) []types.OrderLevel {
+	levels := make([]types.OrderLevel, 0, len(m))
+	for price, size := range m {
+		levels = append(levels, types.OrderLevel{Price: types.FloatString(price), Size: types.FloatString(size)})
+	}
+	return levels
+}
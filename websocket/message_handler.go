@@ -134,6 +134,9 @@ func (w *webSocketClient) handleBookUpdate(msg map[string]interface{}) {
 		TS:      time.Now(),
 	}
 
+	// 完整快照同时写入本地订单簿
+	w.localBook(assetID).ApplySnapshot(parseOrderLevels(msg["bids"]), parseOrderLevels(msg["asks"]))
+
 	// Debug日志：记录订单簿更新
 	internal.LogDebug("[WebSocket] 收到订单簿更新: asset_id=%s, best_bid=%v, best_ask=%v",
 		assetID, bestBid, bestAsk)
@@ -143,6 +146,136 @@ func (w *webSocketClient) handleBookUpdate(msg map[string]interface{}) {
 	}
 }
 
+// handlePriceChange 处理 price_change 消息，更新本地订单簿中的单个档位
+// 兼容两种格式：price_changes 数组中每项带 asset_id，或顶层 asset_id 加 changes 数组。
+// 还没有收到该资产的 book 快照时忽略（无法在不完整的订单簿上增量更新）
+func (w *webSocketClient) handlePriceChange(msg map[string]interface{}) {
+	if changes, ok := msg["price_changes"].([]interface{}); ok {
+		for _, raw := range changes {
+			change, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			assetID, _ := change["asset_id"].(string)
+			w.applyPriceChange(assetID, change)
+		}
+		return
+	}
+
+	assetID, _ := msg["asset_id"].(string)
+	changes, _ := msg["changes"].([]interface{})
+	for _, raw := range changes {
+		if change, ok := raw.(map[string]interface{}); ok {
+			w.applyPriceChange(assetID, change)
+		}
+	}
+}
+
+// applyPriceChange 将单个档位变化应用到已有快照的本地订单簿
+func (w *webSocketClient) applyPriceChange(assetID string, change map[string]interface{}) {
+	assetID = strings.TrimPrefix(assetID, "0x")
+	if assetID == "" {
+		return
+	}
+	w.booksMutex.RLock()
+	book, ok := w.books[assetID]
+	w.booksMutex.RUnlock()
+	if !ok {
+		return
+	}
+	side, _ := change["side"].(string)
+	book.ApplyChange(side, parseFloat64(change["price"]), parseFloat64(change["size"]))
+}
+
+// handleLastTradePrice 处理 last_trade_price 消息，更新本地订单簿的最后成交价
+func (w *webSocketClient) handleLastTradePrice(msg map[string]interface{}) {
+	assetID, ok := msg["asset_id"].(string)
+	if !ok {
+		return
+	}
+	assetID = strings.TrimPrefix(assetID, "0x")
+	w.booksMutex.RLock()
+	book, ok := w.books[assetID]
+	w.booksMutex.RUnlock()
+	if ok {
+		book.SetLastTradePrice(parseFloat64(msg["price"]))
+	}
+}
+
+// localBook 返回资产的本地订单簿，不存在时创建
+func (w *webSocketClient) localBook(assetID string) *LocalOrderBook {
+	w.booksMutex.Lock()
+	defer w.booksMutex.Unlock()
+	if w.books == nil {
+		w.books = make(map[string]*LocalOrderBook)
+	}
+	book, ok := w.books[assetID]
+	if !ok {
+		book = NewLocalOrderBook(assetID)
+		w.books[assetID] = book
+	}
+	return book
+}
+
+// removeBooks 删除指定资产的本地订单簿（取消订阅时使用）
+func (w *webSocketClient) removeBooks(assetIDs []string) {
+	w.booksMutex.Lock()
+	defer w.booksMutex.Unlock()
+	for _, id := range assetIDs {
+		delete(w.books, strings.TrimPrefix(id, "0x"))
+	}
+}
+
+// resetBooks 清空所有本地订单簿的数据（连接断开时使用），保留订单簿上注册的回调
+func (w *webSocketClient) resetBooks() {
+	w.booksMutex.RLock()
+	defer w.booksMutex.RUnlock()
+	for _, book := range w.books {
+		book.Reset()
+	}
+}
+
+// LocalBook 返回资产在本地维护的订单簿，不存在时创建
+// 可在订阅前获取并注册 OnTopOfBookChange 等回调；连接断开重连后订单簿和回调保留，取消订阅后删除
+func (w *webSocketClient) LocalBook(assetID string) *LocalOrderBook {
+	return w.localBook(strings.TrimPrefix(assetID, "0x"))
+}
+
+// OrderBook 返回根据订阅消息在本地维护的订单簿副本（Source 为 wss）
+// 客户端未运行、资产未订阅或还没有收到 book 快照时返回 false，此时应通过 REST 查询
+func (w *webSocketClient) OrderBook(assetID string) (*types.OrderBookSummary, bool) {
+	if !w.IsRunning() {
+		return nil, false
+	}
+	w.booksMutex.RLock()
+	book, ok := w.books[strings.TrimPrefix(assetID, "0x")]
+	w.booksMutex.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return book.Summary()
+}
+
+// parseOrderLevels 解析消息中的档位列表，格式无效的档位被忽略
+func parseOrderLevels(raw interface{}) []types.OrderLevel {
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+	levels := make([]types.OrderLevel, 0, len(items))
+	for _, item := range items {
+		level, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		levels = append(levels, types.OrderLevel{
+			Price: types.FloatString(parseFloat64(level["price"])),
+			Size:  types.FloatString(parseFloat64(level["size"])),
+		})
+	}
+	return levels
+}
+
 // parseFloat64 safely parses a float64 from interface{}
 func parseFloat64(v interface{}) float64 {
 	switch val := v.(type) {
@@ -1,13 +1,10 @@
 package websocket
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net"
 	"net/http"
 	"net/url"
-	"os"
 	"sync"
 	"time"
 
@@ -43,13 +40,19 @@ type sportsWebSocketClient struct {
 	lastConnected   time.Time
 	disconnectedAt  *time.Time
 	disconnectMutex sync.RWMutex
+	proxyURL        *url.URL
+	proxyErr        error // 显式配置的代理地址无效，Start 直接返回该错误
 }
 
 // NewSportsClient 创建新的 Sports WebSocket 客户端
-func NewSportsClient(reconnectDelay time.Duration) SportsClient {
+// 代理地址无效时 Start 返回该错误
+func NewSportsClient(reconnectDelay time.Duration, options ...ClientOption) SportsClient {
+	proxyURL, proxyErr := parseProxyOption(options)
 	return &sportsWebSocketClient{
 		reconnectDelay: reconnectDelay,
 		stopChan:       make(chan struct{}),
+		proxyURL:       proxyURL,
+		proxyErr:       proxyErr,
 	}
 }
 
@@ -65,6 +68,9 @@ func (s *sportsWebSocketClient) SetAuth(auth *WebSocketAuth) {
 
 // Start 启动 Sports WebSocket 连接
 func (s *sportsWebSocketClient) Start() error {
+	if s.proxyErr != nil {
+		return s.proxyErr
+	}
 	s.runningMutex.Lock()
 	if s.running {
 		s.runningMutex.Unlock()
@@ -136,44 +142,9 @@ func (s *sportsWebSocketClient) run() {
 
 // connectAndListen 连接并监听消息
 func (s *sportsWebSocketClient) connectAndListen() error {
-	netDialer := &net.Dialer{
-		Timeout:   internal.WebSocketDialTimeout,
-		DualStack: false,
-		KeepAlive: internal.WebSocketKeepAlive,
-	}
-
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-	}
-
-	var proxyURL *url.URL
-	if proxyEnv := os.Getenv("HTTPS_PROXY"); proxyEnv != "" {
-		if proxyEnv == "" {
-			proxyEnv = os.Getenv("HTTP_PROXY")
-		}
-		if proxyEnv != "" {
-			proxyURL, _ = url.Parse(proxyEnv)
-		}
-	}
-
-	dialer := websocket.Dialer{
-		HandshakeTimeout: internal.WebSocketHandshakeTimeout,
-		TLSClientConfig:  tlsConfig,
-		Proxy: func(req *http.Request) (*url.URL, error) {
-			if proxyURL != nil {
-				return proxyURL, nil
-			}
-			return http.ProxyFromEnvironment(req)
-		},
-		NetDial: func(network, addr string) (net.Conn, error) {
-			if network == "tcp" {
-				network = "tcp4"
-			}
-			return netDialer.Dial(network, addr)
-		},
-	}
+	dialer := internal.WebSocketDialer(s.proxyURL)
 
-	conn, _, err := dialer.Dial(wsSportsURL, nil)
+	conn, _, err := dialer.Dial(wsSportsURL, http.Header{"User-Agent": {internal.UserAgent()}})
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
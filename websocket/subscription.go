@@ -10,8 +10,10 @@ import (
 // 这会发送包含新资产列表的订阅消息
 func (w *webSocketClient) UpdateSubscription(assetIDs []string) error {
 	w.subscribedMutex.Lock()
+	removed := subtractIDs(w.subscribedIDs, assetIDs)
 	w.subscribedIDs = assetIDs
 	w.subscribedMutex.Unlock()
+	w.removeBooks(removed)
 	w.connMutex.Lock()
 	conn := w.conn
 	w.connMutex.Unlock()
@@ -86,6 +88,7 @@ func (w *webSocketClient) UnsubscribeAssets(assetIDs []string) error {
 	}
 	w.subscribedIDs = newSubscribed
 	w.subscribedMutex.Unlock()
+	w.removeBooks(assetIDs)
 
 	// Send unsubscribe message
 	unsubMsg := map[string]interface{}{
@@ -100,8 +103,26 @@ func (w *webSocketClient) UnsubscribeAssets(assetIDs []string) error {
 	return conn.WriteJSON(unsubMsg)
 }
 
+// subtractIDs 返回在 ids 中但不在 keep 中的资产ID
+func subtractIDs(ids, keep []string) []string {
+	keepMap := make(map[string]bool, len(keep))
+	for _, id := range keep {
+		keepMap[id] = true
+	}
+	removed := []string{}
+	for _, id := range ids {
+		if !keepMap[id] {
+			removed = append(removed, id)
+		}
+	}
+	return removed
+}
+
 // StartUserChannel 启动 USER 频道 WebSocket 连接（需要认证）
 func (w *webSocketClient) StartUserChannel() error {
+	if w.proxyErr != nil {
+		return w.proxyErr
+	}
 	if w.auth == nil {
 		return fmt.Errorf("authentication required for USER channel")
 	}